@@ -0,0 +1,52 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains type adapters, which let rules of a basic type validate domain types.
+package arbiter
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeAdapter converts values of a domain type to the type its rules validate.
+type typeAdapter struct {
+	to reflect.Type
+	fn reflect.Value
+}
+
+var (
+	adaptersMutex sync.RWMutex
+	adapters      = make(map[reflect.Type]typeAdapter)
+)
+
+// RegisterTypeAdapter registers fn to convert values of the domain type T, such as
+// type Email string or type UserID int64, to V, so that schema rules of V validate fields
+// of type T without being rewritten for it. Schemas built afterwards apply the rules of
+// SchemaBuilder.Field and SchemaBuilder.Rules to fn(value) when a rule does not accept T
+// itself. Transformers of V, such as rule.Trim, also modify fields of T if V converts
+// back to T. Registering an adapter for T again replaces it.
+//
+// Example:
+//
+//	type Email string
+//
+//	arbiter.RegisterTypeAdapter(func(e Email) string { return string(e) })
+//
+//	schema := arbiter.MustBuild(arbiter.For[User]().
+//	    Field("Email", rule.Trim(), rule.IsEmail()).  // User.Email is an Email
+//	    Rules("Name", "required,len=2 50"))
+func RegisterTypeAdapter[T, V any](fn func(T) V) {
+	if fn == nil {
+		return
+	}
+	adaptersMutex.Lock()
+	adapters[reflect.TypeFor[T]()] = typeAdapter{to: reflect.TypeFor[V](), fn: reflect.ValueOf(fn)}
+	adaptersMutex.Unlock()
+}
+
+// lookupAdapter returns the adapter registered for values of type t.
+func lookupAdapter(t reflect.Type) (typeAdapter, bool) {
+	adaptersMutex.RLock()
+	a, ok := adapters[t]
+	adaptersMutex.RUnlock()
+	return a, ok
+}
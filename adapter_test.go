@@ -0,0 +1,51 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of type adapters.
+package arbiter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type (
+	testEmail  string
+	testUserID int64
+	testHandle string
+)
+
+type testMember struct {
+	ID     testUserID
+	Email  testEmail
+	Handle testHandle
+}
+
+func TestRegisterTypeAdapter(t *testing.T) {
+	arbiter.RegisterTypeAdapter(func(e testEmail) string { return string(e) })
+	arbiter.RegisterTypeAdapter(func(id testUserID) int64 { return int64(id) })
+
+	schema, err := arbiter.For[testMember]().
+		Field("Email", rule.Trim(), rule.Lower(), rule.IsEmail()).
+		Rules("ID", "required,min=1").
+		Build()
+	assert.NoError(t, err)
+
+	member := &testMember{ID: 7, Email: "  Ada@Example.COM "}
+	assert.NoError(t, schema.Validate(member))
+	assert.Equal(t, testEmail("ada@example.com"), member.Email)
+
+	errs := validationErrors(schema.ValidateAll(&testMember{ID: -1, Email: "ada"}))
+	if assert.Len(t, errs, 2) {
+		assert.ErrorIs(t, errs[0], rule.ErrEmail)
+		assert.Equal(t, "ID", errs[1].Name)
+		assert.ErrorIs(t, errs[1], rule.ErrMin)
+	}
+
+	_, err = arbiter.For[testMember]().Field("Handle", rule.IsEmail()).Build()
+	assert.Error(t, err)
+	_, err = arbiter.For[testMember]().Rules("Handle", "len=1 10").Build()
+	assert.Error(t, err)
+}
@@ -64,6 +64,12 @@ func ValidateAll[T any](value T, rules ...rule.Rule[T]) []error {
 // The value parameter must be a pointer to a struct.
 // The nilErr parameter is the error message to use if the struct is nil.
 // The fields parameter is a list of field rules to apply.
+// A field error is returned as a *FieldError named after the field, such as
+// "Age: value is less than minimum"; the name is found from the field's position in the
+// struct unless it was set with FieldRule.Name.
+// Embedded structs whose type has a schema registered with RegisterSchema or implements
+// Validatable are validated first, unless they are given to a NestedField; their errors
+// are named like promoted fields, such as "Email" for the Email field of an embedded Base.
 //
 // Example:
 //
@@ -94,6 +100,72 @@ func ValidateAll[T any](value T, rules ...rule.Rule[T]) []error {
 //	    ),
 //	)
 func ValidateStruct(value any, nilErr string, fields ...IFieldRule) error {
+	if err := checkStruct(value, nilErr); err != nil {
+		return err
+	}
+	// validate fields
+	opts := newOptions(value, fields)
+	if errs := embeddedErrors(value, fields, opts, false); errs != nil {
+		return errs[0]
+	}
+	for _, field := range fields {
+		if err := field.validate(opts); err != nil {
+			if fe, ok := err.(*FieldError); ok {
+				fe.resolve(value)
+				fe.render(opts.locale)
+				logErrors(value, fe)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateStructAll validates a struct like ValidateStruct, but applies every rule of
+// every field and returns all failures as ValidationErrors, so that forms can show
+// the errors next to each field. It returns nil if all rules pass; otherwise the
+// ValidationErrors are reachable with errors.As, like the *FieldError of ValidateStruct.
+// If value is not a valid struct pointer, the only error has a nil Field.
+//
+// Example:
+//
+//	err := ValidateStructAll(user, "User cannot be nil",
+//	    Field(&user.Name, rule.Required[string]()),
+//	    Field(&user.Password,
+//	        rule.Len[string](8, 64).Errf("Password must be at least 8 characters"),
+//	        rule.Regex(`[0-9]`).Errf("Password must contain a digit"),
+//	    ),
+//	)
+//	var errs ValidationErrors
+//	if errors.As(err, &errs) {
+//	    for _, err := range errs.For(&user.Password) {
+//	        // Render each password error
+//	    }
+//	}
+func ValidateStructAll(value any, nilErr string, fields ...IFieldRule) error {
+	if err := checkStruct(value, nilErr); err != nil {
+		return ValidationErrors{{Err: err}}
+	}
+	opts := newOptions(value, fields)
+	errs := embeddedErrors(value, fields, opts, true)
+	embedded := len(errs)
+	for _, field := range fields {
+		errs = field.collect(errs, opts)
+	}
+	for _, fe := range errs {
+		fe.resolve(value)
+		fe.render(opts.locale)
+	}
+	logErrors(value, errs[embedded:]...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// checkStruct returns an error unless value is a non-nil pointer to a struct.
+// The error is only built on failure to keep the happy path allocation free.
+func checkStruct(value any, nilErr string) error {
 	if value == nil {
 		if nilErr != "" {
 			return errors.New(nilErr)
@@ -106,14 +178,11 @@ func ValidateStruct(value any, nilErr string, fields ...IFieldRule) error {
 		return errors.New("value must be a pointer")
 	}
 	// value is must not nil
-	if err := Validate(value, rule.NotNil.Errf(nilErr)); err != nil {
-		return err
-	}
-	// validate fields
-	for _, field := range fields {
-		if err := field.validate(); err != nil {
-			return err
+	if v.IsNil() {
+		if nilErr != "" {
+			return errors.New(nilErr)
 		}
+		return rule.ErrNotNil
 	}
 	return nil
 }
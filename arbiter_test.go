@@ -3,12 +3,24 @@
 package arbiter_test
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/byteweap/arbiter"
 	"github.com/byteweap/arbiter/rule"
 )
 
+// validationErrors returns the ValidationErrors within err, as returned by
+// ValidateStructAll and Schema.ValidateAll, or nil if err is nil.
+func validationErrors(err error) arbiter.ValidationErrors {
+	var errs arbiter.ValidationErrors
+	errors.As(err, &errs)
+	return errs
+}
+
 // TestValidate tests the Validate function with various types and rules.
 // It verifies that the function correctly applies validation rules to values.
 func TestValidate(t *testing.T) {
@@ -229,3 +241,153 @@ func TestValidateStruct(t *testing.T) {
 		}
 	})
 }
+
+// TestValidateStructNilPointer verifies that a typed nil pointer is rejected with nilErr,
+// or with rule.ErrNotNil when nilErr is empty.
+func TestValidateStructNilPointer(t *testing.T) {
+	type Person struct{ Name string }
+	var person *Person
+
+	err := arbiter.ValidateStruct(person, "Person cannot be nil")
+	if err == nil || err.Error() != "Person cannot be nil" {
+		t.Errorf("Expected nilErr message, got %v", err)
+	}
+	if err := arbiter.ValidateStruct(person, ""); err != rule.ErrNotNil {
+		t.Errorf("Expected rule.ErrNotNil, got %v", err)
+	}
+}
+
+// TestValidateAllocations verifies that the happy path of Validate and ValidateStruct,
+// with rules built ahead of time, does not allocate.
+func TestValidateAllocations(t *testing.T) {
+	type Person struct {
+		Name string
+		Age  int
+		Tags []string
+	}
+	person := &Person{Name: "John", Age: 30, Tags: []string{"admin"}}
+	nameRules := []rule.Rule[string]{rule.Required[string](), rule.Len[string](2, 50)}
+	ageRules := []rule.Rule[int]{rule.Between[int](0, 120), rule.MultipleOf(5)}
+	fields := []arbiter.IFieldRule{
+		arbiter.Field(&person.Name, nameRules...),
+		arbiter.Field(&person.Age, ageRules...),
+		arbiter.Field(&person.Tags, rule.SliceLen[[]string](1, 10)),
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = arbiter.Validate("John", nameRules...)
+	}); allocs != 0 {
+		t.Errorf("Validate allocated %v times, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = arbiter.ValidateStruct(person, "Person cannot be nil", fields...)
+	}); allocs != 0 {
+		t.Errorf("ValidateStruct allocated %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	rules := []rule.Rule[string]{rule.Required[string](), rule.Len[string](2, 50), rule.Emailv}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = arbiter.Validate("john@example.com", rules...)
+	}
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	type Person struct {
+		Name string
+		Age  int
+	}
+	person := &Person{Name: "John", Age: 30}
+	fields := []arbiter.IFieldRule{
+		arbiter.Field(&person.Name, rule.Required[string](), rule.Len[string](2, 50)),
+		arbiter.Field(&person.Age, rule.Between[int](0, 120)),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = arbiter.ValidateStruct(person, "Person cannot be nil", fields...)
+	}
+}
+
+// TestValidateStructAll verifies that ValidateStructAll reports every failed rule of
+// every field, and that the errors can be looked up by field.
+func TestValidateStructAll(t *testing.T) {
+	type Address struct{ City string }
+	type Person struct {
+		Name     string
+		Age      int
+		Password string
+		Address  Address
+		Tags     []string
+	}
+	person := &Person{Age: -1, Password: "secret", Tags: []string{"ok", ""}}
+
+	errs := validationErrors(arbiter.ValidateStructAll(person, "Person cannot be nil",
+		arbiter.Field(&person.Name, rule.Required[string]()),
+		arbiter.Field(&person.Age, rule.Min[int](0)),
+		arbiter.Field(&person.Password, rule.Len[string](8, 64), rule.Regex(`[0-9]`)),
+		arbiter.NestedField(&person.Address,
+			arbiter.Field(&person.Address.City, rule.Required[string]()),
+		),
+		arbiter.SliceField(&person.Tags, func(tag *string) arbiter.IFieldRule {
+			return arbiter.Field(tag, rule.Required[string]())
+		}),
+	))
+	assert.Len(t, errs, 6)
+	assert.Equal(t, []error{rule.ErrRequired}, errs.For(&person.Name))
+	assert.Len(t, errs.For(&person.Password), 2)
+	assert.Equal(t, []error{rule.ErrRequired}, errs.For(&person.Address.City))
+	assert.Nil(t, errs.For(&person.Tags[0]))
+	assert.Equal(t, []error{rule.ErrRequired}, errs.For(&person.Tags[1]))
+	assert.ErrorIs(t, errs, rule.ErrRequired)
+
+	// A valid struct returns an untyped nil error
+	person = &Person{Name: "John", Password: "secret123"}
+	err := arbiter.ValidateStructAll(person, "Person cannot be nil",
+		arbiter.Field(&person.Name, rule.Required[string]()),
+		arbiter.Field(&person.Password, rule.Len[string](8, 64), rule.Regex(`[0-9]`)),
+	)
+	assert.True(t, err == nil)
+}
+
+// TestValidateStructAllNil verifies that a nil struct is reported as a single error without a field.
+func TestValidateStructAllNil(t *testing.T) {
+	var person *struct{ Name string }
+	errs := validationErrors(arbiter.ValidateStructAll(person, "Person cannot be nil"))
+	assert.Len(t, errs, 1)
+	assert.Nil(t, errs[0].Field)
+	assert.EqualError(t, errs, "Person cannot be nil")
+}
+
+// TestValidationErrorsJSON verifies the JSON encoding of ValidationErrors.
+func TestValidationErrorsJSON(t *testing.T) {
+	type Person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	person := &Person{Age: -1}
+
+	errs := validationErrors(arbiter.ValidateStructAll(person, "Person cannot be nil",
+		arbiter.Field(&person.Name, rule.Required[string]().Errf("Name is required")),
+		arbiter.Field(&person.Age, rule.Min(0)),
+	))
+	data, err := json.Marshal(errs)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"errors":[
+		{"field":"name","code":"required","message":"Name is required"},
+		{"field":"age","code":"min","message":"value is less than minimum","params":{"min":0}}
+	]}`, string(data))
+
+	assert.Nil(t, arbiter.ValidateStructAll(&Person{Name: "John"}, "Person cannot be nil"))
+	data, err = json.Marshal(arbiter.ValidationErrors(nil))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"errors":[]}`, string(data))
+
+	// The first error of ValidateStruct carries the parameters of the failed rule too
+	err = arbiter.ValidateStruct(person, "Person cannot be nil",
+		arbiter.Field(&person.Age, rule.Between(0, 120)),
+	)
+	data, _ = json.Marshal(err)
+	assert.JSONEq(t, `{"field":"age","code":"between","message":"is not between 0 and 120","params":{"min":0,"max":120}}`, string(data))
+}
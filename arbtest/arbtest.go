@@ -0,0 +1,206 @@
+// Package arbtest provides helpers for testing rules and schemas built with arbiter, so
+// that tests assert outcomes and error codes in one line instead of repeating the same
+// checks.
+//
+// Example:
+//
+//	func TestSKU(t *testing.T) {
+//	    arbtest.Run(t, skuRule, arbtest.Matrix(
+//	        arbtest.Valid("ABC-1234", "XYZ-0001", ""),  // Regex skips empty strings
+//	        arbtest.Invalid("regex", "abc-1234", "ABC1234"),
+//	    ))
+//	}
+package arbtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// AssertPasses reports a test error for each of values that r rejects. It returns
+// whether all values passed.
+//
+// Example:
+//
+//	arbtest.AssertPasses(t, rule.IsEmail(), "ada@example.com", "a.b+c@example.org")
+func AssertPasses[T any](t testing.TB, r rule.Rule[T], values ...T) bool {
+	t.Helper()
+	ok := true
+	for _, value := range values {
+		if err := r.Validate(value); err != nil {
+			t.Errorf("%v: want no error, got %v", value, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertFails reports a test error unless r rejects value with an error whose code is
+// wantCode, as returned by rule.ErrorCode. An empty wantCode accepts any error. It
+// returns whether the assertion held.
+//
+// Example:
+//
+//	arbtest.AssertFails(t, rule.Between(1, 10), 11, "between")
+func AssertFails[T any](t testing.TB, r rule.Rule[T], value T, wantCode string) bool {
+	t.Helper()
+	err := r.Validate(value)
+	return checkError(t, fmt.Sprint(value), err, wantCode)
+}
+
+// AssertFieldFails reports a test error unless err, as returned by ValidateStruct,
+// ValidateStructAll or a schema, has an error of the named field with the code wantCode.
+// Fields are named as in arbiter.FieldError.Name, such as "Address.City". An empty
+// wantCode accepts any error of the field. It returns whether the assertion held.
+//
+// Example:
+//
+//	err := userSchema.Validate(&User{Age: 200})
+//	arbtest.AssertFieldFails(t, err, "Age", "between")
+func AssertFieldFails(t testing.TB, err error, field, wantCode string) bool {
+	t.Helper()
+	var codes []string
+	for _, fe := range fieldErrors(err) {
+		if fe.Name != field {
+			continue
+		}
+		code := rule.ErrorCode(fe)
+		if wantCode == "" || code == wantCode {
+			return true
+		}
+		codes = append(codes, code)
+	}
+	if codes == nil {
+		t.Errorf("field %s: want error %q, got none in %v", field, wantCode, err)
+	} else {
+		t.Errorf("field %s: want error %q, got %q", field, wantCode, codes)
+	}
+	return false
+}
+
+// AssertFieldPasses reports a test error if err has an error of the named field. It
+// returns whether the field passed.
+//
+// Example:
+//
+//	err := userSchema.ValidateAll(user)
+//	arbtest.AssertFieldPasses(t, err, "Email")
+func AssertFieldPasses(t testing.TB, err error, field string) bool {
+	t.Helper()
+	for _, fe := range fieldErrors(err) {
+		if fe.Name == field {
+			t.Errorf("field %s: want no error, got %v", field, fe.Err)
+			return false
+		}
+	}
+	return true
+}
+
+// fieldErrors returns the field errors in err.
+func fieldErrors(err error) arbiter.ValidationErrors {
+	var errs arbiter.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var fe *arbiter.FieldError
+	if errors.As(err, &fe) {
+		return arbiter.ValidationErrors{fe}
+	}
+	return nil
+}
+
+// checkError reports a test error unless err is an error with the code wantCode.
+func checkError(t testing.TB, name string, err error, wantCode string) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s: want error %q, got none", name, wantCode)
+		return false
+	}
+	if code := rule.ErrorCode(err); wantCode != "" && code != wantCode {
+		t.Errorf("%s: want error %q, got %q (%v)", name, wantCode, code, err)
+		return false
+	}
+	return true
+}
+
+// Case is a case of a rule matrix: a value and whether the rule must reject it, with the
+// code of the expected error.
+type Case[T any] struct {
+	// Name names the subtest of the case. It defaults to the value.
+	Name  string
+	Value T
+	// Code is the expected error code if Fail is set, or "" to accept any error.
+	Code string
+	// Fail reports that the rule must reject the value.
+	Fail bool
+}
+
+// Valid returns cases for values that must pass.
+//
+// Example:
+//
+//	cases := arbtest.Valid(1, 5, 10)
+func Valid[T any](values ...T) []Case[T] {
+	cases := make([]Case[T], len(values))
+	for i, v := range values {
+		cases[i] = Case[T]{Value: v}
+	}
+	return cases
+}
+
+// Invalid returns cases for values that must fail with the error code code. An empty
+// code accepts any error.
+//
+// Example:
+//
+//	cases := arbtest.Invalid("between", 0, 11)
+func Invalid[T any](code string, values ...T) []Case[T] {
+	cases := make([]Case[T], len(values))
+	for i, v := range values {
+		cases[i] = Case[T]{Value: v, Code: code, Fail: true}
+	}
+	return cases
+}
+
+// Matrix joins lists of cases, such as those returned by Valid and Invalid, into one.
+//
+// Example:
+//
+//	cases := arbtest.Matrix(arbtest.Valid(1, 10), arbtest.Invalid("between", 0, 11))
+func Matrix[T any](lists ...[]Case[T]) []Case[T] {
+	var cases []Case[T]
+	for _, list := range lists {
+		cases = append(cases, list...)
+	}
+	return cases
+}
+
+// Run checks r against every case in a subtest named after the case.
+//
+// Example:
+//
+//	arbtest.Run(t, rule.Between(1, 10), arbtest.Matrix(
+//	    arbtest.Valid(1, 5, 10),
+//	    arbtest.Invalid("between", 0, 11),
+//	))
+func Run[T any](t *testing.T, r rule.Rule[T], cases []Case[T]) {
+	t.Helper()
+	for _, c := range cases {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprint(c.Value)
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			if c.Fail {
+				AssertFails(t, r, c.Value, c.Code)
+			} else {
+				AssertPasses(t, r, c.Value)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package arbtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// recorder records the errors reported by the helpers instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertRule(t *testing.T) {
+	between := rule.Between(1, 10)
+	tests := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		errors []string
+	}{
+		{name: "passes", assert: func(t testing.TB) bool { return AssertPasses(t, between, 1, 10) }},
+		{
+			name:   "passes fails",
+			assert: func(t testing.TB) bool { return AssertPasses(t, between, 5, 11) },
+			errors: []string{"11: want no error, got is not between 1 and 10"},
+		},
+		{name: "fails", assert: func(t testing.TB) bool { return AssertFails(t, between, 11, "between") }},
+		{name: "fails any code", assert: func(t testing.TB) bool { return AssertFails(t, between, 0, "") }},
+		{
+			name:   "fails passes",
+			assert: func(t testing.TB) bool { return AssertFails(t, between, 5, "between") },
+			errors: []string{`5: want error "between", got none`},
+		},
+		{
+			name:   "fails wrong code",
+			assert: func(t testing.TB) bool { return AssertFails(t, between, 0, "min") },
+			errors: []string{`0: want error "min", got "between" (is not between 1 and 10)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			assert.Equal(t, tt.errors == nil, tt.assert(r))
+			assert.Equal(t, tt.errors, r.errors)
+		})
+	}
+}
+
+type testUser struct {
+	Name string
+	Age  int
+}
+
+func TestAssertField(t *testing.T) {
+	user := &testUser{Age: 200}
+	errs := arbiter.ValidateStructAll(user, "",
+		arbiter.Field(&user.Name, rule.Required[string]()),
+		arbiter.Field(&user.Age, rule.Between(0, 120)),
+	)
+	err := arbiter.ValidateStruct(user, "", arbiter.Field(&user.Age, rule.Between(0, 120)))
+
+	r := &recorder{TB: t}
+	assert.True(t, AssertFieldFails(r, errs, "Age", "between"))
+	assert.True(t, AssertFieldFails(r, errs, "Name", ""))
+	assert.True(t, AssertFieldFails(r, err, "Age", "between"))
+	assert.Empty(t, r.errors)
+
+	assert.False(t, AssertFieldFails(r, errs, "Age", "min"))
+	assert.False(t, AssertFieldFails(r, err, "Name", "required"))
+	assert.False(t, AssertFieldPasses(r, errs, "Age"))
+	assert.True(t, AssertFieldPasses(r, err, "Name"))
+	assert.Equal(t, []string{
+		`field Age: want error "min", got ["between"]`,
+		`field Name: want error "required", got none in Age: is not between 0 and 120`,
+		"field Age: want no error, got is not between 0 and 120",
+	}, r.errors)
+}
+
+func TestRun(t *testing.T) {
+	cases := Matrix(
+		Valid(1, 5, 10),
+		Invalid("between", 0, 11),
+		[]Case[int]{{Name: "negative", Value: -1, Fail: true}},
+	)
+	assert.Len(t, cases, 6)
+	assert.Equal(t, Case[int]{Value: 0, Code: "between", Fail: true}, cases[3])
+	Run(t, rule.Between(1, 10), cases)
+}
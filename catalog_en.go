@@ -0,0 +1,152 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the built-in en-US message catalog.
+package arbiter
+
+// enUS holds the en-US messages of every error code of the rule package and of this
+// package. Parameterized messages fall back to a generic text if the failed rule did
+// not report its parameters.
+var enUS = map[string]string{
+	"aadhaar":             "must be a valid Aadhaar number",
+	"after":               "must be after the specified time",
+	"argon2_hash":         "must be a valid argon2 hash",
+	"bank_card":           "must be a valid bank card number",
+	"bcrypt_hash":         "must be a valid bcrypt hash",
+	"before":              "must be before the specified time",
+	"between":             "must be between {{if and .Has.Min .Has.Max}}{{.Min}} and {{.Max}}{{else}}the minimum and the maximum{{end}}",
+	"bic":                 "must be a valid SWIFT/BIC code",
+	"business_hours":      "must be within business hours",
+	"card_brand":          "must be a card of {{if .Brands}}the brands {{.Brands}}{{else}}an accepted brand{{end}}",
+	"card_expiry":         "must be a valid expiry date that has not passed",
+	"certificate_expired": "must be a certificate that is currently valid",
+	"china_mobile":        "must be a valid China mobile number",
+	"china_plate":         "must be a valid China license plate number",
+	"chinese_only":        "must contain only Chinese characters",
+	"coerce_bool":         "must be a valid boolean",
+	"coerce_float":        "must be a valid number",
+	"coerce_int":          "must be a valid integer",
+	"condition":           "does not meet the condition",
+	"contains":            "must contain the specified text",
+	"credit_card":         "must be a valid card number",
+	"date_format":         "must be a valid date",
+	"date_time_format":    "must be a valid date and time",
+	"day_of_month":        "must be on an allowed day of the month",
+	"decimal":             "must be a decimal number",
+	"dependency":          "does not meet the requirements of the fields it depends on",
+	"disposable_email":    "must not be a disposable email address",
+	"domain_source":       "cannot be checked because no domain blocklist is set",
+	"divisible_by":        "must be divisible by {{if .Has.Divisor}}{{.Divisor}}{{else}}the specified number{{end}}",
+	"domain":              "must be a valid domain name",
+	"duration_between":    "must be between {{if and .Has.Min .Has.Max}}{{.Min}} and {{.Max}}{{else}}the minimum and the maximum duration{{end}}",
+	"duration_format":     "must be a valid duration",
+	"duration_max":        "must be at most {{if .Has.Max}}{{.Max}}{{else}}the maximum duration{{end}}",
+	"duration_min":        "must be at least {{if .Has.Min}}{{.Min}}{{else}}the minimum duration{{end}}",
+	"ein":                 "must be a valid employer identification number",
+	"email":               "must be a valid email address",
+	"end_of_month":        "must be the last day of the month",
+	"ends_with":           "must end with the specified suffix",
+	"even":                "must be even",
+	"executable":          "must not be an executable file",
+	"field_less":          "must be less than the other field",
+	"field_lte":           "must not be greater than the other field",
+	"file_extension":      "has a file extension that is not allowed",
+	"file_mime_type":      "has a file type that is not allowed",
+	"file_size":           "must have a size between {{if and .Has.Min .Has.Max}}{{.Min}} and {{.Max}} bytes{{else}}the minimum and the maximum{{end}}",
+	"file_type":           "has a file type that is not allowed",
+	"full_width_only":     "must contain only full-width characters",
+	"git_ref_name":        "must be a valid git reference name",
+	"git_sha":             "must be a valid git commit SHA",
+	"glob":                "must match the pattern",
+	"half_width_only":     "must contain only half-width characters",
+	"hkid":                "must be a valid Hong Kong identity card number",
+	"holiday":             "must be a holiday",
+	"holiday_unknown":     "has a date for which the holidays are not known",
+	"homoglyph":           "must not contain confusable characters",
+	"hours_config":        "has invalid business hours configured",
+	"http_header_name":    "must be a valid HTTP header name",
+	"http_header_value":   "must be a valid HTTP header value without line breaks or control characters",
+	"http_method":         "must be one of the HTTP methods {{if .Methods}}{{.Methods}}{{else}}that are allowed{{end}}",
+	"id_card":             "must be a valid ID card number",
+	"image_format":        "must be a valid image in an allowed format",
+	"in":                  "must be one of {{if .Values}}{{.Values}}{{else}}the allowed values{{end}}",
+	"ip":                  "must be a valid IP address",
+	"ipv4":                "must be a valid IPv4 address",
+	"ipv6":                "must be a valid IPv6 address",
+	"isin":                "must be a valid ISIN",
+	"iso_week":            "must be in an allowed ISO week",
+	"jwk":                 "must be a valid JSON Web Key",
+	"jwks":                "must be a valid JSON Web Key Set",
+	"ksuid":               "must be a valid KSUID",
+	"leap_year":           "must be in a leap year",
+	"length":              "must have a length between {{if and .Has.Min .Has.Max}}{{.Min}} and {{.Max}}{{else}}the minimum and the maximum{{end}}",
+	"lower_case_only":     "must contain only lowercase letters",
+	"mac_address":         "must be a valid MAC address",
+	"map_keys":            "must have keys matching {{if .Pattern}}{{.Pattern}}{{else}}the pattern{{end}}",
+	"max":                 "must be at most {{if .Has.Max}}{{.Max}}{{else}}the maximum{{end}}",
+	"max_depth":           "is nested too deeply",
+	"min":                 "must be at least {{if .Has.Min}}{{.Min}}{{else}}the minimum{{end}}",
+	"multiple":            "must be a multiple of {{if .Has.Base}}{{.Base}}{{else}}the base number{{end}}",
+	"mutual_exclude":      "must not be set together with the other fields",
+	"nano_id":             "must be a valid NanoID",
+	"negative":            "must be negative",
+	"nil":                 "must be empty",
+	"nino":                "must be a valid national insurance number",
+	"no_special_chars":    "must contain special characters",
+	"non_zero":            "must not be zero",
+	"not_contains":        "must not contain the specified text",
+	"not_in":              "must not be one of the disallowed values",
+	"not_nil":             "must not be empty",
+	"null_byte":           "must not contain a null byte",
+	"null_required":       "must not be null",
+	"odd":                 "must be odd",
+	"passport":            "must be a valid passport number",
+	"password_complex":    "does not meet the password complexity requirements",
+	"password_entropy":    "is too easy to guess",
+	"password_strength":   "does not meet the password strength requirements",
+	"pem_certificate":     "must be a valid PEM-encoded certificate",
+	"pem_private_key":     "must be a valid PEM-encoded private key",
+	"pem_public_key":      "must be a valid PEM-encoded public key",
+	"phc_string":          "must be a valid PHC string",
+	"phone":               "must be a valid phone number",
+	"port":                "must be a valid port number",
+	"positive":            "must be positive",
+	"precision":           "must have at most {{if .Has.Precision}}{{.Precision}}{{else}}the allowed number of{{end}} decimal places",
+	"prime":               "must be a prime number",
+	"quarter":             "must be in an allowed quarter",
+	"recovery_code":       "must be a valid recovery code",
+	"recurrence":          "must be a valid recurrence rule",
+	"recurrence_limit":    "is too expensive to evaluate as a recurrence rule",
+	"recurrence_mismatch": "does not match the recurrence rule",
+	"regex":               "has an invalid format",
+	"required":            "is required",
+	"required_keys":       "must have the keys {{if .Keys}}{{.Keys}}{{else}}that are required{{end}}",
+	"safe_filename":       "must be a safe file name",
+	"safe_html":           "must not contain disallowed HTML",
+	"secret":              "must not contain a secret",
+	"shell_meta":          "must not contain shell metacharacters",
+	"social_credit":       "must be a valid unified social credit code",
+	"special_chars":       "must not contain special characters",
+	"sql_injection":       "must not contain SQL injection",
+	"ssh_public_key":      "must be a valid SSH public key",
+	"ssn":                 "must be a valid social security number",
+	"starts_with":         "must start with the specified prefix",
+	"subnet_mask":         "must be a valid subnet mask",
+	"tax_number":          "must be a valid {{if .Country}}{{.Country}} {{end}}tax number",
+	"time_between":        "must be between the specified times",
+	"time_format":         "must be a valid time",
+	"totp_secret":         "must be a valid TOTP secret",
+	"type":                "has the wrong type",
+	"ulid":                "must be a valid ULID",
+	"unique_items":        "must not contain duplicate items",
+	"unknown_field":       "is not a known field",
+	"unresolvable_domain": "must be a domain name that resolves",
+	"upper_case_only":     "must contain only uppercase letters",
+	"url":                 "must be a valid URL",
+	"uuid":                "must be a valid UUID",
+	"weekend":             "must be on a weekend",
+	"within_last":         "must be within the specified period before now",
+	"within_next":         "must be within the specified period after now",
+	"workday":             "must be on a workday",
+	"xss":                 "must not contain scripts or markup that could run in a browser",
+	"zero":                "must be zero",
+	"zip_archive":         "must be a valid and safe zip archive",
+}
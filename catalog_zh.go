@@ -0,0 +1,151 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the built-in zh-CN message catalog.
+package arbiter
+
+// zhCN holds the zh-CN messages of every error code of the rule package and of this
+// package, with the same parameters as enUS.
+var zhCN = map[string]string{
+	"aadhaar":             "不是有效的 Aadhaar 号码",
+	"after":               "必须晚于指定时间",
+	"argon2_hash":         "不是有效的 argon2 哈希",
+	"bank_card":           "不是有效的银行卡号",
+	"bcrypt_hash":         "不是有效的 bcrypt 哈希",
+	"before":              "必须早于指定时间",
+	"between":             "必须介于{{if and .Has.Min .Has.Max}} {{.Min}} 和 {{.Max}} {{else}}最小值和最大值{{end}}之间",
+	"bic":                 "不是有效的 SWIFT/BIC 代码",
+	"business_hours":      "必须在工作时间内",
+	"card_brand":          "{{if .Brands}}只支持 {{.Brands}} 卡{{else}}不支持该卡组织{{end}}",
+	"card_expiry":         "卡片有效期无效或已过期",
+	"certificate_expired": "证书已过期或尚未生效",
+	"china_mobile":        "不是有效的中国大陆手机号码",
+	"china_plate":         "不是有效的车牌号码",
+	"chinese_only":        "只能包含中文字符",
+	"coerce_bool":         "不是有效的布尔值",
+	"coerce_float":        "不是有效的数字",
+	"coerce_int":          "不是有效的整数",
+	"condition":           "不满足条件",
+	"contains":            "必须包含指定的内容",
+	"credit_card":         "不是有效的银行卡号",
+	"date_format":         "不是有效的日期",
+	"date_time_format":    "不是有效的日期时间",
+	"day_of_month":        "不在允许的日期内",
+	"decimal":             "不是有效的十进制数",
+	"dependency":          "不满足所依赖字段的要求",
+	"disposable_email":    "不能是一次性邮箱地址",
+	"domain_source":       "未设置域名黑名单，无法校验",
+	"divisible_by":        "必须能被{{if .Has.Divisor}} {{.Divisor}} {{else}}指定的数{{end}}整除",
+	"domain":              "不是有效的域名",
+	"duration_between":    "时长必须介于{{if and .Has.Min .Has.Max}} {{.Min}} 和 {{.Max}} {{else}}最短和最长时长{{end}}之间",
+	"duration_format":     "不是有效的时长",
+	"duration_max":        "时长不能超过{{if .Has.Max}} {{.Max}}{{else}}最长时长{{end}}",
+	"duration_min":        "时长不能少于{{if .Has.Min}} {{.Min}}{{else}}最短时长{{end}}",
+	"ein":                 "不是有效的美国雇主识别号码",
+	"email":               "不是有效的邮箱地址",
+	"end_of_month":        "必须是月末最后一天",
+	"ends_with":           "必须以指定的后缀结尾",
+	"even":                "必须是偶数",
+	"executable":          "不允许上传可执行文件",
+	"field_less":          "必须小于另一个字段",
+	"field_lte":           "不能大于另一个字段",
+	"file_extension":      "文件扩展名不被允许",
+	"file_mime_type":      "文件类型不被允许",
+	"file_size":           "文件大小必须介于{{if and .Has.Min .Has.Max}} {{.Min}} 和 {{.Max}} 字节{{else}}最小值和最大值{{end}}之间",
+	"file_type":           "文件类型不被允许",
+	"full_width_only":     "只能包含全角字符",
+	"git_ref_name":        "不是有效的Git引用名称",
+	"git_sha":             "不是有效的Git提交SHA",
+	"glob":                "不符合指定的模式",
+	"half_width_only":     "只能包含半角字符",
+	"hkid":                "不是有效的香港身份证号码",
+	"holiday":             "必须是节假日",
+	"holiday_unknown":     "所在年份的节假日安排未知",
+	"homoglyph":           "不能包含易混淆的字符",
+	"hours_config":        "营业时间配置无效",
+	"http_header_name":    "不是有效的 HTTP 头名称",
+	"http_header_value":   "不是有效的 HTTP 头的值，不能包含换行符或控制字符",
+	"http_method":         "{{if .Methods}}必须是 HTTP 方法 {{.Methods}} 之一{{else}}HTTP 方法不被允许{{end}}",
+	"id_card":             "不是有效的身份证号码",
+	"image_format":        "不是允许格式的有效图片",
+	"in":                  "必须是{{if .Values}} {{.Values}} {{else}}允许的值{{end}}之一",
+	"ip":                  "不是有效的 IP 地址",
+	"ipv4":                "不是有效的 IPv4 地址",
+	"ipv6":                "不是有效的 IPv6 地址",
+	"isin":                "不是有效的国际证券识别码（ISIN）",
+	"iso_week":            "不在允许的 ISO 周内",
+	"jwk":                 "不是有效的JSON Web Key",
+	"jwks":                "不是有效的JSON Web Key Set",
+	"ksuid":               "不是有效的 KSUID",
+	"leap_year":           "必须在闰年",
+	"length":              "长度必须介于{{if and .Has.Min .Has.Max}} {{.Min}} 和 {{.Max}} {{else}}最小值和最大值{{end}}之间",
+	"lower_case_only":     "只能包含小写字母",
+	"mac_address":         "不是有效的 MAC 地址",
+	"map_keys":            "的键必须匹配{{if .Pattern}} {{.Pattern}}{{else}}指定的格式{{end}}",
+	"max":                 "不能大于{{if .Has.Max}} {{.Max}}{{else}}最大值{{end}}",
+	"max_depth":           "嵌套层级过深",
+	"min":                 "不能小于{{if .Has.Min}} {{.Min}}{{else}}最小值{{end}}",
+	"multiple":            "必须是{{if .Has.Base}} {{.Base}} {{else}}基数{{end}}的倍数",
+	"mutual_exclude":      "不能与其他字段同时设置",
+	"nano_id":             "不是有效的 NanoID",
+	"negative":            "必须是负数",
+	"nil":                 "必须为空",
+	"nino":                "不是有效的英国国民保险号码",
+	"no_special_chars":    "必须包含特殊字符",
+	"non_zero":            "不能为零",
+	"not_contains":        "不能包含指定的内容",
+	"not_in":              "不能是禁止的值",
+	"not_nil":             "不能为空",
+	"null_byte":           "不能包含空字节",
+	"null_required":       "不能为 null",
+	"odd":                 "必须是奇数",
+	"passport":            "不是有效的护照号码",
+	"password_complex":    "密码不满足复杂度要求",
+	"password_entropy":    "密码太容易被猜到",
+	"password_strength":   "密码强度不足",
+	"pem_certificate":     "不是有效的PEM证书",
+	"pem_private_key":     "不是有效的PEM私钥",
+	"pem_public_key":      "不是有效的PEM公钥",
+	"phc_string":          "不是有效的 PHC 字符串",
+	"phone":               "不是有效的手机号码",
+	"port":                "不是有效的端口号",
+	"positive":            "必须是正数",
+	"precision":           "小数位数不能超过{{if .Has.Precision}} {{.Precision}} 位{{else}}限制{{end}}",
+	"prime":               "必须是质数",
+	"quarter":             "不在允许的季度内",
+	"recovery_code":       "不是有效的恢复码",
+	"recurrence":          "不是有效的重复规则",
+	"recurrence_limit":    "重复规则计算量过大",
+	"recurrence_mismatch": "不符合重复规则",
+	"regex":               "格式不正确",
+	"required":            "不能为空",
+	"required_keys":       "必须包含{{if .Keys}}键 {{.Keys}}{{else}}必需的键{{end}}",
+	"safe_filename":       "不是安全的文件名",
+	"safe_html":           "包含不允许的 HTML",
+	"secret":              "不能包含密钥等敏感信息",
+	"shell_meta":          "不能包含 shell 元字符",
+	"social_credit":       "不是有效的统一社会信用代码",
+	"special_chars":       "不能包含特殊字符",
+	"sql_injection":       "包含疑似 SQL 注入的内容",
+	"ssh_public_key":      "不是有效的SSH公钥",
+	"ssn":                 "不是有效的美国社会安全号码",
+	"starts_with":         "必须以指定的前缀开头",
+	"subnet_mask":         "不是有效的子网掩码",
+	"tax_number":          "不是有效的{{if .Country}} {{.Country}} {{end}}税号",
+	"time_between":        "必须在指定的时间范围内",
+	"time_format":         "不是有效的时间",
+	"totp_secret":         "不是有效的 TOTP 密钥",
+	"type":                "类型不正确",
+	"ulid":                "不是有效的 ULID",
+	"unique_items":        "不能包含重复的元素",
+	"unknown_field":       "是未知字段",
+	"unresolvable_domain": "域名无法解析",
+	"upper_case_only":     "只能包含大写字母",
+	"url":                 "不是有效的 URL",
+	"uuid":                "不是有效的 UUID",
+	"weekend":             "必须是周末",
+	"within_last":         "必须在当前时间之前的指定时间段内",
+	"within_next":         "必须在当前时间之后的指定时间段内",
+	"workday":             "必须是工作日",
+	"xss":                 "包含疑似 XSS 攻击的内容",
+	"zero":                "必须为零",
+	"zip_archive":         "不是有效且安全的 zip 压缩包",
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// basicKinds maps the predeclared types supported by the rules to their kind.
+var basicKinds = map[string]string{
+	"string": "string",
+	"int":    "int", "int8": "int", "int16": "int", "int32": "int", "int64": "int",
+	"uint": "uint", "uint8": "uint", "uint16": "uint", "uint32": "uint", "uint64": "uint",
+	"float32": "float", "float64": "float",
+	"byte": "uint", "rune": "int",
+}
+
+// generate returns the source of the Validate methods for the struct types of the
+// package pkg with arbiter tags, or only for the given types if there are any.
+func generate(pkg string, files []*ast.File, typeNames []string) ([]byte, error) {
+	specs := make(map[string]*ast.TypeSpec)
+	var order []string
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				specs[spec.Name.Name] = spec
+				order = append(order, spec.Name.Name)
+			}
+			return true
+		})
+	}
+
+	explicit := len(typeNames) > 0
+	if !explicit {
+		typeNames = order
+	}
+	var methods bytes.Buffer
+	generated, usesArbiter, usesRule := 0, false, false
+	for _, name := range typeNames {
+		spec := specs[name]
+		st, ok := typeExpr(spec).(*ast.StructType)
+		if !ok {
+			if explicit {
+				return nil, fmt.Errorf("type %s is not a struct in package %s", name, pkg)
+			}
+			continue
+		}
+		fields, err := tagFields(st, specs)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		if len(fields) == 0 {
+			if explicit {
+				return nil, fmt.Errorf("type %s has no arbiter tags", name)
+			}
+			continue
+		}
+		embedded := embeddedFields(st)
+		writeMethod(&methods, name, embedded, fields)
+		generated++
+		usesArbiter = usesArbiter || len(embedded) > 0
+		for _, f := range fields {
+			for _, r := range f.rules {
+				usesArbiter = usesArbiter || !r.transform
+				usesRule = usesRule || strings.HasPrefix(r.code, "rule.")
+			}
+		}
+	}
+	if generated == 0 {
+		return nil, fmt.Errorf("no types with arbiter tags in package %s", pkg)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by arbitergen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	b.WriteString("import (\n\t\"errors\"\n\n")
+	if usesArbiter {
+		b.WriteString("\t\"github.com/byteweap/arbiter\"\n")
+	}
+	if usesRule {
+		b.WriteString("\t\"github.com/byteweap/arbiter/rule\"\n")
+	}
+	b.WriteString(")\n")
+	b.Write(methods.Bytes())
+	return format.Source(b.Bytes())
+}
+
+// typeExpr returns the type expression of spec, or nil if spec is nil or generic.
+func typeExpr(spec *ast.TypeSpec) ast.Expr {
+	if spec == nil || spec.TypeParams != nil {
+		return nil
+	}
+	return spec.Type
+}
+
+// tagField is a struct field with the rules declared in its arbiter tag.
+type tagField struct {
+	name  string
+	typ   string
+	tag   string // the literal of the struct tag
+	rules []tagRule
+}
+
+// tagRule is the code that creates a rule of a tag. Transformers modify the field instead
+// of checking it. The string rules, like rule.IsEmail, take fields of named string
+// types converted to string.
+type tagRule struct {
+	code      string
+	transform bool
+	convert   bool
+}
+
+// tagFields returns the fields of st with arbiter tags.
+func tagFields(st *ast.StructType, specs map[string]*ast.TypeSpec) ([]tagField, error) {
+	var fields []tagField
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		spec, ok := reflect.StructTag(tag).Lookup("arbiter")
+		if !ok {
+			continue
+		}
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("embedded field %s cannot have an arbiter tag", types.ExprString(f.Type))
+		}
+		typ := types.ExprString(f.Type)
+		kind := kindOf(f.Type, specs, 0)
+		for _, name := range f.Names {
+			rules, err := tagRules(spec, typ, kind)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name.Name, err)
+			}
+			if len(rules) > 0 {
+				fields = append(fields, tagField{name: name.Name, typ: typ, tag: f.Tag.Value, rules: rules})
+			}
+		}
+	}
+	return fields, nil
+}
+
+// embeddedField is an exported embedded field, which is validated with its own Validate
+// method if it has one.
+type embeddedField struct {
+	name string
+	ptr  bool
+}
+
+// embeddedFields returns the exported embedded fields of st.
+func embeddedFields(st *ast.StructType) []embeddedField {
+	var embedded []embeddedField
+	for _, f := range st.Fields.List {
+		if len(f.Names) > 0 {
+			continue
+		}
+		typ, ptr := f.Type, false
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ, ptr = star.X, true
+		}
+		if sel, ok := typ.(*ast.SelectorExpr); ok {
+			typ = sel.Sel
+		}
+		if ident, ok := typ.(*ast.Ident); ok && ident.IsExported() {
+			embedded = append(embedded, embeddedField{name: ident.Name, ptr: ptr})
+		}
+	}
+	return embedded
+}
+
+// kindOf returns the kind of the basic type underlying expr, such as "string" or
+// "int", following the type declarations of the package, or "" if it is not basic.
+func kindOf(expr ast.Expr, specs map[string]*ast.TypeSpec, depth int) string {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || depth > 8 {
+		return ""
+	}
+	if spec, ok := specs[ident.Name]; ok {
+		return kindOf(typeExpr(spec), specs, depth+1)
+	}
+	return basicKinds[ident.Name]
+}
+
+// tagRules returns the rules declared in spec for a field of type typ with the given kind.
+func tagRules(spec, typ, kind string) ([]tagRule, error) {
+	var rules []tagRule
+	for _, decl := range strings.Split(spec, ",") {
+		decl = strings.TrimSpace(decl)
+		if decl == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(decl, "=")
+		code, err := ruleCode(name, strings.Fields(param), typ, kind)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", name, err)
+		}
+		rules = append(rules, tagRule{
+			code:      code,
+			transform: name == "trim" || name == "lower" || name == "upper",
+			convert:   stringRules[name] != "" && typ != "string",
+		})
+	}
+	return rules, nil
+}
+
+// stringRules maps the rules of string fields to their constructors.
+var stringRules = map[string]string{"email": "IsEmail", "url": "URL", "trim": "Trim", "lower": "Lower", "upper": "Upper"}
+
+// ruleCode returns the code that creates the rule name with the given parameters. Other
+// rules without parameters are looked up when validating, as registered with
+// arbiter.RegisterFunc.
+func ruleCode(name string, params []string, typ, kind string) (string, error) {
+	switch name {
+	case "required":
+		if kind == "" {
+			return "", fmt.Errorf("%s fields are not supported", typ)
+		}
+		return fmt.Sprintf("rule.Required[%s]()", typ), checkParams(params, 0)
+	case "min", "max", "between":
+		want := map[string]int{"min": 1, "max": 1, "between": 2}[name]
+		if err := checkParams(params, want); err != nil {
+			return "", err
+		}
+		args, err := numbers(params, kind, typ)
+		if err != nil {
+			return "", err
+		}
+		constructor := map[string]string{"min": "Min", "max": "Max", "between": "Between"}[name]
+		return fmt.Sprintf("rule.%s[%s](%s)", constructor, typ, strings.Join(args, ", ")), nil
+	case "in":
+		args := params
+		if kind == "string" {
+			args = make([]string, len(params))
+			for i, p := range params {
+				args[i] = strconv.Quote(p)
+			}
+		} else {
+			var err error
+			if args, err = numbers(params, kind, typ); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("rule.In[%s](%s)", typ, strings.Join(args, ", ")), nil
+	case "len":
+		if err := checkParams(params, 2); err != nil {
+			return "", err
+		}
+		args, err := numbers(params, "int", "int")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("rule.Len[%s](%s)", typ, strings.Join(args, ", ")), nil
+	case "email", "url", "trim", "lower", "upper":
+		if kind != "string" {
+			return "", fmt.Errorf("%s fields are not supported", typ)
+		}
+		return fmt.Sprintf("rule.%s()", stringRules[name]), checkParams(params, 0)
+	}
+	if len(params) > 0 {
+		return "", fmt.Errorf("unknown rule")
+	}
+	return fmt.Sprintf("arbiter.NamedRule[%s](%q)", typ, name), nil
+}
+
+// checkParams returns an error unless there are want parameters.
+func checkParams(params []string, want int) error {
+	if len(params) != want {
+		return fmt.Errorf("expected %d parameters, got %d", want, len(params))
+	}
+	return nil
+}
+
+// numbers checks that params are numbers of the given kind.
+func numbers(params []string, kind, typ string) ([]string, error) {
+	for _, p := range params {
+		var err error
+		switch kind {
+		case "int":
+			_, err = strconv.ParseInt(p, 10, 64)
+		case "uint":
+			_, err = strconv.ParseUint(p, 10, 64)
+		case "float":
+			_, err = strconv.ParseFloat(p, 64)
+		default:
+			return nil, fmt.Errorf("%s fields are not supported", typ)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p)
+		}
+	}
+	return params, nil
+}
+
+// writeMethod writes the Validate method of the struct type name, which validates the
+// embedded fields with their Validate methods and then applies the rules of fields in
+// order, stopping at the first failure, like arbiter.ValidateStruct.
+func writeMethod(b *bytes.Buffer, name string, embedded []embeddedField, fields []tagField) {
+	fmt.Fprintf(b, "\n// Validate validates the fields of %s as declared in their arbiter tags.\n", name)
+	fmt.Fprintf(b, "func (v *%s) Validate() error {\n", name)
+	fmt.Fprintf(b, "\tif v == nil {\n\t\treturn errors.New(%q)\n\t}\n", name+" cannot be nil")
+	for _, e := range embedded {
+		ref := "&v." + e.name
+		if e.ptr {
+			ref = "v." + e.name
+		}
+		fmt.Fprintf(b, "\tif e, ok := any(%s).(arbiter.Validatable); ok", ref)
+		if e.ptr {
+			fmt.Fprintf(b, " && v.%s != nil", e.name)
+		}
+		b.WriteString(" {\n\t\tif err := e.Validate(); err != nil {\n\t\t\treturn err\n\t\t}\n\t}\n")
+	}
+	for _, f := range fields {
+		value := "v." + f.name
+		for _, r := range f.rules {
+			arg := value
+			if r.convert {
+				arg = "string(" + value + ")"
+			}
+			if r.transform {
+				result := r.code + ".Transform(" + arg + ")"
+				if r.convert {
+					result = f.typ + "(" + result + ")"
+				}
+				fmt.Fprintf(b, "\t%s = %s\n", value, result)
+				continue
+			}
+			fmt.Fprintf(b, "\t{\n\t\tr := %s\n", r.code)
+			fmt.Fprintf(b, "\t\tif err := r.Validate(%s); err != nil {\n", arg)
+			fmt.Fprintf(b, "\t\t\treturn arbiter.NewFieldError(v, &%s, %q, %s, r, err)\n", value, f.name, f.tag)
+			b.WriteString("\t\t}\n\t}\n")
+		}
+	}
+	b.WriteString("\treturn nil\n}\n")
+}
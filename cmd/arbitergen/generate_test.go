@@ -0,0 +1,226 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// parseSource parses src as the only file of a package.
+func parseSource(t *testing.T, src string) []*ast.File {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "models.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*ast.File{file}
+}
+
+func TestGenerate(t *testing.T) {
+	files := parseSource(t, `package models
+
+type Role string
+
+type User struct {
+	Name  string  `+"`json:\"name\" arbiter:\"trim,required,len=2 50\"`"+`
+	Age   int     `+"`arbiter:\"between=0 120\"`"+`
+	Role  Role    `+"`arbiter:\"in=admin editor\"`"+`
+	Score float64 `+"`arbiter:\"min=0.5\"`"+`
+	Login string  `+"`arbiter:\"required,username\"`"+`
+	Note  string
+}
+
+type Plain struct {
+	A int
+}
+`)
+
+	want := `// Code generated by arbitergen. DO NOT EDIT.
+
+package models
+
+import (
+	"errors"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// Validate validates the fields of User as declared in their arbiter tags.
+func (v *User) Validate() error {
+	if v == nil {
+		return errors.New("User cannot be nil")
+	}
+	v.Name = rule.Trim().Transform(v.Name)
+	{
+		r := rule.Required[string]()
+		if err := r.Validate(v.Name); err != nil {
+			return arbiter.NewFieldError(v, &v.Name, "Name", ` + "`json:\"name\" arbiter:\"trim,required,len=2 50\"`" + `, r, err)
+		}
+	}
+	{
+		r := rule.Len[string](2, 50)
+		if err := r.Validate(v.Name); err != nil {
+			return arbiter.NewFieldError(v, &v.Name, "Name", ` + "`json:\"name\" arbiter:\"trim,required,len=2 50\"`" + `, r, err)
+		}
+	}
+	{
+		r := rule.Between[int](0, 120)
+		if err := r.Validate(v.Age); err != nil {
+			return arbiter.NewFieldError(v, &v.Age, "Age", ` + "`arbiter:\"between=0 120\"`" + `, r, err)
+		}
+	}
+	{
+		r := rule.In[Role]("admin", "editor")
+		if err := r.Validate(v.Role); err != nil {
+			return arbiter.NewFieldError(v, &v.Role, "Role", ` + "`arbiter:\"in=admin editor\"`" + `, r, err)
+		}
+	}
+	{
+		r := rule.Min[float64](0.5)
+		if err := r.Validate(v.Score); err != nil {
+			return arbiter.NewFieldError(v, &v.Score, "Score", ` + "`arbiter:\"min=0.5\"`" + `, r, err)
+		}
+	}
+	{
+		r := rule.Required[string]()
+		if err := r.Validate(v.Login); err != nil {
+			return arbiter.NewFieldError(v, &v.Login, "Login", ` + "`arbiter:\"required,username\"`" + `, r, err)
+		}
+	}
+	{
+		r := arbiter.NamedRule[string]("username")
+		if err := r.Validate(v.Login); err != nil {
+			return arbiter.NewFieldError(v, &v.Login, "Login", ` + "`arbiter:\"required,username\"`" + `, r, err)
+		}
+	}
+	return nil
+}
+`
+	src, err := generate("models", files, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(src))
+
+	_, err = generate("models", files, []string{"Plain"})
+	assert.Error(t, err)
+	_, err = generate("models", files, []string{"Missing"})
+	assert.Error(t, err)
+}
+
+func TestGenerateRegisteredOnly(t *testing.T) {
+	files := parseSource(t, `package models
+
+type Account struct {
+	Login string `+"`json:\"login\" arbiter:\"username\"`"+`
+}
+`)
+
+	want := `// Code generated by arbitergen. DO NOT EDIT.
+
+package models
+
+import (
+	"errors"
+
+	"github.com/byteweap/arbiter"
+)
+
+// Validate validates the fields of Account as declared in their arbiter tags.
+func (v *Account) Validate() error {
+	if v == nil {
+		return errors.New("Account cannot be nil")
+	}
+	{
+		r := arbiter.NamedRule[string]("username")
+		if err := r.Validate(v.Login); err != nil {
+			return arbiter.NewFieldError(v, &v.Login, "Login", ` + "`json:\"login\" arbiter:\"username\"`" + `, r, err)
+		}
+	}
+	return nil
+}
+`
+	src, err := generate("models", files, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(src))
+}
+
+func TestGenerateNamedStringAndEmbedded(t *testing.T) {
+	files := parseSource(t, `package models
+
+type Email string
+
+type Contact struct {
+	Base
+	*Audit
+	Email Email `+"`arbiter:\"trim,email\"`"+`
+}
+`)
+
+	want := `// Code generated by arbitergen. DO NOT EDIT.
+
+package models
+
+import (
+	"errors"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// Validate validates the fields of Contact as declared in their arbiter tags.
+func (v *Contact) Validate() error {
+	if v == nil {
+		return errors.New("Contact cannot be nil")
+	}
+	if e, ok := any(&v.Base).(arbiter.Validatable); ok {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	if e, ok := any(v.Audit).(arbiter.Validatable); ok && v.Audit != nil {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	v.Email = Email(rule.Trim().Transform(string(v.Email)))
+	{
+		r := rule.IsEmail()
+		if err := r.Validate(string(v.Email)); err != nil {
+			return arbiter.NewFieldError(v, &v.Email, "Email", ` + "`arbiter:\"trim,email\"`" + `, r, err)
+		}
+	}
+	return nil
+}
+`
+	src, err := generate("models", files, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(src))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		typ  string
+	}{
+		{name: "unknown rule", tag: "shiny=1", typ: "string"},
+		{name: "missing parameter", tag: "min", typ: "int"},
+		{name: "invalid number", tag: "max=ten", typ: "int"},
+		{name: "negative unsigned", tag: "min=-1", typ: "uint8"},
+		{name: "invalid length", tag: "len=1", typ: "string"},
+		{name: "string rule on number", tag: "email", typ: "int"},
+		{name: "unsupported type", tag: "required", typ: "[]string"},
+		{name: "parameters of required", tag: "required=1", typ: "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := parseSource(t, "package models\n\ntype T struct {\n\tF "+tt.typ+" `arbiter:\""+tt.tag+"\"`\n}\n")
+			_, err := generate("models", files, nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Command arbitergen generates Validate methods from the arbiter struct tags of a
+// package. The generated methods call the rules directly on the field values, so the
+// tags are only read once, when the code is generated, the rules are type checked by the
+// compiler and no reflection is used. Like arbiter.ValidateStruct, they validate the
+// embedded structs that implement arbiter.Validatable first and stop at the first
+// failure, which is returned as an *arbiter.FieldError named after the field.
+//
+// The tag lists rules separated by commas, each optionally followed by "=" and its
+// parameters separated by spaces, like SchemaBuilder.Rules. The supported rules are
+// required, min, max, between, in, len, email, url, trim, lower and upper. Other names
+// refer to the functions registered with arbiter.RegisterFunc, which must be registered
+// before the generated methods are called.
+//
+// Example:
+//
+//	//go:generate go run github.com/byteweap/arbiter/cmd/arbitergen -type User
+//
+//	type User struct {
+//	    Name  string `arbiter:"trim,required,len=2 50"`
+//	    Age   int    `arbiter:"between=0 120"`
+//	    Email string `arbiter:"email"`
+//	}
+//
+//	// err := user.Validate()
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type names; default all types with arbiter tags")
+	output := flag.String("output", "", "output file name; default arbiter_gen.go in the package directory")
+	flag.Parse()
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, "arbiter_gen.go")
+	}
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	pkg, files, err := parseDir(dir)
+	if err != nil {
+		fatal(err)
+	}
+	src, err := generate(pkg, files, types)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+// fatal prints err and exits.
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "arbitergen:", err)
+	os.Exit(1)
+}
+
+// parseDir parses the Go files of the package in dir, except tests and generated files.
+func parseDir(dir string) (string, []*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	fset := token.NewFileSet()
+	var pkg string
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if ast.IsGenerated(file) {
+			continue
+		}
+		pkg = file.Name.Name
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, files, nil
+}
@@ -0,0 +1,152 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains rules that compare two fields of a struct.
+package arbiter
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// Errors of the cross-field rules. Their parameters hold the name of the other field as
+// "field".
+var (
+	// ErrFieldLess is returned when a field is not less than, or before, another field.
+	ErrFieldLess = rule.NewError("field_less", "value must be less than the other field")
+
+	// ErrFieldLTE is returned when a field is greater than, or after, another field.
+	ErrFieldLTE = rule.NewError("field_lte", "value must not be greater than the other field")
+)
+
+// CompareFieldRule validates a field by comparing it with another field of the same
+// struct, such as a start date with an end date. It implements IFieldRule, and its
+// errors belong to the first field.
+type CompareFieldRule[T any] struct {
+	field  *T
+	other  *T
+	cmp    func(a, b T) int
+	strict bool
+	e      error
+	name   string
+	groups []string
+}
+
+// FieldLess creates a rule that checks that the field is less than the other field, such
+// as a minimum price with the maximum price. It fails with ErrFieldLess.
+//
+// Example:
+//
+//	err := arbiter.ValidateStruct(filter, "Filter cannot be nil",
+//	    arbiter.FieldLess(&filter.MinAge, &filter.MaxAge),
+//	)
+func FieldLess[T cmp.Ordered](field, other *T) *CompareFieldRule[T] {
+	return &CompareFieldRule[T]{field: field, other: other, cmp: cmp.Compare[T], strict: true, e: ErrFieldLess}
+}
+
+// FieldLTE creates a rule that checks that the field is less than or equal to the other
+// field, such as "MinPrice ≤ MaxPrice". It fails with ErrFieldLTE.
+//
+// Example:
+//
+//	err := arbiter.ValidateStruct(query, "Query cannot be nil",
+//	    arbiter.FieldLTE(&query.MinPrice, &query.MaxPrice).Name("MinPrice"),
+//	)
+func FieldLTE[T cmp.Ordered](field, other *T) *CompareFieldRule[T] {
+	return &CompareFieldRule[T]{field: field, other: other, cmp: cmp.Compare[T], e: ErrFieldLTE}
+}
+
+// FieldBefore creates a rule that checks that the time field is before the other time
+// field, such as the start of a booking with its end. It fails with ErrFieldLess.
+//
+// Example:
+//
+//	err := arbiter.ValidateStruct(booking, "Booking cannot be nil",
+//	    arbiter.FieldBefore(&booking.StartAt, &booking.EndAt).Errf("The booking must end after it starts"),
+//	)
+func FieldBefore(field, other *time.Time) *CompareFieldRule[time.Time] {
+	return &CompareFieldRule[time.Time]{field: field, other: other, cmp: time.Time.Compare, strict: true, e: ErrFieldLess}
+}
+
+// FieldNotAfter creates a rule that checks that the time field is before or equal to the
+// other time field, such as the start of a date range with its end. It fails with
+// ErrFieldLTE.
+//
+// Example:
+//
+//	err := arbiter.ValidateStruct(report, "Report cannot be nil",
+//	    arbiter.FieldNotAfter(&report.From, &report.To),
+//	)
+func FieldNotAfter(field, other *time.Time) *CompareFieldRule[time.Time] {
+	return &CompareFieldRule[time.Time]{field: field, other: other, cmp: time.Time.Compare, e: ErrFieldLTE}
+}
+
+// Name sets the name of the field used in its errors, like FieldRule.Name.
+//
+// Example:
+//
+//	arbiter.FieldBefore(&booking.StartAt, &booking.EndAt).Name("StartAt")
+func (c *CompareFieldRule[T]) Name(name string) *CompareFieldRule[T] {
+	c.name = name
+	return c
+}
+
+// Groups sets the validation groups of the rule, like FieldRule.Groups.
+//
+// Example:
+//
+//	arbiter.FieldLTE(&q.MinPrice, &q.MaxPrice).Groups("search")
+func (c *CompareFieldRule[T]) Groups(groups ...string) *CompareFieldRule[T] {
+	c.groups = groups
+	return c
+}
+
+// Errf sets a custom error message for the rule. The error keeps the code of the
+// default error and wraps it.
+//
+// Example:
+//
+//	arbiter.FieldLTE(&q.MinPrice, &q.MaxPrice).Errf("The minimum price cannot exceed the maximum")
+func (c *CompareFieldRule[T]) Errf(format string, args ...any) *CompareFieldRule[T] {
+	if format != "" {
+		base := ErrFieldLTE
+		if c.strict {
+			base = ErrFieldLess
+		}
+		c.e = rule.Wrapf(base, format, args...)
+	}
+	return c
+}
+
+// check compares the fields and returns the error of the rule if the comparison fails.
+func (c *CompareFieldRule[T]) check(opts options) error {
+	if c.field == nil || c.other == nil {
+		return nil
+	}
+	n := c.cmp(*c.field, *c.other)
+	if n < 0 || n == 0 && !c.strict {
+		return nil
+	}
+	return &FieldError{
+		Field:  c.field,
+		Name:   c.name,
+		Err:    c.e,
+		Params: map[string]any{"field": fieldName(opts.root, c.other, GoKey)},
+	}
+}
+
+// validate compares the fields if the rule is selected.
+func (c *CompareFieldRule[T]) validate(opts options) error {
+	if !opts.inGroups(c.groups) || !opts.selects(c.name, c.field) {
+		return nil
+	}
+	return c.check(opts)
+}
+
+// collect appends the error of the comparison if it fails.
+func (c *CompareFieldRule[T]) collect(errs ValidationErrors, opts options) ValidationErrors {
+	if err := c.validate(opts); err != nil {
+		errs = append(errs, err.(*FieldError))
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the cross-field comparison rules.
+package arbiter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testBooking struct {
+	StartAt  time.Time
+	EndAt    time.Time
+	MinPrice float64
+	MaxPrice float64
+	MinAge   int
+	MaxAge   int
+}
+
+func TestCompareFields(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		booking testBooking
+		wantErr error
+		field   string
+	}{
+		{
+			name:    "valid",
+			booking: testBooking{StartAt: start, EndAt: start.Add(time.Hour), MinPrice: 10, MaxPrice: 10, MinAge: 18, MaxAge: 30},
+		},
+		{
+			name:    "ends before start",
+			booking: testBooking{StartAt: start, EndAt: start, MaxAge: 1},
+			wantErr: arbiter.ErrFieldLess,
+			field:   "StartAt",
+		},
+		{
+			name:    "min price above max price",
+			booking: testBooking{EndAt: start, MinPrice: 20, MaxPrice: 10, MaxAge: 1},
+			wantErr: arbiter.ErrFieldLTE,
+			field:   "MinPrice",
+		},
+		{
+			name:    "equal ages",
+			booking: testBooking{EndAt: start, MinAge: 30, MaxAge: 30},
+			wantErr: arbiter.ErrFieldLess,
+			field:   "MinAge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &tt.booking
+			err := arbiter.ValidateStruct(b, "Booking cannot be nil",
+				arbiter.FieldBefore(&b.StartAt, &b.EndAt),
+				arbiter.FieldLTE(&b.MinPrice, &b.MaxPrice),
+				arbiter.FieldLess(&b.MinAge, &b.MaxAge),
+			)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			var fe *arbiter.FieldError
+			if assert.True(t, errors.As(err, &fe)) {
+				assert.Equal(t, tt.field, fe.Name)
+			}
+		})
+	}
+}
+
+func TestCompareFieldsAll(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	b := &testBooking{StartAt: day, EndAt: day.AddDate(0, 0, -1), MinPrice: 5, MaxPrice: 1}
+	errs := validationErrors(arbiter.ValidateStructAll(b, "",
+		arbiter.FieldNotAfter(&b.StartAt, &b.EndAt).Errf("The range must not end before it starts"),
+		arbiter.FieldLTE(&b.MinPrice, &b.MaxPrice).Name("min_price"),
+		arbiter.FieldLTE(&b.MinAge, &b.MaxAge).Groups("age"),
+		arbiter.Group("dates"),
+	))
+	if assert.Len(t, errs, 2) {
+		assert.EqualError(t, errs[0], "The range must not end before it starts")
+		assert.Equal(t, "field_lte", rule.ErrorCode(errs[0].Err))
+		assert.Equal(t, map[string]any{"field": "MaxPrice"}, errs[1].Params)
+		assert.Equal(t, "min_price", errs[1].Name)
+	}
+}
@@ -0,0 +1,86 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the decoding of JSON with validation.
+package arbiter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// UnmarshalJSONValidated decodes the JSON data into v and validates it with schema,
+// so that decoding and validation are a single call. Decoding errors are returned as
+// they are; validation failures are returned as ValidationErrors with every failed rule.
+// A nil schema validates v with ValidateValue, that is with the schema registered for T.
+//
+// Example:
+//
+//	var user User
+//	if err := arbiter.UnmarshalJSONValidated(body, &user, userSchema); err != nil {
+//	    return err
+//	}
+func UnmarshalJSONValidated[T any](data []byte, v *T, schema *Schema[T]) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return validateDecoded(v, schema)
+}
+
+// Decoder reads JSON values of type T from a stream and validates each one after it is
+// decoded, like UnmarshalJSONValidated.
+type Decoder[T any] struct {
+	dec    *json.Decoder
+	schema *Schema[T]
+}
+
+// NewDecoder creates a decoder that reads from r and validates with schema, or with the
+// schema registered for T if schema is nil.
+//
+// Example:
+//
+//	dec := arbiter.NewDecoder(r.Body, userSchema).DisallowUnknownFields()
+//	var user User
+//	if err := dec.Decode(&user); err != nil {
+//	    return err
+//	}
+func NewDecoder[T any](r io.Reader, schema *Schema[T]) *Decoder[T] {
+	return &Decoder[T]{dec: json.NewDecoder(r), schema: schema}
+}
+
+// DisallowUnknownFields makes Decode return an error for objects with keys that do not
+// match an exported field of the destination, instead of ignoring them.
+func (d *Decoder[T]) DisallowUnknownFields() *Decoder[T] {
+	d.dec.DisallowUnknownFields()
+	return d
+}
+
+// UseNumber makes Decode decode numbers into interface values as json.Number instead of
+// float64.
+func (d *Decoder[T]) UseNumber() *Decoder[T] {
+	d.dec.UseNumber()
+	return d
+}
+
+// Decode reads the next JSON value into v and validates it. Decoding errors, including
+// io.EOF at the end of the stream, are returned as they are; validation failures are
+// returned as ValidationErrors.
+func (d *Decoder[T]) Decode(v *T) error {
+	if err := d.dec.Decode(v); err != nil {
+		return err
+	}
+	return validateDecoded(v, d.schema)
+}
+
+// More reports whether there is another value in the stream, such as another element
+// of an array being decoded.
+func (d *Decoder[T]) More() bool {
+	return d.dec.More()
+}
+
+// validateDecoded validates the decoded value v with schema, or with ValidateValue if
+// schema is nil.
+func validateDecoded[T any](v *T, schema *Schema[T]) error {
+	if schema == nil {
+		return ValidateValue(v)
+	}
+	return schema.ValidateAll(v)
+}
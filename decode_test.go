@@ -0,0 +1,75 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the decoding of JSON with validation.
+package arbiter_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testDecodeItem struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+var testDecodeSchema = arbiter.MustBuild(arbiter.For[testDecodeItem]().
+	Field("SKU", rule.Trim(), rule.Required[string]()).
+	Field("Quantity", rule.Between(1, 99)))
+
+func TestUnmarshalJSONValidated(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		wantLen int
+	}{
+		{name: "valid", data: `{"sku": " A1 ", "quantity": 2, "extra": true}`},
+		{name: "invalid", data: `{"sku": "", "quantity": 0}`, wantLen: 2},
+		{name: "malformed", data: `{"sku": 1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item testDecodeItem
+			err := arbiter.UnmarshalJSONValidated([]byte(tt.data), &item, testDecodeSchema)
+			var errs arbiter.ValidationErrors
+			switch {
+			case tt.wantLen > 0:
+				assert.True(t, errors.As(err, &errs))
+				assert.Len(t, errs, tt.wantLen)
+			case tt.wantErr:
+				assert.Error(t, err)
+				assert.False(t, errors.As(err, &errs))
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, testDecodeItem{SKU: "A1", Quantity: 2}, item)
+			}
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	dec := arbiter.NewDecoder(strings.NewReader(`{"sku": "A1", "quantity": 1} {"sku": "B2"}`), testDecodeSchema)
+	var first, second testDecodeItem
+	assert.NoError(t, dec.Decode(&first))
+	assert.ErrorIs(t, dec.Decode(&second), rule.ErrBetween)
+	assert.False(t, dec.More())
+	assert.ErrorIs(t, dec.Decode(&second), io.EOF)
+
+	dec = arbiter.NewDecoder(strings.NewReader(`{"sku": "A1", "quantity": 1, "price": 3}`), testDecodeSchema).
+		DisallowUnknownFields()
+	var item testDecodeItem
+	assert.ErrorContains(t, dec.Decode(&item), `unknown field "price"`)
+
+	arbiter.RegisterSchema(testDecodeSchema)
+	dec = arbiter.NewDecoder[testDecodeItem](strings.NewReader(`{"sku": "A1"}`), nil)
+	item = testDecodeItem{}
+	assert.ErrorIs(t, dec.Decode(&item), rule.ErrBetween)
+}
@@ -0,0 +1,67 @@
+// Package echovalidate routes the validation of the echo web framework through arbiter,
+// so that c.Validate validates structs with the schemas registered with
+// arbiter.RegisterSchema and returns arbiter.ValidationErrors.
+//
+// Validator implements the echo.Validator interface without importing echo, so this
+// package adds no dependencies.
+//
+// Example:
+//
+//	e := echo.New()
+//	e.Validator = echovalidate.New()
+//	arbiter.RegisterSchema(signupSchema)
+//
+//	e.POST("/signup", func(c echo.Context) error {
+//	    var req Signup
+//	    if err := c.Bind(&req); err != nil {
+//	        return err
+//	    }
+//	    if err := c.Validate(&req); err != nil {
+//	        return c.JSON(http.StatusUnprocessableEntity, err)
+//	    }
+//	    return c.NoContent(http.StatusCreated)
+//	})
+package echovalidate
+
+import (
+	"github.com/byteweap/arbiter"
+)
+
+// Validator validates the values passed to c.Validate with arbiter.ValidateValue.
+type Validator struct {
+	wrap func(err error) error
+}
+
+// New creates a validator for echo's Echo.Validator.
+//
+// Example:
+//
+//	e.Validator = echovalidate.New()
+func New() *Validator {
+	return &Validator{}
+}
+
+// WrapError sets a function that converts the errors of Validate, for example into an
+// *echo.HTTPError so that handlers can return them directly.
+//
+// Example:
+//
+//	e.Validator = echovalidate.New().WrapError(func(err error) error {
+//	    return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+//	})
+func (v *Validator) WrapError(wrap func(err error) error) *Validator {
+	v.wrap = wrap
+	return v
+}
+
+// Validate validates i, a struct or a pointer to one, with the schema registered for its
+// type, or its Validate method if it implements arbiter.Validatable. The error is an
+// arbiter.ValidationErrors with every failure for registered schemas, unless converted
+// by the WrapError function. Values of other types are not validated.
+func (v *Validator) Validate(i any) error {
+	err := arbiter.ValidateValue(i)
+	if err != nil && v.wrap != nil {
+		return v.wrap(err)
+	}
+	return err
+}
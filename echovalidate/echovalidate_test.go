@@ -0,0 +1,49 @@
+package echovalidate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// validator is the echo.Validator interface.
+type validator interface {
+	Validate(i any) error
+}
+
+type testComment struct {
+	Body string `json:"body"`
+}
+
+func init() {
+	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[testComment]().
+		Field("Body", rule.Trim(), rule.Len[string](1, 280))))
+}
+
+func TestValidate(t *testing.T) {
+	var v validator = New()
+
+	c := &testComment{Body: " hi "}
+	assert.NoError(t, v.Validate(c))
+	assert.Equal(t, "hi", c.Body)
+
+	err := v.Validate(&testComment{Body: "  "})
+	var errs arbiter.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 1) {
+		assert.Equal(t, "Body", errs[0].Name)
+		assert.ErrorIs(t, err, rule.ErrLength)
+	}
+	assert.NoError(t, v.Validate(&struct{ Body string }{}))
+
+	v = New().WrapError(func(err error) error {
+		return fmt.Errorf("code=422: %w", err)
+	})
+	err = v.Validate(&testComment{})
+	assert.ErrorContains(t, err, "code=422")
+	assert.True(t, errors.As(err, &errs))
+}
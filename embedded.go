@@ -0,0 +1,107 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the validation of embedded structs by ValidateStruct.
+package arbiter
+
+import (
+	"errors"
+	"reflect"
+)
+
+// embeddedErrors validates the embedded structs of the struct that root points to which
+// have a schema registered with RegisterSchema or implement Validatable, so that the
+// rules of a base type apply to every type that embeds it. Embedded structs behind a nil
+// pointer, and those given to a NestedField among fields, are skipped. If all is false,
+// it stops at the first failing embedded struct. The errors are named within root.
+func embeddedErrors(root any, fields []IFieldRule, opts options, all bool) ValidationErrors {
+	v := reflect.ValueOf(root).Elem()
+	var errs ValidationErrors
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+		embedded, ok := embeddedStruct(v.Field(i))
+		if !ok || hasNestedField(fields, embedded) {
+			continue
+		}
+		start := len(errs)
+		errs = appendErrors(errs, v.Field(i).Addr().Interface(), ValidateValue(embedded))
+		for _, fe := range errs[start:] {
+			rebase(fe, root, opts.locale)
+		}
+		if opts.only != nil {
+			errs = append(errs[:start], selected(errs[start:], opts)...)
+		}
+		if !all && len(errs) > 0 {
+			return errs[:1]
+		}
+	}
+	return errs
+}
+
+// embeddedStruct returns a pointer to the struct of the embedded field fv, or false if
+// it is not a struct or a non-nil pointer to one.
+func embeddedStruct(fv reflect.Value) (any, bool) {
+	switch {
+	case fv.Kind() == reflect.Struct:
+		return fv.Addr().Interface(), true
+	case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+		return fv.Interface(), true
+	}
+	return nil, false
+}
+
+// hasNestedField reports whether fields validate the struct that ptr points to with a
+// NestedField.
+func hasNestedField(fields []IFieldRule, ptr any) bool {
+	for _, field := range fields {
+		if n, ok := field.(*NestedFieldRule); ok && n.field == ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// appendErrors appends err, the error of an embedded struct, as field errors. Errors
+// that are not field errors belong to the embedded field that field points to.
+func appendErrors(errs ValidationErrors, field any, err error) ValidationErrors {
+	if err == nil {
+		return errs
+	}
+	var ve ValidationErrors
+	if errors.As(err, &ve) {
+		return append(errs, ve...)
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return append(errs, fe)
+	}
+	return append(errs, &FieldError{Field: field, Err: err})
+}
+
+// rebase names fe within root instead of the embedded struct it was validated in, and
+// renders its message again with the new name, from the catalog of locale if set.
+// Explicit names are kept.
+func rebase(fe *FieldError, root any, locale string) {
+	if fe.Name != "" && fe.path == nil {
+		return
+	}
+	fe.Name, fe.path = "", nil
+	fe.resolve(root)
+	fe.render(locale)
+}
+
+// selected returns the errors of the fields selected by Only.
+func selected(errs ValidationErrors, opts options) ValidationErrors {
+	var kept ValidationErrors
+	for _, fe := range errs {
+		name := fe.Name
+		if fe.path != nil {
+			name = fe.path.name(opts.only.key)
+		}
+		if opts.selects(name, fe.Field) {
+			kept = append(kept, fe)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,118 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the validation of embedded structs.
+package arbiter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type Audited struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Versioned struct {
+	Version int
+}
+
+func (t *Versioned) Validate() error {
+	if t.Version < 0 {
+		return errors.New("version cannot be negative")
+	}
+	return nil
+}
+
+type testDocument struct {
+	Audited
+	*Versioned `json:"timestamps"`
+	Title      string `json:"title"`
+}
+
+func init() {
+	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[Audited]().
+		Field("CreatedBy", rule.Required[string]())))
+}
+
+func TestValidateStructEmbedded(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     testDocument
+		wantErr string
+		jsonKey string
+	}{
+		{
+			name: "valid",
+			doc:  testDocument{Audited: Audited{CreatedBy: "ada"}, Versioned: &Versioned{}, Title: "a"},
+		},
+		{
+			name:    "nil embedded pointer",
+			doc:     testDocument{Audited: Audited{CreatedBy: "ada"}, Title: "a"},
+			wantErr: "",
+		},
+		{
+			name:    "schema",
+			doc:     testDocument{Title: "a"},
+			wantErr: "CreatedBy: required",
+			jsonKey: "created_by",
+		},
+		{
+			name:    "validate method",
+			doc:     testDocument{Audited: Audited{CreatedBy: "ada"}, Versioned: &Versioned{Version: -1}, Title: "a"},
+			wantErr: "Versioned: version cannot be negative",
+			jsonKey: "timestamps",
+		},
+		{
+			name:    "fields after embedded",
+			doc:     testDocument{Audited: Audited{CreatedBy: "ada"}},
+			wantErr: "Title: required",
+			jsonKey: "title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &tt.doc
+			err := arbiter.ValidateStruct(doc, "Document cannot be nil",
+				arbiter.Field(&doc.Title, rule.Required[string]()),
+			)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			var fe *arbiter.FieldError
+			if assert.True(t, errors.As(err, &fe)) {
+				assert.Equal(t, tt.jsonKey, fe.Key(arbiter.JSONKey))
+			}
+		})
+	}
+}
+
+func TestValidateStructAllEmbedded(t *testing.T) {
+	doc := &testDocument{Versioned: &Versioned{Version: -1}}
+	errs := validationErrors(arbiter.ValidateStructAll(doc, "Document cannot be nil",
+		arbiter.Field(&doc.Title, rule.Required[string]()),
+	))
+	if assert.Len(t, errs, 3) {
+		assert.Equal(t, []string{"CreatedBy", "Versioned", "Title"}, []string{errs[0].Name, errs[1].Name, errs[2].Name})
+		assert.Equal(t, &doc.CreatedBy, errs[0].Field)
+	}
+
+	errs = validationErrors(arbiter.ValidateStructAll(doc, "Document cannot be nil",
+		arbiter.Field(&doc.Title, rule.Required[string]()),
+		arbiter.Only("Title"),
+	))
+	assert.Len(t, errs, 1)
+
+	// An embedded struct given to a NestedField is validated by its rules only
+	errs = validationErrors(arbiter.ValidateStructAll(doc, "Document cannot be nil",
+		arbiter.NestedField(&doc.Audited),
+		arbiter.NestedField(doc.Versioned),
+	))
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,278 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the error types returned by ValidateStruct and ValidateStructAll.
+package arbiter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// FieldError is a validation error of a single struct field.
+// Field is the pointer that was passed to Field, so the error can be matched to the
+// field it belongs to; it is nil for errors of the struct itself, such as a nil pointer.
+// Name is the name set with FieldRule.Name, joined with the names of enclosing nested
+// and slice fields. Fields without a name are named after their path in the validated
+// struct, such as "Age", "Address.City" or "Tags[1]".
+//
+// Example:
+//
+//	var errs ValidationErrors
+//	errors.As(ValidateStructAll(user, "User cannot be nil", fields...), &errs)
+//	for _, fe := range errs {
+//	    if fe.Field == &user.Email {
+//	        // Show fe.Err next to the email input
+//	    }
+//	}
+type FieldError struct {
+	Field any
+	Name  string
+	Err   error
+	// Params are the parameters of the failed rule, such as the bounds of rule.Between,
+	// if it implements rule.Parameterized.
+	Params map[string]any
+	// Message is the message rendered from the template set with SetMessageTemplate for
+	// the rule's error code. If set, it is the whole error message, without the name prefix.
+	Message string
+
+	// path is the path of the field in the validated struct, used to name the field by
+	// other keys. It is nil if the field has an explicit name or is not part of the struct.
+	path fieldPath
+}
+
+// resolve names the field after its path in root unless it has an explicit name.
+func (e *FieldError) resolve(root any) {
+	if e.Name != "" {
+		return
+	}
+	e.path = findPath(root, e.Field)
+	e.Name = e.path.name(GoKey)
+}
+
+// NewFieldError returns the error of the struct field name, with the struct tag tag, that
+// field points to in the struct that root points to, for the failure err of rule r. It
+// builds the error as ValidateStruct does without searching the struct, for the Validate
+// methods generated by cmd/arbitergen, and passes it to the logger set with SetLogger.
+//
+// Example:
+//
+//	r := rule.Required[string]()
+//	if err := r.Validate(v.Name); err != nil {
+//	    return arbiter.NewFieldError(v, &v.Name, "Name", `json:"name"`, r, err)
+//	}
+func NewFieldError(root, field any, name, tag string, r any, err error) *FieldError {
+	fe := &FieldError{
+		Field:  field,
+		Name:   name,
+		Err:    err,
+		Params: params(r),
+		path:   fieldPath{{field: reflect.StructField{Name: name, Tag: reflect.StructTag(tag)}, index: -1}},
+	}
+	fe.render("")
+	logErrors(root, fe)
+	return fe
+}
+
+// Key returns the name of the field with each struct field named by key, such as
+// "address.city" for JSONKey. Explicit names set with FieldRule.Name are returned as is.
+//
+// Example:
+//
+//	var fe *FieldError
+//	if errors.As(err, &fe) {
+//	    field := fe.Key(JSONKey)  // "email"
+//	}
+func (e *FieldError) Key(key FieldKey) string {
+	if e.path == nil {
+		return e.Name
+	}
+	return e.path.name(key)
+}
+
+// Error returns the message rendered from a template or, if there is none, the message
+// of the underlying error, prefixed with the field name unless it was set with Errf.
+func (e *FieldError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Name == "" || customMessage(e.Err) {
+		return e.Err.Error()
+	}
+	return e.Name + ": " + e.Err.Error()
+}
+
+// customMessage reports whether the message of err was set with Errf, or, for the
+// joined errors of FieldRule.All, whether all of their messages were. Such messages are
+// written for the field and are not prefixed with its name.
+func customMessage(err error) bool {
+	if re, ok := err.(*rule.Error); ok {
+		return re.Custom()
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		for _, err := range errs {
+			if !customMessage(err) {
+				return false
+			}
+		}
+		return len(errs) > 0
+	}
+	return false
+}
+
+// Unwrap returns the underlying error, so errors.Is and errors.As see the rule's error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of field errors returned by ValidateStructAll and
+// Schema.ValidateAll, in the order the fields and their rules were given. They return
+// it as an error; use errors.As to get it.
+//
+// Example:
+//
+//	var errs ValidationErrors
+//	if err := ValidateStructAll(user, "User cannot be nil", fields...); errors.As(err, &errs) {
+//	    emailErrs := errs.For(&user.Email)  // every error of the email field
+//	}
+type ValidationErrors []*FieldError
+
+// Error joins the messages of all field errors with "; ".
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is and errors.As search all of them.
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, e := range v {
+		errs[i] = e
+	}
+	return errs
+}
+
+// For returns the errors of the field with the given pointer, or nil if it is valid.
+//
+// Example:
+//
+//	var errs ValidationErrors
+//	errors.As(ValidateStructAll(user, "User cannot be nil",
+//	    Field(&user.Password, rule.Len[string](8, 64), rule.Regex(`[0-9]`)),
+//	), &errs)
+//	passwordErrs := errs.For(&user.Password)  // both errors for the password "secret"
+func (v ValidationErrors) For(field any) []error {
+	var errs []error
+	for _, e := range v {
+		if e.Field == field {
+			errs = append(errs, e.Err)
+		}
+	}
+	return errs
+}
+
+// ByField returns the errors grouped by field name, for example to render an API error
+// payload. Errors of unnamed fields are grouped under "".
+//
+// Example:
+//
+//	var errs ValidationErrors
+//	errors.As(ValidateStructAll(user, "User cannot be nil",
+//	    Field(&user.Email, rule.IsEmail()).Name("email"),
+//	), &errs)
+//	payload := errs.ByField()  // map[email:[invalid email format]]
+func (v ValidationErrors) ByField() map[string][]error {
+	if len(v) == 0 {
+		return nil
+	}
+	fields := make(map[string][]error)
+	for _, e := range v {
+		fields[e.Name] = append(fields[e.Name], e.Err)
+	}
+	return fields
+}
+
+// ByKey returns the errors grouped by field name like ByField, with each struct field
+// named by key, so that API error payloads use the names of the wire format.
+//
+// Example:
+//
+//	type User struct {
+//	    Email   string  `json:"email"`
+//	    Address Address `json:"address"`
+//	}
+//
+//	var errs ValidationErrors
+//	errors.As(ValidateStructAll(user, "User cannot be nil",
+//	    Field(&user.Email, rule.IsEmail()),
+//	    Field(&user.Address.City, rule.Required[string]()),
+//	), &errs)
+//	payload := errs.ByKey(JSONKey)  // map[email:[...] address.city:[...]]
+func (v ValidationErrors) ByKey(key FieldKey) map[string][]error {
+	if len(v) == 0 {
+		return nil
+	}
+	fields := make(map[string][]error)
+	for _, e := range v {
+		name := e.Key(key)
+		fields[name] = append(fields[name], e.Err)
+	}
+	return fields
+}
+
+// params returns the parameters of r if it implements rule.Parameterized.
+func params(r any) map[string]any {
+	if p, ok := r.(rule.Parameterized); ok {
+		return p.Params()
+	}
+	return nil
+}
+
+// fieldErrorJSON is the JSON representation of a FieldError.
+type fieldErrorJSON struct {
+	Field   string         `json:"field"`
+	Code    string         `json:"code,omitempty"`
+	Message string         `json:"message"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+// MarshalJSON encodes the error as an object with the field name from its json tag, the
+// rule's error code, the message and the rule's parameters.
+//
+// Example:
+//
+//	data, _ := json.Marshal(fe)
+//	// {"field":"age","code":"min","message":"value is less than minimum","params":{"min":0}}
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fieldErrorJSON{
+		Field:   e.Key(JSONKey),
+		Code:    rule.ErrorCode(e.Err),
+		Message: rule.Ternary(e.Message != "", e.Message, e.Err.Error()),
+		Params:  e.Params,
+	})
+}
+
+// MarshalJSON encodes the errors as an object with an "errors" list, so that HTTP
+// handlers can return them as the response body.
+//
+// Example:
+//
+//	if err := ValidateStructAll(user, "User cannot be nil", fields...); err != nil {
+//	    w.WriteHeader(http.StatusUnprocessableEntity)
+//	    json.NewEncoder(w).Encode(err)
+//	    // {"errors":[{"field":"age","code":"min","message":"value is less than minimum","params":{"min":0}}]}
+//	}
+func (v ValidationErrors) MarshalJSON() ([]byte, error) {
+	errs := []*FieldError(v)
+	if errs == nil {
+		errs = []*FieldError{}
+	}
+	return json.Marshal(struct {
+		Errors []*FieldError `json:"errors"`
+	}{Errors: errs})
+}
@@ -0,0 +1,105 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains Explain, which reports the outcome of every rule of a schema.
+package arbiter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// RuleResult is the outcome of a rule of a schema field, as reported by Explain.
+type RuleResult struct {
+	// Field is the name of the field as declared in the schema, such as "Address.City".
+	Field string
+	// Rule describes the rule.
+	Rule rule.RuleInfo
+	// Skipped reports that the rule was not applied because the field is behind a nil
+	// pointer.
+	Skipped bool
+	// Err is the error of the rule, or nil if it passed or was skipped.
+	Err error
+}
+
+// Passed reports whether the rule was applied and passed.
+func (r RuleResult) Passed() bool {
+	return !r.Skipped && r.Err == nil
+}
+
+// Report is the list of rule outcomes returned by Explain, in the order the rules are
+// applied.
+type Report []RuleResult
+
+// Failed returns the results of the rules that failed.
+//
+// Example:
+//
+//	for _, r := range arbiter.Explain(userSchema, user).Failed() {
+//	    log.Printf("%s %s: %v", r.Field, r.Rule.Name, r.Err)
+//	}
+func (r Report) Failed() Report {
+	var failed Report
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// String formats the report with one line per rule, such as "FAIL Age max: value is
+// greater than maximum".
+func (r Report) String() string {
+	var b strings.Builder
+	for _, result := range r {
+		switch {
+		case result.Skipped:
+			b.WriteString("SKIP ")
+		case result.Err != nil:
+			b.WriteString("FAIL ")
+		default:
+			b.WriteString("PASS ")
+		}
+		b.WriteString(result.Field + " " + result.Rule.Name)
+		if result.Err != nil {
+			b.WriteString(": " + result.Err.Error())
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Explain applies every rule of the schema to a copy of value, without stopping at the
+// first failure, and reports the outcome of each, to help find out why a value is
+// rejected. Transformers are applied to the copy, so the rules after them see the
+// transformed value as they would with Validate, but value itself is not modified.
+// Fields behind pointers are shared with the copy and may be transformed. A nil value
+// returns an empty report.
+//
+// Example:
+//
+//	fmt.Print(arbiter.Explain(userSchema, user))
+//	// PASS Name transform
+//	// PASS Name length
+//	// PASS Age min
+//	// FAIL Age max: value is greater than maximum
+func Explain[T any](s *Schema[T], value *T) Report {
+	if value == nil {
+		return nil
+	}
+	cp := *value
+	v := reflect.ValueOf(&cp).Elem()
+	var report Report
+	for _, field := range s.fields {
+		fv, ok := field.value(v)
+		for _, r := range field.rules {
+			result := RuleResult{Field: field.name, Rule: rule.Describe(r.rule), Skipped: !ok}
+			if ok {
+				result.Err = r.check(fv)
+			}
+			report = append(report, result)
+		}
+	}
+	return report
+}
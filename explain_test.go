@@ -0,0 +1,43 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify Explain.
+package arbiter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+func TestExplain(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaUser]().
+		Field("Name", rule.Trim(), rule.Len[string](2, 10)).
+		Field("Age", rule.Min(0), rule.Max(120)).
+		Field("Home.City", rule.Required[string]()))
+
+	user := &testSchemaUser{Name: " A ", Age: 130}
+	report := arbiter.Explain(schema, user)
+	assert.Equal(t, " A ", user.Name)
+	if assert.Len(t, report, 5) {
+		assert.True(t, report[0].Passed())
+		assert.ErrorIs(t, report[1].Err, rule.ErrLength)
+		assert.True(t, report[2].Passed())
+		assert.ErrorIs(t, report[3].Err, rule.ErrMax)
+		assert.True(t, report[4].Skipped)
+		assert.False(t, report[4].Passed())
+	}
+	assert.Equal(t, "PASS Name transform\n"+
+		"FAIL Name length: length is not between 2 and 10\n"+
+		"PASS Age min\n"+
+		"FAIL Age max: "+rule.ErrMax.Error()+"\n"+
+		"SKIP Home.City required\n", report.String())
+
+	failed := report.Failed()
+	if assert.Len(t, failed, 2) {
+		assert.Equal(t, "Name", failed[0].Field)
+		assert.Equal(t, "Age", failed[1].Field)
+	}
+	assert.Empty(t, arbiter.Explain(schema, nil))
+}
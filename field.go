@@ -2,7 +2,12 @@
 // This file contains types and functions for validating struct fields.
 package arbiter
 
-import "github.com/byteweap/arbiter/rule"
+import (
+	"errors"
+	"strconv"
+
+	"github.com/byteweap/arbiter/rule"
+)
 
 // IFieldRule is an interface that defines the contract for field validation rules.
 // Any type that implements this interface can be used with ValidateStruct.
@@ -15,7 +20,7 @@ import "github.com/byteweap/arbiter/rule"
 //	    rules []rule.Rule[string]
 //	}
 //
-//	func (f *CustomFieldRule) validate() error {
+//	func (f *CustomFieldRule) validate(opts options) error {
 //	    for _, rule := range f.rules {
 //	        if err := rule.Validate(*f.field); err != nil {
 //	            return err
@@ -24,7 +29,9 @@ import "github.com/byteweap/arbiter/rule"
 //	    return nil
 //	}
 type IFieldRule interface {
-	validate() error
+	validate(opts options) error
+	// collect appends the errors of every failed rule to errs, as used by ValidateStructAll.
+	collect(errs ValidationErrors, opts options) ValidationErrors
 }
 
 // FieldRule is a generic type that implements IFieldRule for validating a field
@@ -65,13 +72,18 @@ type IFieldRule interface {
 //	    nameRule, ageRule, emailRule,
 //	)
 type FieldRule[T any] struct {
-	field *T
-	rules []rule.Rule[T]
+	field  *T
+	rules  []rule.Rule[T]
+	name   string
+	groups []string
+	all    bool
 }
 
 // Field creates a new field validation rule for a field of any type.
 // The field parameter is a pointer to the field to validate.
 // The rules parameter is a list of validation rules to apply to the field.
+// Rules that implement rule.Transformer, such as rule.Trim, modify the field in place
+// before the rules after them are applied.
 //
 // Example:
 //
@@ -104,8 +116,58 @@ func Field[T any](field *T, rules ...rule.Rule[T]) *FieldRule[T] {
 	return &FieldRule[T]{field: field, rules: rules}
 }
 
+// Name sets the name of the field used in its errors. Errors of a named field are
+// returned as a *FieldError whose message is prefixed with the name, such as
+// "Email: invalid email format".
+//
+// Example:
+//
+//	err := ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.Email, rule.IsEmail()).Name("Email"),
+//	)
+//	var fe *FieldError
+//	if errors.As(err, &fe) {
+//	    // fe.Name is "Email"
+//	}
+func (f *FieldRule[T]) Name(name string) *FieldRule[T] {
+	f.name = name
+	return f
+}
+
+// Groups sets the validation groups of the field. The field is only validated when no
+// group or one of its groups is selected with Group.
+//
+// Example:
+//
+//	err := ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.ID, rule.Required[int]()).Groups("update", "patch"),
+//	    Group("create"),
+//	)  // ID is not validated
+func (f *FieldRule[T]) Groups(groups ...string) *FieldRule[T] {
+	f.groups = groups
+	return f
+}
+
+// All makes the field apply every rule instead of stopping at the first failure.
+// The errors of all failed rules are joined with errors.Join, so each of them
+// can still be matched with errors.Is.
+//
+// Example:
+//
+//	err := ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.Password,
+//	        rule.Len[string](8, 64).Errf("Password must be at least 8 characters"),
+//	        rule.Regex(`[0-9]`).Errf("Password must contain a digit"),
+//	    ).All(),
+//	)
+//	// err reports both problems for the password "secret"
+func (f *FieldRule[T]) All() *FieldRule[T] {
+	f.all = true
+	return f
+}
+
 // validate applies all validation rules to the field.
-// It returns nil if all rules pass, or the first error encountered.
+// It returns nil if all rules pass, or the first error encountered unless All is set.
 //
 // Example:
 //
@@ -131,25 +193,75 @@ func Field[T any](field *T, rules ...rule.Rule[T]) *FieldRule[T] {
 //	)
 //
 //	// Validate fields directly
-//	err := nameRule.validate()  // returns nil
-//	err = priceRule.validate()  // returns nil
+//	err := nameRule.validate(options{})  // returns nil
+//	err = priceRule.validate(options{})  // returns nil
 //
 //	// Or use with ValidateStruct
 //	err = ValidateStruct(product, "Product cannot be nil",
 //	    nameRule, priceRule,
 //	)
-func (f *FieldRule[T]) validate() error {
+func (f *FieldRule[T]) validate(opts options) error {
+	if !opts.inGroups(f.groups) || !opts.selects(f.name, f.field) {
+		return nil
+	}
+	if f.all {
+		var errs []error
+		for _, r := range f.rules {
+			if err := f.check(r, opts); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) == 0 {
+			return nil
+		}
+		return f.fieldError(errors.Join(errs...), nil)
+	}
 	for _, r := range f.rules {
-		if err := r.Validate(*f.field); err != nil {
-			return err
+		if err := f.check(r, opts); err != nil {
+			return f.fieldError(err, r)
 		}
 	}
 	return nil
 }
 
+// check transforms the field in place if r is a rule.Transformer, or validates it
+// otherwise, with the context of opts if r is a rule.ContextRule.
+func (f *FieldRule[T]) check(r rule.Rule[T], opts options) error {
+	if t, ok := r.(rule.Transformer[T]); ok {
+		*f.field = t.Transform(*f.field)
+		return nil
+	}
+	if c, ok := r.(rule.ContextRule[T]); ok && opts.ctx != nil {
+		return c.ValidateContext(opts.ctx, *f.field)
+	}
+	return r.Validate(*f.field)
+}
+
+// fieldError wraps err in a *FieldError for the field, with the parameters of the
+// failed rule r if it has any.
+func (f *FieldRule[T]) fieldError(err error, r rule.Rule[T]) error {
+	return &FieldError{Field: f.field, Name: f.name, Err: err, Params: params(r)}
+}
+
+// collect applies all validation rules to the field and appends an error for each failed rule.
+func (f *FieldRule[T]) collect(errs ValidationErrors, opts options) ValidationErrors {
+	if !opts.inGroups(f.groups) || !opts.selects(f.name, f.field) {
+		return errs
+	}
+	for _, r := range f.rules {
+		if err := f.check(r, opts); err != nil {
+			errs = append(errs, &FieldError{Field: f.field, Name: f.name, Err: err, Params: params(r)})
+		}
+	}
+	return errs
+}
+
 // NestedFieldRule validates a nested struct field by applying a list of sub-field rules.
 type NestedFieldRule struct {
+	field  any
 	fields []IFieldRule
+	name   string
+	groups []string
 }
 
 // NestedField creates a validation rule for a nested struct field.
@@ -174,25 +286,82 @@ type NestedFieldRule struct {
 //	        arbiter.Field(&user.Address.Street, rule.Len[string](1, 100)),
 //	    ),
 //	)
-func NestedField(_ any, fields ...IFieldRule) *NestedFieldRule {
-	return &NestedFieldRule{fields: fields}
+func NestedField(field any, fields ...IFieldRule) *NestedFieldRule {
+	return &NestedFieldRule{field: field, fields: fields}
+}
+
+// Name sets the name of the nested struct, which prefixes the names of its sub-fields
+// in errors, such as "Address.City: required".
+//
+// Example:
+//
+//	arbiter.NestedField(&user.Address,
+//	    arbiter.Field(&user.Address.City, rule.Required[string]()).Name("City"),
+//	).Name("Address")
+func (n *NestedFieldRule) Name(name string) *NestedFieldRule {
+	n.name = name
+	return n
+}
+
+// Groups sets the validation groups of the nested struct, which apply to all its sub-fields.
+//
+// Example:
+//
+//	arbiter.NestedField(&user.Billing,
+//	    arbiter.Field(&user.Billing.IBAN, rule.Required[string]()),
+//	).Groups("checkout")
+func (n *NestedFieldRule) Groups(groups ...string) *NestedFieldRule {
+	n.groups = groups
+	return n
 }
 
 // validate applies all sub-field rules to the nested struct.
 // Returns nil if all rules pass, or the first error encountered.
-func (n *NestedFieldRule) validate() error {
+func (n *NestedFieldRule) validate(opts options) error {
+	if !opts.inGroups(n.groups) {
+		return nil
+	}
+	opts, ok, err := opts.enter(n.field)
+	if err != nil {
+		return &FieldError{Field: n.field, Name: n.name, Err: err}
+	}
+	if !ok {
+		return nil
+	}
 	for _, field := range n.fields {
-		if err := field.validate(); err != nil {
-			return err
+		if err := field.validate(opts); err != nil {
+			return prefixError(n.field, n.name, err)
 		}
 	}
 	return nil
 }
 
+// collect appends the errors of all sub-field rules of the nested struct.
+func (n *NestedFieldRule) collect(errs ValidationErrors, opts options) ValidationErrors {
+	if !opts.inGroups(n.groups) {
+		return errs
+	}
+	opts, ok, err := opts.enter(n.field)
+	if err != nil {
+		return append(errs, &FieldError{Field: n.field, Name: n.name, Err: err})
+	}
+	if !ok {
+		return errs
+	}
+	start := len(errs)
+	for _, field := range n.fields {
+		errs = field.collect(errs, opts)
+	}
+	prefixNames(errs[start:], n.name)
+	return errs
+}
+
 // SliceFieldRule validates each element in a slice by applying rules generated from a callback.
 type SliceFieldRule[T any] struct {
-	field *[]T
-	fn    func(*T) IFieldRule
+	field  *[]T
+	fn     func(*T) IFieldRule
+	name   string
+	groups []string
 }
 
 // SliceField creates a validation rule for a slice field.
@@ -214,17 +383,109 @@ func SliceField[T any](field *[]T, fn func(*T) IFieldRule) *SliceFieldRule[T] {
 	return &SliceFieldRule[T]{field: field, fn: fn}
 }
 
+// Name sets the name of the slice. Errors of its elements are named with the index,
+// such as "Tags[1]: required".
+//
+// Example:
+//
+//	arbiter.SliceField(&user.Tags, func(tag *string) arbiter.IFieldRule {
+//	    return arbiter.Field(tag, rule.Required[string]())
+//	}).Name("Tags")
+func (s *SliceFieldRule[T]) Name(name string) *SliceFieldRule[T] {
+	s.name = name
+	return s
+}
+
+// Groups sets the validation groups of the slice, which apply to all its elements.
+//
+// Example:
+//
+//	arbiter.SliceField(&order.Items, func(item *Item) arbiter.IFieldRule {
+//	    return arbiter.Field(&item.Quantity, rule.Positivev[int]())
+//	}).Groups("create")
+func (s *SliceFieldRule[T]) Groups(groups ...string) *SliceFieldRule[T] {
+	s.groups = groups
+	return s
+}
+
+// elementName returns the name of the element at index i, or "" if the slice is unnamed.
+func (s *SliceFieldRule[T]) elementName(i int) string {
+	if s.name == "" {
+		return ""
+	}
+	return s.name + "[" + strconv.Itoa(i) + "]"
+}
+
 // validate iterates over each element in the slice and applies the rules from the callback.
 // Returns nil if all elements pass, or the first error encountered.
-func (s *SliceFieldRule[T]) validate() error {
-	if s.fn == nil || s.field == nil {
+func (s *SliceFieldRule[T]) validate(opts options) error {
+	if s.fn == nil || s.field == nil || !opts.inGroups(s.groups) {
+		return nil
+	}
+	opts, ok, err := opts.enter(s.field)
+	if err != nil {
+		return &FieldError{Field: s.field, Name: s.name, Err: err}
+	}
+	if !ok {
 		return nil
 	}
 	for i := range *s.field {
 		f := s.fn(&(*s.field)[i])
-		if err := f.validate(); err != nil {
-			return err
+		if err := f.validate(opts); err != nil {
+			return prefixError(&(*s.field)[i], s.elementName(i), err)
 		}
 	}
 	return nil
 }
+
+// collect appends the errors of the rules from the callback for every element in the slice.
+func (s *SliceFieldRule[T]) collect(errs ValidationErrors, opts options) ValidationErrors {
+	if s.fn == nil || s.field == nil || !opts.inGroups(s.groups) {
+		return errs
+	}
+	opts, ok, err := opts.enter(s.field)
+	if err != nil {
+		return append(errs, &FieldError{Field: s.field, Name: s.name, Err: err})
+	}
+	if !ok {
+		return errs
+	}
+	for i := range *s.field {
+		start := len(errs)
+		errs = s.fn(&(*s.field)[i]).collect(errs, opts)
+		prefixNames(errs[start:], s.elementName(i))
+	}
+	return errs
+}
+
+// prefixError prefixes the name of err with the name of its parent. An error without
+// a name is wrapped in a *FieldError for the parent field.
+func prefixError(field any, name string, err error) error {
+	if name == "" {
+		return err
+	}
+	if fe, ok := err.(*FieldError); ok {
+		prefixed := *fe
+		prefixed.Name = joinName(name, fe.Name)
+		return &prefixed
+	}
+	return &FieldError{Field: field, Name: name, Err: err}
+}
+
+// prefixNames prefixes the names of errs with the name of their parent.
+func prefixNames(errs ValidationErrors, name string) {
+	if name == "" {
+		return
+	}
+	for _, fe := range errs {
+		fe.Name = joinName(name, fe.Name)
+	}
+}
+
+// joinName joins the name of a parent and a child field with a dot.
+func joinName(parent, child string) string {
+	if child == "" {
+		return parent
+	}
+	return parent + "." + child
+}
@@ -3,6 +3,9 @@
 package arbiter_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/byteweap/arbiter"
@@ -178,6 +181,48 @@ func TestFieldCustomError(t *testing.T) {
 	}
 }
 
+func TestFieldAll(t *testing.T) {
+	user := &testUser{Password: "secret"}
+	tooShort := errors.New("password is too short")
+	noDigit := errors.New("password must contain a digit")
+
+	err := arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Password,
+			rule.Len[string](8, 64).Errf(tooShort.Error()),
+			rule.Regex(`[0-9]`).Errf(noDigit.Error()),
+		).All(),
+	)
+	if err == nil || err.Error() != "password is too short\npassword must contain a digit" {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+
+	// Without All only the first failure is reported
+	err = arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Password, rule.Len[string](8, 64), rule.Regex(`[0-9]`)),
+	)
+	if err == nil || err.Error() != "Password: length is not between 8 and 64" {
+		t.Errorf("Expected first error only, got %v", err)
+	}
+
+	user.Password = "secret123"
+	err = arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Password, rule.Len[string](8, 64), rule.Regex(`[0-9]`)).All(),
+	)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestFieldAllErrorsIs(t *testing.T) {
+	user := &testUser{Age: -3}
+	err := arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Age, rule.Positivev[int](), rule.Even[int]()).All(),
+	)
+	if !errors.Is(err, rule.ErrPositive) || !errors.Is(err, rule.ErrEven) {
+		t.Errorf("Expected errors.Is to match both errors, got %v", err)
+	}
+}
+
 // Nested struct tests
 
 type testAddress struct {
@@ -416,3 +461,439 @@ func TestSliceFieldNilFn(t *testing.T) {
 		t.Errorf("Expected no error for nil callback, got %v", err)
 	}
 }
+
+func TestFieldName(t *testing.T) {
+	user := &testUser{Username: "jo", Age: -1}
+
+	err := arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Username, rule.Len[string](3, 20)).Name("Username"),
+	)
+	var fe *arbiter.FieldError
+	if !errors.As(err, &fe) || fe.Name != "Username" || fe.Field != &user.Username {
+		t.Fatalf("Expected a *FieldError for Username, got %v", err)
+	}
+	if err.Error() != "Username: length is not between 3 and 20" {
+		t.Errorf("Expected prefixed error message, got %v", err)
+	}
+
+	errs := validationErrors(arbiter.ValidateStructAll(user, "User cannot be nil",
+		arbiter.Field(&user.Username, rule.Len[string](3, 20)).Name("Username"),
+		arbiter.Field(&user.Age, rule.Min[int](0), rule.Even[int]()).Name("Age"),
+	))
+	byField := errs.ByField()
+	if len(byField) != 2 || len(byField["Username"]) != 1 || len(byField["Age"]) != 2 {
+		t.Errorf("Expected errors grouped by field name, got %v", byField)
+	}
+}
+
+func TestNestedFieldName(t *testing.T) {
+	person := &testPersonWithAddress{Name: "John"}
+
+	err := arbiter.ValidateStruct(person, "Person cannot be nil",
+		arbiter.NestedField(&person.Address,
+			arbiter.Field(&person.Address.City, rule.Required[string]()).Name("City"),
+		).Name("Address"),
+	)
+	if err == nil || err.Error() != "Address.City: required" {
+		t.Errorf("Expected nested field name, got %v", err)
+	}
+
+	// An unnamed sub-field is reported with the name of the nested struct
+	errs := validationErrors(arbiter.ValidateStructAll(person, "Person cannot be nil",
+		arbiter.NestedField(&person.Address,
+			arbiter.Field(&person.Address.City, rule.Required[string]()),
+			arbiter.Field(&person.Address.Street, rule.Required[string]()).Name("Street"),
+		).Name("Address"),
+	))
+	if len(errs) != 2 || errs[0].Name != "Address" || errs[1].Name != "Address.Street" {
+		t.Errorf("Expected nested field names, got %v", errs)
+	}
+}
+
+func TestSliceFieldName(t *testing.T) {
+	tags := &struct{ Tags []string }{Tags: []string{"go", "", "rust", ""}}
+
+	err := arbiter.ValidateStruct(tags, "Tags cannot be nil",
+		arbiter.SliceField(&tags.Tags, func(tag *string) arbiter.IFieldRule {
+			return arbiter.Field(tag, rule.Required[string]())
+		}).Name("Tags"),
+	)
+	if err == nil || err.Error() != "Tags[1]: required" {
+		t.Errorf("Expected indexed field name, got %v", err)
+	}
+
+	errs := validationErrors(arbiter.ValidateStructAll(tags, "Tags cannot be nil",
+		arbiter.SliceField(&tags.Tags, func(tag *string) arbiter.IFieldRule {
+			return arbiter.Field(tag, rule.Required[string]())
+		}).Name("Tags"),
+	))
+	if errs.Error() != "Tags[1]: required; Tags[3]: required" {
+		t.Errorf("Expected indexed field names, got %v", errs)
+	}
+}
+
+func TestFieldAutomaticName(t *testing.T) {
+	type Profile struct {
+		Bio string
+	}
+	type account struct {
+		Email   string
+		Address testAddress
+		Profile *Profile
+		Tags    []string
+		Items   []testAddress
+		limits  [2]int
+	}
+	acc := &account{
+		Address: testAddress{Street: "Main St"},
+		Profile: &Profile{},
+		Tags:    []string{"go", ""},
+		Items:   []testAddress{{City: "Rome"}, {}},
+		limits:  [2]int{1, -1},
+	}
+
+	tests := []struct {
+		name  string
+		field arbiter.IFieldRule
+		want  string
+	}{
+		{name: "field", field: arbiter.Field(&acc.Email, rule.Required[string]()), want: "Email: required"},
+		{name: "first field of nested struct", field: arbiter.Field(&acc.Address.City, rule.Required[string]()), want: "Address.City: required"},
+		{name: "pointer to struct", field: arbiter.Field(&acc.Profile.Bio, rule.Required[string]()), want: "Profile.Bio: required"},
+		{name: "slice element", field: arbiter.Field(&acc.Tags[1], rule.Required[string]()), want: "Tags[1]: required"},
+		{name: "field of slice element", field: arbiter.Field(&acc.Items[1].City, rule.Required[string]()), want: "Items[1].City: required"},
+		{name: "unexported array element", field: arbiter.Field(&acc.limits[1], rule.Min[int](0)), want: "limits[1]: value is less than minimum"},
+		{name: "slice field", field: arbiter.SliceField(&acc.Tags, func(tag *string) arbiter.IFieldRule {
+			return arbiter.Field(tag, rule.Required[string]())
+		}), want: "Tags[1]: required"},
+		{name: "explicit name", field: arbiter.Field(&acc.Email, rule.Required[string]()).Name("email"), want: "email: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := arbiter.ValidateStruct(acc, "Account cannot be nil", tt.field)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ValidateStruct() error = %v, want %v", err, tt.want)
+			}
+			errs := validationErrors(arbiter.ValidateStructAll(acc, "Account cannot be nil", tt.field))
+			if errs.Error() != tt.want {
+				t.Errorf("ValidateStructAll() error = %v, want %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldOutsideStruct(t *testing.T) {
+	user := &testUser{}
+	other := ""
+	err := arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&other, rule.Required[string]()),
+	)
+	if err == nil || err.Error() != "required" {
+		t.Errorf("Expected unnamed error for a field outside the struct, got %v", err)
+	}
+}
+
+func TestFieldKey(t *testing.T) {
+	type address struct {
+		City string `json:"city" form:"town"`
+	}
+	type signup struct {
+		Email    string    `json:"email,omitempty" form:"email_address"`
+		Nickname string    `json:",omitempty"`
+		Secret   string    `json:"-"`
+		Address  address   `json:"address"`
+		Contacts []address `json:"contacts"`
+	}
+	s := &signup{Contacts: []address{{City: "Rome"}, {}}}
+
+	errs := validationErrors(arbiter.ValidateStructAll(s, "Signup cannot be nil",
+		arbiter.Field(&s.Email, rule.Required[string]()),
+		arbiter.Field(&s.Nickname, rule.Required[string]()),
+		arbiter.Field(&s.Secret, rule.Required[string]()),
+		arbiter.Field(&s.Address.City, rule.Required[string]()),
+		arbiter.Field(&s.Contacts[1].City, rule.Required[string]()),
+		arbiter.Field(&s.Email, rule.Required[string]()).Name("mail"),
+	))
+	// The path of each field is recorded during validation, so the struct is not searched again.
+	s.Contacts = nil
+
+	tests := []struct {
+		name string
+		key  arbiter.FieldKey
+		want []string
+	}{
+		{name: "go name", key: arbiter.GoKey, want: []string{"Email", "Nickname", "Secret", "Address.City", "Contacts[1].City", "mail"}},
+		{name: "json tag", key: arbiter.JSONKey, want: []string{"email", "Nickname", "Secret", "address.city", "contacts[1].city", "mail"}},
+		{name: "custom tag", key: arbiter.TagKey("form"), want: []string{"email_address", "Nickname", "Secret", "Address.town", "Contacts[1].town", "mail"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byKey := errs.ByKey(tt.key)
+			if len(byKey) != len(tt.want) {
+				t.Errorf("ByKey() = %v, want keys %v", byKey, tt.want)
+			}
+			for i, want := range tt.want {
+				if got := errs[i].Key(tt.key); got != want {
+					t.Errorf("FieldError.Key() = %v, want %v", got, want)
+				}
+				if _, ok := byKey[want]; !ok {
+					t.Errorf("ByKey() is missing key %v", want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFieldError(t *testing.T) {
+	type account struct {
+		Age int `json:"age"`
+	}
+	a := &account{Age: -1}
+	r := rule.Min(0)
+
+	var want *arbiter.FieldError
+	if !errors.As(arbiter.ValidateStruct(a, "Account cannot be nil", arbiter.Field(&a.Age, r)), &want) {
+		t.Fatal("Expected a FieldError from ValidateStruct")
+	}
+	got := arbiter.NewFieldError(a, &a.Age, "Age", `json:"age"`, r, r.Validate(a.Age))
+
+	if got.Field != want.Field || got.Name != want.Name || got.Error() != want.Error() {
+		t.Errorf("NewFieldError() = %v, want %v", got, want)
+	}
+	if got.Key(arbiter.JSONKey) != "age" {
+		t.Errorf("FieldError.Key() = %v, want age", got.Key(arbiter.JSONKey))
+	}
+	if got.Params["min"] != 0 {
+		t.Errorf("FieldError.Params = %v, want min 0", got.Params)
+	}
+}
+
+func TestFieldGroups(t *testing.T) {
+	type account struct {
+		ID       int
+		Password string
+		Email    string
+		Billing  testAddress
+		Tags     []string
+	}
+	acc := &account{Email: "x", Tags: []string{""}}
+	fields := func(extra ...arbiter.IFieldRule) []arbiter.IFieldRule {
+		return append([]arbiter.IFieldRule{
+			arbiter.Field(&acc.ID, rule.Required[int]()).Groups("update"),
+			arbiter.Field(&acc.Password, rule.Required[string]()).Groups("create", "reset"),
+			arbiter.Field(&acc.Email, rule.Len[string](3, 100)),
+			arbiter.NestedField(&acc.Billing,
+				arbiter.Field(&acc.Billing.City, rule.Required[string]()),
+			).Groups("checkout"),
+			arbiter.SliceField(&acc.Tags, func(tag *string) arbiter.IFieldRule {
+				return arbiter.Field(tag, rule.Required[string]())
+			}).Groups("admin"),
+		}, extra...)
+	}
+
+	tests := []struct {
+		name  string
+		group []arbiter.IFieldRule
+		want  []string
+	}{
+		{name: "no group", want: []string{"ID", "Password", "Email", "Billing.City", "Tags[0]"}},
+		{name: "create", group: []arbiter.IFieldRule{arbiter.Group("create")}, want: []string{"Password", "Email"}},
+		{name: "update", group: []arbiter.IFieldRule{arbiter.Group("update")}, want: []string{"ID", "Email"}},
+		{name: "several groups", group: []arbiter.IFieldRule{arbiter.Group("reset", "checkout")}, want: []string{"Password", "Email", "Billing.City"}},
+		{name: "several options", group: []arbiter.IFieldRule{arbiter.Group("update"), arbiter.Group("admin")}, want: []string{"ID", "Email", "Tags[0]"}},
+		{name: "unknown group", group: []arbiter.IFieldRule{arbiter.Group("patch")}, want: []string{"Email"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validationErrors(arbiter.ValidateStructAll(acc, "Account cannot be nil", fields(tt.group...)...))
+			var names []string
+			for _, fe := range errs {
+				names = append(names, fe.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateStructAll() fields = %v, want %v", names, tt.want)
+			}
+
+			err := arbiter.ValidateStruct(acc, "Account cannot be nil", fields(tt.group...)...)
+			var fe *arbiter.FieldError
+			if !errors.As(err, &fe) || fe.Name != tt.want[0] {
+				t.Errorf("ValidateStruct() error = %v, want field %v", err, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestOnly(t *testing.T) {
+	type patch struct {
+		Name    string      `json:"name"`
+		Email   string      `json:"email"`
+		Address testAddress `json:"address"`
+		Tags    []string    `json:"tags"`
+	}
+	p := &patch{Email: "x", Tags: []string{""}}
+	fields := func(only arbiter.IFieldRule) []arbiter.IFieldRule {
+		return []arbiter.IFieldRule{
+			arbiter.Field(&p.Name, rule.Required[string]()),
+			arbiter.Field(&p.Email, rule.Len[string](3, 100)).Name("mail"),
+			arbiter.NestedField(&p.Address,
+				arbiter.Field(&p.Address.City, rule.Required[string]()),
+				arbiter.Field(&p.Address.Zip, rule.Required[string]()),
+			),
+			arbiter.SliceField(&p.Tags, func(tag *string) arbiter.IFieldRule {
+				return arbiter.Field(tag, rule.Required[string]())
+			}),
+			only,
+		}
+	}
+
+	tests := []struct {
+		name string
+		only *arbiter.OnlyOption
+		want []string
+	}{
+		{name: "field", only: arbiter.Only("Name"), want: []string{"Name"}},
+		{name: "explicit name", only: arbiter.Only("mail"), want: []string{"mail"}},
+		{name: "nested struct", only: arbiter.Only("Address"), want: []string{"Address.City", "Address.Zip"}},
+		{name: "nested field", only: arbiter.Only("Address.Zip"), want: []string{"Address.Zip"}},
+		{name: "slice", only: arbiter.Only("Tags"), want: []string{"Tags[0]"}},
+		{name: "prefix is not a parent", only: arbiter.Only("Addr", "Nam"), want: nil},
+		{name: "json key", only: arbiter.Only("name", "address").Key(arbiter.JSONKey), want: []string{"Name", "Address.City", "Address.Zip"}},
+		{name: "nothing changed", only: arbiter.Only(), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validationErrors(arbiter.ValidateStructAll(p, "Patch cannot be nil", fields(tt.only)...))
+			var names []string
+			for _, fe := range errs {
+				names = append(names, fe.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidateStructAll() fields = %v, want %v", names, tt.want)
+			}
+			err := arbiter.ValidateStruct(p, "Patch cannot be nil", fields(tt.only)...)
+			if (err != nil) != (len(tt.want) > 0) {
+				t.Errorf("ValidateStruct() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldTransform(t *testing.T) {
+	user := &testUser{Username: "  John ", Website: " Example.COM "}
+
+	err := arbiter.ValidateStruct(user, "User cannot be nil",
+		arbiter.Field(&user.Username, rule.Trim(), rule.Lower(), rule.Len[string](1, 4)),
+		arbiter.Field(&user.Website, rule.Trim(), rule.Lower(), rule.Domain()),
+	)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if user.Username != "john" || user.Website != "example.com" {
+		t.Errorf("Expected fields to be normalized, got %q and %q", user.Username, user.Website)
+	}
+
+	// Rules before a transformer see the original value
+	user.Password = "  "
+	errs := validationErrors(arbiter.ValidateStructAll(user, "User cannot be nil",
+		arbiter.Field(&user.Password, rule.Required[string](), rule.Trim(), rule.Required[string]()),
+	))
+	if len(errs) != 1 || user.Password != "" {
+		t.Errorf("Expected one error after trimming, got %v", errs)
+	}
+}
+
+type testNode struct {
+	Name     string
+	Children []*testNode
+}
+
+// testNodeRule validates a node and, recursively, its children.
+func testNodeRule(n *testNode) arbiter.IFieldRule {
+	return arbiter.NestedField(n,
+		arbiter.Field(&n.Name, rule.Required[string]()),
+		arbiter.SliceField(&n.Children, func(c **testNode) arbiter.IFieldRule { return testNodeRule(*c) }),
+	)
+}
+
+func TestMaxDepth(t *testing.T) {
+	// A cycle: the child points back to the root
+	cyclic := &testNode{Name: "root"}
+	cyclic.Children = []*testNode{{Name: "child", Children: []*testNode{cyclic}}}
+	invalidCycle := &testNode{Name: "root"}
+	invalidCycle.Children = []*testNode{{Children: []*testNode{invalidCycle}}}
+
+	// A chain of 10 nodes, which is 20 levels of nested structs and slices
+	chain := &testNode{Name: "0"}
+	for n, i := chain, 1; i < 10; i++ {
+		n.Children = []*testNode{{Name: fmt.Sprint(i)}}
+		n = n.Children[0]
+	}
+
+	tests := []struct {
+		name    string
+		node    *testNode
+		opts    []arbiter.IFieldRule
+		wantErr error
+	}{
+		{name: "cycle", node: cyclic},
+		{name: "invalid cycle", node: invalidCycle, wantErr: rule.ErrRequired},
+		{name: "default limit", node: chain},
+		{name: "within limit", node: chain, opts: []arbiter.IFieldRule{arbiter.MaxDepth(20)}},
+		{name: "beyond limit", node: chain, opts: []arbiter.IFieldRule{arbiter.MaxDepth(5)}, wantErr: arbiter.ErrMaxDepth},
+		{name: "no limit", node: chain, opts: []arbiter.IFieldRule{arbiter.MaxDepth(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := append([]arbiter.IFieldRule{testNodeRule(tt.node)}, tt.opts...)
+			err := arbiter.ValidateStruct(tt.node, "Node cannot be nil", fields...)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("ValidateStruct() error = %v, want %v", err, tt.wantErr)
+			}
+			errs := validationErrors(arbiter.ValidateStructAll(tt.node, "Node cannot be nil", fields...))
+			if tt.wantErr == nil && errs != nil || tt.wantErr != nil && (len(errs) != 1 || !errors.Is(errs[0], tt.wantErr)) {
+				t.Errorf("ValidateStructAll() errors = %v, want %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+// testContextRule fails when its context is cancelled, and records whether it was used.
+type testContextRule struct {
+	usedContext bool
+}
+
+func (r *testContextRule) Validate(string) error {
+	return nil
+}
+
+func (r *testContextRule) ValidateContext(ctx context.Context, _ string) error {
+	r.usedContext = true
+	return ctx.Err()
+}
+
+func TestContextOption(t *testing.T) {
+	user := &testUser{Username: "ada"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := &testContextRule{}
+	err := arbiter.ValidateStruct(user, "User cannot be nil", arbiter.Field(&user.Username, r), arbiter.Context(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateStruct() error = %v, want %v", err, context.Canceled)
+	}
+	errs := validationErrors(arbiter.ValidateStructAll(user, "User cannot be nil", arbiter.Field(&user.Username, r), arbiter.Context(ctx)))
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("ValidateStructAll() errors = %v, want %v", errs, context.Canceled)
+	}
+
+	// Without Context, rules are validated with Validate
+	r = &testContextRule{}
+	if err := arbiter.ValidateStruct(user, "User cannot be nil", arbiter.Field(&user.Username, r)); err != nil || r.usedContext {
+		t.Errorf("ValidateStruct() error = %v, used context %v", err, r.usedContext)
+	}
+}
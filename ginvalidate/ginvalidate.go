@@ -0,0 +1,99 @@
+// Package ginvalidate routes the binding of the gin web framework through arbiter, so
+// that c.ShouldBind and the other binding methods validate structs with the schemas
+// registered with arbiter.RegisterSchema and return arbiter.ValidationErrors.
+//
+// Validator implements gin's binding.StructValidator interface without importing gin,
+// so this package adds no dependencies.
+//
+// Example:
+//
+//	func init() {
+//	    arbiter.RegisterSchema(signupSchema)
+//	    binding.Validator = ginvalidate.New()
+//	}
+//
+//	func signup(c *gin.Context) {
+//	    var req Signup
+//	    if err := c.ShouldBind(&req); err != nil {
+//	        c.JSON(ginvalidate.Status(err), err)
+//	        return
+//	    }
+//	}
+package ginvalidate
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/byteweap/arbiter"
+)
+
+// Validator validates the values bound by gin with arbiter.ValidateValue.
+type Validator struct{}
+
+// New creates a validator for gin's binding.Validator.
+//
+// Example:
+//
+//	binding.Validator = ginvalidate.New()
+func New() *Validator {
+	return &Validator{}
+}
+
+// ValidateStruct validates obj, a struct, a pointer to one or a slice or array of them,
+// with the schema registered for its type, or its Validate method if it implements
+// arbiter.Validatable. The failures of all elements of a slice are returned together,
+// in the order of the elements. Values of other types are not validated.
+func (v *Validator) ValidateStruct(obj any) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return arbiter.ValidateValue(obj)
+	}
+
+	var all arbiter.ValidationErrors
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		if elem.CanAddr() && elem.Kind() != reflect.Ptr {
+			elem = elem.Addr()
+		}
+		err := v.ValidateStruct(elem.Interface())
+		if err == nil {
+			continue
+		}
+		var errs arbiter.ValidationErrors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		all = append(all, errs...)
+	}
+	if all != nil {
+		return all
+	}
+	return nil
+}
+
+// Engine returns nil, as the validator has no underlying validation engine.
+func (v *Validator) Engine() any {
+	return nil
+}
+
+// Status returns the HTTP status for an error returned by binding: 422 Unprocessable
+// Entity for arbiter.ValidationErrors and 400 Bad Request for other errors, such as
+// malformed bodies.
+//
+// Example:
+//
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//	    c.AbortWithStatusJSON(ginvalidate.Status(err), err)
+//	}
+func Status(err error) int {
+	var errs arbiter.ValidationErrors
+	if errors.As(err, &errs) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,65 @@
+package ginvalidate
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// structValidator is gin's binding.StructValidator interface.
+type structValidator interface {
+	ValidateStruct(any) error
+	Engine() any
+}
+
+type testLogin struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func init() {
+	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[testLogin]().
+		Field("User", rule.Required[string]()).
+		Field("Password", rule.Len[string](8, 64))))
+}
+
+func TestValidateStruct(t *testing.T) {
+	var v structValidator = New()
+	assert.Nil(t, v.Engine())
+
+	tests := []struct {
+		name    string
+		obj     any
+		wantLen int
+	}{
+		{name: "valid pointer", obj: &testLogin{User: "ada", Password: "secret123"}},
+		{name: "invalid pointer", obj: &testLogin{Password: "short"}, wantLen: 2},
+		{name: "invalid value", obj: testLogin{User: "ada"}, wantLen: 1},
+		{name: "slice", obj: &[]testLogin{{User: "ada", Password: "secret123"}, {}}, wantLen: 2},
+		{name: "slice of pointers", obj: []*testLogin{{}, nil}, wantLen: 2},
+		{name: "unregistered", obj: &struct{ Name string }{}},
+		{name: "nil", obj: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateStruct(tt.obj)
+			if tt.wantLen == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var errs arbiter.ValidationErrors
+			if assert.True(t, errors.As(err, &errs)) {
+				assert.Len(t, errs, tt.wantLen)
+				assert.Equal(t, http.StatusUnprocessableEntity, Status(err))
+			}
+		})
+	}
+
+	assert.Equal(t, http.StatusBadRequest, Status(errors.New("unexpected EOF")))
+}
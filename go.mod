@@ -1,8 +1,12 @@
 module github.com/byteweap/arbiter
 
-go 1.23
+go 1.23.0
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/net v0.38.0
+	golang.org/x/text v0.23.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
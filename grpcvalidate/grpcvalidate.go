@@ -0,0 +1,121 @@
+// Package grpcvalidate provides gRPC server interceptors that validate request messages
+// with arbiter before they reach the service, so that handlers only receive valid
+// requests.
+//
+// Messages are validated with the schema registered for their type with
+// arbiter.RegisterSchema, or with their Validate method if they implement
+// arbiter.Validatable. Invalid requests fail with codes.InvalidArgument and a
+// google.rpc.BadRequest detail listing the field violations.
+//
+// The package is a separate module, so that only programs using it depend on gRPC:
+//
+//	go get github.com/byteweap/arbiter/grpcvalidate
+//
+// Example:
+//
+//	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[pb.CreateUserRequest]().
+//	    Field("Email", rule.Required[string](), rule.IsEmail())))
+//
+//	server := grpc.NewServer(
+//	    grpc.UnaryInterceptor(grpcvalidate.UnaryServerInterceptor()),
+//	    grpc.StreamInterceptor(grpcvalidate.StreamServerInterceptor()),
+//	)
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+// UnaryServerInterceptor returns an interceptor that validates the request of unary
+// calls and returns the error of Status for invalid requests without calling the handler.
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+//	    logging.UnaryServerInterceptor(logger),
+//	    grpcvalidate.UnaryServerInterceptor(),
+//	))
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := arbiter.ValidateValue(req); err != nil {
+			return nil, Status(err).Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that validates every message received
+// on streams. RecvMsg returns the error of Status for an invalid message.
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.StreamInterceptor(grpcvalidate.StreamServerInterceptor()))
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss})
+	}
+}
+
+// serverStream validates the messages received on the stream it wraps.
+type serverStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg receives the message m and validates it.
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := arbiter.ValidateValue(m); err != nil {
+		return Status(err).Err()
+	}
+	return nil
+}
+
+// Status converts a validation error to a status with codes.InvalidArgument. The status
+// has a google.rpc.BadRequest detail with a field violation for each *arbiter.FieldError
+// in err: the field is named by the json tag of each struct field, which is the proto
+// field name for generated messages, and the reason is the upper-case error code, such
+// as "EMAIL". The status message is the message of err.
+//
+// Example:
+//
+//	if err := arbiter.ValidateValue(req); err != nil {
+//	    return nil, grpcvalidate.Status(err).Err()
+//	}
+func Status(err error) *status.Status {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	var errs arbiter.ValidationErrors
+	var fe *arbiter.FieldError
+	switch {
+	case errors.As(err, &errs):
+	case errors.As(err, &fe):
+		errs = arbiter.ValidationErrors{fe}
+	default:
+		return st
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, fe := range errs {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Key(arbiter.JSONKey),
+			Description: rule.Ternary(fe.Message != "", fe.Message, fe.Err.Error()),
+			Reason:      strings.ToUpper(rule.ErrorCode(fe.Err)),
+		})
+	}
+	if detailed, err := st.WithDetails(br); err == nil {
+		return detailed
+	}
+	return st
+}
@@ -0,0 +1,87 @@
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testCreateUser struct {
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+}
+
+type testPing struct{ Seq int }
+
+func (p *testPing) Validate() error {
+	return rule.Ternary(p.Seq >= 0, nil, errors.New("seq is negative"))
+}
+
+func init() {
+	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[testCreateUser]().
+		Field("DisplayName", rule.Required[string]()).
+		Field("Email", rule.IsEmail())))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), &testCreateUser{DisplayName: "Ada"}, nil, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	resp, err = interceptor(context.Background(), &testCreateUser{Email: "ada"}, nil, handler)
+	assert.Nil(t, resp)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		br := st.Details()[0].(*errdetails.BadRequest)
+		if assert.Len(t, br.GetFieldViolations(), 2) {
+			assert.Equal(t, "display_name", br.GetFieldViolations()[0].GetField())
+			assert.Equal(t, "REQUIRED", br.GetFieldViolations()[0].GetReason())
+			assert.Equal(t, "email", br.GetFieldViolations()[1].GetField())
+			assert.Equal(t, "invalid email format", br.GetFieldViolations()[1].GetDescription())
+		}
+	}
+
+	_, err = interceptor(context.Background(), &testPing{Seq: -1}, nil, handler)
+	st = status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "seq is negative", st.Message())
+	assert.Empty(t, st.Details())
+}
+
+// testStream is a server stream that receives a single message.
+type testStream struct {
+	grpc.ServerStream
+	msg *testCreateUser
+}
+
+func (s *testStream) RecvMsg(m any) error {
+	*m.(*testCreateUser) = *s.msg
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor()
+	handler := func(_ any, ss grpc.ServerStream) error {
+		var req testCreateUser
+		return ss.RecvMsg(&req)
+	}
+
+	err := interceptor(nil, &testStream{msg: &testCreateUser{DisplayName: "Ada"}}, nil, handler)
+	assert.NoError(t, err)
+
+	err = interceptor(nil, &testStream{msg: &testCreateUser{}}, nil, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
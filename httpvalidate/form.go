@@ -0,0 +1,124 @@
+package httpvalidate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeForm sets the fields of the struct that v points to from form values. Each
+// field is matched by the name in its form tag, or by its Go name if it has none; a
+// tag of "-" skips the field. Fields of string, bool, integer and float types, pointers
+// to them and slices of them are supported; nested structs are decoded from the same
+// values. Values that cannot be parsed return an error naming the field.
+//
+// Example:
+//
+//	type Search struct {
+//	    Query string   `form:"q"`
+//	    Page  int      `form:"page"`
+//	    Tags  []string `form:"tag"`
+//	}
+//
+//	var s Search
+//	err := httpvalidate.DecodeForm(r.URL.Query(), &s)  // ?q=go&page=2&tag=a&tag=b
+func DecodeForm(values map[string][]string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("value must be a pointer to a struct")
+	}
+	return decodeStruct(values, rv.Elem())
+}
+
+// decodeStruct sets the fields of the struct v from values.
+func decodeStruct(values map[string][]string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("form"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if name == "" && fv.Kind() == reflect.Struct {
+			if err := decodeStruct(values, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		vals, ok := values[name]
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		if err := setField(fv, vals); err != nil {
+			return fmt.Errorf("form field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField sets fv from the form values vals.
+func setField(fv reflect.Value, vals []string) error {
+	switch fv.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := setValue(s.Index(i), val); err != nil {
+				return err
+			}
+		}
+		fv.Set(s)
+		return nil
+	case reflect.Ptr:
+		p := reflect.New(fv.Type().Elem())
+		if err := setValue(p.Elem(), vals[0]); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+	return setValue(fv, vals[0])
+}
+
+// setValue parses val into v, a value of a basic type.
+func setValue(v reflect.Value, val string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(val, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Package httpvalidate provides net/http middleware that decodes request bodies into
+// structs and validates them with arbiter schemas, so that handlers only receive valid
+// requests.
+//
+// Example:
+//
+//	var signupSchema = arbiter.MustBuild(arbiter.For[Signup]().
+//	    Field("Email", rule.Trim(), rule.Required[string](), rule.IsEmail()).
+//	    Field("Password", rule.Len[string](8, 64)))
+//
+//	mux.Handle("POST /signup", httpvalidate.New(signupSchema).HandlerFunc(
+//	    func(w http.ResponseWriter, r *http.Request, signup *Signup) {
+//	        // signup is decoded and valid
+//	    },
+//	))
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/byteweap/arbiter"
+)
+
+// DefaultMaxBodySize is the default limit of the size of request bodies, 1 MiB.
+const DefaultMaxBodySize = 1 << 20
+
+// Decoder decodes the body of r into v, a pointer to a struct.
+type Decoder func(r *http.Request, v any) error
+
+// ErrorWriter writes the response for a request that could not be decoded or is not
+// valid. err is an arbiter.ValidationErrors if the request is not valid.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, err error)
+
+// Validator decodes and validates requests into values of the struct type T.
+// Configure it with its option methods before it handles requests.
+type Validator[T any] struct {
+	schema      *arbiter.Schema[T]
+	decode      Decoder
+	writeError  ErrorWriter
+	maxBodySize int64
+}
+
+// New creates a validator that decodes requests with Decode, validates them with
+// schema and writes errors with WriteError.
+//
+// Example:
+//
+//	v := httpvalidate.New(userSchema).MaxBodySize(64 << 10)
+func New[T any](schema *arbiter.Schema[T]) *Validator[T] {
+	return &Validator[T]{schema: schema, decode: Decode, writeError: WriteError, maxBodySize: DefaultMaxBodySize}
+}
+
+// Decoder sets the function that decodes request bodies, for example to support
+// another content type.
+//
+// Example:
+//
+//	v := httpvalidate.New(userSchema).Decoder(func(r *http.Request, v any) error {
+//	    return xml.NewDecoder(r.Body).Decode(v)
+//	})
+func (v *Validator[T]) Decoder(decode Decoder) *Validator[T] {
+	if decode != nil {
+		v.decode = decode
+	}
+	return v
+}
+
+// ErrorWriter sets the function that writes the response for invalid requests, for
+// example to match the error format of an existing API.
+//
+// Example:
+//
+//	v := httpvalidate.New(userSchema).ErrorWriter(func(w http.ResponseWriter, r *http.Request, err error) {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	})
+func (v *Validator[T]) ErrorWriter(write ErrorWriter) *Validator[T] {
+	if write != nil {
+		v.writeError = write
+	}
+	return v
+}
+
+// MaxBodySize sets the limit of the size of request bodies in bytes. Larger bodies fail
+// to decode. A limit of 0 or less removes the limit.
+//
+// Example:
+//
+//	v := httpvalidate.New(uploadSchema).MaxBodySize(10 << 20)
+func (v *Validator[T]) MaxBodySize(n int64) *Validator[T] {
+	v.maxBodySize = n
+	return v
+}
+
+// Bind decodes and validates the request. The error is returned by the decoder or is
+// an arbiter.ValidationErrors with every failed rule.
+//
+// Example:
+//
+//	user, err := v.Bind(w, r)
+//	if err != nil {
+//	    httpvalidate.WriteError(w, r, err)
+//	    return
+//	}
+func (v *Validator[T]) Bind(w http.ResponseWriter, r *http.Request) (*T, error) {
+	if v.maxBodySize > 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, v.maxBodySize)
+	}
+	value := new(T)
+	if err := v.decode(r, value); err != nil {
+		return nil, err
+	}
+	if err := v.schema.ValidateAll(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// HandlerFunc returns a handler that calls fn with the decoded value of valid requests,
+// and writes an error response for other requests.
+//
+// Example:
+//
+//	mux.Handle("POST /users", httpvalidate.New(userSchema).HandlerFunc(createUser))
+//
+//	func createUser(w http.ResponseWriter, r *http.Request, user *User) {
+//	    // ...
+//	}
+func (v *Validator[T]) HandlerFunc(fn func(w http.ResponseWriter, r *http.Request, value *T)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, err := v.Bind(w, r)
+		if err != nil {
+			v.writeError(w, r, err)
+			return
+		}
+		fn(w, r, value)
+	})
+}
+
+// contextKey is the key of the decoded value of type T in the request context.
+type contextKey[T any] struct{}
+
+// Middleware returns middleware that decodes and validates requests before calling
+// next, which gets the decoded value with Value. Invalid requests get an error response
+// and do not reach next.
+//
+// Example:
+//
+//	mux.Handle("POST /users", httpvalidate.New(userSchema).Middleware(http.HandlerFunc(createUser)))
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//	    user := httpvalidate.Value[User](r)
+//	}
+func (v *Validator[T]) Middleware(next http.Handler) http.Handler {
+	return v.HandlerFunc(func(w http.ResponseWriter, r *http.Request, value *T) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, value)))
+	})
+}
+
+// Value returns the value decoded by Middleware for the request, or nil if there is none.
+//
+// Example:
+//
+//	user := httpvalidate.Value[User](r)
+func Value[T any](r *http.Request) *T {
+	value, _ := r.Context().Value(contextKey[T]{}).(*T)
+	return value
+}
+
+// Decode decodes the request body by its content type: JSON for application/json and
+// requests without a content type, and the form values for
+// application/x-www-form-urlencoded and multipart/form-data. Form values are matched
+// to fields by their form tag or their name.
+func Decode(r *http.Request, v any) error {
+	mediaType := ""
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(ct); err != nil {
+			return err
+		}
+	}
+	switch mediaType {
+	case "", "application/json":
+		if r.Body == nil || r.Body == http.NoBody {
+			return errors.New("request body is empty")
+		}
+		return json.NewDecoder(r.Body).Decode(v)
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		return DecodeForm(r.PostForm, v)
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(DefaultMaxBodySize); err != nil {
+			return err
+		}
+		return DecodeForm(r.MultipartForm.Value, v)
+	}
+	return &UnsupportedMediaTypeError{MediaType: mediaType}
+}
+
+// UnsupportedMediaTypeError is returned by Decode for a content type it cannot decode.
+type UnsupportedMediaTypeError struct {
+	MediaType string
+}
+
+// Error returns the error message.
+func (e *UnsupportedMediaTypeError) Error() string {
+	return "unsupported media type " + e.MediaType
+}
+
+// WriteError writes err as JSON in the format of arbiter.ValidationErrors, such as
+// {"errors":[{"field":"email","code":"email","message":"invalid email format"}]}.
+// The status is 422 Unprocessable Entity for validation errors, 413 Request Entity Too
+// Large for bodies over the size limit, 415 Unsupported Media Type for content types
+// that cannot be decoded and 400 Bad Request for other decoding errors.
+func WriteError(w http.ResponseWriter, _ *http.Request, err error) {
+	status := http.StatusBadRequest
+	var errs arbiter.ValidationErrors
+	var maxBytes *http.MaxBytesError
+	var mediaType *UnsupportedMediaTypeError
+	switch {
+	case errors.As(err, &errs):
+		status = http.StatusUnprocessableEntity
+	case errors.As(err, &maxBytes):
+		status = http.StatusRequestEntityTooLarge
+	case errors.As(err, &mediaType):
+		status = http.StatusUnsupportedMediaType
+	}
+	if errs == nil {
+		errs = arbiter.ValidationErrors{{Err: err}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errs)
+}
@@ -0,0 +1,189 @@
+package httpvalidate
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testSignup struct {
+	Email    string   `json:"email" form:"email"`
+	Password string   `json:"password" form:"password"`
+	Age      int      `json:"age" form:"age"`
+	Tags     []string `json:"tags" form:"tag"`
+}
+
+var testSignupSchema = arbiter.MustBuild(arbiter.For[testSignup]().
+	Field("Email", rule.Trim(), rule.Required[string](), rule.IsEmail()).
+	Field("Password", rule.Len[string](8, 64)).
+	Field("Age", rule.Between(18, 120)))
+
+func TestHandlerFunc(t *testing.T) {
+	handler := New(testSignupSchema).MaxBodySize(256).HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request, signup *testSignup) {
+			_, _ = w.Write([]byte(signup.Email))
+		})
+
+	form := url.Values{"email": {" ada@example.com "}, "password": {"secret123"}, "age": {"36"}}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "valid json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"email": " ada@example.com ", "password": "secret123", "age": 36}`,
+			wantStatus:  http.StatusOK,
+			wantBody:    "ada@example.com",
+		},
+		{
+			name:        "valid form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        form.Encode(),
+			wantStatus:  http.StatusOK,
+			wantBody:    "ada@example.com",
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			body:        `{"email": "ada", "password": "secret", "age": 36}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantBody: `{"errors":[{"field":"email","code":"email","message":"invalid email format"},` +
+				`{"field":"password","code":"length","message":"length is not between 8 and 64","params":{"max":64,"min":8}}]}`,
+		},
+		{
+			name:        "malformed json",
+			contentType: "application/json",
+			body:        `{"email":`,
+			wantStatus:  http.StatusBadRequest,
+			wantBody:    `{"errors":[{"field":"","message":"unexpected EOF"}]}`,
+		},
+		{
+			name:        "invalid form value",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "age=old",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported media type",
+			contentType: "text/plain",
+			body:        "hello",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "body too large",
+			contentType: "application/json",
+			body:        `{"email": "` + strings.Repeat("a", 300) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var got *testSignup
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = Value[testSignup](r)
+	})
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("email", "ada@example.com")
+	_ = mw.WriteField("password", "secret123")
+	_ = mw.WriteField("age", "36")
+	_ = mw.WriteField("tag", "a")
+	_ = mw.WriteField("tag", "b")
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	New(testSignupSchema).Middleware(next).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, []string{"a", "b"}, got.Tags)
+	}
+	assert.Nil(t, Value[testSignup](httptest.NewRequest(http.MethodGet, "/", nil)))
+}
+
+func TestHooks(t *testing.T) {
+	var decoded, written bool
+	v := New(testSignupSchema).
+		Decoder(func(_ *http.Request, v any) error {
+			decoded = true
+			v.(*testSignup).Email = "bad"
+			return nil
+		}).
+		ErrorWriter(func(w http.ResponseWriter, _ *http.Request, err error) {
+			written = true
+			http.Error(w, err.Error(), http.StatusTeapot)
+		})
+
+	rec := httptest.NewRecorder()
+	v.HandlerFunc(func(http.ResponseWriter, *http.Request, *testSignup) {}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.True(t, decoded)
+	assert.True(t, written)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+}
+
+func TestDecodeForm(t *testing.T) {
+	type inner struct {
+		Note string `form:"note"`
+	}
+	type search struct {
+		Query   string   `form:"q"`
+		Page    uint8    `form:"page"`
+		Score   *float64 `form:"score"`
+		Active  bool
+		IDs     []int  `form:"id"`
+		Skipped string `form:"-"`
+		inner
+		Nested inner
+	}
+
+	var s search
+	err := DecodeForm(url.Values{
+		"q": {"go"}, "page": {"2"}, "score": {"1.5"}, "Active": {"true"},
+		"id": {"1", "2"}, "-": {"x"}, "note": {"n"},
+	}, &s)
+	assert.NoError(t, err)
+	assert.Equal(t, "go", s.Query)
+	assert.Equal(t, uint8(2), s.Page)
+	assert.Equal(t, 1.5, *s.Score)
+	assert.True(t, s.Active)
+	assert.Equal(t, []int{1, 2}, s.IDs)
+	assert.Empty(t, s.Skipped)
+	assert.Equal(t, "n", s.Nested.Note)
+
+	assert.Error(t, DecodeForm(url.Values{"page": {"300"}}, &s))
+	assert.Error(t, DecodeForm(url.Values{"id": {"x"}}, &s))
+	assert.Error(t, DecodeForm(url.Values{"Active": {"maybe"}}, &s))
+	assert.Error(t, DecodeForm(nil, s))
+}
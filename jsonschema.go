@@ -0,0 +1,703 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the export of schemas as JSON Schema and validators built from JSON Schema.
+package arbiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// JSONSchemaDraft is the URI of the JSON Schema draft used by ToJSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ToJSONSchema returns a JSON Schema (draft 2020-12) document describing the fields of
+// the schema, so that frontends can apply the same constraints. Fields are named by
+// their json tag and typed after their Go type; rules that implement
+// rule.JSONSchemaRule, such as Len, Between, Min, Max, Regex, In and Required, add their
+// keywords. Other rules are not represented, and fields tagged json:"-" are left out.
+// The result can be encoded with json.Marshal.
+//
+// Example:
+//
+//	schema := arbiter.MustBuild(arbiter.For[User]().
+//	    Field("Name", rule.Required[string](), rule.Len[string](2, 50)).
+//	    Field("Age", rule.Between(0, 120)))
+//
+//	doc, err := json.Marshal(arbiter.ToJSONSchema(schema))
+//	// {"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",
+//	//  "properties":{"name":{"type":"string","minLength":2,"maxLength":50},
+//	//  "age":{"type":"integer","minimum":0,"maximum":120}},"required":["name"]}
+func ToJSONSchema[T any](s *Schema[T]) map[string]any {
+	doc := jsonSchemaObject(reflect.TypeFor[T](), s.fields)
+	doc["$schema"] = JSONSchemaDraft
+	return doc
+}
+
+// jsonSchemaObject returns the JSON Schema of the struct type t with the given fields.
+func jsonSchemaObject(t reflect.Type, fields []schemaField) map[string]any {
+	root := map[string]any{"type": "object"}
+	for _, field := range fields {
+		if jsonOmitted(t, field.path) {
+			continue
+		}
+		obj, prop, key := jsonSchemaProperty(root, t, field.path)
+		for _, r := range field.rules {
+			js, ok := r.rule.(rule.JSONSchemaRule)
+			if !ok {
+				continue
+			}
+			for k, v := range js.JSONSchema() {
+				if k != "required" {
+					prop[k] = v
+				} else if required, _ := v.(bool); required {
+					addRequired(obj, key)
+				}
+			}
+		}
+	}
+	return root
+}
+
+// jsonSchemaProperty returns the schema of the field at path within the schema root of
+// the struct type t, creating it and its parents as needed, together with the schema of
+// its parent object and its key.
+func jsonSchemaProperty(root map[string]any, t reflect.Type, path [][]int) (obj, prop map[string]any, key string) {
+	prop = root
+	for _, index := range path {
+		obj = prop
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		sf := t.FieldByIndex(index)
+		props, _ := obj["properties"].(map[string]any)
+		if props == nil {
+			props = make(map[string]any)
+			obj["properties"] = props
+		}
+		key = JSONKey(sf)
+		if prop, _ = props[key].(map[string]any); prop == nil {
+			prop = jsonSchemaType(sf.Type)
+			props[key] = prop
+		}
+		t = sf.Type
+	}
+	return obj, prop, key
+}
+
+// jsonOmitted reports whether the field at path within the struct type t, or one of its
+// parents, is tagged json:"-", so that it never appears in JSON.
+func jsonOmitted(t reflect.Type, path [][]int) bool {
+	for _, index := range path {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		sf := t.FieldByIndex(index)
+		if sf.Tag.Get("json") == "-" {
+			return true
+		}
+		t = sf.Type
+	}
+	return false
+}
+
+// addRequired adds key to the required properties of obj.
+func addRequired(obj map[string]any, key string) {
+	required, _ := obj["required"].([]string)
+	if !slices.Contains(required, key) {
+		obj["required"] = append(required, key)
+	}
+}
+
+// jsonSchemaType returns the JSON Schema type of values of the Go type t.
+func jsonSchemaType(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeFor[time.Time]() {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": jsonSchemaType(t.Elem())}
+	case reflect.Map, reflect.Struct:
+		return map[string]any{"type": "object"}
+	}
+	return map[string]any{}
+}
+
+// Errors of validators built with FromJSONSchema, for keywords without a matching rule.
+var (
+	// ErrJSONType is returned when a value does not have the type of its JSON Schema.
+	ErrJSONType = rule.NewError("type", "value has the wrong type")
+
+	// ErrJSONUnknownField is returned for a property not allowed by additionalProperties.
+	ErrJSONUnknownField = rule.NewError("unknown_field", "unknown field")
+
+	// ErrJSONUniqueItems is returned when an array with uniqueItems has duplicates.
+	ErrJSONUniqueItems = rule.NewError("unique_items", "array items are not unique")
+)
+
+// JSONSchemaValidator validates decoded JSON values against a JSON Schema document.
+// Use FromJSONSchema to create one. It is immutable and safe for concurrent use.
+type JSONSchemaValidator struct {
+	root *jsonNode
+}
+
+// jsonNode is a compiled JSON Schema.
+type jsonNode struct {
+	types      []string
+	checks     []jsonCheck
+	properties map[string]*jsonNode
+	required   []string
+	closed     bool
+	items      *jsonNode
+	unique     bool
+}
+
+// jsonCheck is a rule applied to the values of the JSON type it supports.
+type jsonCheck struct {
+	rule     any
+	validate func(value any) error
+}
+
+// FromJSONSchema builds a validator from a JSON Schema document, for contracts that are
+// defined schema first. The keywords type, properties, required, additionalProperties
+// (as a boolean), items, enum, const, minLength, maxLength, pattern, format, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, minItems, maxItems and
+// uniqueItems are mapped to the rules of this module; the formats email, uri, uuid,
+// ipv4, ipv6 and date-time are checked and other formats are ignored. Annotations such
+// as title and description are ignored, and other keywords, such as $ref and allOf,
+// return an error. Unlike the rules, pattern and format also apply to empty strings, as
+// JSON Schema requires.
+//
+// Example:
+//
+//	v, err := arbiter.FromJSONSchema([]byte(`{
+//	    "type": "object",
+//	    "required": ["email"],
+//	    "properties": {
+//	        "email": {"type": "string", "format": "email"},
+//	        "age": {"type": "integer", "minimum": 0}
+//	    }
+//	}`))
+//
+//	err = v.ValidateJSON(body)  // e.g. "age: value is less than minimum"
+func FromJSONSchema(doc []byte) (*JSONSchemaValidator, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(doc, &schema); err != nil {
+		return nil, err
+	}
+	root, err := compileJSONNode(schema, "")
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchemaValidator{root: root}, nil
+}
+
+// Validate validates a decoded JSON value, as returned by json.Unmarshal into an any,
+// and returns the first error as a *FieldError named after its path, such as
+// "address.city" or "tags[1]".
+//
+// Example:
+//
+//	var payload any
+//	_ = json.Unmarshal(body, &payload)
+//	err := v.Validate(payload)
+func (v *JSONSchemaValidator) Validate(value any) error {
+	c := &jsonErrors{first: true}
+	v.root.collect(c, value, "")
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs[0]
+}
+
+// ValidateAll validates a decoded JSON value like Validate, but returns every failure.
+// It returns nil if the value is valid.
+//
+// Example:
+//
+//	errs := v.ValidateAll(payload)
+//	body, _ := json.Marshal(errs)
+func (v *JSONSchemaValidator) ValidateAll(value any) ValidationErrors {
+	c := &jsonErrors{}
+	v.root.collect(c, value, "")
+	return c.errs
+}
+
+// ValidateJSON decodes data and validates it like Validate. It returns the decoding
+// error if data is not valid JSON.
+//
+// Example:
+//
+//	if err := v.ValidateJSON(body); err != nil {
+//	    http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+//	}
+func (v *JSONSchemaValidator) ValidateJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	return v.Validate(value)
+}
+
+// jsonErrors collects the errors of a validation.
+type jsonErrors struct {
+	errs  ValidationErrors
+	first bool
+}
+
+// add adds an error of the value at path and reports whether the validation stops.
+func (c *jsonErrors) add(path string, err error, r any) bool {
+	fe := &FieldError{Name: path, Err: err, Params: params(r)}
+	fe.render("")
+	c.errs = append(c.errs, fe)
+	return c.first
+}
+
+// done reports whether the validation stops at an error that was added.
+func (c *jsonErrors) done() bool {
+	return c.first && len(c.errs) > 0
+}
+
+// collect adds the errors of value at path to c.
+func (n *jsonNode) collect(c *jsonErrors, value any, path string) {
+	kind := jsonKind(value)
+	if len(n.types) > 0 && !slices.Contains(n.types, kind) &&
+		(kind != "integer" || !slices.Contains(n.types, "number")) {
+		c.add(path, ErrJSONType, nil)
+		return
+	}
+	for _, check := range n.checks {
+		if err := check.validate(value); err != nil && c.add(path, err, check.rule) {
+			return
+		}
+	}
+	switch value := value.(type) {
+	case map[string]any:
+		n.collectObject(c, value, path)
+	case []any:
+		n.collectArray(c, value, path)
+	}
+}
+
+// collectObject adds the errors of the properties of the object at path to c.
+func (n *jsonNode) collectObject(c *jsonErrors, value map[string]any, path string) {
+	for _, key := range n.required {
+		if _, ok := value[key]; !ok && c.add(jsonPath(path, key), rule.ErrRequired, nil) {
+			return
+		}
+	}
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		prop := n.properties[key]
+		if prop == nil {
+			if n.closed && c.add(jsonPath(path, key), ErrJSONUnknownField, nil) {
+				return
+			}
+			continue
+		}
+		if prop.collect(c, value[key], jsonPath(path, key)); c.done() {
+			return
+		}
+	}
+}
+
+// collectArray adds the errors of the items of the array at path to c.
+func (n *jsonNode) collectArray(c *jsonErrors, value []any, path string) {
+	if n.unique && !uniqueItems(value) && c.add(path, ErrJSONUniqueItems, nil) {
+		return
+	}
+	if n.items == nil {
+		return
+	}
+	for i, item := range value {
+		if n.items.collect(c, item, path+"["+strconv.Itoa(i)+"]"); c.done() {
+			return
+		}
+	}
+}
+
+// jsonPath returns the path of the property key of the object at path.
+func jsonPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonKind returns the JSON Schema type of a decoded JSON value.
+func jsonKind(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		if f, ok := jsonNumber(v); ok {
+			if f == math.Trunc(f) {
+				return "integer"
+			}
+			return "number"
+		}
+	}
+	return ""
+}
+
+// jsonNumber returns the value of a decoded JSON number.
+func jsonNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// uniqueItems reports whether the items of a decoded JSON array are all different. The
+// items are compared by their canonical encoding, so that large arrays take linear time.
+func uniqueItems(items []any) bool {
+	seen := make(map[string]struct{}, len(items))
+	var b strings.Builder
+	for _, item := range items {
+		b.Reset()
+		writeCanonicalJSON(&b, item)
+		if _, ok := seen[b.String()]; ok {
+			return false
+		}
+		seen[b.String()] = struct{}{}
+	}
+	return true
+}
+
+// jsonEqual reports whether two decoded JSON values are equal, comparing numbers by value.
+func jsonEqual(a, b any) bool {
+	var x, y strings.Builder
+	writeCanonicalJSON(&x, a)
+	writeCanonicalJSON(&y, b)
+	return x.String() == y.String()
+}
+
+// writeCanonicalJSON writes an encoding of a decoded JSON value to b that is the same for
+// equal values: numbers are written by value and object members are sorted by key.
+func writeCanonicalJSON(b *strings.Builder, value any) {
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case string:
+		b.WriteString(strconv.Quote(v))
+	case []any:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCanonicalJSON(b, item)
+		}
+		b.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte(':')
+			writeCanonicalJSON(b, v[key])
+		}
+		b.WriteByte('}')
+	default:
+		if f, ok := jsonNumber(v); ok {
+			b.WriteString(strconv.FormatFloat(f+0, 'g', -1, 64)) // +0 turns -0 into 0
+			return
+		}
+		fmt.Fprintf(b, "%T:%v", v, v)
+	}
+}
+
+// jsonAnnotations are the keywords that do not affect validation.
+var jsonAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// compileJSONNode compiles the JSON Schema at path.
+func compileJSONNode(schema map[string]any, path string) (*jsonNode, error) {
+	n := &jsonNode{}
+	keys := make([]string, 0, len(schema))
+	for key := range schema {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := n.compile(key, schema, path); err != nil {
+			return nil, fmt.Errorf("json schema %s: %w", jsonPath(path, key), err)
+		}
+	}
+	return n, nil
+}
+
+// compile adds the keyword key of schema to the node.
+//
+//nolint:gocyclo,gocognit // one case per keyword
+func (n *jsonNode) compile(key string, schema map[string]any, path string) error {
+	value := schema[key]
+	switch key {
+	case "type":
+		switch t := value.(type) {
+		case string:
+			n.types = []string{t}
+		case []any:
+			for _, v := range t {
+				s, ok := v.(string)
+				if !ok {
+					return fmt.Errorf("invalid type %v", v)
+				}
+				n.types = append(n.types, s)
+			}
+		default:
+			return fmt.Errorf("invalid type %v", value)
+		}
+	case "properties":
+		props, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("must be an object")
+		}
+		n.properties = make(map[string]*jsonNode, len(props))
+		for name, prop := range props {
+			sub, ok := prop.(map[string]any)
+			if !ok {
+				return fmt.Errorf("property %s must be an object", name)
+			}
+			node, err := compileJSONNode(sub, jsonPath(path, name))
+			if err != nil {
+				return err
+			}
+			n.properties[name] = node
+		}
+	case "required":
+		list, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("must be an array")
+		}
+		for _, v := range list {
+			s, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("invalid property name %v", v)
+			}
+			n.required = append(n.required, s)
+		}
+	case "additionalProperties":
+		allowed, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("only booleans are supported")
+		}
+		n.closed = !allowed
+	case "items":
+		sub, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("must be an object")
+		}
+		node, err := compileJSONNode(sub, path+"[]")
+		if err != nil {
+			return err
+		}
+		n.items = node
+	case "uniqueItems":
+		n.unique, _ = value.(bool)
+	case "enum", "const":
+		values, ok := value.([]any)
+		if key == "const" {
+			values, ok = []any{value}, true
+		}
+		if !ok {
+			return fmt.Errorf("must be an array")
+		}
+		n.checks = append(n.checks, jsonCheck{rule: nil, validate: func(v any) error {
+			for _, allowed := range values {
+				if jsonEqual(v, allowed) {
+					return nil
+				}
+			}
+			return rule.ErrIn
+		}})
+	case "minLength", "maxLength":
+		if _, done := schema["minLength"]; key == "maxLength" && done {
+			return nil // compiled with minLength
+		}
+		min, max, err := jsonBounds(schema, "minLength", "maxLength")
+		if err != nil {
+			return err
+		}
+		n.checks = append(n.checks, jsonTypeCheck(rule.Len[string](min, max)))
+	case "minItems", "maxItems":
+		if _, done := schema["minItems"]; key == "maxItems" && done {
+			return nil // compiled with minItems
+		}
+		min, max, err := jsonBounds(schema, "minItems", "maxItems")
+		if err != nil {
+			return err
+		}
+		n.checks = append(n.checks, jsonTypeCheck(rule.Len[[]any](min, max)))
+	case "pattern":
+		p, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return err
+		}
+		// Unlike the Regex rule, JSON Schema applies the pattern to empty strings too.
+		n.checks = append(n.checks, jsonCheck{rule: rule.Regex(p), validate: func(v any) error {
+			if s, ok := v.(string); ok && !re.MatchString(s) {
+				return rule.ErrRegex
+			}
+			return nil
+		}})
+	case "format":
+		f, _ := value.(string)
+		if format, ok := jsonFormats[f]; ok {
+			r := format.rule()
+			n.checks = append(n.checks, jsonCheck{rule: r, validate: func(v any) error {
+				s, ok := v.(string)
+				if !ok {
+					return nil
+				}
+				// The rules accept empty strings, which none of the formats does.
+				if s == "" {
+					return format.err
+				}
+				return r.Validate(s)
+			}})
+		}
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+		f, ok := jsonNumber(value)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		var r rule.Rule[float64]
+		switch key {
+		case "minimum":
+			r = rule.Min(f)
+		case "maximum":
+			r = rule.Max(f)
+		case "exclusiveMinimum":
+			r = rule.Between(f, math.Inf(1)).ExclusiveMin()
+		case "exclusiveMaximum":
+			r = rule.Between(math.Inf(-1), f).ExclusiveMax()
+		default:
+			if f <= 0 {
+				return fmt.Errorf("must be greater than 0")
+			}
+			r = rule.DivisibleBy(f)
+		}
+		n.checks = append(n.checks, numberCheck(r))
+	default:
+		if !jsonAnnotations[key] {
+			return fmt.Errorf("unsupported keyword")
+		}
+	}
+	return nil
+}
+
+// jsonBounds returns the values of the keywords min and max of schema, with defaults of
+// 0 and math.MaxInt.
+func jsonBounds(schema map[string]any, minKey, maxKey string) (int, int, error) {
+	bounds := []int{0, math.MaxInt}
+	for i, key := range []string{minKey, maxKey} {
+		value, ok := schema[key]
+		if !ok {
+			continue
+		}
+		f, ok := jsonNumber(value)
+		if !ok || f < 0 || f != math.Trunc(f) {
+			return 0, 0, fmt.Errorf("%s must be a non-negative integer", key)
+		}
+		bounds[i] = int(f)
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// jsonFormats map the checked string formats to their rules and to the error returned
+// for empty strings.
+var jsonFormats = map[string]struct {
+	rule func() rule.Rule[string]
+	err  error
+}{
+	"email":     {func() rule.Rule[string] { return rule.IsEmail() }, rule.ErrEmail},
+	"uri":       {func() rule.Rule[string] { return rule.URL() }, rule.ErrURL},
+	"uuid":      {func() rule.Rule[string] { return rule.UUID() }, rule.ErrUUID},
+	"ipv4":      {func() rule.Rule[string] { return rule.IPv4() }, rule.ErrIPv4},
+	"ipv6":      {func() rule.Rule[string] { return rule.IPv6() }, rule.ErrIPv6},
+	"date-time": {func() rule.Rule[string] { return rule.DateTimeFormat(time.RFC3339) }, rule.ErrDateTimeFormat},
+}
+
+// jsonTypeCheck applies r to values of type T.
+func jsonTypeCheck[T any](r rule.Rule[T]) jsonCheck {
+	return jsonCheck{rule: r, validate: func(value any) error {
+		if v, ok := value.(T); ok {
+			return r.Validate(v)
+		}
+		return nil
+	}}
+}
+
+// numberCheck applies r to numbers.
+func numberCheck(r rule.Rule[float64]) jsonCheck {
+	return jsonCheck{rule: r, validate: func(value any) error {
+		if f, ok := jsonNumber(value); ok {
+			return r.Validate(f)
+		}
+		return nil
+	}}
+}
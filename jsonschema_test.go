@@ -0,0 +1,229 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of the JSON Schema export.
+package arbiter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testSchemaAccount struct {
+	Name     string       `json:"name"`
+	Age      int          `json:"age"`
+	Score    float64      `json:"score"`
+	Role     string       `json:"role"`
+	Website  string       `json:"website"`
+	Tags     []string     `json:"tags"`
+	Avatar   []byte       `json:"avatar"`
+	Created  time.Time    `json:"created"`
+	Address  *testAddress `json:"address"`
+	Settings map[string]int
+}
+
+func TestToJSONSchema(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaAccount]().
+		Field("Name", rule.Trim(), rule.Required[string](), rule.Len[string](2, 50)).
+		Field("Age", rule.Between(0, 120).ExclusiveMax()).
+		Field("Score", rule.Min(0.5), rule.Max(10.0)).
+		Field("Role", rule.In("admin", "editor")).
+		Field("Website", rule.URL(), rule.Regex(`^https://`)).
+		Field("Tags", rule.Len[[]string](0, 3)).
+		Field("Avatar").
+		Field("Created").
+		Field("Address.City", rule.Required[string]()).
+		Field("Address.Zip", rule.NotIn("00000")).
+		Field("Settings", rule.Len[map[string]int](1, 5)))
+
+	want := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 50},
+			"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 120},
+			"score": {"type": "number", "minimum": 0.5, "maximum": 10},
+			"role": {"type": "string", "enum": ["admin", "editor"]},
+			"website": {"type": "string", "format": "uri", "pattern": "^$|(?:^https://)"},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 0, "maxItems": 3},
+			"avatar": {"type": "string", "contentEncoding": "base64"},
+			"created": {"type": "string", "format": "date-time"},
+			"address": {
+				"type": "object",
+				"required": ["City"],
+				"properties": {
+					"City": {"type": "string", "minLength": 1},
+					"Zip": {"type": "string", "not": {"enum": ["00000"]}}
+				}
+			},
+			"Settings": {"type": "object", "minProperties": 1, "maxProperties": 5}
+		}
+	}`
+	doc, err := json.Marshal(arbiter.ToJSONSchema(schema))
+	assert.NoError(t, err)
+	assert.JSONEq(t, want, string(doc))
+}
+
+func TestRuleJSONSchema(t *testing.T) {
+	assert.Equal(t, map[string]any{"pattern": "^$|(?:" + `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$` + ")", "format": "email"},
+		rule.IsEmail().JSONSchema())
+	assert.Nil(t, rule.Regex(`(`).JSONSchema())
+	assert.Equal(t, map[string]any{"required": true, "not": map[string]any{"const": 0}}, rule.Required[*int]().JSONSchema())
+	assert.Equal(t, map[string]any{"required": true, "minLength": 1}, rule.Required[*string]().JSONSchema())
+	assert.Nil(t, rule.Len[struct{}](1, 2).JSONSchema())
+}
+
+func TestFromJSONSchema(t *testing.T) {
+	v, err := arbiter.FromJSONSchema([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Account",
+		"type": "object",
+		"required": ["email", "name"],
+		"additionalProperties": false,
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"name": {"type": "string", "minLength": 2, "maxLength": 5},
+			"id": {"type": "string", "format": "uuid"},
+			"ip": {"type": "string", "format": "ipv4"},
+			"code": {"type": "string", "pattern": "^[A-Z]+$"},
+			"role": {"enum": ["admin", "editor", 1]},
+			"kind": {"const": "user"},
+			"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+			"score": {"type": ["number", "null"], "exclusiveMinimum": 0, "maximum": 10, "multipleOf": 0.5},
+			"tags": {"type": "array", "minItems": 1, "uniqueItems": true, "items": {"type": "string", "maxLength": 3}},
+			"created": {"type": "string", "format": "date-time"},
+			"address": {"type": "object", "required": ["city"], "properties": {"city": {"type": "string"}}}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     string
+		wantName string
+		wantErr  error
+	}{
+		{name: "valid", data: `{"email": "a@example.com", "name": "Ada", "age": 36, "score": 9.5, "tags": ["a", "b"], "role": 1, "created": "2024-01-02T03:04:05Z", "address": {"city": "London"}}`},
+		{name: "valid: null score", data: `{"email": "a@example.com", "name": "Ada", "score": null}`},
+		{name: "invalid: required", data: `{"email": "a@example.com"}`, wantName: "name", wantErr: rule.ErrRequired},
+		{name: "invalid: unknown field", data: `{"email": "a@example.com", "name": "Ada", "nickname": "A"}`, wantName: "nickname", wantErr: arbiter.ErrJSONUnknownField},
+		{name: "invalid: type", data: `{"email": "a@example.com", "name": 42}`, wantName: "name", wantErr: arbiter.ErrJSONType},
+		{name: "invalid: integer", data: `{"email": "a@example.com", "name": "Ada", "age": 1.5}`, wantName: "age", wantErr: arbiter.ErrJSONType},
+		{name: "invalid: format", data: `{"email": "nope", "name": "Ada"}`, wantName: "email", wantErr: rule.ErrEmail},
+		{name: "invalid: uuid", data: `{"email": "a@example.com", "name": "Ada", "id": "x"}`, wantName: "id", wantErr: rule.ErrUUID},
+		{name: "invalid: ipv4", data: `{"email": "a@example.com", "name": "Ada", "ip": "::1"}`, wantName: "ip", wantErr: rule.ErrIPv4},
+		{name: "invalid: pattern", data: `{"email": "a@example.com", "name": "Ada", "code": "abc"}`, wantName: "code", wantErr: rule.ErrRegex},
+		{name: "invalid: empty pattern", data: `{"email": "a@example.com", "name": "Ada", "code": ""}`, wantName: "code", wantErr: rule.ErrRegex},
+		{name: "invalid: empty format", data: `{"email": "", "name": "Ada"}`, wantName: "email", wantErr: rule.ErrEmail},
+		{name: "invalid: length", data: `{"email": "a@example.com", "name": "Adaline"}`, wantName: "name", wantErr: rule.ErrLength},
+		{name: "invalid: enum", data: `{"email": "a@example.com", "name": "Ada", "role": "owner"}`, wantName: "role", wantErr: rule.ErrIn},
+		{name: "invalid: const", data: `{"email": "a@example.com", "name": "Ada", "kind": "bot"}`, wantName: "kind", wantErr: rule.ErrIn},
+		{name: "invalid: minimum", data: `{"email": "a@example.com", "name": "Ada", "age": -1}`, wantName: "age", wantErr: rule.ErrMin},
+		{name: "invalid: exclusive maximum", data: `{"email": "a@example.com", "name": "Ada", "age": 150}`, wantName: "age", wantErr: rule.ErrBetween},
+		{name: "invalid: exclusive minimum", data: `{"email": "a@example.com", "name": "Ada", "score": 0}`, wantName: "score", wantErr: rule.ErrBetween},
+		{name: "invalid: multiple", data: `{"email": "a@example.com", "name": "Ada", "score": 0.7}`, wantName: "score", wantErr: rule.ErrDivisibleBy},
+		{name: "invalid: min items", data: `{"email": "a@example.com", "name": "Ada", "tags": []}`, wantName: "tags", wantErr: rule.ErrLength},
+		{name: "invalid: unique items", data: `{"email": "a@example.com", "name": "Ada", "tags": ["a", "a"]}`, wantName: "tags", wantErr: arbiter.ErrJSONUniqueItems},
+		{name: "invalid: items", data: `{"email": "a@example.com", "name": "Ada", "tags": ["a", "long"]}`, wantName: "tags[1]", wantErr: rule.ErrLength},
+		{name: "invalid: date-time", data: `{"email": "a@example.com", "name": "Ada", "created": "yesterday"}`, wantName: "created", wantErr: rule.ErrDateTimeFormat},
+		{name: "invalid: nested", data: `{"email": "a@example.com", "name": "Ada", "address": {}}`, wantName: "address.city", wantErr: rule.ErrRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateJSON([]byte(tt.data))
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			var fe *arbiter.FieldError
+			if assert.ErrorAs(t, err, &fe) {
+				assert.Equal(t, tt.wantName, fe.Name)
+			}
+		})
+	}
+
+	var payload any
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "A", "age": -1, "tags": [1]}`), &payload))
+	errs := v.ValidateAll(payload)
+	if assert.Len(t, errs, 4) {
+		assert.Equal(t, "email: required", errs[0].Error())
+		assert.Equal(t, "age", errs[1].Name)
+		assert.Equal(t, "name", errs[2].Name)
+		assert.Equal(t, "tags[0]", errs[3].Name)
+	}
+	assert.Error(t, v.ValidateJSON([]byte(`{`)))
+	assert.ErrorIs(t, v.Validate("text"), arbiter.ErrJSONType)
+}
+
+func TestFromJSONSchemaUniqueItems(t *testing.T) {
+	v, err := arbiter.FromJSONSchema([]byte(`{"type": "array", "uniqueItems": true}`))
+	assert.NoError(t, err)
+
+	assert.NoError(t, v.ValidateJSON([]byte(`[1, "1", [1], {"a": 1}, {"a": "1"}, null, true]`)))
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`[1, 1.0]`)), arbiter.ErrJSONUniqueItems)
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`[0, -0]`)), arbiter.ErrJSONUniqueItems)
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`[{"a": 1, "b": [2]}, {"b": [2.0], "a": 1}]`)), arbiter.ErrJSONUniqueItems)
+
+	// Large arrays are checked in linear time.
+	items := make([]string, 20000)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id": %d}`, i)
+	}
+	start := time.Now()
+	assert.NoError(t, v.ValidateJSON([]byte("["+strings.Join(items, ",")+"]")))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestFromJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{name: "invalid json", schema: `{`},
+		{name: "unsupported keyword", schema: `{"$ref": "#/$defs/user"}`},
+		{name: "invalid type", schema: `{"type": 1}`},
+		{name: "invalid properties", schema: `{"properties": []}`},
+		{name: "invalid property", schema: `{"properties": {"a": 1}}`},
+		{name: "invalid nested property", schema: `{"properties": {"a": {"allOf": []}}}`},
+		{name: "invalid required", schema: `{"required": [1]}`},
+		{name: "schema additional properties", schema: `{"additionalProperties": {"type": "string"}}`},
+		{name: "invalid pattern", schema: `{"pattern": "("}`},
+		{name: "invalid length", schema: `{"minLength": -1}`},
+		{name: "invalid minimum", schema: `{"minimum": "1"}`},
+		{name: "invalid multiple", schema: `{"multipleOf": 0}`},
+		{name: "invalid items", schema: `{"items": true}`},
+		{name: "invalid enum", schema: `{"enum": "a"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := arbiter.FromJSONSchema([]byte(tt.schema))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONSchemaRoundTrip(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaAccount]().
+		Field("Name", rule.Required[string](), rule.Len[string](2, 50)).
+		Field("Age", rule.Between(0, 120)).
+		Field("Website", rule.Regex(`^https://`)))
+	doc, err := json.Marshal(arbiter.ToJSONSchema(schema))
+	assert.NoError(t, err)
+
+	v, err := arbiter.FromJSONSchema(doc)
+	assert.NoError(t, err)
+	assert.NoError(t, v.ValidateJSON([]byte(`{"name": "Ada", "age": 36, "website": ""}`)))
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`{"name": "Ada", "age": 121}`)), rule.ErrMax)
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`{"name": "Ada", "website": "http://a"}`)), rule.ErrRegex)
+	assert.ErrorIs(t, v.ValidateJSON([]byte(`{"age": 1}`)), rule.ErrRequired)
+}
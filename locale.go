@@ -0,0 +1,205 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the message catalogs, which translate the default messages of rules.
+package arbiter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"golang.org/x/text/language"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+var (
+	catalogsMutex sync.RWMutex
+	catalogs      = make(map[string]map[string]*template.Template)
+	// locales are the tags of the catalogs in the order of the matcher's supported tags.
+	locales []string
+	matcher language.Matcher
+)
+
+func init() {
+	for locale, messages := range map[string]map[string]string{"en-US": enUS, "zh-CN": zhCN} {
+		if err := RegisterCatalog(locale, messages); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCatalog adds messages to the catalog of a locale, such as "en-US" or "fr-FR",
+// creating it if needed. Messages are text/templates keyed by error code, rendered with
+// the same data as SetMessageTemplate except that parameters are formatted as text; use
+// {{if .Has.Max}} to test whether the rule reported them. They do not include the field name,
+// which is prefixed as in FieldError.Error. An empty text removes the message for a code.
+// The en-US and zh-CN catalogs are built in, with a message for every code of the rule
+// package; registering messages for them overrides the built-in ones.
+//
+// Example:
+//
+//	err := RegisterCatalog("fr-FR", map[string]string{
+//	    "required": "est obligatoire",
+//	    "between":  "doit être compris entre {{.Min}} et {{.Max}}",
+//	})
+func RegisterCatalog(locale string, messages map[string]string) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+	parsed := make(map[string]*template.Template, len(messages))
+	for code, text := range messages {
+		if text == "" {
+			parsed[code] = nil
+			continue
+		}
+		tmpl, err := template.New(code).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return err
+		}
+		parsed[code] = tmpl
+	}
+
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	catalog := catalogs[tag.String()]
+	if catalog == nil {
+		catalog = make(map[string]*template.Template, len(parsed))
+		catalogs[tag.String()] = catalog
+		locales = append(locales, tag.String())
+		slices.Sort(locales)
+		tags := make([]language.Tag, len(locales))
+		for i, l := range locales {
+			tags[i] = language.MustParse(l)
+		}
+		matcher = language.NewMatcher(tags)
+	}
+	for code, tmpl := range parsed {
+		if tmpl == nil {
+			delete(catalog, code)
+		} else {
+			catalog[code] = tmpl
+		}
+	}
+	return nil
+}
+
+// matchLocale returns the registered locale that best matches tag, a language tag or an
+// Accept-Language header value, or "" if none does.
+func matchLocale(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	tags, _, err := language.ParseAcceptLanguage(tag)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+	return locales[index]
+}
+
+// catalogMessage renders the message of the catalog of locale for the error code of re,
+// and reports whether the catalog has one.
+func (e *FieldError) catalogMessage(locale string, re *rule.Error) (string, bool) {
+	catalogsMutex.RLock()
+	tmpl := catalogs[locale][re.Code]
+	catalogsMutex.RUnlock()
+	if tmpl == nil {
+		return "", false
+	}
+	data := e.templateData(re, true)
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", false
+	}
+	if e.Name == "" {
+		return b.String(), true
+	}
+	return e.Name + ": " + b.String(), true
+}
+
+// formatParam formats a parameter of a rule as text, joining the elements of lists such
+// as the values of rule.In with ", ".
+func formatParam(v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+	items := make([]string, rv.Len())
+	for i := range items {
+		items[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(items, ", ")
+}
+
+// LocaleOption selects the message catalog of a ValidateStruct call.
+type LocaleOption string
+
+// Locale renders the default messages of the failed rules from the catalog of the
+// locale that best matches tag, which is a language tag such as "zh-CN" or the value of
+// an Accept-Language header, so that each request can be answered in its user's
+// language. Messages set with Errf are kept. Without a matching catalog, or for codes
+// the catalog has no message for, the messages are rendered as without Locale.
+//
+// Example:
+//
+//	err := ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.Name, rule.Required[string]()),
+//	    Locale(r.Header.Get("Accept-Language")),
+//	)
+//	// With "zh-CN,zh;q=0.9", err.Error() is "Name: 不能为空"
+func Locale(tag string) LocaleOption {
+	return LocaleOption(tag)
+}
+
+// validate does nothing, as LocaleOption only configures the validation.
+func (l LocaleOption) validate(options) error {
+	return nil
+}
+
+// collect does nothing, as LocaleOption only configures the validation.
+func (l LocaleOption) collect(errs ValidationErrors, _ options) ValidationErrors {
+	return errs
+}
+
+// Localize returns err with the messages of its field errors rendered from the catalog
+// of the locale that best matches tag, like the Locale option. It translates the errors
+// of schemas and framework integrations, which have no options. err must
+// be a *FieldError or ValidationErrors; other errors are returned as is. The field
+// errors of err are not modified.
+//
+// Example:
+//
+//	if err := userSchema.Validate(&user); err != nil {
+//	    err = Localize(err, r.Header.Get("Accept-Language"))
+//	}
+func Localize(err error, tag string) error {
+	locale := matchLocale(tag)
+	switch e := err.(type) {
+	case ValidationErrors:
+		localized := make(ValidationErrors, len(e))
+		for i, fe := range e {
+			localized[i] = fe.localize(locale)
+		}
+		return localized
+	case *FieldError:
+		return e.localize(locale)
+	}
+	return err
+}
+
+// localize returns a copy of e with its message rendered for locale.
+func (e *FieldError) localize(locale string) *FieldError {
+	c := *e
+	c.Message = ""
+	c.render(locale)
+	return &c
+}
@@ -0,0 +1,119 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of the message catalogs.
+package arbiter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+func TestLocale(t *testing.T) {
+	type Signup struct {
+		Name    string
+		Age     int
+		Roles   []string
+		Role    string
+		Balance int
+	}
+	s := &Signup{Age: 130, Role: "owner", Balance: -5}
+	tests := []struct {
+		name   string
+		field  arbiter.IFieldRule
+		locale string
+		want   string
+	}{
+		{name: "zh-CN", field: arbiter.Field(&s.Name, rule.Required[string]()), locale: "zh-CN", want: "Name: 不能为空"},
+		{name: "zh-CN parameters", field: arbiter.Field(&s.Age, rule.Between(0, 120)), locale: "zh-CN", want: "Age: 必须介于 0 和 120 之间"},
+		{name: "en-US parameters", field: arbiter.Field(&s.Age, rule.Between(0, 120)), locale: "en-US", want: "Age: must be between 0 and 120"},
+		{name: "zero bound", field: arbiter.Field(&s.Age, rule.Between(-10, 0)), locale: "en-US", want: "Age: must be between -10 and 0"},
+		{name: "zh-CN zero bound", field: arbiter.Field(&s.Age, rule.Between(-10, 0)), locale: "zh-CN", want: "Age: 必须介于 -10 和 0 之间"},
+		{name: "zero minimum", field: arbiter.Field(&s.Balance, rule.Min(0)), locale: "en-US", want: "Balance: must be at least 0"},
+		{name: "list parameter", field: arbiter.Field(&s.Role, rule.In("admin", "editor")), locale: "en", want: "Role: must be one of admin, editor"},
+		{name: "Accept-Language", field: arbiter.Field(&s.Name, rule.Required[string]()), locale: "fr-FR;q=0.9, zh-Hans-CN, en;q=0.5", want: "Name: 不能为空"},
+		{name: "explicit name", field: arbiter.Field(&s.Name, rule.Required[string]()).Name("name"), locale: "zh", want: "name: 不能为空"},
+		{name: "custom message is kept", field: arbiter.Field(&s.Age, rule.Between(0, 120).Errf("too old")), locale: "zh-CN", want: "too old"},
+		{name: "no matching locale", field: arbiter.Field(&s.Age, rule.Between(0, 120)), locale: "ja-JP", want: "Age: is not between 0 and 120"},
+		{name: "invalid locale", field: arbiter.Field(&s.Name, rule.Required[string]()), locale: "?", want: "Name: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale := arbiter.Locale(tt.locale)
+			assert.EqualError(t, arbiter.ValidateStruct(s, "Signup cannot be nil", tt.field, locale), tt.want)
+			assert.EqualError(t, arbiter.ValidateStructAll(s, "Signup cannot be nil", tt.field, locale), tt.want)
+		})
+	}
+}
+
+func TestLocaleTemplateFallback(t *testing.T) {
+	assert.NoError(t, arbiter.SetMessageTemplate("required", "{{.Field}} is missing"))
+	assert.NoError(t, arbiter.RegisterCatalog("zh-CN", map[string]string{"required": ""}))
+	defer func() {
+		assert.NoError(t, arbiter.SetMessageTemplate("required", ""))
+		assert.NoError(t, arbiter.RegisterCatalog("zh-CN", map[string]string{"required": "不能为空"}))
+	}()
+
+	type Signup struct{ Name string }
+	s := &Signup{}
+	err := arbiter.ValidateStruct(s, "Signup cannot be nil", arbiter.Field(&s.Name, rule.Required[string]()), arbiter.Locale("zh-CN"))
+	assert.EqualError(t, err, "Name is missing")
+}
+
+func TestLocaleCatalogsComplete(t *testing.T) {
+	for _, locale := range []string{"en-US", "zh-CN"} {
+		for _, code := range rule.Codes() {
+			fe := &arbiter.FieldError{Name: "Field", Err: &rule.Error{Code: code, Message: "default"}}
+			assert.NotEqual(t, "Field: default", arbiter.Localize(fe, locale).Error(), "%s has no message for %s", locale, code)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	type Product struct {
+		Name  string
+		Price int
+	}
+	schema, err := arbiter.For[Product]().Rules("Name", "required").Rules("Price", "min=1").Build()
+	assert.NoError(t, err)
+
+	errs := validationErrors(schema.ValidateAll(&Product{}))
+	localized := arbiter.Localize(errs, "zh-CN")
+	assert.EqualError(t, localized, "Name: 不能为空; Price: 不能小于 1")
+	assert.EqualError(t, errs, "Name: required; Price: value is less than minimum")
+
+	var fe *arbiter.FieldError
+	assert.True(t, errors.As(schema.Validate(&Product{Name: "Lamp"}), &fe))
+	assert.EqualError(t, arbiter.Localize(fe, "en-US"), "Price: must be at least 1")
+	assert.ErrorIs(t, arbiter.Localize(fe, "en-US"), rule.ErrMin)
+
+	plain := errors.New("plain")
+	assert.Equal(t, plain, arbiter.Localize(plain, "zh-CN"))
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	assert.NoError(t, arbiter.RegisterCatalog("fr-FR", map[string]string{
+		"required": "est obligatoire",
+		"between":  "doit être compris entre {{.Min}} et {{.Max}}",
+	}))
+
+	type Signup struct {
+		Name string
+		Age  int
+	}
+	s := &Signup{Age: 130}
+	err := arbiter.ValidateStructAll(s, "Signup cannot be nil",
+		arbiter.Field(&s.Name, rule.Required[string]()),
+		arbiter.Field(&s.Age, rule.Between(0, 120)),
+		arbiter.Field(&s.Age, rule.Max(100)),
+		arbiter.Locale("fr-CA, fr;q=0.8"),
+	)
+	assert.EqualError(t, err, "Name: est obligatoire; Age: doit être compris entre 0 et 120; Age: value is greater than maximum")
+
+	assert.Error(t, arbiter.RegisterCatalog("not a locale", map[string]string{"required": "x"}))
+	assert.Error(t, arbiter.RegisterCatalog("de-DE", map[string]string{"required": "{{.Field"}))
+}
@@ -0,0 +1,109 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the logging hook, which reports validation failures for auditing.
+package arbiter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// LogEvent describes a failed rule of a struct field, as passed to the Logger set with
+// SetLogger.
+type LogEvent struct {
+	// Type is the type of the validated struct.
+	Type reflect.Type
+	// Field is the name of the field, as in FieldError.Name.
+	Field string
+	// Code is the error code of the rule, such as "sql_injection", or "" if it has none.
+	Code string
+	// Value is the value of the field, unless it was redacted. It is nil for fields of
+	// struct or struct pointer type, such as the struct of a StructLevelRule, whose
+	// values may contain any secret.
+	Value any
+	// Err is the error of the field.
+	Err error
+}
+
+// Logger receives the failures of ValidateStruct, ValidateStructAll and schemas. It is
+// called synchronously by the validating goroutine, so it must be safe for concurrent
+// use and should not block.
+type Logger func(event LogEvent)
+
+// RedactedValue replaces the values of the fields redacted by RedactFields.
+const RedactedValue = "[REDACTED]"
+
+var (
+	loggerMutex sync.RWMutex
+	logger      Logger
+)
+
+// SetLogger sets the logger that is called for every field error reported by
+// ValidateStruct, ValidateStructAll, Schema.Validate and Schema.ValidateAll, so that
+// rejected inputs, such as SQL injection or XSS attempts, can be audited centrally.
+// Errors of embedded structs are reported once, with the type of the embedded struct.
+// A nil logger disables logging. Values are logged as is; wrap the logger with
+// RedactFields to hide secrets such as passwords.
+//
+// Example:
+//
+//	arbiter.SetLogger(arbiter.RedactFields(func(e arbiter.LogEvent) {
+//	    slog.Warn("validation failed", "type", e.Type, "field", e.Field, "code", e.Code, "value", e.Value)
+//	}, "Password", "Card.Number"))
+func SetLogger(l Logger) {
+	loggerMutex.Lock()
+	logger = l
+	loggerMutex.Unlock()
+}
+
+// RedactFields returns a logger that passes events to l with the values of the named
+// fields replaced by RedactedValue. Names are matched against the end or the start of
+// LogEvent.Field, so "Password" redacts "Password", "User.Password" and
+// "Items[0].Password", and "Card" redacts every field within Card such as "Card.Number".
+//
+// Example:
+//
+//	arbiter.SetLogger(arbiter.RedactFields(auditLog, "Password", "Token"))
+func RedactFields(l Logger, fields ...string) Logger {
+	return func(event LogEvent) {
+		if slices.ContainsFunc(fields, func(name string) bool { return redacts(name, event.Field) }) {
+			event.Value = RedactedValue
+		}
+		l(event)
+	}
+}
+
+// redacts reports whether name matches the last segments of field, or its first segments.
+func redacts(name, field string) bool {
+	return name != "" && (field == name || strings.HasSuffix(field, "."+name) ||
+		strings.HasPrefix(field, name+".") || strings.HasPrefix(field, name+"["))
+}
+
+// logErrors passes the field errors of the struct that root points to to the logger.
+func logErrors(root any, errs ...*FieldError) {
+	loggerMutex.RLock()
+	l := logger
+	loggerMutex.RUnlock()
+	if l == nil || len(errs) == 0 {
+		return
+	}
+	typ := reflect.TypeOf(root).Elem()
+	for _, fe := range errs {
+		event := LogEvent{Type: typ, Field: fe.Name, Code: rule.ErrorCode(fe.Err), Err: fe}
+		if v := reflect.ValueOf(fe.Field); v.Kind() == reflect.Ptr && !v.IsNil() && !structKind(v.Elem().Type()) {
+			event.Value = v.Elem().Interface()
+		}
+		l(event)
+	}
+}
+
+// structKind reports whether t is a struct or a pointer to a struct.
+func structKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
@@ -0,0 +1,101 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the logging hook.
+package arbiter_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testLogin struct {
+	Audited
+	Username string
+	Password string
+}
+
+func TestSetLogger(t *testing.T) {
+	var events []arbiter.LogEvent
+	arbiter.SetLogger(arbiter.RedactFields(func(e arbiter.LogEvent) {
+		events = append(events, e)
+	}, "Password"))
+	defer arbiter.SetLogger(nil)
+
+	login := &testLogin{Username: "' OR 1=1 --", Password: "hunter2"}
+	errs := validationErrors(arbiter.ValidateStructAll(login, "Login cannot be nil",
+		arbiter.Field(&login.Username, rule.SQLInjection()),
+		arbiter.Field(&login.Password, rule.Len[string](8, 64)),
+	))
+	assert.Len(t, errs, 3)
+
+	want := []arbiter.LogEvent{
+		{Type: reflect.TypeFor[Audited](), Field: "CreatedBy", Code: "required", Value: ""},
+		{Type: reflect.TypeFor[testLogin](), Field: "Username", Code: "sql_injection", Value: "' OR 1=1 --"},
+		{Type: reflect.TypeFor[testLogin](), Field: "Password", Code: "length", Value: arbiter.RedactedValue},
+	}
+	if assert.Len(t, events, len(want)) {
+		for i, e := range events {
+			assert.Error(t, e.Err)
+			e.Err = nil
+			assert.Equal(t, want[i], e)
+		}
+	}
+
+	events = nil
+	assert.NoError(t, arbiter.ValidateStruct(&testLogin{Audited: Audited{CreatedBy: "ada"}}, ""))
+	assert.Empty(t, events)
+
+	arbiter.SetLogger(nil)
+	assert.Error(t, arbiter.ValidateStruct(login, "", arbiter.Field(&login.Username, rule.SQLInjection())))
+	assert.Empty(t, events)
+}
+
+func TestLoggerStructValues(t *testing.T) {
+	var events []arbiter.LogEvent
+	arbiter.SetLogger(func(e arbiter.LogEvent) {
+		events = append(events, e)
+	})
+	defer arbiter.SetLogger(nil)
+
+	login := &testLogin{Audited: Audited{CreatedBy: "ada"}, Username: "ada", Password: "hunter2"}
+	assert.Error(t, arbiter.ValidateStruct(login, "Login cannot be nil",
+		arbiter.StructRule(func(l *testLogin) error {
+			return rule.Ternary(l.Password == "hunter2", errors.New("password is too common"), nil)
+		}),
+	))
+	if assert.Len(t, events, 1) {
+		assert.Nil(t, events[0].Value)
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	tests := []struct {
+		field    string
+		redacted bool
+	}{
+		{field: "Password", redacted: true},
+		{field: "User.Password", redacted: true},
+		{field: "Items[0].Password", redacted: true},
+		{field: "Card.Number", redacted: true},
+		{field: "Card.Expiry.Month", redacted: true},
+		{field: "Cards[1]", redacted: true},
+		{field: "OldPassword", redacted: false},
+		{field: "Passwords", redacted: false},
+		{field: "Cardholder", redacted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			var got any
+			arbiter.RedactFields(func(e arbiter.LogEvent) {
+				got = e.Value
+			}, "Password", "Card", "Cards")(arbiter.LogEvent{Field: tt.field, Value: "secret"})
+			assert.Equal(t, tt.redacted, got == arbiter.RedactedValue)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the discovery of field names from the layout of the validated struct.
+package arbiter
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldKey returns the name of a struct field as used in error keys, such as its Go
+// name or the name in one of its tags.
+//
+// Example:
+//
+//	var errs ValidationErrors
+//	errors.As(ValidateStructAll(user, "User cannot be nil", fields...), &errs)
+//	payload := errs.ByKey(JSONKey)  // map[email:[...] address.city:[...]]
+type FieldKey func(field reflect.StructField) string
+
+// GoKey names fields by their Go name, such as "Email". It is the default.
+var GoKey FieldKey = func(field reflect.StructField) string {
+	return field.Name
+}
+
+// JSONKey names fields by the name in their json tag, such as "email", so error keys
+// line up with the JSON the client sent.
+var JSONKey = TagKey("json")
+
+// TagKey names fields by the name in the given struct tag, which is the part before the
+// first comma as in the json, yaml and form tags. Fields without a name in the tag, or
+// with the name "-", use their Go name.
+//
+// Example:
+//
+//	type Signup struct {
+//	    Email string `form:"email_address"`
+//	}
+//
+//	payload := errs.ByKey(TagKey("form"))  // map[email_address:[...]]
+func TagKey(tag string) FieldKey {
+	return func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	}
+}
+
+// fieldName returns the path of the field that target points to within the struct that
+// root points to, such as "Age", "Address.City" or "Tags[1]", with each field named by
+// key, or "" if target is not part of the struct.
+func fieldName(root, target any, key FieldKey) string {
+	return findPath(root, target).name(key)
+}
+
+// fieldPath is the path of a field within a struct: the struct fields and the slice and
+// array indexes leading to it, outermost first. It is recorded once, so that the field
+// can be named by any key without searching the struct again.
+type fieldPath []pathStep
+
+// pathStep is a struct field or, if index is not negative, a slice or array index.
+type pathStep struct {
+	field reflect.StructField
+	index int
+}
+
+// name returns the path with each struct field named by key, such as "Address.City" or
+// "Tags[1]". Fields of embedded structs are named without the name of the embedded
+// struct, unless key names it from a tag. It returns "" for an empty path.
+func (p fieldPath) name(key FieldKey) string {
+	var name string
+	for i := len(p) - 1; i >= 0; i-- {
+		step := p[i]
+		part := "[" + strconv.Itoa(step.index) + "]"
+		if step.index < 0 {
+			part = key(step.field)
+		}
+		switch {
+		case i == len(p)-1:
+			name = part
+		case step.index < 0 && step.field.Anonymous && part == step.field.Name && name[0] != '[':
+			// Promoted fields of embedded structs are named as fields of the outer
+			// struct, like encoding/json does unless the tag names the embedded struct
+		default:
+			name = joinPath(part, name)
+		}
+	}
+	return name
+}
+
+// findPath returns the path of the field that target points to within the struct that
+// root points to. Fields of nested structs, pointers to structs and slice elements are
+// searched. It returns nil if target is not part of the struct.
+func findPath(root, target any) fieldPath {
+	t := reflect.ValueOf(target)
+	if t.Kind() != reflect.Ptr || t.IsNil() {
+		return nil
+	}
+	v := reflect.ValueOf(root)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	path, _ := findField(v.Elem(), t.Pointer(), t.Type().Elem(), 0)
+	return path
+}
+
+// maxFieldDepth limits the search through pointers, which may form cycles.
+const maxFieldDepth = 32
+
+// findField searches the addressable value v for the value of type typ at address addr.
+func findField(v reflect.Value, addr uintptr, typ reflect.Type, depth int) (fieldPath, bool) {
+	if depth > maxFieldDepth {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			fv := v.Field(i)
+			step := pathStep{field: field, index: -1}
+			if fv.UnsafeAddr() == addr && field.Type == typ {
+				return fieldPath{step}, true
+			}
+			if path, ok := findField(fv, addr, typ, depth+1); ok {
+				return append(fieldPath{step}, path...), true
+			}
+		}
+	case reflect.Array, reflect.Slice:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		size := v.Type().Elem().Size()
+		start := v.Index(0).UnsafeAddr()
+		if size == 0 || addr < start || addr >= start+uintptr(v.Len())*size {
+			return nil, false
+		}
+		i := int((addr - start) / size)
+		step := pathStep{index: i}
+		if v.Type().Elem() == typ {
+			return fieldPath{step}, true
+		}
+		if path, ok := findField(v.Index(i), addr, typ, depth+1); ok {
+			return append(fieldPath{step}, path...), true
+		}
+	case reflect.Ptr:
+		if !v.IsNil() && v.Type().Elem().Kind() == reflect.Struct {
+			return findField(v.Elem(), addr, typ, depth+1)
+		}
+	}
+	return nil, false
+}
+
+// joinPath joins the path of a parent with the path of a child, which is either a
+// field name or an index such as "[1]".
+func joinPath(parent, child string) string {
+	if child != "" && child[0] == '[' {
+		return parent + child
+	}
+	return joinName(parent, child)
+}
@@ -0,0 +1,119 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the generation of OpenAPI schemas and parameters from schemas.
+package arbiter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification that generated schemas
+// and parameters follow.
+type OpenAPIVersion string
+
+const (
+	// OpenAPI30 generates OpenAPI 3.0 schema objects, which use boolean exclusiveMinimum
+	// and exclusiveMaximum, enum instead of const and the byte format for base64 strings.
+	OpenAPI30 OpenAPIVersion = "3.0"
+	// OpenAPI31 generates OpenAPI 3.1 schema objects, which are JSON Schema draft 2020-12.
+	OpenAPI31 OpenAPIVersion = "3.1"
+)
+
+// ToOpenAPISchema returns the schema object of the struct type T for the
+// components/schemas section of an OpenAPI document, with the constraints of the schema
+// as described by ToJSONSchema. Generating the documentation from the schema that
+// validates requests keeps the two from drifting apart.
+//
+// Example:
+//
+//	doc := map[string]any{
+//	    "openapi": "3.1.0",
+//	    "components": map[string]any{
+//	        "schemas": map[string]any{
+//	            "User": arbiter.ToOpenAPISchema(userSchema, arbiter.OpenAPI31),
+//	        },
+//	    },
+//	}
+func ToOpenAPISchema[T any](s *Schema[T], version OpenAPIVersion) map[string]any {
+	obj := jsonSchemaObject(reflect.TypeFor[T](), s.fields)
+	if version == OpenAPI30 {
+		openAPI30(obj)
+	}
+	return obj
+}
+
+// ToOpenAPIParameters returns a parameter object for each top-level field of the
+// schema, located in in ("query", "path", "header" or "cookie"). Parameters are named by
+// the json tag of their field and are in the order the fields were added; path
+// parameters are always required, as OpenAPI demands. Fields tagged json:"-" have no
+// parameter.
+//
+// Example:
+//
+//	listSchema := arbiter.MustBuild(arbiter.For[ListUsers]().
+//	    Field("Page", rule.Min(1)).
+//	    Field("Size", rule.Between(1, 100)))
+//
+//	params := arbiter.ToOpenAPIParameters(listSchema, "query", arbiter.OpenAPI31)
+//	// [{"name": "page", "in": "query", "required": false, "schema": {"type": "integer", "minimum": 1}}, ...]
+func ToOpenAPIParameters[T any](s *Schema[T], in string, version OpenAPIVersion) []map[string]any {
+	obj := ToOpenAPISchema(s, version)
+	props, _ := obj["properties"].(map[string]any)
+	required, _ := obj["required"].([]string)
+	t := reflect.TypeFor[T]()
+
+	var names []string
+	var params []map[string]any
+	for _, field := range s.fields {
+		if jsonOmitted(t, field.path) {
+			continue
+		}
+		name := JSONKey(t.FieldByIndex(field.path[0]))
+		if slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+		params = append(params, map[string]any{
+			"name":     name,
+			"in":       in,
+			"required": in == "path" || slices.Contains(required, name),
+			"schema":   props[name],
+		})
+	}
+	return params
+}
+
+// openAPI30 converts the JSON Schema keywords of schema and its subschemas to their
+// OpenAPI 3.0 form in place.
+func openAPI30(schema map[string]any) {
+	for _, bound := range []string{"Minimum", "Maximum"} {
+		exclusive := "exclusive" + bound
+		if v, ok := schema[exclusive]; ok {
+			if _, isBool := v.(bool); !isBool {
+				schema[strings.ToLower(bound)] = v
+				schema[exclusive] = true
+			}
+		}
+	}
+	if v, ok := schema["const"]; ok {
+		delete(schema, "const")
+		schema["enum"] = []any{v}
+	}
+	if schema["contentEncoding"] == "base64" {
+		delete(schema, "contentEncoding")
+		schema["format"] = "byte"
+	}
+	if props, ok := schema["properties"].(map[string]any); ok {
+		for _, prop := range props {
+			if sub, ok := prop.(map[string]any); ok {
+				openAPI30(sub)
+			}
+		}
+	}
+	for _, key := range []string{"items", "not"} {
+		if sub, ok := schema[key].(map[string]any); ok {
+			openAPI30(sub)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of the OpenAPI generation.
+package arbiter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testListQuery struct {
+	ID     string `json:"id"`
+	Page   int    `json:"page"`
+	Size   int    `json:"size"`
+	Filter struct {
+		Name string `json:"name"`
+	} `json:"filter"`
+	Token string `json:"-"`
+}
+
+func TestToOpenAPISchema(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaAccount]().
+		Field("Age", rule.Between(0, 120).ExclusiveMin()).
+		Field("Avatar").
+		Field("Tags", rule.SliceLen[[]string](1, 3)).
+		Field("Address.Zip", rule.Len[string](5, 5)))
+
+	tests := []struct {
+		name    string
+		version arbiter.OpenAPIVersion
+		want    string
+	}{
+		{
+			name:    "3.1",
+			version: arbiter.OpenAPI31,
+			want: `{"type": "object", "properties": {
+				"age": {"type": "integer", "exclusiveMinimum": 0, "maximum": 120},
+				"avatar": {"type": "string", "contentEncoding": "base64"},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3},
+				"address": {"type": "object", "properties": {"Zip": {"type": "string", "minLength": 5, "maxLength": 5}}}
+			}}`,
+		},
+		{
+			name:    "3.0",
+			version: arbiter.OpenAPI30,
+			want: `{"type": "object", "properties": {
+				"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 120},
+				"avatar": {"type": "string", "format": "byte"},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3},
+				"address": {"type": "object", "properties": {"Zip": {"type": "string", "minLength": 5, "maxLength": 5}}}
+			}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := json.Marshal(arbiter.ToOpenAPISchema(schema, tt.version))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(doc))
+		})
+	}
+}
+
+func TestToOpenAPIParameters(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testListQuery]().
+		Field("Page", rule.Required[int](), rule.Min(1)).
+		Field("Size", rule.Between(1, 100)).
+		Field("Filter.Name", rule.Len[string](0, 20)).
+		Field("Token", rule.Required[string]()))
+
+	params, err := json.Marshal(arbiter.ToOpenAPIParameters(schema, "query", arbiter.OpenAPI30))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "page", "in": "query", "required": true, "schema": {"type": "integer", "minimum": 1, "not": {"enum": [0]}}},
+		{"name": "size", "in": "query", "required": false, "schema": {"type": "integer", "minimum": 1, "maximum": 100}},
+		{"name": "filter", "in": "query", "required": false, "schema": {"type": "object", "properties": {"name": {"type": "string", "minLength": 0, "maxLength": 20}}}}
+	]`, string(params))
+	obj := arbiter.ToOpenAPISchema(schema, arbiter.OpenAPI31)
+	assert.NotContains(t, obj["properties"], "Token")
+	assert.Equal(t, []string{"page"}, obj["required"])
+
+	path := arbiter.MustBuild(arbiter.For[testListQuery]().Field("ID", rule.UUID()))
+	assert.Equal(t, true, arbiter.ToOpenAPIParameters(path, "path", arbiter.OpenAPI31)[0]["required"])
+}
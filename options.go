@@ -0,0 +1,275 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the options of ValidateStruct, which are passed along with the fields.
+package arbiter
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// DefaultMaxDepth is the default limit of the nesting of NestedField and SliceField
+// rules in a ValidateStruct call. Each nested struct and each slice is one level.
+const DefaultMaxDepth = 256
+
+// ErrMaxDepth is returned for a nested struct or slice beyond the depth limit set with
+// MaxDepth.
+var ErrMaxDepth = rule.NewError("max_depth", "value is nested too deeply")
+
+// options are the settings of a ValidateStruct or ValidateStructAll call, passed down
+// to every field rule.
+type options struct {
+	groups []string
+	// root is the validated struct, used to find the names of fields for only.
+	root any
+	only *OnlyOption
+	// current is the struct whose rules are being validated, the root or a nested struct,
+	// for StructRule.
+	current any
+	// locale is the registered locale selected with Locale, or "" for none.
+	locale string
+	// ctx is the context given with Context, or nil for none.
+	ctx context.Context
+
+	maxDepth int
+	// path holds the nested structs and slices being validated, from the outermost.
+	path []nestedValue
+}
+
+// nestedValue identifies a nested struct or slice by its address and type, as the first
+// field of a struct has the address of the struct.
+type nestedValue struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// newOptions returns the options given among fields. Options are IFieldRules that
+// configure the validation instead of validating a field.
+func newOptions(root any, fields []IFieldRule) options {
+	opts := options{root: root, current: root, maxDepth: DefaultMaxDepth}
+	for _, field := range fields {
+		switch o := field.(type) {
+		case GroupOption:
+			// Clip the groups so that appending another Group copies them instead of modifying o
+			opts.groups = append(opts.groups[:len(opts.groups):len(opts.groups)], o...)
+		case *OnlyOption:
+			opts.only = o
+		case MaxDepthOption:
+			opts.maxDepth = int(o)
+		case LocaleOption:
+			opts.locale = matchLocale(string(o))
+		case ContextOption:
+			opts.ctx = o.ctx
+		}
+	}
+	return opts
+}
+
+// enter returns the options for the rules within the nested struct or slice that field
+// points to. It reports false if field is already being validated by an enclosing rule,
+// as happens when pointers form a cycle, so that it is not validated again. It returns
+// ErrMaxDepth if the nesting exceeds the depth limit. The returned options have field as
+// the current struct of StructRule.
+func (o options) enter(field any) (options, bool, error) {
+	o.current = field
+	v := reflect.ValueOf(field)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return o, true, nil
+	}
+	nv := nestedValue{addr: v.Pointer(), typ: v.Type()}
+	if slices.Contains(o.path, nv) {
+		return o, false, nil
+	}
+	if o.maxDepth > 0 && len(o.path) >= o.maxDepth {
+		return o, false, ErrMaxDepth
+	}
+	// Clip the path so that siblings do not overwrite each other's entries
+	o.path = append(o.path[:len(o.path):len(o.path)], nv)
+	return o, true, nil
+}
+
+// selects reports whether the field with the given explicit name and pointer is
+// validated with the fields selected by Only.
+func (o options) selects(name string, field any) bool {
+	if o.only == nil {
+		return true
+	}
+	if name == "" {
+		name = fieldName(o.root, field, o.only.key)
+	}
+	for _, selected := range o.only.names {
+		if name == selected || strings.HasPrefix(name, selected) &&
+			(name[len(selected)] == '.' || name[len(selected)] == '[') {
+			return true
+		}
+	}
+	return false
+}
+
+// inGroups reports whether a field in the given groups is validated. Fields without
+// groups are always validated, as are all fields if no group is selected.
+func (o options) inGroups(groups []string) bool {
+	if len(groups) == 0 || len(o.groups) == 0 {
+		return true
+	}
+	for _, g := range groups {
+		for _, selected := range o.groups {
+			if g == selected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GroupOption selects the validation groups of a ValidateStruct call.
+type GroupOption []string
+
+// Group selects the groups of fields to validate, so that the same field list can
+// serve several scenarios such as create and update. Fields set to other groups with
+// Groups are skipped; fields without groups are always validated. Without Group, all
+// fields are validated.
+//
+// Example:
+//
+//	fields := []IFieldRule{
+//	    Field(&user.ID, rule.Required[int]()).Groups("update"),
+//	    Field(&user.Password, rule.Required[string]()).Groups("create"),
+//	    Field(&user.Email, rule.IsEmail()),
+//	}
+//
+//	// On create: validates Password and Email
+//	err := ValidateStruct(user, "User cannot be nil", append(fields, Group("create"))...)
+func Group(names ...string) GroupOption {
+	return GroupOption(names)
+}
+
+// validate does nothing, as GroupOption only configures the validation.
+func (g GroupOption) validate(options) error {
+	return nil
+}
+
+// collect does nothing, as GroupOption only configures the validation.
+func (g GroupOption) collect(errs ValidationErrors, _ options) ValidationErrors {
+	return errs
+}
+
+// OnlyOption restricts a ValidateStruct call to a set of fields.
+type OnlyOption struct {
+	names []string
+	key   FieldKey
+}
+
+// Only restricts the validation to the named fields and their sub-fields, such as the
+// fields a PATCH request changed, so that untouched fields are not validated. Fields
+// are named by their path in the struct, such as "Email", "Address" or "Address.City",
+// or by the name set with FieldRule.Name; use Key to match the json tag instead.
+//
+// Example:
+//
+//	// Only the fields sent in the PATCH body are validated
+//	err := ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.Name, rule.Required[string]()),
+//	    Field(&user.Email, rule.IsEmail()),
+//	    NestedField(&user.Address,
+//	        Field(&user.Address.City, rule.Required[string]()),
+//	    ),
+//	    Only("Email", "Address"),
+//	)
+func Only(names ...string) *OnlyOption {
+	return &OnlyOption{names: names, key: GoKey}
+}
+
+// Key sets how fields are named for matching, for example JSONKey to match the keys
+// of the request body.
+//
+// Example:
+//
+//	var patch map[string]json.RawMessage
+//	_ = json.Unmarshal(body, &patch)
+//	changed := make([]string, 0, len(patch))
+//	for name := range patch {
+//	    changed = append(changed, name)
+//	}
+//	err := ValidateStruct(user, "User cannot be nil", append(fields, Only(changed...).Key(JSONKey))...)
+func (o *OnlyOption) Key(key FieldKey) *OnlyOption {
+	o.key = key
+	return o
+}
+
+// validate does nothing, as OnlyOption only configures the validation.
+func (o *OnlyOption) validate(options) error {
+	return nil
+}
+
+// collect does nothing, as OnlyOption only configures the validation.
+func (o *OnlyOption) collect(errs ValidationErrors, _ options) ValidationErrors {
+	return errs
+}
+
+// MaxDepthOption sets the depth limit of a ValidateStruct call.
+type MaxDepthOption int
+
+// MaxDepth limits the nesting of NestedField and SliceField rules to n levels, each
+// nested struct and each slice being one level, so that deeply nested or recursive data
+// such as trees cannot exhaust the stack. A nested struct or slice beyond the limit fails
+// with ErrMaxDepth. The default is DefaultMaxDepth; 0 or less removes the limit.
+// Independently of the limit, a struct or slice reached again through a pointer cycle
+// while it is being validated is skipped.
+//
+// Example:
+//
+//	var nodeRule func(n *Node) arbiter.IFieldRule
+//	nodeRule = func(n *Node) arbiter.IFieldRule {
+//	    return arbiter.NestedField(n,
+//	        arbiter.Field(&n.Name, rule.Required[string]()),
+//	        arbiter.SliceField(&n.Children, func(c **Node) arbiter.IFieldRule { return nodeRule(*c) }),
+//	    )
+//	}
+//
+//	err := arbiter.ValidateStruct(root, "Tree cannot be nil", nodeRule(root), arbiter.MaxDepth(32))
+func MaxDepth(n int) MaxDepthOption {
+	return MaxDepthOption(n)
+}
+
+// validate does nothing, as MaxDepthOption only configures the validation.
+func (m MaxDepthOption) validate(options) error {
+	return nil
+}
+
+// collect does nothing, as MaxDepthOption only configures the validation.
+func (m MaxDepthOption) collect(errs ValidationErrors, _ options) ValidationErrors {
+	return errs
+}
+
+// ContextOption sets the context of a ValidateStruct call.
+type ContextOption struct {
+	ctx context.Context
+}
+
+// Context passes ctx to the rules that implement rule.ContextRule, such as
+// rule.ResolvableDomain, so that their I/O is cancelled with the request. Other rules
+// are not affected.
+//
+// Example:
+//
+//	err := ValidateStruct(hook, "Webhook cannot be nil",
+//	    Field(&hook.Host, rule.Domain(), webhookDomain),
+//	    Context(r.Context()),
+//	)
+func Context(ctx context.Context) ContextOption {
+	return ContextOption{ctx: ctx}
+}
+
+// validate does nothing, as ContextOption only configures the validation.
+func (c ContextOption) validate(options) error {
+	return nil
+}
+
+// collect does nothing, as ContextOption only configures the validation.
+func (c ContextOption) collect(errs ValidationErrors, _ options) ValidationErrors {
+	return errs
+}
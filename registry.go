@@ -0,0 +1,297 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains the rule registry, which creates rules by name for schemas.
+package arbiter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// RuleFactory creates a rule for a field of type typ from the parameters of its
+// declaration, such as []string{"1", "10"} for "between=1 10". The rule must be a
+// rule.Rule of typ. The factory parses the parameters itself and returns an error if
+// they or the type are not supported.
+//
+// Example:
+//
+//	var prefix RuleFactory = func(typ reflect.Type, params []string) (any, error) {
+//	    if typ != reflect.TypeFor[string]() || len(params) != 1 {
+//	        return nil, errors.New("prefix needs one parameter and a string field")
+//	    }
+//	    return rule.StartWith(params[0]), nil
+//	}
+type RuleFactory func(typ reflect.Type, params []string) (any, error)
+
+var (
+	rulesMutex sync.RWMutex
+	rules      = map[string]RuleFactory{
+		"required": builtinRule("required"),
+		"min":      builtinRule("min"),
+		"max":      builtinRule("max"),
+		"between":  builtinRule("between"),
+		"in":       builtinRule("in"),
+		"len":      builtinRule("len"),
+		"email":    builtinRule("email"),
+		"url":      builtinRule("url"),
+		"trim":     builtinRule("trim"),
+		"lower":    builtinRule("lower"),
+		"upper":    builtinRule("upper"),
+	}
+)
+
+// RegisterRule registers a factory that creates the rule with the given name, so that
+// it can be declared by name, such as with SchemaBuilder.Rules. Registering a name
+// again replaces its factory, including the built-in ones: required, min, max,
+// between, in, len, email, url, trim, lower and upper. Names must not be empty or
+// contain commas, equal signs or white space.
+//
+// Example:
+//
+//	err := RegisterRule("sku", func(typ reflect.Type, params []string) (any, error) {
+//	    if typ != reflect.TypeFor[string]() {
+//	        return nil, fmt.Errorf("sku does not support %s", typ)
+//	    }
+//	    return rule.Regex(`^[A-Z]{3}-[0-9]{4}$`).Errf("Invalid SKU"), nil
+//	})
+//
+//	schema, err := For[Product]().Rules("SKU", "required,sku").Build()
+func RegisterRule(name string, factory RuleFactory) error {
+	if name == "" || strings.ContainsAny(name, ",= \t\n") {
+		return fmt.Errorf("invalid rule name %q", name)
+	}
+	if factory == nil {
+		return fmt.Errorf("rule %s: factory is nil", name)
+	}
+	rulesMutex.Lock()
+	rules[name] = factory
+	delete(funcs, name)
+	rulesMutex.Unlock()
+	return nil
+}
+
+// funcs holds the functions registered with RegisterFunc by name.
+var funcs = make(map[string]any)
+
+// funcRule validates values with a function registered with RegisterFunc.
+type funcRule[T any] struct {
+	name string
+	fn   func(T) error
+}
+
+// Validate returns the error of the function for value.
+func (r *funcRule[T]) Validate(value T) error {
+	return r.fn(value)
+}
+
+// Describe returns the description of the rule, which is named as it was registered.
+func (r *funcRule[T]) Describe() rule.RuleInfo {
+	return rule.RuleInfo{Name: r.name}
+}
+
+// RegisterFunc registers fn as a rule named name for fields of type T, so that project
+// specific checks can be declared by name like the built-in rules, such as with
+// SchemaBuilder.Rules or in the arbiter tags read by arbitergen. The rule takes no
+// parameters and returns the error of fn as is, so fn should return a *rule.Error, e.g.
+// made with rule.Wrapf, to give its errors a code. Fields whose type adapter converts
+// them to T can use the rule too. Registering a name again replaces its rule.
+//
+// Example:
+//
+//	var errUsername = rule.NewError("username", "invalid username")
+//
+//	err := arbiter.RegisterFunc("username", func(s string) error {
+//	    if !usernamePattern.MatchString(s) {
+//	        return errUsername
+//	    }
+//	    return nil
+//	})
+//
+//	schema, err := arbiter.For[User]().Rules("Name", "required,username").Build()
+func RegisterFunc[T any](name string, fn func(T) error) error {
+	if fn == nil {
+		return fmt.Errorf("rule %s: function is nil", name)
+	}
+	want := reflect.TypeFor[T]()
+	err := RegisterRule(name, func(typ reflect.Type, params []string) (any, error) {
+		if len(params) != 0 {
+			return nil, fmt.Errorf("expected 0 parameters, got %d", len(params))
+		}
+		if a, ok := lookupAdapter(typ); ok {
+			typ = a.to
+		}
+		if typ != want {
+			return nil, fmt.Errorf("%s fields are not supported", typ)
+		}
+		return &funcRule[T]{name: name, fn: fn}, nil
+	})
+	if err != nil {
+		return err
+	}
+	rulesMutex.Lock()
+	funcs[name] = fn
+	rulesMutex.Unlock()
+	return nil
+}
+
+// NamedRule returns the rule registered with RegisterFunc under name for values of type
+// T. If there is none, the rule returns an error for every value, so a missing
+// registration is not silently ignored. Code generated by arbitergen uses it for the
+// rules it does not know.
+//
+// Example:
+//
+//	err := arbiter.Validate(user.Name, arbiter.NamedRule[string]("username"))
+func NamedRule[T any](name string) rule.Rule[T] {
+	rulesMutex.RLock()
+	fn, _ := funcs[name].(func(T) error)
+	rulesMutex.RUnlock()
+	if fn == nil {
+		err := fmt.Errorf("rule %s is not registered for %s", name, reflect.TypeFor[T]())
+		fn = func(T) error { return err }
+	}
+	return &funcRule[T]{name: name, fn: fn}
+}
+
+// newRules creates the rules declared by spec for a field of type typ. The spec lists
+// rule names separated by commas, each optionally followed by "=" and its parameters
+// separated by spaces, such as "required,between=1 10".
+func newRules(typ reflect.Type, spec string) ([]any, error) {
+	var list []any
+	for _, decl := range strings.Split(spec, ",") {
+		decl = strings.TrimSpace(decl)
+		if decl == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(decl, "=")
+		rulesMutex.RLock()
+		factory := rules[name]
+		rulesMutex.RUnlock()
+		if factory == nil {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		r, err := factory(typ, strings.Fields(param))
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", name, err)
+		}
+		list = append(list, r)
+	}
+	return list, nil
+}
+
+// builtinRule returns the factory of a built-in rule, which supports string fields,
+// fields of the basic numeric types and fields whose registered type adapter converts
+// them to one of these types.
+func builtinRule(name string) RuleFactory {
+	return func(typ reflect.Type, params []string) (any, error) {
+		if a, ok := lookupAdapter(typ); ok {
+			typ = a.to
+		}
+		if typ == reflect.TypeFor[string]() {
+			return stringRule(name, params)
+		}
+		if build, ok := orderedRules[typ]; ok {
+			return build(name, params)
+		}
+		return nil, fmt.Errorf("%s fields are not supported", typ)
+	}
+}
+
+// stringRule creates the built-in rule name for a string field.
+func stringRule(name string, params []string) (any, error) {
+	switch name {
+	case "required":
+		return rule.Required[string](), nil
+	case "in":
+		return rule.In(params...), nil
+	case "len":
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected 2 parameters, got %d", len(params))
+		}
+		min, err := strconv.Atoi(params[0])
+		if err != nil {
+			return nil, err
+		}
+		max, err := strconv.Atoi(params[1])
+		if err != nil {
+			return nil, err
+		}
+		return rule.Len[string](min, max), nil
+	case "email":
+		return rule.IsEmail(), nil
+	case "url":
+		return rule.URL(), nil
+	case "trim":
+		return rule.Trim(), nil
+	case "lower":
+		return rule.Lower(), nil
+	case "upper":
+		return rule.Upper(), nil
+	}
+	return nil, fmt.Errorf("string fields are not supported")
+}
+
+// orderedRules create the built-in rules for fields of the basic numeric types.
+var orderedRules = map[reflect.Type]func(name string, params []string) (any, error){
+	reflect.TypeFor[int]():     orderedRule[int],
+	reflect.TypeFor[int8]():    orderedRule[int8],
+	reflect.TypeFor[int16]():   orderedRule[int16],
+	reflect.TypeFor[int32]():   orderedRule[int32],
+	reflect.TypeFor[int64]():   orderedRule[int64],
+	reflect.TypeFor[uint]():    orderedRule[uint],
+	reflect.TypeFor[uint8]():   orderedRule[uint8],
+	reflect.TypeFor[uint16]():  orderedRule[uint16],
+	reflect.TypeFor[uint32]():  orderedRule[uint32],
+	reflect.TypeFor[uint64]():  orderedRule[uint64],
+	reflect.TypeFor[float32](): orderedRule[float32],
+	reflect.TypeFor[float64](): orderedRule[float64],
+}
+
+// orderedRule creates the built-in rule name for a numeric field of type T.
+func orderedRule[T rule.Ordered](name string, params []string) (any, error) {
+	args := make([]T, len(params))
+	for i, p := range params {
+		n, err := parseOrdered[T](p)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = n
+	}
+	want := map[string]int{"required": 0, "min": 1, "max": 1, "between": 2}
+	if n, ok := want[name]; ok && len(args) != n {
+		return nil, fmt.Errorf("expected %d parameters, got %d", n, len(args))
+	}
+	switch name {
+	case "required":
+		return rule.Required[T](), nil
+	case "min":
+		return rule.Min(args[0]), nil
+	case "max":
+		return rule.Max(args[0]), nil
+	case "between":
+		return rule.Between(args[0], args[1]), nil
+	case "in":
+		return rule.In(args...), nil
+	}
+	return nil, fmt.Errorf("%s fields are not supported", reflect.TypeFor[T]())
+}
+
+// parseOrdered parses s as a number of type T.
+func parseOrdered[T rule.Ordered](s string) (T, error) {
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		return T(f), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		return T(n), err
+	default:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		return T(n), err
+	}
+}
@@ -0,0 +1,141 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of the rule registry.
+package arbiter_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testProduct struct {
+	SKU      string
+	Name     string
+	Role     string
+	Quantity int
+	Weight   float64
+	Stock    uint8
+	Tags     []string
+}
+
+func TestSchemaRules(t *testing.T) {
+	schema, err := arbiter.For[testProduct]().
+		Rules("Name", "trim, required, len=2 20").
+		Rules("Role", "in=admin editor").
+		Rules("Quantity", "min=1,max=99").
+		Rules("Weight", "between=0.5 10").
+		Rules("Stock", "in=1 2 3").
+		Build()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		product testProduct
+		wantErr error
+	}{
+		{name: "valid", product: testProduct{Name: " Lamp ", Role: "admin", Quantity: 3, Weight: 1.5, Stock: 2}},
+		{name: "invalid: name", product: testProduct{Name: "  ", Role: "admin", Quantity: 3, Weight: 1.5, Stock: 2}, wantErr: rule.ErrRequired},
+		{name: "invalid: role", product: testProduct{Name: "Lamp", Role: "owner", Quantity: 3, Weight: 1.5, Stock: 2}, wantErr: rule.ErrIn},
+		{name: "invalid: quantity", product: testProduct{Name: "Lamp", Role: "admin", Quantity: 100, Weight: 1.5, Stock: 2}, wantErr: rule.ErrMax},
+		{name: "invalid: weight", product: testProduct{Name: "Lamp", Role: "admin", Quantity: 3, Weight: 0.1, Stock: 2}, wantErr: rule.ErrBetween},
+		{name: "invalid: stock", product: testProduct{Name: "Lamp", Role: "admin", Quantity: 3, Weight: 1.5, Stock: 4}, wantErr: rule.ErrIn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(&tt.product)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	err := arbiter.RegisterRule("test_sku", func(typ reflect.Type, params []string) (any, error) {
+		if typ != reflect.TypeFor[string]() {
+			return nil, fmt.Errorf("%s fields are not supported", typ)
+		}
+		return rule.Regex(`^[A-Z]{3}-[0-9]{4}$`).Errf("Invalid SKU"), nil
+	})
+	assert.NoError(t, err)
+
+	schema, err := arbiter.For[testProduct]().Rules("SKU", "required,test_sku").Build()
+	assert.NoError(t, err)
+	assert.NoError(t, schema.Validate(&testProduct{SKU: "ABC-1234"}))
+	assert.EqualError(t, schema.Validate(&testProduct{SKU: "abc"}), "Invalid SKU")
+
+	_, err = arbiter.For[testProduct]().Rules("Quantity", "test_sku").Build()
+	assert.Error(t, err)
+
+	assert.Error(t, arbiter.RegisterRule("", func(reflect.Type, []string) (any, error) { return nil, nil }))
+	assert.Error(t, arbiter.RegisterRule("a,b", func(reflect.Type, []string) (any, error) { return nil, nil }))
+	assert.Error(t, arbiter.RegisterRule("nil_factory", nil))
+}
+
+func TestRegisterFunc(t *testing.T) {
+	errUsername := &rule.Error{Code: "username", Message: "invalid username"}
+	err := arbiter.RegisterFunc("test_username", func(s string) error {
+		return rule.Ternary[error](len(s) >= 3 && s[0] != '_', nil, errUsername)
+	})
+	assert.NoError(t, err)
+
+	schema, err := arbiter.For[testProduct]().Rules("Name", "required,test_username").Build()
+	assert.NoError(t, err)
+	assert.NoError(t, schema.Validate(&testProduct{Name: "ada"}))
+	err = schema.Validate(&testProduct{Name: "_ad"})
+	assert.ErrorIs(t, err, errUsername)
+	assert.Equal(t, "username", rule.ErrorCode(err))
+
+	assert.NoError(t, arbiter.Validate("ada", arbiter.NamedRule[string]("test_username")))
+	assert.ErrorIs(t, arbiter.Validate("a", arbiter.NamedRule[string]("test_username")), errUsername)
+	assert.EqualError(t, arbiter.Validate(1, arbiter.NamedRule[int]("test_username")),
+		"rule test_username is not registered for int")
+	assert.Equal(t, "test_username", rule.Describe(arbiter.NamedRule[string]("test_username")).Name)
+
+	_, err = arbiter.For[testProduct]().Rules("Quantity", "test_username").Build()
+	assert.Error(t, err)
+	_, err = arbiter.For[testProduct]().Rules("Name", "test_username=1").Build()
+	assert.Error(t, err)
+	assert.Error(t, arbiter.RegisterFunc[string]("nil_func", nil))
+	assert.Error(t, arbiter.RegisterFunc("a b", func(string) error { return nil }))
+}
+
+func TestSchemaRulesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		spec  string
+	}{
+		{name: "unknown rule", field: "Name", spec: "required,shiny"},
+		{name: "missing parameter", field: "Quantity", spec: "min"},
+		{name: "too many parameters", field: "Quantity", spec: "between=1 2 3"},
+		{name: "invalid number", field: "Quantity", spec: "max=ten"},
+		{name: "out of range number", field: "Stock", spec: "max=300"},
+		{name: "invalid length", field: "Name", spec: "len=1"},
+		{name: "string rule on number", field: "Quantity", spec: "email"},
+		{name: "unsupported type", field: "Tags", spec: "required"},
+		{name: "unknown field", field: "Price", spec: "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := arbiter.For[testProduct]().Rules(tt.field, tt.spec).Build()
+			assert.Error(t, err)
+		})
+	}
+
+	// Factory errors are wrapped
+	sentinel := errors.New("bad parameter")
+	assert.NoError(t, arbiter.RegisterRule("test_fail", func(reflect.Type, []string) (any, error) { return nil, sentinel }))
+	_, err := arbiter.For[testProduct]().Rules("Name", "test_fail").Build()
+	assert.ErrorIs(t, err, sentinel)
+}
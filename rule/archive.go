@@ -0,0 +1,291 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the ZipArchive rule, which checks uploaded archives before extraction.
+package rule
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"strings"
+)
+
+// ErrZipArchive is returned when a file is not a valid zip archive or is unsafe to extract.
+var ErrZipArchive = newError("zip_archive", "file is not a valid or safe zip archive")
+
+// defaultMaxZipFileSize is the default limit of the size of the archive file itself.
+const defaultMaxZipFileSize = 100 << 20
+
+// ZipArchiveRule validates a zip archive before it is extracted, protecting against
+// zip bombs and path traversal ("zip slip"). Without size, ratio or depth limits only the
+// central directory is read. With them, the entries are decompressed (and discarded) and
+// the limits are enforced on the bytes actually decompressed, so an archive cannot evade
+// them by declaring false sizes. Entries whose path contains ".." are always rejected.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxEntries(1000).MaxUncompressedSize(100 << 20).MaxCompressionRatio(100)
+//	err := rule.Validate(file)  // returns nil if the archive is safe to extract
+type ZipArchiveRule struct {
+	maxFileSize     int64
+	maxEntries      int
+	maxSize         uint64
+	maxRatio        float64
+	maxDepth        int
+	checkDepth      bool
+	noAbsolutePaths bool
+	e               error
+}
+
+// ZipArchive creates a new zip archive validation rule. Without options it only checks
+// that the file is a readable zip archive without path traversal; use the options to
+// set limits appropriate for the upload.
+//
+// Example:
+//
+//	rule := ZipArchive().
+//	    MaxEntries(500).
+//	    MaxUncompressedSize(50 << 20).
+//	    MaxCompressionRatio(50).
+//	    NoAbsolutePaths().
+//	    Errf("Archive is too large or contains invalid paths")
+func ZipArchive() *ZipArchiveRule {
+	return &ZipArchiveRule{
+		maxFileSize: defaultMaxZipFileSize,
+		e:           ErrZipArchive,
+	}
+}
+
+// MaxFileSize limits the size of the archive file itself in bytes, which is 100MB by
+// default. Values less than or equal to zero are ignored.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxFileSize(10 << 20)  // 10MB
+func (r *ZipArchiveRule) MaxFileSize(n int64) *ZipArchiveRule {
+	if n > 0 {
+		r.maxFileSize = n
+	}
+	return r
+}
+
+// MaxEntries limits the number of files and directories in the archive.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxEntries(1000)
+func (r *ZipArchiveRule) MaxEntries(n int) *ZipArchiveRule {
+	r.maxEntries = n
+	return r
+}
+
+// MaxUncompressedSize limits the total uncompressed size of all entries in bytes,
+// including the entries of nested archives checked with MaxDepth.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxUncompressedSize(100 << 20)  // 100MB
+func (r *ZipArchiveRule) MaxUncompressedSize(n uint64) *ZipArchiveRule {
+	r.maxSize = n
+	return r
+}
+
+// MaxCompressionRatio limits the ratio of uncompressed to compressed size of each entry.
+// Ordinary files rarely compress better than 20:1, while zip bombs reach ratios above 1000:1.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxCompressionRatio(100)
+func (r *ZipArchiveRule) MaxCompressionRatio(ratio float64) *ZipArchiveRule {
+	r.maxRatio = ratio
+	return r
+}
+
+// MaxDepth limits the nesting of zip archives, such as a zip file inside the archive.
+// Nested archives are recognized by their content rather than their name, so office
+// documents (.docx, .xlsx) and Java archives (.jar) count as nested archives too. Nested
+// archives within the limit are checked with the same rules, and their entries count
+// towards MaxEntries and MaxUncompressedSize. MaxDepth(0) rejects any nested archive;
+// without MaxDepth nested archives are not inspected.
+//
+// Example:
+//
+//	rule := ZipArchive().MaxUncompressedSize(100 << 20).MaxDepth(1)
+func (r *ZipArchiveRule) MaxDepth(n int) *ZipArchiveRule {
+	r.maxDepth = max(n, 0)
+	r.checkDepth = true
+	return r
+}
+
+// NoAbsolutePaths rejects entries with absolute paths, such as "/etc/cron.d/job",
+// "\\server\share\file" or "C:\Windows\file".
+//
+// Example:
+//
+//	rule := ZipArchive().NoAbsolutePaths()
+func (r *ZipArchiveRule) NoAbsolutePaths() *ZipArchiveRule {
+	r.noAbsolutePaths = true
+	return r
+}
+
+// Validate checks if the file is a zip archive within the configured limits.
+// Files implementing io.ReaderAt and io.Seeker, such as *os.File and multipart.File,
+// are read in place and their read position is restored; other readers are read into
+// memory, up to the limit of MaxFileSize.
+//
+// Example:
+//
+//	file, _ := os.Open("upload.zip")
+//	defer file.Close()
+//	rule := ZipArchive().MaxEntries(100)
+//	err := rule.Validate(file)  // returns nil if the archive has at most 100 entries
+func (r *ZipArchiveRule) Validate(file io.Reader) error {
+	limit := Ternary(r.maxFileSize > 0, r.maxFileSize, defaultMaxZipFileSize)
+	reader, size, err := readerAt(file, limit)
+	if err != nil {
+		return err
+	}
+	var archive *zip.Reader
+	if size <= limit {
+		archive, err = zip.NewReader(reader, size)
+	}
+	if archive == nil || err != nil || !r.safe(archive, 0, &zipTotals{}) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrZipArchive
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ZipArchiveRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrZipArchive)
+}
+
+// readerAt returns file as an io.ReaderAt with its size, reading it into memory if needed.
+// At most limit+1 bytes are read into memory, so a size above limit means the file is larger.
+func readerAt(file io.Reader, limit int64) (io.ReaderAt, int64, error) {
+	if f, ok := file.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		current, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, 0, err
+		}
+		size, err := f.Seek(0, io.SeekEnd)
+		if _, serr := f.Seek(current, io.SeekStart); err == nil {
+			err = serr
+		}
+		return f, size, err
+	}
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(data), int64(len(data)), nil
+}
+
+// zipTotals counts the entries and decompressed bytes of an archive and its nested archives.
+type zipTotals struct {
+	entries int
+	size    uint64
+}
+
+// safe reports whether the archive's entries are within the configured limits.
+// depth is the nesting depth of the archive, which is 0 for the validated file.
+func (r *ZipArchiveRule) safe(archive *zip.Reader, depth int, totals *zipTotals) bool {
+	totals.entries += len(archive.File)
+	if r.maxEntries > 0 && totals.entries > r.maxEntries {
+		return false
+	}
+	for _, f := range archive.File {
+		if !r.safePath(f.Name) {
+			return false
+		}
+	}
+	if r.maxSize == 0 && r.maxRatio == 0 && !r.checkDepth {
+		return true
+	}
+	for _, f := range archive.File {
+		if !r.safeEntry(f, depth, totals) {
+			return false
+		}
+	}
+	return true
+}
+
+// safeEntry decompresses an entry and reports whether the bytes actually decompressed are
+// within the size and ratio limits and, if it is a nested archive, whether it is safe.
+func (r *ZipArchiveRule) safeEntry(f *zip.File, depth int, totals *zipTotals) bool {
+	limit := uint64(math.MaxInt64 - 1)
+	if r.maxSize > 0 {
+		limit = r.maxSize - min(totals.size, r.maxSize)
+	}
+	if r.maxRatio > 0 {
+		limit = min(limit, uint64(r.maxRatio*float64(f.CompressedSize64)))
+	}
+	// Reject declared sizes over the limits without decompressing.
+	if f.UncompressedSize64 > limit {
+		return false
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false
+	}
+	defer rc.Close()
+
+	content := bufio.NewReader(rc)
+	var buf bytes.Buffer
+	dst := io.Discard
+	if magic, _ := content.Peek(4); r.checkDepth && string(magic) == "PK\x03\x04" {
+		// Nested archives are read into memory, like a validated file that is not seekable.
+		if depth >= r.maxDepth {
+			return false
+		}
+		limit = min(limit, uint64(Ternary(r.maxFileSize > 0, r.maxFileSize, defaultMaxZipFileSize)))
+		dst = &buf
+	}
+	n, err := io.Copy(dst, io.LimitReader(content, int64(limit)+1))
+	if err != nil || uint64(n) > limit {
+		return false
+	}
+	totals.size += uint64(n)
+	if dst == io.Discard {
+		return true
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), n)
+	return err == nil && r.safe(archive, depth+1, totals)
+}
+
+// safePath reports whether an entry name is free of path traversal and, if configured,
+// is a relative path.
+func (r *ZipArchiveRule) safePath(name string) bool {
+	normalized := strings.ReplaceAll(name, `\`, "/")
+	for _, part := range strings.Split(normalized, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	if !r.noAbsolutePaths {
+		return true
+	}
+	isDrive := len(name) >= 2 && name[1] == ':' &&
+		(name[0] >= 'a' && name[0] <= 'z' || name[0] >= 'A' && name[0] <= 'Z')
+	return !strings.HasPrefix(normalized, "/") && !isDrive
+}
+
+// Errf sets a custom error message for zip archive validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := ZipArchive().Errf("Please upload a smaller archive")
+func (r *ZipArchiveRule) Errf(format string, args ...any) *ZipArchiveRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
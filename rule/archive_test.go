@@ -0,0 +1,136 @@
+package rule
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildZip creates a zip archive with the given entries.
+func buildZip(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// buildLyingZip creates a zip archive with an entry declaring a smaller uncompressed size
+// than its content.
+func buildLyingZip(t *testing.T, content []byte, declared uint64) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	fw, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	fw.Write(content)
+	fw.Close()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.CreateRaw(&zip.FileHeader{
+		Name:               "zeros.bin",
+		Method:             zip.Deflate,
+		CRC32:              crc32.ChecksumIEEE(content),
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: declared,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(compressed.Bytes())
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestZipArchive(t *testing.T) {
+	normal := buildZip(t, map[string][]byte{"docs/readme.txt": []byte("hello"), "main.go": []byte("package main")})
+	bomb := buildZip(t, map[string][]byte{"zeros.bin": make([]byte, 1<<20)})
+	lying := buildLyingZip(t, make([]byte, 1<<20), 100)
+	nested := buildZip(t, map[string][]byte{"inner.zip": normal})
+	nested2 := buildZip(t, map[string][]byte{"outer.zip": nested})
+	nestedBomb := buildZip(t, map[string][]byte{"inner.zip": bomb})
+
+	tests := []struct {
+		name    string
+		rule    *ZipArchiveRule
+		value   []byte
+		wantErr bool
+	}{
+		{name: "valid: normal archive", rule: ZipArchive(), value: normal, wantErr: false},
+		{name: "valid: within limits", rule: ZipArchive().MaxEntries(2).MaxUncompressedSize(100).MaxCompressionRatio(10), value: normal, wantErr: false},
+		{name: "invalid: not a zip", rule: ZipArchive(), value: []byte("%PDF-1.4"), wantErr: true},
+		{name: "invalid: too many entries", rule: ZipArchive().MaxEntries(1), value: normal, wantErr: true},
+		{name: "invalid: too large", rule: ZipArchive().MaxUncompressedSize(1 << 19), value: bomb, wantErr: true},
+		{name: "invalid: compression ratio", rule: ZipArchive().MaxCompressionRatio(100), value: bomb, wantErr: true},
+		{name: "valid: bomb without limits", rule: ZipArchive(), value: bomb, wantErr: false},
+		{name: "invalid: false declared size", rule: ZipArchive().MaxUncompressedSize(1 << 19), value: lying, wantErr: true},
+		{name: "invalid: false declared ratio", rule: ZipArchive().MaxCompressionRatio(100), value: lying, wantErr: true},
+		{name: "invalid: archive file too large", rule: ZipArchive().MaxFileSize(100), value: normal, wantErr: true},
+		{name: "valid: nested archive not inspected", rule: ZipArchive(), value: nested2, wantErr: false},
+		{name: "valid: nested archive within depth", rule: ZipArchive().MaxDepth(1), value: nested, wantErr: false},
+		{name: "invalid: nested archive", rule: ZipArchive().MaxDepth(0), value: nested, wantErr: true},
+		{name: "invalid: nested archive too deep", rule: ZipArchive().MaxDepth(1), value: nested2, wantErr: true},
+		{name: "invalid: nested archive entries", rule: ZipArchive().MaxDepth(1).MaxEntries(2), value: nested, wantErr: true},
+		{name: "invalid: nested archive too large", rule: ZipArchive().MaxDepth(1).MaxUncompressedSize(1 << 19), value: nestedBomb, wantErr: true},
+		{name: "invalid: path traversal", rule: ZipArchive(), value: buildZip(t, map[string][]byte{"../../etc/passwd": nil}), wantErr: true},
+		{name: "invalid: windows path traversal", rule: ZipArchive(), value: buildZip(t, map[string][]byte{`a\..\..\evil.dll`: nil}), wantErr: true},
+		{name: "valid: absolute path allowed by default", rule: ZipArchive(), value: buildZip(t, map[string][]byte{"/etc/cron.d/job": nil}), wantErr: false},
+		{name: "invalid: absolute path", rule: ZipArchive().NoAbsolutePaths(), value: buildZip(t, map[string][]byte{"/etc/cron.d/job": nil}), wantErr: true},
+		{name: "invalid: drive letter", rule: ZipArchive().NoAbsolutePaths(), value: buildZip(t, map[string][]byte{`C:\Windows\evil.dll`: nil}), wantErr: true},
+		{name: "valid: dots in file name", rule: ZipArchive().NoAbsolutePaths(), value: buildZip(t, map[string][]byte{"a..b/c.txt": nil}), wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(bytes.NewReader(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ZipArchiveRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestZipArchiveReaders(t *testing.T) {
+	data := buildZip(t, map[string][]byte{"a.txt": []byte("a")})
+
+	path := filepath.Join(t.TempDir(), "a.zip")
+	assert.Nil(t, os.WriteFile(path, data, 0o600))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.Nil(t, ZipArchive().Validate(file))
+	content, _ := io.ReadAll(file)
+	assert.Equal(t, data, content)
+
+	assert.Nil(t, ZipArchive().Validate(&nonSeekerReader{data: data}))
+	assert.ErrorIs(t, ZipArchive().MaxFileSize(10).Validate(&nonSeekerReader{data: data}), ErrZipArchive)
+}
+
+func TestZipArchiveErrf(t *testing.T) {
+	err := ZipArchive().Errf("custom archive error").Validate(bytes.NewReader([]byte("nope")))
+	assert.EqualError(t, err, "custom archive error")
+}
+
+func TestZipArchiveFallback(t *testing.T) {
+	err := (&ZipArchiveRule{}).Validate(bytes.NewReader([]byte("nope")))
+	assert.ErrorIs(t, err, ErrZipArchive)
+}
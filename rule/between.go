@@ -1,9 +1,8 @@
 // Package rule provides a collection of validation rules for various data types.
 package rule
 
-import (
-	"fmt"
-)
+// ErrBetween is returned when a value is not within the range. Its message includes the range.
+var ErrBetween = newError("between", "value is not between minimum and maximum")
 
 const (
 	ErrBetweenFormat = "is not between %v and %v"
@@ -22,9 +21,11 @@ const (
 //	err = rule.Validate(0.5)  // returns nil
 //	err = rule.Validate(1.5)  // returns ErrBetween
 type BetweenRule[T Ordered] struct {
-	min T
-	max T
-	e   error
+	min          T
+	max          T
+	exclusiveMin bool
+	exclusiveMax bool
+	e            error
 }
 
 // Between creates a new range validation rule with the specified minimum and maximum values.
@@ -41,7 +42,6 @@ func Between[T Ordered](min, max T) *BetweenRule[T] {
 	return &BetweenRule[T]{
 		min: min,
 		max: max,
-		e:   fmt.Errorf(ErrBetweenFormat, min, max),
 	}
 }
 
@@ -54,8 +54,56 @@ func Between[T Ordered](min, max T) *BetweenRule[T] {
 //	err := rule.Validate(15)  // returns error with message "Age must be between 1 and 10"
 func (r *BetweenRule[T]) Errf(format string, args ...any) *BetweenRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(ErrBetween, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Between(1, 10).Params()  // map[string]any{"min": 1, "max": 10}
+func (r *BetweenRule[T]) Params() map[string]any {
+	return map[string]any{"min": r.min, "max": r.max}
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule, minimum and maximum, or
+// exclusiveMinimum and exclusiveMaximum for excluded bounds.
+//
+// Example:
+//
+//	keywords := Between(1, 10).JSONSchema()  // map[string]any{"minimum": 1, "maximum": 10}
+func (r *BetweenRule[T]) JSONSchema() map[string]any {
+	return map[string]any{
+		Ternary(r.exclusiveMin, "exclusiveMinimum", "minimum"): r.min,
+		Ternary(r.exclusiveMax, "exclusiveMaximum", "maximum"): r.max,
 	}
+}
+
+// ExclusiveMin excludes the minimum from the range, so values must be strictly greater than min.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	// Half-open interval (0, 1]
+//	rule := Between[float64](0, 1).ExclusiveMin()
+//	err := rule.Validate(0)  // returns error
+func (r *BetweenRule[T]) ExclusiveMin() *BetweenRule[T] {
+	r.exclusiveMin = true
+	return r
+}
+
+// ExclusiveMax excludes the maximum from the range, so values must be strictly less than max.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	// Half-open interval [0, 100)
+//	rule := Between[int](0, 100).ExclusiveMax()
+//	err := rule.Validate(100)  // returns error
+func (r *BetweenRule[T]) ExclusiveMax() *BetweenRule[T] {
+	r.exclusiveMax = true
 	return r
 }
 
@@ -69,11 +117,16 @@ func (r *BetweenRule[T]) Errf(format string, args ...any) *BetweenRule[T] {
 //	    // Handle validation error
 //	}
 func (r *BetweenRule[T]) Validate(value T) error {
-	if value < r.min || value > r.max {
+	if value < r.min || value > r.max || (r.exclusiveMin && value == r.min) || (r.exclusiveMax && value == r.max) {
 		if r.e != nil {
 			return r.e
 		}
-		return fmt.Errorf("value %v is not between %v and %v", value, r.min, r.max)
+		return formatError(ErrBetween, ErrBetweenFormat, r.min, r.max)
 	}
 	return nil
 }
+
+// Describe returns the name, parameters and message of the rule.
+func (r *BetweenRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrBetween)
+}
@@ -13,29 +13,49 @@ func TestBetweenFallback(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBetweenLazyError(t *testing.T) {
+	assert.EqualError(t, Between[int](1, 10).Validate(11), "is not between 1 and 10")
+	assert.EqualError(t, MultipleOf(3).Validate(4), "is not a multiple of 3")
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = Between[int](1, 10).Validate(5) }))
+}
+
 func TestBetweenRule(t *testing.T) {
 	err := Between(3, 10).Validate(2)
-	assert.Equal(t, fmt.Errorf(ErrBetweenFormat, 3, 10), err)
+	assert.EqualError(t, err, fmt.Sprintf(ErrBetweenFormat, 3, 10))
 
 	err = Between(3, 10).Validate(5)
 	assert.Nil(t, err)
 
 	err = Between(3, 10).Validate(11)
-	assert.Equal(t, fmt.Errorf(ErrBetweenFormat, 3, 10), err)
+	assert.EqualError(t, err, fmt.Sprintf(ErrBetweenFormat, 3, 10))
 
 	err = Between(3.0, 10.0).Validate(2.5)
-	assert.Equal(t, fmt.Errorf(ErrBetweenFormat, 3.0, 10.0), err)
+	assert.EqualError(t, err, fmt.Sprintf(ErrBetweenFormat, 3.0, 10.0))
 
 	err = Between(3.0, 10.0).Validate(5.5)
 	assert.Nil(t, err)
 
 	err = Between(3.0, 10.0).Validate(10.5)
-	assert.Equal(t, fmt.Errorf(ErrBetweenFormat, 3.0, 10.0), err)
+	assert.EqualError(t, err, fmt.Sprintf(ErrBetweenFormat, 3.0, 10.0))
 
 	customErr := Between(3, 10).Errf("invalid range").Validate(2)
 	assert.Equal(t, "invalid range", customErr.Error())
 }
 
+func TestBetweenExclusive(t *testing.T) {
+	assert.Nil(t, Between(3, 10).Validate(3))
+	assert.Nil(t, Between(3, 10).Validate(10))
+
+	assert.Error(t, Between(3, 10).ExclusiveMin().Validate(3))
+	assert.Nil(t, Between(3, 10).ExclusiveMin().Validate(10))
+
+	assert.Nil(t, Between(3, 10).ExclusiveMax().Validate(3))
+	assert.Error(t, Between(3, 10).ExclusiveMax().Validate(10))
+
+	assert.Error(t, Between(0.0, 1.0).ExclusiveMin().ExclusiveMax().Validate(1.0))
+	assert.Nil(t, Between(0.0, 1.0).ExclusiveMin().ExclusiveMax().Validate(0.5))
+}
+
 func BenchmarkBetweenRule(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
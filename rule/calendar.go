@@ -0,0 +1,343 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains calendar rules for validating leap years, days of the month,
+// quarters and ISO weeks.
+package rule
+
+import (
+	"slices"
+	"time"
+)
+
+// Calendar validation errors
+var (
+	// ErrLeapYear is returned when a time does not fall in a leap year.
+	ErrLeapYear = newError("leap_year", "time must be in a leap year")
+
+	// ErrEndOfMonth is returned when a time does not fall on the last day of its month.
+	ErrEndOfMonth = newError("end_of_month", "time must be the last day of the month")
+
+	// ErrDayOfMonth is returned when a time does not fall on one of the allowed days of the month.
+	ErrDayOfMonth = newError("day_of_month", "time is not on an allowed day of the month")
+
+	// ErrQuarter is returned when a time does not fall in one of the allowed quarters.
+	ErrQuarter = newError("quarter", "time is not in an allowed quarter")
+
+	// ErrISOWeek is returned when a time does not fall in one of the allowed ISO 8601 weeks.
+	ErrISOWeek = newError("iso_week", "time is not in an allowed ISO week")
+)
+
+// isLeapYear reports whether year is a leap year in the Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// LeapYearRule validates that a time falls in a leap year.
+//
+// Example:
+//
+//	rule := LeapYear()
+//	err := rule.Validate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC))   // returns error
+type LeapYearRule struct {
+	e error
+}
+
+// LeapYear creates a new leap year validation rule.
+// Leap years follow the Gregorian calendar: divisible by 4, except centuries not divisible by 400.
+//
+// Example:
+//
+//	rule := LeapYear().Errf("Year must be a leap year")
+func LeapYear() *LeapYearRule {
+	return &LeapYearRule{
+		e: ErrLeapYear,
+	}
+}
+
+// Validate checks if the given time falls in a leap year.
+//
+// Example:
+//
+//	rule := LeapYear()
+//	err := rule.Validate(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))   // returns error
+func (r *LeapYearRule) Validate(value time.Time) error {
+	if !isLeapYear(value.Year()) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrLeapYear
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *LeapYearRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrLeapYear)
+}
+
+// Errf sets a custom error message for leap year validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := LeapYear().Errf("February 29th is only available in leap years")
+func (r *LeapYearRule) Errf(format string, args ...any) *LeapYearRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// EndOfMonthRule validates that a time falls on the last day of its month.
+//
+// Example:
+//
+//	rule := EndOfMonth()
+//	err := rule.Validate(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC))   // returns nil
+//	err = rule.Validate(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC))   // returns error
+type EndOfMonthRule struct {
+	e error
+}
+
+// EndOfMonth creates a new end-of-month validation rule, e.g. for month-end settlement dates.
+//
+// Example:
+//
+//	type Invoice struct {
+//	    PeriodEnd time.Time
+//	}
+//
+//	rule := EndOfMonth().Errf("Billing period must end on the last day of a month")
+func EndOfMonth() *EndOfMonthRule {
+	return &EndOfMonthRule{
+		e: ErrEndOfMonth,
+	}
+}
+
+// Validate checks if the given time falls on the last day of its month.
+//
+// Example:
+//
+//	rule := EndOfMonth()
+//	err := rule.Validate(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC))   // returns error
+func (r *EndOfMonthRule) Validate(value time.Time) error {
+	if value.AddDate(0, 0, 1).Day() != 1 {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrEndOfMonth
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *EndOfMonthRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrEndOfMonth)
+}
+
+// Errf sets a custom error message for end-of-month validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := EndOfMonth().Errf("Settlement date must be the last day of the month")
+func (r *EndOfMonthRule) Errf(format string, args ...any) *EndOfMonthRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// DayOfMonthRule validates that a time falls on one of the allowed days of the month.
+//
+// Example:
+//
+//	rule := DayOfMonth(1, 15)
+//	err := rule.Validate(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC))   // returns error
+type DayOfMonthRule struct {
+	days []int
+	e    error
+}
+
+// DayOfMonth creates a new day-of-month validation rule for billing-cycle style inputs.
+// Days are 1-based; a day that does not exist in a month (e.g. 31 in April) never matches,
+// so combine with EndOfMonth using Or if month-end dates should also be accepted.
+//
+// Example:
+//
+//	type Subscription struct {
+//	    ChargeDate time.Time
+//	}
+//
+//	rule := DayOfMonth(1, 15).Errf("Charge date must be the 1st or 15th")
+func DayOfMonth(allowed ...int) *DayOfMonthRule {
+	return &DayOfMonthRule{
+		days: append([]int{}, allowed...),
+		e:    ErrDayOfMonth,
+	}
+}
+
+// Validate checks if the given time falls on one of the allowed days of the month.
+//
+// Example:
+//
+//	rule := DayOfMonth(1, 15)
+//	err := rule.Validate(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))   // returns error
+func (r *DayOfMonthRule) Validate(value time.Time) error {
+	if !slices.Contains(r.days, value.Day()) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrDayOfMonth
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DayOfMonthRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDayOfMonth)
+}
+
+// Errf sets a custom error message for day-of-month validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := DayOfMonth(1).Errf("Rent is due on the 1st")
+func (r *DayOfMonthRule) Errf(format string, args ...any) *DayOfMonthRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// QuarterRule validates that a time falls in one of the allowed calendar quarters.
+//
+// Example:
+//
+//	rule := Quarter(1, 2)
+//	err := rule.Validate(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))  // returns nil (Q2)
+//	err = rule.Validate(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC))   // returns error (Q3)
+type QuarterRule struct {
+	quarters []int
+	e        error
+}
+
+// Quarter creates a new quarter validation rule for reporting-period inputs.
+// Quarters are numbered 1 to 4, Q1 being January to March.
+//
+// Example:
+//
+//	type Report struct {
+//	    PeriodStart time.Time
+//	}
+//
+//	rule := Quarter(4).Errf("Annual report must start in Q4")
+func Quarter(q ...int) *QuarterRule {
+	return &QuarterRule{
+		quarters: append([]int{}, q...),
+		e:        ErrQuarter,
+	}
+}
+
+// Validate checks if the given time falls in one of the allowed quarters.
+//
+// Example:
+//
+//	rule := Quarter(1)
+//	err := rule.Validate(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))    // returns error
+func (r *QuarterRule) Validate(value time.Time) error {
+	if !slices.Contains(r.quarters, (int(value.Month())-1)/3+1) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrQuarter
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *QuarterRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrQuarter)
+}
+
+// Errf sets a custom error message for quarter validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := Quarter(1, 2).Errf("Only the first half of the year is open")
+func (r *QuarterRule) Errf(format string, args ...any) *QuarterRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// ISOWeekRule validates that a time falls in one of the allowed ISO 8601 week numbers.
+//
+// Example:
+//
+//	rule := ISOWeek(1)
+//	err := rule.Validate(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC))  // returns nil (week 1 of 2025)
+//	err = rule.Validate(time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC))   // returns error (week 52)
+type ISOWeekRule struct {
+	weeks []int
+	e     error
+}
+
+// ISOWeek creates a new ISO week validation rule.
+// Week numbers are those returned by time.Time.ISOWeek (1 to 53); weeks start on Monday
+// and week 1 is the week containing the first Thursday of the year, so dates near
+// the turn of the year may belong to a week of the adjacent year.
+//
+// Example:
+//
+//	rule := ISOWeek(26, 27).Errf("Delivery must be scheduled in week 26 or 27")
+func ISOWeek(weeks ...int) *ISOWeekRule {
+	return &ISOWeekRule{
+		weeks: append([]int{}, weeks...),
+		e:     ErrISOWeek,
+	}
+}
+
+// Validate checks if the given time falls in one of the allowed ISO weeks.
+//
+// Example:
+//
+//	rule := ISOWeek(1)
+//	err := rule.Validate(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))  // returns nil
+//	err = rule.Validate(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))   // returns error (week 2)
+func (r *ISOWeekRule) Validate(value time.Time) error {
+	if _, week := value.ISOWeek(); !slices.Contains(r.weeks, week) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrISOWeek
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ISOWeekRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrISOWeek)
+}
+
+// Errf sets a custom error message for ISO week validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := ISOWeek(1).Errf("Kick-off must be in the first week of the year")
+func (r *ISOWeekRule) Errf(format string, args ...any) *ISOWeekRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,146 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func calendarDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestLeapYear(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *LeapYearRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: divisible by 4", rule: LeapYear(), value: calendarDay(2024, time.March, 1), wantErr: false},
+		{name: "valid: divisible by 400", rule: LeapYear(), value: calendarDay(2000, time.March, 1), wantErr: false},
+		{name: "invalid: century", rule: LeapYear(), value: calendarDay(1900, time.March, 1), wantErr: true},
+		{name: "invalid: common year", rule: LeapYear(), value: calendarDay(2023, time.March, 1), wantErr: true},
+		{name: "custom error message", rule: LeapYear().Errf("custom error"), value: calendarDay(2023, time.March, 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LeapYearRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEndOfMonth(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *EndOfMonthRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: leap February", rule: EndOfMonth(), value: calendarDay(2024, time.February, 29), wantErr: false},
+		{name: "valid: common February", rule: EndOfMonth(), value: calendarDay(2023, time.February, 28), wantErr: false},
+		{name: "valid: 30-day month", rule: EndOfMonth(), value: calendarDay(2024, time.April, 30), wantErr: false},
+		{name: "valid: December", rule: EndOfMonth(), value: calendarDay(2024, time.December, 31), wantErr: false},
+		{name: "invalid: leap February 28th", rule: EndOfMonth(), value: calendarDay(2024, time.February, 28), wantErr: true},
+		{name: "invalid: first day", rule: EndOfMonth(), value: calendarDay(2024, time.May, 1), wantErr: true},
+		{name: "custom error message", rule: EndOfMonth().Errf("custom error"), value: calendarDay(2024, time.May, 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EndOfMonthRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDayOfMonth(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *DayOfMonthRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: first", rule: DayOfMonth(1, 15), value: calendarDay(2024, time.May, 1), wantErr: false},
+		{name: "valid: fifteenth", rule: DayOfMonth(1, 15), value: calendarDay(2024, time.May, 15), wantErr: false},
+		{name: "invalid: other day", rule: DayOfMonth(1, 15), value: calendarDay(2024, time.May, 16), wantErr: true},
+		{name: "invalid: no days allowed", rule: DayOfMonth(), value: calendarDay(2024, time.May, 1), wantErr: true},
+		{name: "custom error message", rule: DayOfMonth(1).Errf("custom error"), value: calendarDay(2024, time.May, 2), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DayOfMonthRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	billing := Or[time.Time](DayOfMonth(1, 15), EndOfMonth())
+	assert.Nil(t, billing.Validate(calendarDay(2024, time.April, 30)))
+	assert.Error(t, billing.Validate(calendarDay(2024, time.April, 29)))
+}
+
+func TestQuarter(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *QuarterRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: last day of Q1", rule: Quarter(1), value: calendarDay(2024, time.March, 31), wantErr: false},
+		{name: "valid: one of several", rule: Quarter(2, 4), value: calendarDay(2024, time.November, 1), wantErr: false},
+		{name: "invalid: first day of Q2", rule: Quarter(1), value: calendarDay(2024, time.April, 1), wantErr: true},
+		{name: "invalid: out of range quarter", rule: Quarter(5), value: calendarDay(2024, time.December, 1), wantErr: true},
+		{name: "custom error message", rule: Quarter(1).Errf("custom error"), value: calendarDay(2024, time.July, 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuarterRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *ISOWeekRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: first week", rule: ISOWeek(1), value: calendarDay(2024, time.January, 3), wantErr: false},
+		{name: "valid: week 1 of next year", rule: ISOWeek(1), value: calendarDay(2024, time.December, 30), wantErr: false},
+		{name: "valid: week 53", rule: ISOWeek(53), value: calendarDay(2021, time.January, 1), wantErr: false},
+		{name: "invalid: week 52", rule: ISOWeek(1), value: calendarDay(2024, time.December, 29), wantErr: true},
+		{name: "custom error message", rule: ISOWeek(1).Errf("custom error"), value: calendarDay(2024, time.January, 8), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ISOWeekRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalendarFallback(t *testing.T) {
+	assert.ErrorIs(t, (&LeapYearRule{}).Validate(calendarDay(2023, time.January, 1)), ErrLeapYear)
+	assert.ErrorIs(t, (&EndOfMonthRule{}).Validate(calendarDay(2023, time.January, 1)), ErrEndOfMonth)
+	assert.ErrorIs(t, (&DayOfMonthRule{}).Validate(calendarDay(2023, time.January, 1)), ErrDayOfMonth)
+	assert.ErrorIs(t, (&QuarterRule{}).Validate(calendarDay(2023, time.January, 1)), ErrQuarter)
+	assert.ErrorIs(t, (&ISOWeekRule{}).Validate(calendarDay(2023, time.January, 1)), ErrISOWeek)
+}
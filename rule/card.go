@@ -0,0 +1,301 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating payment card numbers and expiry dates.
+package rule
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error variables for payment card validation
+var (
+	// ErrCreditCard is returned when a string is not a valid card number of a known brand
+	ErrCreditCard = newError("credit_card", "invalid credit card number")
+	// ErrCardBrand is returned when a card number is valid but its brand is not accepted
+	ErrCardBrand = newError("card_brand", "card brand is not accepted")
+	// ErrCardExpiry is returned when a card expiry date is invalid or in the past
+	ErrCardExpiry = newError("card_expiry", "card expiry date is invalid or in the past")
+)
+
+// The card brands detected by CreditCard and CardBrand.
+const (
+	BrandVisa       = "visa"
+	BrandMastercard = "mastercard"
+	BrandAmex       = "amex"
+	BrandDiscover   = "discover"
+	BrandJCB        = "jcb"
+	BrandDiners     = "diners"
+	BrandUnionPay   = "unionpay"
+	BrandMaestro    = "maestro"
+	BrandMir        = "mir"
+)
+
+// cardPrefix is a range of card number prefixes with the same number of digits, such
+// as 51 to 55.
+type cardPrefix struct {
+	lo, hi, digits int
+}
+
+// cardBrand is the issuer identification number ranges and number lengths of a brand.
+type cardBrand struct {
+	name     string
+	prefixes []cardPrefix
+	lengths  []int
+}
+
+// cardBrands are the brands detected by CardBrand, with more specific prefixes first.
+var cardBrands = []cardBrand{
+	{name: BrandAmex, prefixes: []cardPrefix{{34, 34, 2}, {37, 37, 2}}, lengths: []int{15}},
+	{name: BrandDiners, prefixes: []cardPrefix{{300, 305, 3}, {36, 36, 2}, {38, 39, 2}}, lengths: []int{14, 15, 16, 17, 18, 19}},
+	{name: BrandJCB, prefixes: []cardPrefix{{3528, 3589, 4}}, lengths: []int{16, 17, 18, 19}},
+	{name: BrandDiscover, prefixes: []cardPrefix{{6011, 6011, 4}, {644, 649, 3}, {65, 65, 2}}, lengths: []int{16, 17, 18, 19}},
+	{name: BrandMir, prefixes: []cardPrefix{{2200, 2204, 4}}, lengths: []int{16, 17, 18, 19}},
+	{name: BrandMastercard, prefixes: []cardPrefix{{51, 55, 2}, {2221, 2720, 4}}, lengths: []int{16}},
+	{name: BrandUnionPay, prefixes: []cardPrefix{{62, 62, 2}}, lengths: []int{16, 17, 18, 19}},
+	{name: BrandMaestro, prefixes: []cardPrefix{{6304, 6304, 4}, {6759, 6759, 4}, {676770, 676770, 6}, {676774, 676774, 6}, {50, 50, 2}, {56, 58, 2}}, lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{name: BrandVisa, prefixes: []cardPrefix{{4, 4, 1}}, lengths: []int{13, 16, 19}},
+}
+
+// CreditCardRule validates that a string is a payment card number: its prefix and
+// length must be those of a known brand and its last digit must be the Luhn check digit.
+// Spaces and dashes between the digits are ignored.
+//
+// Example:
+//
+//	rule := CreditCard()
+//	err := rule.Validate("4111 1111 1111 1111")  // returns nil
+//	err = rule.Validate("4111 1111 1111 1112")   // returns ErrCreditCard (wrong check digit)
+type CreditCardRule struct {
+	brands []string
+	e      error
+}
+
+// CreditCard creates a rule that validates card numbers of the brands in the table of
+// CardBrand.
+//
+// Example:
+//
+//	arbiter.Field(&payment.CardNumber, rule.Required[string](), rule.CreditCard())
+func CreditCard() *CreditCardRule {
+	return &CreditCardRule{}
+}
+
+// Brands restricts the rule to cards of the given brands, such as BrandVisa or
+// "unionpay". Valid numbers of other brands fail with ErrCardBrand.
+//
+// Example:
+//
+//	rule := CreditCard().Brands(BrandVisa, BrandUnionPay)
+//	err := rule.Validate("6212345678901232")  // returns nil
+//	err = rule.Validate("378282246310005")    // returns ErrCardBrand (American Express)
+func (r *CreditCardRule) Brands(brands ...string) *CreditCardRule {
+	r.brands = brands
+	return r
+}
+
+// Validate checks if the string is a valid card number of an accepted brand.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := CreditCard()
+//	err := rule.Validate("5555-5555-5555-4444")  // returns nil (Mastercard)
+//	err = rule.Validate("1234567812345670")      // returns ErrCreditCard (unknown brand)
+func (r *CreditCardRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	brand, ok := CardBrand(value)
+	if !ok {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrCreditCard
+	}
+	if len(r.brands) > 0 && !slices.Contains(r.brands, brand) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrCardBrand
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *CreditCardRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrCreditCard)
+}
+
+// Errf sets a custom error message for the validation rule, returned both for invalid
+// numbers and for brands that are not accepted.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := CreditCard().Brands(BrandVisa).Errf("Only Visa cards are accepted")
+func (r *CreditCardRule) Errf(format string, args ...any) *CreditCardRule {
+	if format != "" {
+		r.e = wrapf(ErrCreditCard, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := CreditCard().Brands(BrandVisa).Params()  // map[string]any{"brands": []string{"visa"}}
+func (r *CreditCardRule) Params() map[string]any {
+	return map[string]any{"brands": r.brands}
+}
+
+// CardBrand returns the brand of a card number, such as BrandVisa, and reports whether
+// the number is valid: its prefix and length are those of a known brand and its last
+// digit is the Luhn check digit. Spaces and dashes between the digits are ignored.
+//
+// Example:
+//
+//	brand, ok := CardBrand("4111 1111 1111 1111")  // "visa", true
+//	brand, ok = CardBrand("6200000000000005")      // "unionpay", true
+func CardBrand(number string) (string, bool) {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if !containsOnly(number, "0123456789") || !luhnValid(number) {
+		return "", false
+	}
+	for _, brand := range cardBrands {
+		if brand.matches(number) {
+			return brand.name, true
+		}
+	}
+	return "", false
+}
+
+// matches reports whether number has a prefix and length of the brand.
+func (b cardBrand) matches(number string) bool {
+	if !slices.Contains(b.lengths, len(number)) {
+		return false
+	}
+	for _, p := range b.prefixes {
+		if prefix, err := strconv.Atoi(number[:p.digits]); err == nil && prefix >= p.lo && prefix <= p.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// luhnValid reports whether the last digit of number, a non-empty string of digits, is
+// its Luhn check digit.
+func luhnValid(number string) bool {
+	if number == "" {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		d := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// CardExpiryRule validates that a string is a card expiry date in the MM/YY or MM/YYYY
+// format that has not passed. A card is valid until the end of its expiry month.
+//
+// Example:
+//
+//	rule := CardExpiry()
+//	err := rule.Validate("01/27")  // returns nil until the end of January 2027
+//	err = rule.Validate("13/27")   // returns ErrCardExpiry
+type CardExpiryRule struct {
+	now func() time.Time
+	e   error
+}
+
+// CardExpiry creates a rule that validates card expiry dates.
+//
+// Example:
+//
+//	arbiter.Field(&payment.Expiry, rule.Required[string](), rule.CardExpiry())
+func CardExpiry() *CardExpiryRule {
+	return &CardExpiryRule{now: time.Now, e: ErrCardExpiry}
+}
+
+// Clock sets the function used to obtain the current time, which is time.Now by default.
+// This is useful for deterministic tests or when validating against a reference time.
+//
+// Example:
+//
+//	ref := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+//	rule := CardExpiry().Clock(func() time.Time { return ref })
+func (r *CardExpiryRule) Clock(now func() time.Time) *CardExpiryRule {
+	if now != nil {
+		r.now = now
+	}
+	return r
+}
+
+// Validate checks if the string is a valid expiry date that has not passed.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := CardExpiry()
+//	err := rule.Validate("12/2099")  // returns nil
+//	err = rule.Validate("01/20")     // returns ErrCardExpiry (expired)
+func (r *CardExpiryRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	year, month, ok := parseCardExpiry(value)
+	now := Ternary(r.now == nil, time.Now, r.now)()
+	if !ok || year < now.Year() || year == now.Year() && month < now.Month() {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrCardExpiry
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *CardExpiryRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrCardExpiry)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := CardExpiry().Errf("Your card has expired")
+func (r *CardExpiryRule) Errf(format string, args ...any) *CardExpiryRule {
+	if format != "" {
+		r.e = wrapf(ErrCardExpiry, format, args...)
+	}
+	return r
+}
+
+// parseCardExpiry parses an expiry date in the MM/YY or MM/YYYY format. Two-digit years
+// are in the 21st century.
+func parseCardExpiry(value string) (int, time.Month, bool) {
+	mm, yy, ok := strings.Cut(value, "/")
+	if !ok || len(mm) != 2 || len(yy) != 2 && len(yy) != 4 ||
+		!containsOnly(mm, "0123456789") || !containsOnly(yy, "0123456789") {
+		return 0, 0, false
+	}
+	month, _ := strconv.Atoi(mm)
+	year, _ := strconv.Atoi(yy)
+	if month < 1 || month > 12 {
+		return 0, 0, false
+	}
+	if len(yy) == 2 {
+		year += 2000
+	}
+	return year, time.Month(month), true
+}
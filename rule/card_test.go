@@ -0,0 +1,107 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+		wantOK bool
+	}{
+		{number: "4111111111111111", want: BrandVisa, wantOK: true},
+		{number: "4111 1111 1111 1111", want: BrandVisa, wantOK: true},
+		{number: "5555-5555-5555-4444", want: BrandMastercard, wantOK: true},
+		{number: "2223003122003222", want: BrandMastercard, wantOK: true},
+		{number: "378282246310005", want: BrandAmex, wantOK: true},
+		{number: "6011111111111117", want: BrandDiscover, wantOK: true},
+		{number: "3530111333300000", want: BrandJCB, wantOK: true},
+		{number: "30569309025904", want: BrandDiners, wantOK: true},
+		{number: "6200000000000005", want: BrandUnionPay, wantOK: true},
+		{number: "6759649826438453", want: BrandMaestro, wantOK: true},
+		{number: "2200000000000004", want: BrandMir, wantOK: true},
+		{number: "4111111111111112"},
+		{number: "1234567812345670"},
+		{number: "37828224631000"},
+		{number: "4111-1111-1111-111a"},
+		{number: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			brand, ok := CardBrand(tt.number)
+			assert.Equal(t, tt.want, brand)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestCreditCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *CreditCardRule
+		value   string
+		wantErr error
+	}{
+		{name: "valid: any brand", rule: CreditCard(), value: "378282246310005"},
+		{name: "valid: empty string", rule: CreditCard(), value: ""},
+		{name: "invalid: check digit", rule: CreditCard(), value: "4111111111111112", wantErr: ErrCreditCard},
+		{name: "invalid: unknown brand", rule: CreditCard(), value: "1234567812345670", wantErr: ErrCreditCard},
+		{name: "valid: accepted brand", rule: CreditCard().Brands(BrandVisa, "unionpay"), value: "6212345678901232"},
+		{name: "invalid: brand not accepted", rule: CreditCard().Brands(BrandVisa, "unionpay"), value: "378282246310005", wantErr: ErrCardBrand},
+		{name: "invalid: number with accepted brands", rule: CreditCard().Brands(BrandVisa), value: "4111", wantErr: ErrCreditCard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.EqualError(t, CreditCard().Brands(BrandVisa).Errf("Only Visa").Validate("378282246310005"), "Only Visa")
+	assert.Equal(t, map[string]any{"brands": []string{"visa"}}, CreditCard().Brands(BrandVisa).Params())
+}
+
+func TestCardExpiry(t *testing.T) {
+	now := func() time.Time { return time.Date(2027, 1, 31, 23, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: current month", value: "01/27"},
+		{name: "valid: next month", value: "02/27"},
+		{name: "valid: four-digit year", value: "12/2030"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: last month", value: "12/26", wantErr: true},
+		{name: "invalid: last year", value: "06/2026", wantErr: true},
+		{name: "invalid: month 13", value: "13/27", wantErr: true},
+		{name: "invalid: month 00", value: "00/27", wantErr: true},
+		{name: "invalid: no separator", value: "0127", wantErr: true},
+		{name: "invalid: single-digit month", value: "1/27", wantErr: true},
+		{name: "invalid: three-digit year", value: "01/027", wantErr: true},
+		{name: "invalid: letters", value: "ab/cd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CardExpiry().Clock(now).Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrCardExpiry)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.EqualError(t, CardExpiry().Errf("Card expired").Validate("01/20"), "Card expired")
+}
@@ -0,0 +1,229 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating formats specific to mainland China, such as
+// mobile numbers and vehicle license plates.
+package rule
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Error variables for mainland China format validation
+var (
+	// ErrChinaMobile is returned when a string is not a valid mainland China mobile number
+	ErrChinaMobile = newError("china_mobile", "invalid China mobile number")
+	// ErrChinaPlate is returned when a string is not a valid mainland China license plate
+	ErrChinaPlate = newError("china_plate", "invalid China license plate number")
+)
+
+// regexChinaPlate matches a province abbreviation and the letter of the issuing
+// authority, followed by five letters and digits for standard blue plates or by six
+// characters for new-energy green plates, whose first (small vehicles) or last (large
+// vehicles) character is the energy type letter. I and O are never used.
+var regexChinaPlate = regexp.MustCompile(`^[京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼]` +
+	`[A-HJ-NP-Z](?:[A-HJ-NP-Z0-9]{5}|[A-HJK][A-HJ-NP-Z0-9][0-9]{4}|[0-9]{5}[A-HJK])$`)
+
+// Carrier is a mainland China mobile network operator, as identified by the first three
+// digits of a mobile number.
+type Carrier string
+
+// The carriers of mainland China mobile numbers.
+const (
+	CarrierChinaMobile   Carrier = "china_mobile"
+	CarrierChinaUnicom   Carrier = "china_unicom"
+	CarrierChinaTelecom  Carrier = "china_telecom"
+	CarrierChinaBroadnet Carrier = "china_broadnet"
+	// CarrierVirtual is a mobile virtual network operator, whose numbers use the 162,
+	// 165, 167, 170 and 171 prefixes.
+	CarrierVirtual Carrier = "virtual"
+)
+
+// chinaMobilePrefixes maps the allocated three-digit prefixes of mobile numbers to their
+// carriers. Prefixes of data-only and IoT numbers, such as 140 to 144, and of satellite
+// phones are not included.
+var chinaMobilePrefixes = map[string]Carrier{
+	"130": CarrierChinaUnicom, "131": CarrierChinaUnicom, "132": CarrierChinaUnicom,
+	"133": CarrierChinaTelecom, "134": CarrierChinaMobile, "135": CarrierChinaMobile,
+	"136": CarrierChinaMobile, "137": CarrierChinaMobile, "138": CarrierChinaMobile,
+	"139": CarrierChinaMobile, "145": CarrierChinaUnicom, "146": CarrierChinaUnicom,
+	"147": CarrierChinaMobile, "148": CarrierChinaMobile, "149": CarrierChinaTelecom,
+	"150": CarrierChinaMobile, "151": CarrierChinaMobile, "152": CarrierChinaMobile,
+	"153": CarrierChinaTelecom, "155": CarrierChinaUnicom, "156": CarrierChinaUnicom,
+	"157": CarrierChinaMobile, "158": CarrierChinaMobile, "159": CarrierChinaMobile,
+	"162": CarrierVirtual, "165": CarrierVirtual, "166": CarrierChinaUnicom,
+	"167": CarrierVirtual, "170": CarrierVirtual, "171": CarrierVirtual,
+	"172": CarrierChinaMobile, "173": CarrierChinaTelecom, "175": CarrierChinaUnicom,
+	"176": CarrierChinaUnicom, "177": CarrierChinaTelecom, "178": CarrierChinaMobile,
+	"180": CarrierChinaTelecom, "181": CarrierChinaTelecom, "182": CarrierChinaMobile,
+	"183": CarrierChinaMobile, "184": CarrierChinaMobile, "185": CarrierChinaUnicom,
+	"186": CarrierChinaUnicom, "187": CarrierChinaMobile, "188": CarrierChinaMobile,
+	"189": CarrierChinaTelecom, "190": CarrierChinaTelecom, "191": CarrierChinaTelecom,
+	"192": CarrierChinaBroadnet, "193": CarrierChinaTelecom, "195": CarrierChinaMobile,
+	"196": CarrierChinaUnicom, "197": CarrierChinaMobile, "198": CarrierChinaMobile,
+	"199": CarrierChinaTelecom,
+}
+
+// ChinaMobileRule validates that a string is a mainland China mobile number: 11 digits
+// matching 1[3-9]\d{9} whose first three digits are a prefix allocated to a carrier.
+// Unlike IsPhone, it rejects numbers such as 12345678901 or 15412345678.
+//
+// Example:
+//
+//	rule := ChinaMobile()
+//	err := rule.Validate("13800138000")  // returns nil
+//	err = rule.Validate("15412345678")   // returns ErrChinaMobile (154 is not allocated)
+type ChinaMobileRule struct {
+	countryCode bool
+	carriers    []Carrier
+	e           error
+}
+
+// ChinaMobile creates a rule that validates mainland China mobile numbers. The number
+// must not have a country code or separators unless AllowCountryCode is set; use
+// NormalizeChinaMobile before the rule to store numbers in the plain 11-digit form.
+//
+// Example:
+//
+//	arbiter.Field(&user.Mobile, rule.NormalizeChinaMobile(), rule.ChinaMobile())
+func ChinaMobile() *ChinaMobileRule {
+	return &ChinaMobileRule{e: ErrChinaMobile}
+}
+
+// AllowCountryCode accepts numbers with the +86, 0086 or 86 country code, optionally
+// followed by a space or a dash, such as "+86 13800138000".
+//
+// Example:
+//
+//	rule := ChinaMobile().AllowCountryCode()
+//	err := rule.Validate("+86 13800138000")  // returns nil
+func (r *ChinaMobileRule) AllowCountryCode() *ChinaMobileRule {
+	r.countryCode = true
+	return r
+}
+
+// Carriers restricts the rule to the numbers of the given carriers.
+//
+// Example:
+//
+//	rule := ChinaMobile().Carriers(CarrierChinaMobile, CarrierChinaUnicom)
+//	err := rule.Validate("13800138000")  // returns nil
+//	err = rule.Validate("18900001111")   // returns ErrChinaMobile (China Telecom)
+func (r *ChinaMobileRule) Carriers(carriers ...Carrier) *ChinaMobileRule {
+	r.carriers = carriers
+	return r
+}
+
+// Validate checks if the string is a mainland China mobile number.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := ChinaMobile()
+//	err := rule.Validate("12345678901")     // returns ErrChinaMobile
+//	err = rule.Validate("+8613800138000")  // returns ErrChinaMobile (no AllowCountryCode)
+func (r *ChinaMobileRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if r.countryCode {
+		value = trimChinaCountryCode(value)
+	}
+	carrier, ok := MobileCarrier(value)
+	if !ok || len(r.carriers) > 0 && !slices.Contains(r.carriers, carrier) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrChinaMobile
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ChinaMobileRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrChinaMobile)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := ChinaMobile().Errf("Please enter a valid mobile number")
+func (r *ChinaMobileRule) Errf(format string, args ...any) *ChinaMobileRule {
+	if format != "" {
+		r.e = wrapf(ErrChinaMobile, format, args...)
+	}
+	return r
+}
+
+// MobileCarrier returns the carrier of a mainland China mobile number in the plain
+// 11-digit form, and reports whether the number is valid.
+//
+// Example:
+//
+//	carrier, ok := MobileCarrier("13800138000")  // CarrierChinaMobile, true
+//	carrier, ok = MobileCarrier("15412345678")   // "", false
+func MobileCarrier(number string) (Carrier, bool) {
+	if len(number) != 11 || !containsOnly(number, "0123456789") {
+		return "", false
+	}
+	carrier, ok := chinaMobilePrefixes[number[:3]]
+	return carrier, ok
+}
+
+// NormalizeChinaMobile creates a transformer that removes spaces and dashes and the
+// +86, 0086 or 86 country code from a mainland China mobile number, so that numbers are
+// validated and stored in the plain 11-digit form. Other values are kept as they are.
+//
+// Example:
+//
+//	arbiter.Field(&user.Mobile, rule.NormalizeChinaMobile(), rule.ChinaMobile())
+//	// "+86 138-0013-8000" becomes "13800138000"
+func NormalizeChinaMobile() *TransformRule[string] {
+	return Transform(normalizeChinaMobile)
+}
+
+// normalizeChinaMobile returns the plain 11-digit form of value, or value unchanged if
+// it does not look like a mobile number with a country code or separators.
+func normalizeChinaMobile(value string) string {
+	number := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(value))
+	number = trimChinaCountryCode(number)
+	if len(number) != 11 || !containsOnly(number, "0123456789") {
+		return value
+	}
+	return number
+}
+
+// trimChinaCountryCode removes the +86, 0086 or 86 country code and a following space
+// or dash from a mobile number. An 11-digit number is returned as is, as it cannot have
+// a country code.
+func trimChinaCountryCode(value string) string {
+	if len(value) == 11 {
+		return value
+	}
+	for _, code := range []string{"+86", "0086", "86"} {
+		if rest, ok := strings.CutPrefix(value, code); ok {
+			if rest != "" && (rest[0] == ' ' || rest[0] == '-') {
+				rest = rest[1:]
+			}
+			return rest
+		}
+	}
+	return value
+}
+
+// ChinaPlate returns a new RegexRule that validates mainland China vehicle license
+// plates: standard blue plates such as "京A12345" and new-energy green plates such as
+// "粤BD12345" (small vehicles) or "沪A12345F" (large vehicles). Letters must be upper
+// case and I and O are rejected, as they are never issued.
+//
+// Example:
+//
+//	rule := ChinaPlate()
+//	err := rule.Validate("京A12345")  // returns nil
+//	err = rule.Validate("粤BD12345")  // returns nil
+//	err = rule.Validate("京AO1234")   // returns ErrChinaPlate
+func ChinaPlate() *RegexRule {
+	return &RegexRule{regex: regexChinaPlate, e: ErrChinaPlate}
+}
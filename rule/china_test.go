@@ -0,0 +1,125 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChinaMobile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *ChinaMobileRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: china mobile", rule: ChinaMobile(), value: "13800138000"},
+		{name: "valid: virtual operator", rule: ChinaMobile(), value: "17012345678"},
+		{name: "valid: broadnet", rule: ChinaMobile(), value: "19212345678"},
+		{name: "valid: empty string", rule: ChinaMobile(), value: ""},
+		{name: "invalid: second digit", rule: ChinaMobile(), value: "12345678901", wantErr: true},
+		{name: "invalid: unallocated prefix", rule: ChinaMobile(), value: "15412345678", wantErr: true},
+		{name: "invalid: too short", rule: ChinaMobile(), value: "1380013800", wantErr: true},
+		{name: "invalid: letters", rule: ChinaMobile(), value: "1380013800a", wantErr: true},
+		{name: "invalid: country code not allowed", rule: ChinaMobile(), value: "+8613800138000", wantErr: true},
+		{name: "valid: +86", rule: ChinaMobile().AllowCountryCode(), value: "+8613800138000"},
+		{name: "valid: +86 with space", rule: ChinaMobile().AllowCountryCode(), value: "+86 13800138000"},
+		{name: "valid: 0086 with dash", rule: ChinaMobile().AllowCountryCode(), value: "0086-13800138000"},
+		{name: "valid: 86", rule: ChinaMobile().AllowCountryCode(), value: "8613800138000"},
+		{name: "invalid: other country code", rule: ChinaMobile().AllowCountryCode(), value: "+8513800138000", wantErr: true},
+		{name: "invalid: two separators", rule: ChinaMobile().AllowCountryCode(), value: "+86 -13800138000", wantErr: true},
+		{name: "valid: allowed carrier", rule: ChinaMobile().Carriers(CarrierChinaMobile, CarrierChinaUnicom), value: "18612345678"},
+		{name: "invalid: other carrier", rule: ChinaMobile().Carriers(CarrierChinaMobile, CarrierChinaUnicom), value: "18912345678", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrChinaMobile)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.EqualError(t, ChinaMobile().Errf("Invalid mobile").Validate("123"), "Invalid mobile")
+}
+
+func TestMobileCarrier(t *testing.T) {
+	tests := []struct {
+		number string
+		want   Carrier
+		wantOK bool
+	}{
+		{number: "13800138000", want: CarrierChinaMobile, wantOK: true},
+		{number: "18612345678", want: CarrierChinaUnicom, wantOK: true},
+		{number: "18912345678", want: CarrierChinaTelecom, wantOK: true},
+		{number: "19212345678", want: CarrierChinaBroadnet, wantOK: true},
+		{number: "17112345678", want: CarrierVirtual, wantOK: true},
+		{number: "14012345678"},
+		{number: "+8613800138000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			carrier, ok := MobileCarrier(tt.number)
+			assert.Equal(t, tt.want, carrier)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestNormalizeChinaMobile(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "13800138000", want: "13800138000"},
+		{value: "+86 138-0013-8000", want: "13800138000"},
+		{value: " 0086 13800138000 ", want: "13800138000"},
+		{value: "8613800138000", want: "13800138000"},
+		{value: "138 0013 8000", want: "13800138000"},
+		{value: "+1 555 0100", want: "+1 555 0100"},
+		{value: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeChinaMobile().Transform(tt.value))
+		})
+	}
+}
+
+func TestChinaPlate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: blue plate", value: "京A12345"},
+		{name: "valid: blue plate with letters", value: "沪BC8D9E"},
+		{name: "valid: small new-energy plate", value: "粤BD12345"},
+		{name: "valid: small new-energy plate with letter", value: "粤BFA1234"},
+		{name: "valid: large new-energy plate", value: "沪A12345F"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: letter O", value: "京AO1234", wantErr: true},
+		{name: "invalid: letter I as authority", value: "京I12345", wantErr: true},
+		{name: "invalid: unknown province", value: "港A12345", wantErr: true},
+		{name: "invalid: lower case", value: "京a12345", wantErr: true},
+		{name: "invalid: too short", value: "京A1234", wantErr: true},
+		{name: "invalid: new-energy without energy letter", value: "京A1234567", wantErr: true},
+		{name: "invalid: six characters without energy letter", value: "京AZ12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ChinaPlate().Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrChinaPlate)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
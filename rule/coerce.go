@@ -0,0 +1,248 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains coercion rules, which parse string inputs into typed values.
+package rule
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Coercion errors
+var (
+	// ErrCoerceInt is returned when a string is not a valid integer.
+	ErrCoerceInt = newError("coerce_int", "value is not a valid integer")
+
+	// ErrCoerceFloat is returned when a string is not a valid finite number.
+	ErrCoerceFloat = newError("coerce_float", "value is not a valid number")
+
+	// ErrCoerceBool is returned when a string is not a valid boolean.
+	ErrCoerceBool = newError("coerce_bool", "value is not a valid boolean")
+)
+
+// CoerceNumberRule parses a string, such as a form or query value, into a number,
+// optionally checks its range, and writes it to a target. Surrounding white space is
+// ignored. Empty strings are considered valid and leave the target unchanged
+// (use Required() if needed).
+//
+// Every successful validation writes to the target, so a coercion rule must not be
+// shared between goroutines, for example in a package-level variable or a Schema. Create
+// it with the target of each validation instead, as with the field rules of ValidateStruct.
+//
+// Example:
+//
+//	var age int
+//	rule := CoerceInt(&age).Between(0, 120)
+//	err := rule.Validate("42")   // returns nil, age is 42
+//	err = rule.Validate("abc")   // returns ErrCoerceInt
+//	err = rule.Validate("150")   // returns ErrBetween
+type CoerceNumberRule[T int | float64] struct {
+	target    *T
+	min, max  T
+	hasRange  bool
+	e, rangeE error
+}
+
+// CoerceInt creates a rule that parses a base 10 integer into target.
+//
+// Example:
+//
+//	type Form struct {
+//	    Age string
+//	}
+//
+//	var age int
+//	arbiter.Field(&form.Age, rule.CoerceInt(&age).Between(18, 120).Errf("Please enter your age"))
+func CoerceInt(target *int) *CoerceNumberRule[int] {
+	return &CoerceNumberRule[int]{target: target, e: ErrCoerceInt}
+}
+
+// CoerceFloat creates a rule that parses a finite decimal number into target.
+// NaN and infinities are rejected.
+//
+// Example:
+//
+//	var price float64
+//	rule := CoerceFloat(&price).Between(0, 10000)
+//	err := rule.Validate("19.99")  // returns nil, price is 19.99
+func CoerceFloat(target *float64) *CoerceNumberRule[float64] {
+	return &CoerceNumberRule[float64]{target: target, e: ErrCoerceFloat}
+}
+
+// parseNumber parses s as a base 10 int or a finite float64, depending on T. It returns
+// the sentinel error of T if s is not valid.
+func parseNumber[T int | float64](s string) (T, error) {
+	var n T
+	switch p := any(&n).(type) {
+	case *int:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return n, ErrCoerceInt
+		}
+		*p = i
+	case *float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return n, ErrCoerceFloat
+		}
+		*p = f
+	}
+	return n, nil
+}
+
+// Between requires the parsed number to be between min and max, inclusive.
+//
+// Example:
+//
+//	rule := CoerceInt(&quantity).Between(1, 99)
+func (r *CoerceNumberRule[T]) Between(min, max T) *CoerceNumberRule[T] {
+	r.min, r.max, r.hasRange = min, max, true
+	return r
+}
+
+// Validate parses the string and, if it is a valid number within the range, writes it
+// to the target.
+//
+// Example:
+//
+//	var page int
+//	rule := CoerceInt(&page).Between(1, 1000)
+//	err := rule.Validate(" 3 ")  // returns nil, page is 3
+//	err = rule.Validate("3.5")   // returns error, page is unchanged
+func (r *CoerceNumberRule[T]) Validate(value string) error {
+	value = strings.TrimSpace(value)
+	if skipEmpty(value) {
+		return nil
+	}
+	n, err := parseNumber[T](value)
+	if err != nil {
+		if r.e != nil {
+			return r.e
+		}
+		return err
+	}
+	if r.hasRange && (n < r.min || n > r.max) {
+		if r.rangeE != nil {
+			return r.rangeE
+		}
+		return formatError(ErrBetween, ErrBetweenFormat, r.min, r.max)
+	}
+	if r.target != nil {
+		*r.target = n
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *CoerceNumberRule[T]) Describe() RuleInfo {
+	// Parsing an empty string fails with the sentinel error of T
+	_, base := parseNumber[T]("")
+	return describe(r, r.e, base)
+}
+
+// Errf sets a custom error message for coercion failures.
+// The message replaces both the parse and the range error.
+//
+// Example:
+//
+//	rule := CoerceInt(&age).Between(0, 120).Errf("Age must be a whole number between 0 and 120")
+func (r *CoerceNumberRule[T]) Errf(format string, args ...any) *CoerceNumberRule[T] {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+		r.rangeE = wrapf(ErrBetween, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := CoerceInt(&age).Between(0, 120).Params()  // map[string]any{"min": 0, "max": 120}
+func (r *CoerceNumberRule[T]) Params() map[string]any {
+	if !r.hasRange {
+		return nil
+	}
+	return map[string]any{"min": r.min, "max": r.max}
+}
+
+// CoerceBoolRule parses a string, such as a form or query value, into a boolean and
+// writes it to a target. Besides the values accepted by strconv.ParseBool, it accepts
+// "on", "off", "yes" and "no" in any case, as sent by HTML checkboxes and toggles.
+// Empty strings are considered valid and leave the target unchanged
+// (use Required() if needed). Like CoerceInt, the rule writes to its target and must not
+// be shared between goroutines.
+//
+// Example:
+//
+//	var subscribe bool
+//	rule := CoerceBool(&subscribe)
+//	err := rule.Validate("on")     // returns nil, subscribe is true
+//	err = rule.Validate("maybe")   // returns ErrCoerceBool
+type CoerceBoolRule struct {
+	target *bool
+	e      error
+}
+
+// CoerceBool creates a rule that parses a boolean into target.
+//
+// Example:
+//
+//	var terms bool
+//	arbiter.Field(&form.Terms, rule.CoerceBool(&terms).Errf("Invalid value for terms"))
+func CoerceBool(target *bool) *CoerceBoolRule {
+	return &CoerceBoolRule{target: target, e: ErrCoerceBool}
+}
+
+// Validate parses the string and, if it is a valid boolean, writes it to the target.
+//
+// Example:
+//
+//	var admin bool
+//	rule := CoerceBool(&admin)
+//	err := rule.Validate("TRUE")  // returns nil, admin is true
+//	err = rule.Validate("no")     // returns nil, admin is false
+func (r *CoerceBoolRule) Validate(value string) error {
+	value = strings.TrimSpace(value)
+	if skipEmpty(value) {
+		return nil
+	}
+	var b bool
+	switch strings.ToLower(value) {
+	case "on", "yes":
+		b = true
+	case "off", "no":
+		b = false
+	default:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			if r.e != nil {
+				return r.e
+			}
+			return ErrCoerceBool
+		}
+		b = parsed
+	}
+	if r.target != nil {
+		*r.target = b
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *CoerceBoolRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrCoerceBool)
+}
+
+// Errf sets a custom error message for boolean coercion failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := CoerceBool(&remember).Errf("Remember me must be on or off")
+func (r *CoerceBoolRule) Errf(format string, args ...any) *CoerceBoolRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
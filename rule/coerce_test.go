@@ -0,0 +1,117 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerceInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr error
+	}{
+		{name: "valid", value: "42", want: 42},
+		{name: "valid: white space", value: " 7 ", want: 7},
+		{name: "valid: negative bound", value: "-10", want: -10},
+		{name: "valid: empty", value: "", want: 99},
+		{name: "invalid: float", value: "3.5", want: 99, wantErr: ErrCoerceInt},
+		{name: "invalid: text", value: "abc", want: 99, wantErr: ErrCoerceInt},
+		{name: "invalid: below range", value: "-11", want: 99, wantErr: ErrBetween},
+		{name: "invalid: above range", value: "121", want: 99, wantErr: ErrBetween},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 99
+			err := CoerceInt(&got).Between(-10, 120).Validate(tt.value)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCoerceFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "valid", value: "19.99", want: 19.99, wantErr: false},
+		{name: "valid: integer", value: "5", want: 5, wantErr: false},
+		{name: "valid: exponent", value: "1e3", want: 1000, wantErr: false},
+		{name: "invalid: nan", value: "NaN", want: -1, wantErr: true},
+		{name: "invalid: infinity", value: "+Inf", want: -1, wantErr: true},
+		{name: "invalid: text", value: "ten", want: -1, wantErr: true},
+		{name: "invalid: out of range", value: "10000.01", want: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := -1.0
+			err := CoerceFloat(&got).Between(0, 10000).Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CoerceNumberRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCoerceBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{name: "valid: true", value: "true", want: true, wantErr: false},
+		{name: "valid: upper case", value: "TRUE", want: true, wantErr: false},
+		{name: "valid: checkbox", value: "on", want: true, wantErr: false},
+		{name: "valid: yes", value: "Yes", want: true, wantErr: false},
+		{name: "valid: 1", value: "1", want: true, wantErr: false},
+		{name: "valid: empty", value: "", want: false, wantErr: false},
+		{name: "invalid: maybe", value: "maybe", want: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			err := CoerceBool(&got).Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CoerceBoolRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	var got bool
+	assert.Nil(t, CoerceBool(&got).Validate("off"))
+	assert.False(t, got)
+}
+
+func TestCoerceErrf(t *testing.T) {
+	var n int
+	rule := CoerceInt(&n).Between(1, 10).Errf("Enter a number from 1 to 10")
+	assert.EqualError(t, rule.Validate("x"), "Enter a number from 1 to 10")
+	assert.ErrorIs(t, rule.Validate("x"), ErrCoerceInt)
+	assert.EqualError(t, rule.Validate("11"), "Enter a number from 1 to 10")
+	assert.ErrorIs(t, rule.Validate("11"), ErrBetween)
+	assert.EqualError(t, CoerceBool(nil).Errf("bad").Validate("x"), "bad")
+	assert.Equal(t, map[string]any{"min": 1, "max": 10}, rule.Params())
+	assert.Nil(t, CoerceInt(&n).Params())
+}
+
+func TestCoerceFallback(t *testing.T) {
+	assert.ErrorIs(t, (&CoerceNumberRule[int]{}).Validate("x"), ErrCoerceInt)
+	assert.ErrorIs(t, (&CoerceNumberRule[float64]{}).Validate("x"), ErrCoerceFloat)
+	assert.Nil(t, (&CoerceNumberRule[int]{}).Validate("5"))
+	assert.ErrorIs(t, (&CoerceBoolRule{}).Validate("x"), ErrCoerceBool)
+}
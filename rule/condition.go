@@ -1,16 +1,13 @@
 // Package rule provides validation rules for various data types
 package rule
 
-import (
-	"errors"
-	"fmt"
-)
+import "strings"
 
 // Common validation errors for condition rules
 var (
-	ErrCondition     = errors.New("condition validation failed")
-	ErrDependency    = errors.New("dependency validation failed")
-	ErrMutualExclude = errors.New("mutual exclude validation failed")
+	ErrCondition     = newError("condition", "condition validation failed")
+	ErrDependency    = newError("dependency", "dependency validation failed")
+	ErrMutualExclude = newError("mutual_exclude", "mutual exclude validation failed")
 )
 
 // ConditionRule represents a rule that combines multiple rules using logical operators.
@@ -83,6 +80,14 @@ func (r *ConditionRule[T]) Validate(value T) error {
 	}
 }
 
+// Describe returns the name, parameters and message of the rule, with its sub-rules.
+func (r *ConditionRule[T]) Describe() RuleInfo {
+	info := describe(r, r.e, ErrCondition)
+	info.Name = strings.ToLower(r.operator)
+	info.Rules = describeRules(r.rules)
+	return info
+}
+
 // Errf sets a custom error message for the validation rule using a formatted string.
 // Returns the rule instance for method chaining.
 //
@@ -91,7 +96,7 @@ func (r *ConditionRule[T]) Validate(value T) error {
 //	rule := And(Length(5), Contains("a")).Errf("Invalid value")
 func (r *ConditionRule[T]) Errf(format string, args ...any) *ConditionRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -148,6 +153,14 @@ func (r *DependencyRule[T, D]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule, with the rule of the dependency.
+func (r *DependencyRule[T, D]) Describe() RuleInfo {
+	info := describe(r, r.e, ErrDependency)
+	info.Params = map[string]any{"field": r.field, "dependency": r.dependency}
+	info.Rules = []RuleInfo{Describe(r.validator)}
+	return info
+}
+
 // Errf sets a custom error message for the validation rule using a formatted string.
 // Returns the rule instance for method chaining.
 //
@@ -156,7 +169,7 @@ func (r *DependencyRule[T, D]) Validate(value T) error {
 //	rule := Dependency("age", "isAdult", GreaterThan(18), func(p Person) int { return p.Age }).Errf("Age must be greater than 18")
 func (r *DependencyRule[T, D]) Errf(format string, args ...any) *DependencyRule[T, D] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -213,6 +226,11 @@ func (r *MutualExcludeRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *MutualExcludeRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrMutualExclude)
+}
+
 // Errf sets a custom error message for the validation rule using a formatted string.
 // Returns the rule instance for method chaining.
 //
@@ -221,7 +239,80 @@ func (r *MutualExcludeRule[T]) Validate(value T) error {
 //	rule := MutualExclude([]string{"type1", "type2"}, []string{"A", "B"}, func(a, b string) bool { return a == b }).Errf("Invalid type")
 func (r *MutualExcludeRule[T]) Errf(format string, args ...any) *MutualExcludeRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// IfRule applies one of two lists of rules depending on whether a condition rule passes,
+// so branching validation can be declared within a single field's rule chain.
+//
+// Example:
+//
+//	// 18 character document numbers are ID cards, others are passports
+//	rule := If(Len[string](18, 18)).Then(IsIDCard()).Else(IsPassport())
+//	err := rule.Validate("11010519491231002X")  // validated as an ID card
+//	err = rule.Validate("E12345678")            // validated as a passport
+type IfRule[T any] struct {
+	cond Rule[T]
+	then []Rule[T]
+	els  []Rule[T]
+}
+
+// If creates a rule that applies the rules of Then if cond passes, and the rules of Else
+// otherwise. The error of cond itself is never returned.
+//
+// Example:
+//
+//	rule := If(In("CN")).Then(Required[string]())
+func If[T any](cond Rule[T]) *IfRule[T] {
+	return &IfRule[T]{cond: cond}
+}
+
+// Then sets the rules applied when the condition passes.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := If(Len[string](18, 18)).Then(IsIDCard())
+func (r *IfRule[T]) Then(rules ...Rule[T]) *IfRule[T] {
+	r.then = rules
+	return r
+}
+
+// Else sets the rules applied when the condition fails.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := If(Len[string](18, 18)).Then(IsIDCard()).Else(IsPassport())
+func (r *IfRule[T]) Else(rules ...Rule[T]) *IfRule[T] {
+	r.els = rules
+	return r
+}
+
+// Validate evaluates the condition and applies the rules of the matching branch in order.
+// Returns nil if they all pass, or the error of the first failing rule as is. A nil
+// condition counts as passing.
+func (r *IfRule[T]) Validate(value T) error {
+	rules := r.els
+	if r.cond == nil || r.cond.Validate(value) == nil {
+		rules = r.then
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns the description of the rule. Its rules are the condition, and the
+// Then and Else rules are its "then" and "else" parameters.
+func (r *IfRule[T]) Describe() RuleInfo {
+	return RuleInfo{
+		Name:   "if",
+		Params: map[string]any{"then": describeRules(r.then), "else": describeRules(r.els)},
+		Rules:  []RuleInfo{Describe(r.cond)},
+	}
+}
@@ -275,3 +275,28 @@ func TestCustomErrorMessage(t *testing.T) {
 		assert.Equal(t, "Custom mutual exclude error", err.Error())
 	})
 }
+
+// TestIfRule tests the If/Then/Else combinator.
+func TestIfRule(t *testing.T) {
+	document := If(Len[string](18, 18)).Then(IsIDCard()).Else(IsPassport())
+	tests := []struct {
+		name    string
+		rule    Rule[string]
+		value   string
+		wantErr error
+	}{
+		{name: "then passes", rule: document, value: "11010519491231002X"},
+		{name: "then fails", rule: document, value: "1101051949123100ZZ", wantErr: ErrIDCard},
+		{name: "else passes", rule: document, value: "E12345678"},
+		{name: "else fails", rule: document, value: "12", wantErr: ErrPassport},
+		{name: "no else", rule: If(In("CN")).Then(Len[string](3, 3)), value: "US"},
+		{name: "nil condition", rule: If[string](nil).Then(Required[string]()), value: "", wantErr: ErrRequired},
+		{name: "empty branches", rule: If(Required[string]()), value: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.rule.Validate(tt.value), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,390 @@
+// Package decimalrule provides rules for exact decimal numbers, such as money amounts held
+// in decimal.Decimal of github.com/shopspring/decimal. Unlike rule.Min, rule.Max and
+// rule.Precision with float64, which cannot represent most currency amounts, these rules
+// compare values exactly. The package depends on no decimal library: any type whose
+// String method returns the exact value works.
+//
+// Example:
+//
+//	arbiter.Field(&order.Total,
+//	    decimalrule.Positive[decimal.Decimal](),
+//	    decimalrule.Precision[decimal.Decimal](2),
+//	    decimalrule.Max(decimal.NewFromInt(10000)),
+//	)
+package decimalrule
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+const (
+	// maxDigits bounds the number of digits of a decimal value.
+	maxDigits = 1000
+	// maxExponent bounds the absolute value of the exponent of a decimal value, so that
+	// values such as "1e-1000000" are rejected instead of expanded.
+	maxExponent = 10000
+)
+
+// Decimal is the constraint of exact decimal types, such as decimal.Decimal of
+// github.com/shopspring/decimal, whose String method returns the exact value in plain or
+// scientific notation.
+type Decimal interface {
+	String() string
+}
+
+// number is an exact decimal number: coef × 10^exp, where coef holds the significant
+// digits without leading or trailing zeros, and is empty for zero.
+type number struct {
+	neg  bool
+	coef string
+	exp  int
+}
+
+// parse returns the exact value of d, such as "-12.50" or "1.5e-3". Values with more than
+// maxDigits digits or an exponent beyond maxExponent are rejected.
+func parse[T Decimal](d T) (number, bool) {
+	s := d.String()
+	var v number
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		v.neg = s[0] == '-'
+		s = s[1:]
+	}
+	mantissa, exponent, hasExp := strings.Cut(strings.ToLower(s), "e")
+	intPart, frac, _ := strings.Cut(mantissa, ".")
+	digits := intPart + frac
+	if digits == "" || len(digits) > maxDigits || strings.Trim(digits, "0123456789") != "" {
+		return number{}, false
+	}
+	if hasExp {
+		// Check the length before parsing, so that huge exponents are not converted.
+		unsigned := strings.TrimLeft(exponent, "+-")
+		if len(exponent)-len(unsigned) > 1 || unsigned == "" || len(unsigned) > 6 {
+			return number{}, false
+		}
+		n, err := strconv.Atoi(exponent)
+		if err != nil || n < -maxExponent || n > maxExponent {
+			return number{}, false
+		}
+		v.exp = n
+	}
+	v.exp -= len(frac)
+	v.coef = strings.TrimLeft(digits, "0")
+	trimmed := strings.TrimRight(v.coef, "0")
+	v.exp += len(v.coef) - len(trimmed)
+	v.coef = trimmed
+	if v.coef == "" {
+		return number{}, true
+	}
+	return v, true
+}
+
+// sign returns -1, 0 or 1 for negative values, zero and positive values.
+func (v number) sign() int {
+	switch {
+	case v.coef == "":
+		return 0
+	case v.neg:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// cmp returns -1, 0 or 1 if v is less than, equal to or greater than o.
+func (v number) cmp(o number) int {
+	if s, t := v.sign(), o.sign(); s != t || s == 0 {
+		return cmp.Compare(s, t)
+	}
+	// Without leading zeros, the value with more integer digits has the larger magnitude.
+	c := cmp.Compare(len(v.coef)+v.exp, len(o.coef)+o.exp)
+	for i := 0; c == 0 && i < max(len(v.coef), len(o.coef)); i++ {
+		c = cmp.Compare(digitAt(v.coef, i), digitAt(o.coef, i))
+	}
+	if v.neg {
+		return -c
+	}
+	return c
+}
+
+// places returns the number of decimal places of v.
+func (v number) places() int {
+	return max(-v.exp, 0)
+}
+
+// digitAt returns the i-th digit of coef, or '0' past its end.
+func digitAt(coef string, i int) byte {
+	if i < len(coef) {
+		return coef[i]
+	}
+	return '0'
+}
+
+// describe returns the description of a rule whose current error is e and whose default
+// error is base.
+func describe(e, base error, params map[string]any) rule.RuleInfo {
+	if e == nil {
+		e = base
+	}
+	return rule.RuleInfo{Name: rule.ErrorCode(e), Message: e.Error(), Params: params}
+}
+
+// MinRule validates that a decimal value is greater than or equal to a minimum.
+//
+// Example:
+//
+//	rule := decimalrule.Min(decimal.RequireFromString("0.01"))
+//	err := rule.Validate(decimal.RequireFromString("9.99"))   // returns nil
+//	err = rule.Validate(decimal.RequireFromString("0.001"))   // returns rule.ErrMin
+type MinRule[T Decimal] struct {
+	min T
+	e   error
+}
+
+// Min creates a rule that checks if a decimal value is at least min.
+//
+// Example:
+//
+//	priceRule := decimalrule.Min(decimal.NewFromInt(1)).Errf("Price must be at least 1")
+func Min[T Decimal](min T) *MinRule[T] {
+	return &MinRule[T]{min: min, e: rule.ErrMin}
+}
+
+// Validate checks if value is greater than or equal to the minimum. Values or bounds
+// that are not numbers return rule.ErrDecimal.
+//
+// Example:
+//
+//	err := decimalrule.Min(decimal.NewFromInt(1)).Validate(decimal.RequireFromString("0.99"))  // returns rule.ErrMin
+func (r *MinRule[T]) Validate(value T) error {
+	v, ok := parse(value)
+	minimum, minOK := parse(r.min)
+	if !ok || !minOK {
+		return rule.ErrDecimal
+	}
+	if v.cmp(minimum) < 0 {
+		return r.e
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *MinRule[T]) Describe() rule.RuleInfo {
+	return describe(r.e, rule.ErrMin, r.Params())
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := decimalrule.Min(decimal.NewFromInt(1)).Errf("Amount must be at least %s", "1.00")
+func (r *MinRule[T]) Errf(format string, args ...any) *MinRule[T] {
+	if format != "" {
+		r.e = rule.Wrapf(rule.ErrMin, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+// The bound is its exact string form.
+//
+// Example:
+//
+//	params := decimalrule.Min(decimal.RequireFromString("0.01")).Params()  // map[string]any{"min": "0.01"}
+func (r *MinRule[T]) Params() map[string]any {
+	return map[string]any{"min": r.min.String()}
+}
+
+// MaxRule validates that a decimal value is less than or equal to a maximum.
+//
+// Example:
+//
+//	rule := decimalrule.Max(decimal.NewFromInt(10000))
+//	err := rule.Validate(decimal.RequireFromString("9999.99"))   // returns nil
+//	err = rule.Validate(decimal.RequireFromString("10000.01"))   // returns rule.ErrMax
+type MaxRule[T Decimal] struct {
+	max T
+	e   error
+}
+
+// Max creates a rule that checks if a decimal value is at most max.
+//
+// Example:
+//
+//	refundRule := decimalrule.Max(order.Total).Errf("Refund cannot exceed the order total")
+func Max[T Decimal](max T) *MaxRule[T] {
+	return &MaxRule[T]{max: max, e: rule.ErrMax}
+}
+
+// Validate checks if value is less than or equal to the maximum. Values or bounds that
+// are not numbers return rule.ErrDecimal.
+//
+// Example:
+//
+//	err := decimalrule.Max(decimal.NewFromInt(100)).Validate(decimal.RequireFromString("100.01"))  // returns rule.ErrMax
+func (r *MaxRule[T]) Validate(value T) error {
+	v, ok := parse(value)
+	maximum, maxOK := parse(r.max)
+	if !ok || !maxOK {
+		return rule.ErrDecimal
+	}
+	if v.cmp(maximum) > 0 {
+		return r.e
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *MaxRule[T]) Describe() rule.RuleInfo {
+	return describe(r.e, rule.ErrMax, r.Params())
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := decimalrule.Max(decimal.NewFromInt(100)).Errf("Discount is too large")
+func (r *MaxRule[T]) Errf(format string, args ...any) *MaxRule[T] {
+	if format != "" {
+		r.e = rule.Wrapf(rule.ErrMax, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+// The bound is its exact string form.
+//
+// Example:
+//
+//	params := decimalrule.Max(decimal.NewFromInt(100)).Params()  // map[string]any{"max": "100"}
+func (r *MaxRule[T]) Params() map[string]any {
+	return map[string]any{"max": r.max.String()}
+}
+
+// PrecisionRule validates that a decimal value has at most a number of decimal places,
+// such as 2 for most currencies. Trailing zeros do not count, so 1.50 has one decimal
+// place.
+//
+// Example:
+//
+//	rule := decimalrule.Precision[decimal.Decimal](2)
+//	err := rule.Validate(decimal.RequireFromString("19.99"))   // returns nil
+//	err = rule.Validate(decimal.RequireFromString("19.999"))   // returns rule.ErrPrecision
+type PrecisionRule[T Decimal] struct {
+	precision int
+	e         error
+}
+
+// Precision creates a rule that checks if a decimal value has at most precision decimal
+// places.
+//
+// Example:
+//
+//	amountRule := decimalrule.Precision[decimal.Decimal](2).Errf("Amount must have at most 2 decimal places")
+func Precision[T Decimal](precision int) *PrecisionRule[T] {
+	return &PrecisionRule[T]{precision: precision, e: rule.ErrPrecision}
+}
+
+// Validate checks if value has at most the allowed number of decimal places. Values that
+// are not numbers return rule.ErrDecimal.
+//
+// Example:
+//
+//	err := decimalrule.Precision[decimal.Decimal](0).Validate(decimal.RequireFromString("1.5"))  // returns rule.ErrPrecision
+func (r *PrecisionRule[T]) Validate(value T) error {
+	v, ok := parse(value)
+	if !ok {
+		return rule.ErrDecimal
+	}
+	if v.places() > r.precision {
+		return r.e
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PrecisionRule[T]) Describe() rule.RuleInfo {
+	return describe(r.e, rule.ErrPrecision, r.Params())
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := decimalrule.Precision[decimal.Decimal](2).Errf("Use at most 2 decimal places")
+func (r *PrecisionRule[T]) Errf(format string, args ...any) *PrecisionRule[T] {
+	if format != "" {
+		r.e = rule.Wrapf(rule.ErrPrecision, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := decimalrule.Precision[decimal.Decimal](2).Params()  // map[string]any{"precision": 2}
+func (r *PrecisionRule[T]) Params() map[string]any {
+	return map[string]any{"precision": r.precision}
+}
+
+// PositiveRule validates that a decimal value is greater than zero.
+//
+// Example:
+//
+//	rule := decimalrule.Positive[decimal.Decimal]()
+//	err := rule.Validate(decimal.RequireFromString("0.01"))  // returns nil
+//	err = rule.Validate(decimal.Zero)                        // returns rule.ErrPositive
+type PositiveRule[T Decimal] struct {
+	e error
+}
+
+// Positive creates a rule that checks if a decimal value is greater than zero.
+//
+// Example:
+//
+//	totalRule := decimalrule.Positive[decimal.Decimal]().Errf("Total must be positive")
+func Positive[T Decimal]() *PositiveRule[T] {
+	return &PositiveRule[T]{e: rule.ErrPositive}
+}
+
+// Validate checks if value is greater than zero. Values that are not numbers return
+// rule.ErrDecimal.
+//
+// Example:
+//
+//	err := decimalrule.Positive[decimal.Decimal]().Validate(decimal.NewFromInt(-1))  // returns rule.ErrPositive
+func (r *PositiveRule[T]) Validate(value T) error {
+	v, ok := parse(value)
+	if !ok {
+		return rule.ErrDecimal
+	}
+	if v.sign() <= 0 {
+		return r.e
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PositiveRule[T]) Describe() rule.RuleInfo {
+	return describe(r.e, rule.ErrPositive, nil)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := decimalrule.Positive[decimal.Decimal]().Errf("Price must be positive")
+func (r *PositiveRule[T]) Errf(format string, args ...any) *PositiveRule[T] {
+	if format != "" {
+		r.e = rule.Wrapf(rule.ErrPositive, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,68 @@
+package decimalrule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+// testDecimal is a minimal exact decimal type, like decimal.Decimal of shopspring/decimal.
+type testDecimal string
+
+func (d testDecimal) String() string {
+	return string(d)
+}
+
+func TestDecimalRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    rule.Rule[testDecimal]
+		value   testDecimal
+		wantErr error
+	}{
+		{name: "min equal", rule: Min(testDecimal("0.01")), value: "0.010"},
+		{name: "min below", rule: Min(testDecimal("0.01")), value: "0.0099999999999999999", wantErr: rule.ErrMin},
+		{name: "max exact", rule: Max(testDecimal("10000")), value: "1e4"},
+		{name: "max above", rule: Max(testDecimal("10000")), value: "10000.000000000000001", wantErr: rule.ErrMax},
+		{name: "precision", rule: Precision[testDecimal](2), value: "19.99"},
+		{name: "precision trailing zeros", rule: Precision[testDecimal](2), value: "19.9900"},
+		{name: "precision exceeded", rule: Precision[testDecimal](2), value: "0.105", wantErr: rule.ErrPrecision},
+		{name: "precision exponent", rule: Precision[testDecimal](2), value: "1.5e-3", wantErr: rule.ErrPrecision},
+		{name: "precision zero", rule: Precision[testDecimal](0), value: "120"},
+		{name: "positive", rule: Positive[testDecimal](), value: "0.000001"},
+		{name: "positive zero", rule: Positive[testDecimal](), value: "0.00", wantErr: rule.ErrPositive},
+		{name: "positive negative", rule: Positive[testDecimal](), value: "-1", wantErr: rule.ErrPositive},
+		{name: "not a number", rule: Positive[testDecimal](), value: "abc", wantErr: rule.ErrDecimal},
+		{name: "invalid bound", rule: Min(testDecimal("")), value: "1", wantErr: rule.ErrDecimal},
+		{name: "min negative", rule: Min(testDecimal("-2.5")), value: "-2.49"},
+		{name: "min negative below", rule: Min(testDecimal("-2.5")), value: "-2.51", wantErr: rule.ErrMin},
+		{name: "max zero", rule: Max(testDecimal("0")), value: "-0.00"},
+		{name: "max different magnitude", rule: Max(testDecimal("9.99")), value: "10", wantErr: rule.ErrMax},
+		{name: "max signed exponent", rule: Max(testDecimal("1")), value: "+1E+0"},
+		{name: "precision large exponent", rule: Precision[testDecimal](2), value: "1e-10000", wantErr: rule.ErrPrecision},
+		{name: "exponent too large", rule: Precision[testDecimal](2), value: "1e-100000", wantErr: rule.ErrDecimal},
+		{name: "exponent too long", rule: Min(testDecimal("0")), value: "1e-1000000000000", wantErr: rule.ErrDecimal},
+		{name: "too many digits", rule: Min(testDecimal("0")), value: testDecimal(strings.Repeat("9", 1001)), wantErr: rule.ErrDecimal},
+		{name: "malformed exponent", rule: Min(testDecimal("0")), value: "1e+-5", wantErr: rule.ErrDecimal},
+		{name: "missing exponent", rule: Min(testDecimal("0")), value: "1e", wantErr: rule.ErrDecimal},
+		{name: "two points", rule: Min(testDecimal("0")), value: "1.2.3", wantErr: rule.ErrDecimal},
+		{name: "fraction", rule: Min(testDecimal("0")), value: "1/3", wantErr: rule.ErrDecimal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.rule.Validate(tt.value), tt.wantErr)
+		})
+	}
+
+	err := Min(testDecimal("1")).Errf("at least %s", "1.00").Validate("0.5")
+	assert.EqualError(t, err, "at least 1.00")
+	assert.Equal(t, "min", rule.ErrorCode(err))
+	assert.Equal(t, map[string]any{"min": "0.01"}, Min(testDecimal("0.01")).Params())
+	assert.Equal(t, map[string]any{"precision": 2}, Precision[testDecimal](2).Params())
+	assert.Equal(t, "positive", Positive[testDecimal]().Describe().Name)
+	assert.Equal(t, map[string]any{"max": "100"}, Max(testDecimal("100")).Describe().Params)
+}
@@ -0,0 +1,91 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rule that applies value rules to pointer fields.
+package rule
+
+// DerefRule validates the value a pointer points to with rules of the value type, so
+// optional fields such as *string can use the same rules as string fields. Nil pointers
+// are accepted unless Required is set.
+//
+// Example:
+//
+//	name := "A"
+//	rule := Deref(Len[string](2, 50))
+//	err := rule.Validate(nil)     // returns nil
+//	err = rule.Validate(&name)    // returns ErrLength
+type DerefRule[T any] struct {
+	rules    []Rule[T]
+	required bool
+	e        error
+}
+
+// Deref creates a rule that applies rules to the value a *T points to, in order, and
+// returns the first error.
+//
+// Example:
+//
+//	nicknameRule := Deref(Trim(), Len[string](2, 20))
+func Deref[T any](rules ...Rule[T]) *DerefRule[T] {
+	return &DerefRule[T]{rules: rules, e: ErrRequired}
+}
+
+// Required makes the rule return ErrRequired for nil pointers.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := Deref(Min(1)).Required()
+//	err := rule.Validate(nil)  // returns ErrRequired
+func (r *DerefRule[T]) Required() *DerefRule[T] {
+	r.required = true
+	return r
+}
+
+// Validate applies the rules to *value if value is not nil. Rules that implement
+// Transformer modify the value in place, as they do for fields.
+//
+// Example:
+//
+//	quantity := 0
+//	err := Deref(Min(1)).Validate(&quantity)  // returns ErrMin
+func (r *DerefRule[T]) Validate(value *T) error {
+	if value == nil {
+		if r.required {
+			return r.e
+		}
+		return nil
+	}
+	for _, rule := range r.rules {
+		if t, ok := rule.(Transformer[T]); ok {
+			*value = t.Transform(*value)
+			continue
+		}
+		if err := rule.Validate(*value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns the description of the rule, with its inner rules. The message is the
+// error for nil pointers, if they are not accepted.
+func (r *DerefRule[T]) Describe() RuleInfo {
+	info := RuleInfo{Name: "deref", Rules: describeRules(r.rules)}
+	if r.required {
+		info.Message = r.e.Error()
+	}
+	return info
+}
+
+// Errf sets a custom error message for nil pointers and makes the rule required.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := Deref(IsEmail()).Errf("Email is required")
+func (r *DerefRule[T]) Errf(format string, args ...any) *DerefRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrRequired, format, args...)
+		r.required = true
+	}
+	return r
+}
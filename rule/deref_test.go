@@ -0,0 +1,43 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeref(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		rule    *DerefRule[string]
+		value   *string
+		wantErr error
+		want    string
+	}{
+		{name: "nil", rule: Deref(Len[string](2, 5)), value: nil},
+		{name: "valid", rule: Deref(Len[string](2, 5)), value: ptr("Ada"), want: "Ada"},
+		{name: "invalid", rule: Deref(Len[string](2, 5)), value: ptr("A"), wantErr: ErrLength, want: "A"},
+		{name: "required nil", rule: Deref(Len[string](2, 5)).Required(), value: nil, wantErr: ErrRequired},
+		{name: "required valid", rule: Deref(Len[string](2, 5)).Required(), value: ptr("Ada"), want: "Ada"},
+		{name: "transform", rule: Deref[string](Trim(), Len[string](2, 5)), value: ptr("  Ada "), want: "Ada"},
+		{name: "transform then invalid", rule: Deref[string](Trim(), Len[string](2, 5)), value: ptr(" A "), wantErr: ErrLength, want: "A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+			if tt.value != nil {
+				assert.Equal(t, tt.want, *tt.value)
+			}
+		})
+	}
+
+	err := Deref(Min(1)).Errf("Quantity is required").Validate(nil)
+	assert.EqualError(t, err, "Quantity is required")
+	assert.ErrorIs(t, err, ErrRequired)
+}
@@ -0,0 +1,77 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the descriptions of rules, which let tooling list their constraints.
+package rule
+
+import "fmt"
+
+// RuleInfo describes a rule, so that tooling can list, document or serialize the
+// constraints applied to a value.
+//
+// Example:
+//
+//	info := Between(1, 10).Describe()
+//	// RuleInfo{Name: "between", Params: map[min:1 max:10], Message: "value is not between minimum and maximum"}
+type RuleInfo struct {
+	// Name identifies the rule. It is the code of the rule's errors, such as "between", or
+	// the lower case name of combinators such as "and", "optional" and "deref".
+	Name string `json:"name"`
+	// Params are the parameters of the rule, as returned by its Params method.
+	Params map[string]any `json:"params,omitempty"`
+	// Message is the message of the rule's errors: the one set with Errf or the default.
+	Message string `json:"message,omitempty"`
+	// Rules describes the inner rules of combinators, in order.
+	Rules []RuleInfo `json:"rules,omitempty"`
+}
+
+// Describer is implemented by rules that describe themselves, as all rules of this
+// package do.
+type Describer interface {
+	Describe() RuleInfo
+}
+
+// Describe returns the description of r, a rule of any type. Rules that do not implement
+// Describer are named after their type, such as "*main.SKURule", with the parameters of
+// Parameterized rules.
+//
+// Example:
+//
+//	info := Describe(Len[string](2, 50))  // RuleInfo{Name: "length", Params: map[min:2 max:50], ...}
+func Describe(r any) RuleInfo {
+	if d, ok := r.(Describer); ok {
+		return d.Describe()
+	}
+	info := RuleInfo{Name: fmt.Sprintf("%T", r)}
+	if p, ok := r.(Parameterized); ok {
+		info.Params = p.Params()
+	}
+	return info
+}
+
+// describe returns the description of the rule r whose current error is e and whose
+// default error is base. The name is the code of e, which keeps the code of base when
+// the message was set with Errf.
+func describe(r any, e, base error) RuleInfo {
+	if e == nil {
+		e = base
+	}
+	info := RuleInfo{Name: ErrorCode(e), Message: e.Error()}
+	if info.Name == "" {
+		info.Name = ErrorCode(base)
+	}
+	if p, ok := r.(Parameterized); ok {
+		info.Params = p.Params()
+	}
+	return info
+}
+
+// describeRules returns the descriptions of rules.
+func describeRules[T any](rules []Rule[T]) []RuleInfo {
+	if len(rules) == 0 {
+		return nil
+	}
+	infos := make([]RuleInfo, len(rules))
+	for i, r := range rules {
+		infos[i] = Describe(r)
+	}
+	return infos
+}
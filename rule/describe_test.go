@@ -0,0 +1,75 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUndescribed struct{}
+
+func (testUndescribed) Validate(string) error { return nil }
+
+func (testUndescribed) Params() map[string]any { return map[string]any{"n": 1} }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name string
+		rule any
+		want RuleInfo
+	}{
+		{
+			name: "parameterized",
+			rule: Between(1, 10),
+			want: RuleInfo{Name: "between", Params: map[string]any{"min": 1, "max": 10}, Message: ErrBetween.Error()},
+		},
+		{
+			name: "custom message",
+			rule: Min(18).Errf("You must be an adult"),
+			want: RuleInfo{Name: "min", Params: map[string]any{"min": 18}, Message: "You must be an adult"},
+		},
+		{
+			name: "sentinel by option",
+			rule: NotIn("root"),
+			want: RuleInfo{Name: "not_in", Params: Describe(NotIn("root")).Params, Message: ErrNotIn.Error()},
+		},
+		{
+			name: "format rule",
+			rule: IsEmail(),
+			want: RuleInfo{Name: "email", Message: ErrEmail.Error()},
+		},
+		{
+			name: "coerce float",
+			rule: CoerceFloat(nil),
+			want: RuleInfo{Name: "coerce_float", Message: ErrCoerceFloat.Error()},
+		},
+		{
+			name: "combinator",
+			rule: Optional[int](And[int](Min(1), Even[int]())),
+			want: RuleInfo{Name: "optional", Rules: []RuleInfo{{
+				Name:    "and",
+				Message: ErrCondition.Error(),
+				Rules: []RuleInfo{
+					{Name: "min", Params: map[string]any{"min": 1}, Message: ErrMin.Error()},
+					{Name: "even", Message: ErrEven.Error()},
+				},
+			}}},
+		},
+		{
+			name: "required deref",
+			rule: Deref[int]().Required(),
+			want: RuleInfo{Name: "deref", Message: ErrRequired.Error()},
+		},
+		{
+			name: "undescribed",
+			rule: testUndescribed{},
+			want: RuleInfo{Name: "rule.testUndescribed", Params: map[string]any{"n": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Describe(tt.rule))
+		})
+	}
+}
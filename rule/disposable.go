@@ -0,0 +1,223 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rule rejecting email addresses of disposable mail providers.
+package rule
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDisposableEmail is returned when an email address belongs to a disposable mail provider
+	ErrDisposableEmail = newError("disposable_email", "disposable email addresses are not allowed")
+
+	// ErrNoDomainSource is returned by NotDisposableEmail when no blocklist was set with Source.
+	ErrNoDomainSource = newError("domain_source", "no domain blocklist is set")
+)
+
+// DomainSource is the interface implemented by domain blocklists.
+// Contains reports whether a domain, in lower case, is on the list.
+//
+// Example:
+//
+//	type BlockedDomains struct{ db *sql.DB }
+//
+//	func (s BlockedDomains) Contains(domain string) bool {
+//	    var n int
+//	    s.db.QueryRow("SELECT COUNT(*) FROM blocked_domains WHERE domain = ?", domain).Scan(&n)
+//	    return n > 0
+//	}
+//
+//	rule := NotDisposableEmail().Source(BlockedDomains{db})
+type DomainSource interface {
+	Contains(domain string) bool
+}
+
+// DomainList is a DomainSource backed by a set of domains. A domain on the list also
+// matches its subdomains. It is safe for concurrent use, so it can be refreshed with Load
+// while rules are validating, for example from a periodically downloaded copy of a
+// maintained list such as https://github.com/disposable-email-domains/disposable-email-domains.
+//
+// Example:
+//
+//	list := NewDomainList("mailinator.com")
+//	ok := list.Contains("eu.mailinator.com")  // true
+type DomainList struct {
+	mu      sync.RWMutex
+	domains map[string]struct{}
+}
+
+// NewDomainList creates a new DomainList with the given domains.
+//
+// Example:
+//
+//	rule := NotDisposableEmail().Source(NewDomainList("spam.example", "trash.example"))
+func NewDomainList(domains ...string) *DomainList {
+	l := &DomainList{domains: make(map[string]struct{}, len(domains))}
+	return l.Add(domains...)
+}
+
+// Add adds domains to the list.
+//
+// Example:
+//
+//	list := NewDomainList("mailinator.com").Add("throwaway.example")
+func (l *DomainList) Add(domains ...string) *DomainList {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, domain := range domains {
+		if domain = normalizeListedDomain(domain); domain != "" {
+			l.domains[domain] = struct{}{}
+		}
+	}
+	return l
+}
+
+// Load replaces the domains of the list with those read from r, one per line. Blank
+// lines and lines starting with # are ignored. If reading fails, the list is unchanged.
+//
+// Example:
+//
+//	f, err := os.Open("disposable_domains.txt")
+//	if err == nil {
+//	    defer f.Close()
+//	    err = list.Load(f)
+//	}
+func (l *DomainList) Load(r io.Reader) error {
+	domains := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if domain := normalizeListedDomain(line); domain != "" {
+			domains[domain] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.domains = domains
+	l.mu.Unlock()
+	return nil
+}
+
+// Contains reports whether domain or one of its parent domains is on the list.
+// Domains are compared case-insensitively.
+func (l *DomainList) Contains(domain string) bool {
+	domain = normalizeListedDomain(domain)
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for domain != "" {
+		if _, ok := l.domains[domain]; ok {
+			return true
+		}
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+	return false
+}
+
+// Len returns the number of domains on the list.
+func (l *DomainList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.domains)
+}
+
+// normalizeListedDomain returns domain in lower case without surrounding spaces and a
+// trailing dot.
+func normalizeListedDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// NotDisposableEmailRule validates that an email address does not belong to a disposable
+// mail provider, such as mailinator.com. Only the domain after the last @ is checked;
+// combine the rule with IsEmail to validate the address itself. Providers come and go,
+// so no list is built in: the blocklist must be set with Source.
+//
+// Example:
+//
+//	rule := NotDisposableEmail().Source(NewDomainList("mailinator.com"))
+//	err := rule.Validate("john@example.com")    // returns nil
+//	err = rule.Validate("john@mailinator.com")  // returns ErrDisposableEmail
+type NotDisposableEmailRule struct {
+	source DomainSource
+	e      error
+}
+
+// NotDisposableEmail creates a rule that rejects the domains of the blocklist set with
+// Source. Without a blocklist, Validate returns ErrNoDomainSource for every address.
+//
+// Example:
+//
+//	disposable := NewDomainList()
+//	err := disposable.Load(f)  // f is a downloaded list, one domain per line
+//	arbiter.Field(&user.Email, rule.IsEmail(), rule.NotDisposableEmail().Source(disposable))
+func NotDisposableEmail() *NotDisposableEmailRule {
+	return &NotDisposableEmailRule{e: ErrDisposableEmail}
+}
+
+// Source sets the blocklist the rule checks.
+//
+// Example:
+//
+//	list := NewDomainList("competitor.example")
+//	rule := NotDisposableEmail().Source(list)
+func (r *NotDisposableEmailRule) Source(source DomainSource) *NotDisposableEmailRule {
+	if source != nil {
+		r.source = source
+	}
+	return r
+}
+
+// Validate checks that the domain of the email address is not on the blocklist.
+// Returns nil if the address is allowed, empty or has no @, ErrNoDomainSource if no
+// blocklist is set, or an error otherwise.
+//
+// Example:
+//
+//	rule := NotDisposableEmail().Source(NewDomainList("yopmail.com"))
+//	err := rule.Validate("john@gmail.com")     // returns nil
+//	err = rule.Validate("john@YOPMAIL.com")    // returns ErrDisposableEmail
+func (r *NotDisposableEmailRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	at := strings.LastIndexByte(value, '@')
+	if at < 0 {
+		return nil
+	}
+	if r.source == nil {
+		return ErrNoDomainSource
+	}
+	if r.source.Contains(strings.ToLower(value[at+1:])) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrDisposableEmail
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NotDisposableEmailRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDisposableEmail)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := NotDisposableEmail().Source(list).Errf("Please use a permanent email address")
+func (r *NotDisposableEmailRule) Errf(format string, args ...any) *NotDisposableEmailRule {
+	if format != "" {
+		r.e = wrapf(ErrDisposableEmail, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,69 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotDisposableEmail(t *testing.T) {
+	list := NewDomainList("mailinator.com", "yopmail.com", "guerrillamail.com")
+	tests := []struct {
+		name    string
+		rule    *NotDisposableEmailRule
+		value   string
+		wantErr error
+	}{
+		{name: "valid: regular provider", rule: NotDisposableEmail().Source(list), value: "john@gmail.com"},
+		{name: "valid: empty string", rule: NotDisposableEmail().Source(list), value: ""},
+		{name: "valid: no at sign", rule: NotDisposableEmail().Source(list), value: "mailinator.com"},
+		{name: "valid: listed domain in local part", rule: NotDisposableEmail().Source(list), value: "mailinator.com@example.com"},
+		{name: "invalid: listed domain", rule: NotDisposableEmail().Source(list), value: "john@mailinator.com", wantErr: ErrDisposableEmail},
+		{name: "invalid: upper case", rule: NotDisposableEmail().Source(list), value: "john@YopMail.COM", wantErr: ErrDisposableEmail},
+		{name: "invalid: subdomain", rule: NotDisposableEmail().Source(list), value: "john@eu.guerrillamail.com", wantErr: ErrDisposableEmail},
+		{name: "valid: other source", rule: NotDisposableEmail().Source(NewDomainList("spam.example")), value: "john@mailinator.com"},
+		{name: "invalid: other source", rule: NotDisposableEmail().Source(NewDomainList("spam.example")), value: "john@spam.example", wantErr: ErrDisposableEmail},
+		{name: "invalid: no source", rule: NotDisposableEmail(), value: "john@gmail.com", wantErr: ErrNoDomainSource},
+		{name: "invalid: nil source", rule: NotDisposableEmail().Source(nil), value: "john@gmail.com", wantErr: ErrNoDomainSource},
+		{name: "valid: empty string without source", rule: NotDisposableEmail(), value: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	err := NotDisposableEmail().Source(list).Errf("use a permanent address").Validate("john@mailinator.com")
+	assert.EqualError(t, err, "use a permanent address")
+	assert.ErrorIs(t, err, ErrDisposableEmail)
+}
+
+func TestDomainList(t *testing.T) {
+	list := NewDomainList("Spam.Example.", "")
+	assert.Equal(t, 1, list.Len())
+	assert.True(t, list.Contains("spam.example"))
+	assert.True(t, list.Contains("a.b.SPAM.example"))
+	assert.False(t, list.Contains("example"))
+	assert.False(t, list.Contains("notspam.example"))
+
+	list.Add("trash.example")
+	assert.True(t, list.Contains("trash.example"))
+
+	assert.Nil(t, list.Load(strings.NewReader("# comment\n\n  junk.example  \nJUNK.example\n")))
+	assert.Equal(t, 1, list.Len())
+	assert.True(t, list.Contains("junk.example"))
+	assert.False(t, list.Contains("spam.example"))
+
+	failing := iotest.ErrReader(errors.New("read failed"))
+	assert.EqualError(t, list.Load(failing), "read failed")
+	assert.True(t, list.Contains("junk.example"))
+}
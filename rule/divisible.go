@@ -2,12 +2,11 @@ package rule
 
 import (
 	"errors"
-	"fmt"
 	"math"
 )
 
 // ErrDivisibleBy is returned when a value is not divisible by the specified number
-var ErrDivisibleBy = errors.New("value is not divisible by the specified number")
+var ErrDivisibleBy = newError("divisible_by", "value is not divisible by the specified number")
 
 // DivisibleByRule represents a validation rule that checks if a number is divisible by a given divisor
 // Example: DivisibleBy(2) will validate that a number is divisible by 2 (even numbers)
@@ -48,11 +47,25 @@ func (r *DivisibleByRule) Validate(value float64) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *DivisibleByRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDivisibleBy)
+}
+
 // Errf sets a custom error message for the rule using a format string
 // Example: rule.Errf("Number %v must be divisible by %v", value, divisor)
 func (r *DivisibleByRule) Errf(format string, args ...any) *DivisibleByRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := DivisibleBy(0.5).Params()  // map[string]any{"divisor": 0.5}
+func (r *DivisibleByRule) Params() map[string]any {
+	return map[string]any{"divisor": r.divisor}
+}
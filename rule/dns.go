@@ -0,0 +1,247 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rule checking that a domain name resolves in DNS.
+package rule
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnresolvableDomain is returned when a domain name has no A or AAAA records
+var ErrUnresolvableDomain = newError("unresolvable_domain", "domain name does not resolve")
+
+const (
+	// defaultResolveTimeout is the time a ResolvableDomain lookup may take by default.
+	defaultResolveTimeout = 3 * time.Second
+	// defaultResolveCacheTTL is the time ResolvableDomain caches a result by default.
+	defaultResolveCacheTTL = 5 * time.Minute
+	// maxResolveCacheEntries bounds the results cached by one ResolvableDomain rule; the
+	// least recently used result is evicted first.
+	maxResolveCacheEntries = 1024
+)
+
+// HostResolver is the interface implemented by DNS resolvers, such as *net.Resolver.
+// LookupIPAddr returns the IPv4 and IPv6 addresses of a host.
+//
+// Example:
+//
+//	resolver := &net.Resolver{
+//	    PreferGo: true,
+//	    Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+//	        return (&net.Dialer{}).DialContext(ctx, network, "1.1.1.1:53")
+//	    },
+//	}
+//	rule := ResolvableDomain().Resolver(resolver)
+type HostResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// resolveResult is a cached lookup result.
+type resolveResult struct {
+	domain  string
+	ok      bool
+	expires time.Time
+}
+
+// ResolvableDomainRule validates that a domain name has at least one A or AAAA record,
+// for flows such as webhook registration or custom-domain onboarding where the domain
+// must already point somewhere. Lookups are bounded by a timeout, and their results are
+// cached, so that validating the same domain again does not query DNS. IP addresses such
+// as "127.0.0.1" are not domain names and fail validation without a lookup.
+//
+// Lookups that fail for reasons other than the domain not existing, such as timeouts,
+// fail validation but are not cached. Validate looks domains up with context.Background;
+// use ValidateContext, or the arbiter.Context option of ValidateStruct, to cancel the
+// lookup with the request.
+//
+// Example:
+//
+//	rule := ResolvableDomain()
+//	err := rule.Validate("example.com")             // returns nil
+//	err = rule.Validate("does-not-exist.invalid")  // returns ErrUnresolvableDomain
+type ResolvableDomainRule struct {
+	resolver HostResolver
+	timeout  time.Duration
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// cache maps domains to their elements in lru, which holds resolveResults from the
+	// most to the least recently used.
+	cache map[string]*list.Element
+	lru   list.List
+
+	e error
+}
+
+// ResolvableDomain creates a rule that resolves domain names with net.DefaultResolver,
+// a timeout of 3 seconds and results cached for 5 minutes. The rule is meant to be
+// created once and reused, so that its cache is shared between validations.
+//
+// Example:
+//
+//	var webhookDomain = rule.ResolvableDomain().Timeout(time.Second)
+//
+//	arbiter.Field(&hook.Host, rule.Domain(), webhookDomain)
+func ResolvableDomain() *ResolvableDomainRule {
+	return &ResolvableDomainRule{
+		resolver: net.DefaultResolver,
+		timeout:  defaultResolveTimeout,
+		ttl:      defaultResolveCacheTTL,
+		now:      time.Now,
+		e:        ErrUnresolvableDomain,
+	}
+}
+
+// Resolver sets the resolver used for lookups, which is net.DefaultResolver by default.
+//
+// Example:
+//
+//	rule := ResolvableDomain().Resolver(&net.Resolver{PreferGo: true})
+func (r *ResolvableDomainRule) Resolver(resolver HostResolver) *ResolvableDomainRule {
+	if resolver != nil {
+		r.resolver = resolver
+	}
+	return r
+}
+
+// Timeout sets the time a lookup may take before the domain is reported as unresolvable.
+// Values less than or equal to zero are ignored.
+//
+// Example:
+//
+//	rule := ResolvableDomain().Timeout(500 * time.Millisecond)
+func (r *ResolvableDomainRule) Timeout(timeout time.Duration) *ResolvableDomainRule {
+	if timeout > 0 {
+		r.timeout = timeout
+	}
+	return r
+}
+
+// CacheTTL sets how long lookup results are cached. Zero disables the cache.
+//
+// Example:
+//
+//	rule := ResolvableDomain().CacheTTL(time.Hour)
+func (r *ResolvableDomainRule) CacheTTL(ttl time.Duration) *ResolvableDomainRule {
+	r.ttl = max(ttl, 0)
+	return r
+}
+
+// Validate checks if the domain name resolves to at least one IP address.
+// Returns nil if the domain resolves or is empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := ResolvableDomain()
+//	err := rule.Validate("localhost")  // returns nil
+//	err = rule.Validate("bad domain")  // returns ErrUnresolvableDomain (not looked up)
+func (r *ResolvableDomainRule) Validate(value string) error {
+	return r.ValidateContext(context.Background(), value)
+}
+
+// ValidateContext checks if the domain name resolves like Validate, cancelling the lookup
+// when ctx is done.
+//
+// Example:
+//
+//	rule := ResolvableDomain()
+//	err := rule.ValidateContext(r.Context(), "example.com")  // returns nil
+func (r *ResolvableDomainRule) ValidateContext(ctx context.Context, value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	domain := strings.TrimSuffix(strings.ToLower(value), ".")
+	if !r.resolves(ctx, domain) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrUnresolvableDomain
+	}
+	return nil
+}
+
+// resolves looks up domain, using and updating the cache.
+func (r *ResolvableDomainRule) resolves(ctx context.Context, domain string) bool {
+	if domain == "" || len(domain) > 253 || strings.ContainsAny(domain, " /:@") || net.ParseIP(domain) != nil {
+		return false
+	}
+	now := Ternary(r.now == nil, time.Now, r.now)()
+	if ok, cached := r.cached(domain, now); cached {
+		return ok
+	}
+
+	resolver := Ternary[HostResolver](r.resolver != nil, r.resolver, net.DefaultResolver)
+	ctx, cancel := context.WithTimeout(ctx, Ternary(r.timeout > 0, r.timeout, defaultResolveTimeout))
+	defer cancel()
+	addrs, err := resolver.LookupIPAddr(ctx, domain)
+
+	resolved := err == nil && len(addrs) > 0
+	var dnsErr *net.DNSError
+	if r.ttl > 0 && (err == nil || errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		r.store(resolveResult{domain: domain, ok: resolved, expires: now.Add(r.ttl)})
+	}
+	return resolved
+}
+
+// cached returns the cached result for domain, and whether there is one that has not
+// expired at now. Expired results are evicted.
+func (r *ResolvableDomainRule) cached(domain string, now time.Time) (ok, found bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elem, found := r.cache[domain]
+	if !found {
+		return false, false
+	}
+	result := elem.Value.(resolveResult)
+	if !now.Before(result.expires) {
+		r.lru.Remove(elem)
+		delete(r.cache, domain)
+		return false, false
+	}
+	r.lru.MoveToFront(elem)
+	return result.ok, true
+}
+
+// store caches result, evicting the least recently used result if the cache is full.
+func (r *ResolvableDomainRule) store(result resolveResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cache == nil {
+		r.cache = make(map[string]*list.Element)
+	}
+	if elem, ok := r.cache[result.domain]; ok {
+		elem.Value = result
+		r.lru.MoveToFront(elem)
+		return
+	}
+	if r.lru.Len() >= maxResolveCacheEntries {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.cache, oldest.Value.(resolveResult).domain)
+	}
+	r.cache[result.domain] = r.lru.PushFront(result)
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ResolvableDomainRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrUnresolvableDomain)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := ResolvableDomain().Errf("Point your domain at our servers before adding it")
+func (r *ResolvableDomainRule) Errf(format string, args ...any) *ResolvableDomainRule {
+	if format != "" {
+		r.e = wrapf(ErrUnresolvableDomain, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,147 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResolver resolves the hosts of its map and counts the lookups.
+type fakeResolver struct {
+	hosts   map[string][]net.IPAddr
+	err     error
+	lookups atomic.Int32
+}
+
+func (f *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	f.lookups.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if addrs, ok := f.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func newFakeResolver() *fakeResolver {
+	return &fakeResolver{hosts: map[string][]net.IPAddr{
+		"example.com": {{IP: net.ParseIP("93.184.215.14")}},
+		"ipv6.test":   {{IP: net.ParseIP("2001:db8::1")}},
+		"empty.test":  {},
+	}}
+}
+
+func TestResolvableDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: A record", value: "example.com", wantErr: false},
+		{name: "valid: AAAA record", value: "ipv6.test", wantErr: false},
+		{name: "valid: upper case and trailing dot", value: "EXAMPLE.com.", wantErr: false},
+		{name: "valid: empty string", value: "", wantErr: false},
+		{name: "invalid: not found", value: "missing.test", wantErr: true},
+		{name: "invalid: no addresses", value: "empty.test", wantErr: true},
+		{name: "invalid: not a domain", value: "https://example.com", wantErr: true},
+		{name: "invalid: IPv4 address", value: "127.0.0.1", wantErr: true},
+		{name: "invalid: IPv6 address", value: "::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ResolvableDomain().Resolver(newFakeResolver()).Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolvableDomain().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrUnresolvableDomain)
+			}
+		})
+	}
+}
+
+func TestResolvableDomainCache(t *testing.T) {
+	resolver := newFakeResolver()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := ResolvableDomain().Resolver(resolver).CacheTTL(time.Minute)
+	r.now = func() time.Time { return now }
+
+	assert.Nil(t, r.Validate("example.com"))
+	assert.Nil(t, r.Validate("Example.com"))
+	assert.Error(t, r.Validate("missing.test"))
+	assert.Error(t, r.Validate("missing.test"))
+	assert.Equal(t, int32(2), resolver.lookups.Load())
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, r.Validate("example.com"))
+	assert.Equal(t, int32(3), resolver.lookups.Load())
+
+	// Without a cache, every validation looks the domain up.
+	uncached := ResolvableDomain().Resolver(resolver).CacheTTL(0)
+	assert.Nil(t, uncached.Validate("example.com"))
+	assert.Nil(t, uncached.Validate("example.com"))
+	assert.Equal(t, int32(5), resolver.lookups.Load())
+
+	// Temporary failures are not cached.
+	failing := &fakeResolver{err: errors.New("server misbehaving")}
+	r = ResolvableDomain().Resolver(failing)
+	assert.Error(t, r.Validate("example.com"))
+	assert.Error(t, r.Validate("example.com"))
+	assert.Equal(t, int32(2), failing.lookups.Load())
+}
+
+// slowResolver blocks until the context is done.
+type slowResolver struct{}
+
+func (slowResolver) LookupIPAddr(ctx context.Context, _ string) ([]net.IPAddr, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestResolvableDomainTimeout(t *testing.T) {
+	start := time.Now()
+	err := ResolvableDomain().Resolver(slowResolver{}).Timeout(20 * time.Millisecond).Validate("example.com")
+	assert.ErrorIs(t, err, ErrUnresolvableDomain)
+	assert.Less(t, time.Since(start), time.Second)
+
+	err = ResolvableDomain().Resolver(slowResolver{}).Timeout(10*time.Millisecond).
+		Errf("%s does not resolve", "domain").Validate("example.com")
+	assert.EqualError(t, err, "domain does not resolve")
+}
+
+func TestResolvableDomainEviction(t *testing.T) {
+	resolver := newFakeResolver()
+	r := ResolvableDomain().Resolver(resolver)
+	assert.Nil(t, r.Validate("example.com"))
+	for i := range maxResolveCacheEntries {
+		assert.Error(t, r.Validate(fmt.Sprintf("missing-%d.test", i)))
+		// Keep example.com recently used
+		assert.Nil(t, r.Validate("example.com"))
+	}
+	assert.Equal(t, int32(maxResolveCacheEntries+1), resolver.lookups.Load())
+	assert.Equal(t, maxResolveCacheEntries, len(r.cache))
+
+	// The least recently used result was evicted, the others are still cached.
+	assert.Error(t, r.Validate("missing-0.test"))
+	assert.Error(t, r.Validate("missing-2.test"))
+	assert.Equal(t, int32(maxResolveCacheEntries+2), resolver.lookups.Load())
+}
+
+func TestResolvableDomainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := ResolvableDomain().Resolver(slowResolver{}).ValidateContext(ctx, "example.com")
+	assert.ErrorIs(t, err, ErrUnresolvableDomain)
+	assert.Less(t, time.Since(start), time.Second)
+
+	var _ ContextRule[string] = ResolvableDomain()
+}
@@ -0,0 +1,334 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating time.Duration values and duration strings.
+package rule
+
+import (
+	"time"
+)
+
+// Duration validation errors
+var (
+	// ErrDurationMin is returned when a duration is shorter than the minimum allowed duration.
+	ErrDurationMin = newError("duration_min", "duration is shorter than minimum")
+
+	// ErrDurationMax is returned when a duration is longer than the maximum allowed duration.
+	ErrDurationMax = newError("duration_max", "duration is longer than maximum")
+
+	// ErrDurationFormat is returned when a string cannot be parsed by time.ParseDuration.
+	ErrDurationFormat = newError("duration_format", "invalid duration format")
+
+	// ErrDurationBetween is returned when a duration is not within the range. Its message includes the range.
+	ErrDurationBetween = newError("duration_between", "duration is not between minimum and maximum")
+)
+
+const (
+	// ErrDurationBetweenFormat is the format string for duration range validation errors.
+	ErrDurationBetweenFormat = "duration is not between %v and %v"
+)
+
+// DurationBetweenRule validates that a duration falls within an inclusive range.
+//
+// Example:
+//
+//	rule := DurationBetween(time.Second, time.Minute)
+//	err := rule.Validate(30 * time.Second)  // returns nil
+//	err = rule.Validate(2 * time.Minute)    // returns error
+type DurationBetweenRule struct {
+	min time.Duration
+	max time.Duration
+	e   error
+}
+
+// DurationBetween creates a new duration range validation rule.
+// The rule ensures that a duration is between min and max (inclusive).
+//
+// Example:
+//
+//	type Config struct {
+//	    Timeout time.Duration
+//	}
+//
+//	rule := DurationBetween(100*time.Millisecond, 30*time.Second).Errf("Timeout must be between 100ms and 30s")
+func DurationBetween(min, max time.Duration) *DurationBetweenRule {
+	return &DurationBetweenRule{
+		min: min,
+		max: max,
+	}
+}
+
+// Validate checks if the duration is between the minimum and maximum (inclusive).
+// Returns nil if the duration is valid, or an error otherwise.
+//
+// Example:
+//
+//	rule := DurationBetween(time.Second, time.Minute)
+//	err := rule.Validate(time.Second)      // returns nil
+//	err = rule.Validate(time.Millisecond)  // returns error
+func (r *DurationBetweenRule) Validate(value time.Duration) error {
+	if value < r.min || value > r.max {
+		if r.e != nil {
+			return r.e
+		}
+		return formatError(ErrDurationBetween, ErrDurationBetweenFormat, r.min, r.max)
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DurationBetweenRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDurationBetween)
+}
+
+// Errf sets a custom error message for duration range validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := DurationBetween(time.Second, time.Minute).Errf("Interval must be between 1s and 1m")
+func (r *DurationBetweenRule) Errf(format string, args ...any) *DurationBetweenRule {
+	if format != "" {
+		r.e = wrapf(ErrDurationBetween, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := DurationBetween(time.Second, time.Minute).Params()  // map[string]any{"min": "1s", "max": "1m0s"}
+func (r *DurationBetweenRule) Params() map[string]any {
+	return map[string]any{"min": r.min.String(), "max": r.max.String()}
+}
+
+// DurationMinRule validates that a duration is greater than or equal to a minimum duration.
+//
+// Example:
+//
+//	rule := DurationMin(time.Second)
+//	err := rule.Validate(2 * time.Second)        // returns nil
+//	err = rule.Validate(500 * time.Millisecond)  // returns error
+type DurationMinRule struct {
+	min time.Duration
+	e   error
+}
+
+// DurationMin creates a new minimum duration validation rule.
+//
+// Example:
+//
+//	rule := DurationMin(time.Second).Errf("Poll interval must be at least 1s")
+func DurationMin(min time.Duration) *DurationMinRule {
+	return &DurationMinRule{
+		min: min,
+		e:   ErrDurationMin,
+	}
+}
+
+// Validate checks if the duration is greater than or equal to the minimum.
+// Returns nil if the duration is valid, or an error otherwise.
+//
+// Example:
+//
+//	rule := DurationMin(time.Second)
+//	err := rule.Validate(time.Second)  // returns nil
+//	err = rule.Validate(0)             // returns error
+func (r *DurationMinRule) Validate(value time.Duration) error {
+	if value < r.min {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrDurationMin
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DurationMinRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDurationMin)
+}
+
+// Errf sets a custom error message for minimum duration validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := DurationMin(time.Second).Errf("Retry delay must be at least 1s")
+func (r *DurationMinRule) Errf(format string, args ...any) *DurationMinRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := DurationMin(time.Second).Params()  // map[string]any{"min": "1s"}
+func (r *DurationMinRule) Params() map[string]any {
+	return map[string]any{"min": r.min.String()}
+}
+
+// DurationMaxRule validates that a duration is less than or equal to a maximum duration.
+//
+// Example:
+//
+//	rule := DurationMax(time.Minute)
+//	err := rule.Validate(30 * time.Second)  // returns nil
+//	err = rule.Validate(time.Hour)          // returns error
+type DurationMaxRule struct {
+	max time.Duration
+	e   error
+}
+
+// DurationMax creates a new maximum duration validation rule.
+//
+// Example:
+//
+//	rule := DurationMax(time.Minute).Errf("Timeout cannot exceed 1m")
+func DurationMax(max time.Duration) *DurationMaxRule {
+	return &DurationMaxRule{
+		max: max,
+		e:   ErrDurationMax,
+	}
+}
+
+// Validate checks if the duration is less than or equal to the maximum.
+// Returns nil if the duration is valid, or an error otherwise.
+//
+// Example:
+//
+//	rule := DurationMax(time.Minute)
+//	err := rule.Validate(time.Minute)  // returns nil
+//	err = rule.Validate(time.Hour)     // returns error
+func (r *DurationMaxRule) Validate(value time.Duration) error {
+	if value > r.max {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrDurationMax
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DurationMaxRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDurationMax)
+}
+
+// Errf sets a custom error message for maximum duration validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := DurationMax(time.Minute).Errf("Session timeout cannot exceed 1m")
+func (r *DurationMaxRule) Errf(format string, args ...any) *DurationMaxRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := DurationMax(time.Minute).Params()  // map[string]any{"max": "1m0s"}
+func (r *DurationMaxRule) Params() map[string]any {
+	return map[string]any{"max": r.max.String()}
+}
+
+// DurationStringRule validates that a string is a duration accepted by time.ParseDuration
+// (e.g. "300ms", "1h30m") and that the parsed duration falls within an inclusive range.
+//
+// Example:
+//
+//	rule := DurationString(time.Minute, 2*time.Hour)
+//	err := rule.Validate("1h30m")  // returns nil
+//	err = rule.Validate("3h")      // returns error (out of range)
+//	err = rule.Validate("90")      // returns ErrDurationFormat (missing unit)
+type DurationStringRule struct {
+	min     time.Duration
+	max     time.Duration
+	e       error
+	formatE error
+}
+
+// DurationString creates a new duration string validation rule.
+// The rule parses the string with time.ParseDuration and ensures that the result is
+// between min and max (inclusive).
+//
+// Example:
+//
+//	type Config struct {
+//	    Interval string `yaml:"interval"`
+//	}
+//
+//	rule := DurationString(time.Second, 24*time.Hour).Errf("Interval must be between 1s and 24h")
+func DurationString(min, max time.Duration) *DurationStringRule {
+	return &DurationStringRule{
+		min:     min,
+		max:     max,
+		formatE: ErrDurationFormat,
+	}
+}
+
+// Validate checks if the string is a valid duration within the specified range.
+// Empty strings are considered valid (use Required() if needed).
+// A custom error set via Errf is returned for both parse and range failures.
+//
+// Example:
+//
+//	rule := DurationString(time.Second, time.Minute)
+//	err := rule.Validate("30s")   // returns nil
+//	err = rule.Validate("2m")     // returns error
+//	err = rule.Validate("later")  // returns error
+//	err = rule.Validate("")       // returns nil (empty string is valid)
+func (r *DurationStringRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		if r.formatE != nil {
+			return r.formatE
+		}
+		return ErrDurationFormat
+	}
+	if d < r.min || d > r.max {
+		if r.e != nil {
+			return r.e
+		}
+		return formatError(ErrDurationBetween, ErrDurationBetweenFormat, r.min, r.max)
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DurationStringRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDurationBetween)
+}
+
+// Errf sets a custom error message for duration string validation failures.
+// The message replaces both the format and the range error.
+//
+// Example:
+//
+//	rule := DurationString(time.Second, time.Minute).Errf("Interval must be a duration between 1s and 1m")
+func (r *DurationStringRule) Errf(format string, args ...any) *DurationStringRule {
+	if format != "" {
+		r.e = wrapf(ErrDurationBetween, format, args...)
+		r.formatE = wrapf(r.formatE, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := DurationString(time.Second, time.Minute).Params()  // map[string]any{"min": "1s", "max": "1m0s"}
+func (r *DurationStringRule) Params() map[string]any {
+	return map[string]any{"min": r.min.String(), "max": r.max.String()}
+}
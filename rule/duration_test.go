@@ -0,0 +1,82 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *DurationBetweenRule
+		value   time.Duration
+		wantErr bool
+	}{
+		{name: "valid: within range", rule: DurationBetween(time.Second, time.Minute), value: 30 * time.Second, wantErr: false},
+		{name: "valid: equal to min", rule: DurationBetween(time.Second, time.Minute), value: time.Second, wantErr: false},
+		{name: "valid: equal to max", rule: DurationBetween(time.Second, time.Minute), value: time.Minute, wantErr: false},
+		{name: "invalid: below min", rule: DurationBetween(time.Second, time.Minute), value: time.Millisecond, wantErr: true},
+		{name: "invalid: above max", rule: DurationBetween(time.Second, time.Minute), value: time.Hour, wantErr: true},
+		{name: "custom error message", rule: DurationBetween(time.Second, time.Minute).Errf("custom error"), value: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DurationBetweenRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.EqualError(t, DurationBetween(time.Second, time.Minute).Validate(0), "duration is not between 1s and 1m0s")
+}
+
+func TestDurationMinMax(t *testing.T) {
+	assert.Nil(t, DurationMin(time.Second).Validate(time.Second))
+	assert.ErrorIs(t, DurationMin(time.Second).Validate(time.Millisecond), ErrDurationMin)
+	assert.EqualError(t, DurationMin(time.Second).Errf("too short").Validate(0), "too short")
+
+	assert.Nil(t, DurationMax(time.Minute).Validate(time.Minute))
+	assert.ErrorIs(t, DurationMax(time.Minute).Validate(time.Hour), ErrDurationMax)
+	assert.EqualError(t, DurationMax(time.Minute).Errf("too long").Validate(time.Hour), "too long")
+}
+
+func TestDurationString(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *DurationStringRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: compound duration", rule: DurationString(time.Minute, 2*time.Hour), value: "1h30m", wantErr: false},
+		{name: "valid: milliseconds", rule: DurationString(0, time.Second), value: "300ms", wantErr: false},
+		{name: "valid: empty string", rule: DurationString(time.Minute, time.Hour), value: "", wantErr: false},
+		{name: "invalid: out of range", rule: DurationString(time.Minute, 2*time.Hour), value: "3h", wantErr: true},
+		{name: "invalid: missing unit", rule: DurationString(time.Minute, 2*time.Hour), value: "90", wantErr: true},
+		{name: "invalid: not a duration", rule: DurationString(time.Minute, 2*time.Hour), value: "later", wantErr: true},
+		{name: "custom error message", rule: DurationString(time.Minute, time.Hour).Errf("custom error"), value: "1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DurationStringRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, DurationString(time.Minute, time.Hour).Validate("soon"), ErrDurationFormat)
+	assert.EqualError(t, DurationString(time.Minute, time.Hour).Errf("custom error").Validate("soon"), "custom error")
+}
+
+func TestDurationFallback(t *testing.T) {
+	assert.Error(t, (&DurationBetweenRule{min: time.Second, max: time.Minute}).Validate(0))
+	assert.ErrorIs(t, (&DurationMinRule{min: time.Second}).Validate(0), ErrDurationMin)
+	assert.ErrorIs(t, (&DurationMaxRule{max: time.Second}).Validate(time.Minute), ErrDurationMax)
+	assert.ErrorIs(t, (&DurationStringRule{}).Validate("soon"), ErrDurationFormat)
+	assert.Error(t, (&DurationStringRule{max: time.Second}).Validate("1m"))
+}
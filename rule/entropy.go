@@ -0,0 +1,464 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains a pattern-aware password entropy estimator and the PasswordEntropy rule.
+package rule
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ErrPasswordEntropy is returned when the estimated entropy of a password is below the required minimum.
+var ErrPasswordEntropy = newError("password_entropy", "password is too easy to guess")
+
+// maxRepeatUnit bounds the length of the chunks detected by repeated chunk matching
+// such as "abcabc", which keeps the estimate linear in the length of the password.
+const maxRepeatUnit = 32
+
+// commonPasswords are frequently used passwords and words, ordered by popularity.
+// A match costs log2 of its rank, so earlier entries are cheaper to guess.
+var commonPasswords = []string{
+	"password", "123456", "qwerty", "111111", "abc123", "letmein", "monkey", "dragon",
+	"iloveyou", "admin", "welcome", "login", "princess", "sunshine", "football", "baseball",
+	"master", "shadow", "superman", "trustno1", "michael", "jennifer", "hunter", "starwars",
+	"batman", "freedom", "whatever", "hello", "charlie", "secret", "access", "flower",
+	"mustang", "passw0rd", "qazwsx", "ninja", "azerty", "solo", "loveme", "killer",
+	"jordan", "harley", "ranger", "buster", "thomas", "tigger", "robert", "soccer",
+	"hockey", "george", "andrew", "asshole", "fuckyou", "pepper", "daniel", "summer",
+	"winter", "spring", "autumn", "orange", "banana", "cookie", "chocolate", "computer",
+	"internet", "samsung", "google", "apple", "love", "angel", "lovely", "family",
+	"friend", "friends", "money", "golf", "tennis", "maggie", "ginger", "cheese",
+	"matrix", "silver", "golden", "diamond", "purple", "yellow", "black", "white",
+	"blue", "green", "red", "king", "queen", "prince", "god", "jesus",
+	"heaven", "forever", "blessed", "happy", "smile", "sunny", "baby", "peanut",
+	"tiger", "lion", "eagle", "bear", "wolf", "horse", "pussy", "dog",
+	"cat", "fish", "test", "guest", "user", "root", "default", "changeme",
+	"pass", "letmein1", "welcome1", "password1", "qwertyuiop", "asdfgh", "zxcvbn", "iloveu",
+	"secret1", "office", "company", "server", "system", "manager", "support", "service",
+}
+
+// commonPasswordTrie holds the common passwords with their 1-based rank.
+var commonPasswordTrie = func() *wordTrie {
+	trie := &wordTrie{}
+	for i, word := range commonPasswords {
+		trie.add(word, i+1)
+	}
+	return trie
+}()
+
+// wordTrie is a prefix tree of dictionary words, which finds all words starting at a
+// position of a password in a single walk.
+type wordTrie struct {
+	children map[rune]*wordTrie
+	rank     int
+}
+
+// add inserts word with rank, keeping the lower rank of duplicates.
+func (t *wordTrie) add(word string, rank int) {
+	node := t
+	for _, c := range word {
+		child, ok := node.children[c]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*wordTrie)
+			}
+			child = &wordTrie{}
+			node.children[c] = child
+		}
+		node = child
+	}
+	if node.rank == 0 || rank < node.rank {
+		node.rank = rank
+	}
+}
+
+// match calls visit for every word of at least three runes starting at lower[start],
+// reading each rune either as itself or as a letter it is a l33t substitution for.
+// subs is the number of substitutions undone to read the word.
+func (t *wordTrie) match(lower []rune, start int, visit func(end, rank, subs int)) {
+	var walk func(node *wordTrie, end, subs int)
+	walk = func(node *wordTrie, end, subs int) {
+		if node.rank > 0 && end-start >= 3 {
+			visit(end, node.rank, subs)
+		}
+		if end == len(lower) {
+			return
+		}
+		if child, ok := node.children[lower[end]]; ok {
+			walk(child, end+1, subs)
+		}
+		for _, letter := range leetSubstitutions[lower[end]] {
+			if child, ok := node.children[letter]; ok {
+				walk(child, end+1, subs+1)
+			}
+		}
+	}
+	walk(t, start, 0)
+}
+
+// leetSubstitutions maps common character substitutions to the letters they replace.
+var leetSubstitutions = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '3': {'e'}, '6': {'g'}, '9': {'g'},
+	'1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'}, '0': {'o'}, '$': {'s'}, '5': {'s'},
+	'7': {'t'}, '+': {'t'}, '2': {'z'},
+}
+
+// keyboardRows is the QWERTY layout used to detect keyboard walks such as "qwerty" or "1qaz".
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyPosition is the position of a key on the keyboard; rows are staggered by half a key.
+type keyPosition struct {
+	x float64
+	y int
+}
+
+var keyPositions = func() map[rune]keyPosition {
+	positions := make(map[rune]keyPosition)
+	for y, row := range keyboardRows {
+		for x, key := range row {
+			positions[key] = keyPosition{x: float64(x) + 0.5*float64(y), y: y}
+		}
+	}
+	return positions
+}()
+
+// PasswordEntropyRule validates that a password has at least a minimum estimated entropy.
+// Unlike character-class rules, the estimate accounts for how passwords are actually
+// guessed: common passwords (including l33t variants), keyboard walks, alphabetical and
+// numeric sequences, repeated characters or chunks and years are all scored far below
+// random characters of the same length.
+//
+// Example:
+//
+//	rule := PasswordEntropy(50)
+//	err := rule.Validate("correct horse battery staple")  // returns nil
+//	err = rule.Validate("P@ssw0rd123!")                   // returns error (common password with suffix)
+//	err = rule.Validate("qwertyuiop")                     // returns error (keyboard walk)
+type PasswordEntropyRule struct {
+	minBits float64
+	words   *wordTrie
+	e       error
+}
+
+// PasswordEntropy creates a new password entropy validation rule requiring at least minBits
+// bits of estimated entropy. As a guideline, 30 bits resists online guessing with rate
+// limiting, 50 bits resists casual offline attacks and 70+ bits is suitable for high-value accounts.
+//
+// Example:
+//
+//	type SignUp struct {
+//	    Username string
+//	    Password string
+//	}
+//
+//	rule := PasswordEntropy(50).Dictionary(form.Username, "acme").Errf("Please choose a harder to guess password")
+func PasswordEntropy(minBits float64) *PasswordEntropyRule {
+	return &PasswordEntropyRule{
+		minBits: minBits,
+		e:       ErrPasswordEntropy,
+	}
+}
+
+// Dictionary adds words that should be treated as easy to guess, such as the user name,
+// e-mail address or product name. They are scored like the most common passwords.
+//
+// Example:
+//
+//	rule := PasswordEntropy(50).Dictionary("alice", "alice@example.com", "acme")
+func (r *PasswordEntropyRule) Dictionary(words ...string) *PasswordEntropyRule {
+	if r.words == nil {
+		r.words = &wordTrie{}
+	}
+	for _, word := range words {
+		if word = strings.ToLower(word); len(word) >= 3 {
+			r.words.add(word, 1)
+		}
+	}
+	return r
+}
+
+// Validate checks if the estimated entropy of the password reaches the minimum.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := PasswordEntropy(40)
+//	err := rule.Validate("t7#Vq9!mLp")  // returns nil
+//	err = rule.Validate("aaaaaaaaaa")   // returns error
+//	err = rule.Validate("")             // returns nil (empty string is valid)
+func (r *PasswordEntropyRule) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	if passwordEntropy(value, r.words) < r.minBits {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPasswordEntropy
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PasswordEntropyRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPasswordEntropy)
+}
+
+// Errf sets a custom error message for password entropy validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := PasswordEntropy(50).Errf("Password is too easy to guess")
+func (r *PasswordEntropyRule) Errf(format string, args ...any) *PasswordEntropyRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// MinEntropy additionally requires the password to have at least bits of estimated entropy,
+// using the same estimator as PasswordEntropy.
+//
+// Example:
+//
+//	rule := PasswordStrength().MinEntropy(50)  // character classes and entropy
+func (r *PasswordStrengthRule) MinEntropy(bits float64) *PasswordStrengthRule {
+	r.minEntropy = bits
+	return r
+}
+
+// passwordEntropy estimates the entropy of a password in bits. The password is split into
+// the cheapest sequence of matched patterns and random characters (as in zxcvbn), and the
+// entropy of each part is summed. Each pattern only yields a bounded number of matches
+// ending at a position, so the estimate takes linear time in the length of the password.
+func passwordEntropy(value string, words *wordTrie) float64 {
+	runes := []rune(value)
+	lower := make([]rune, len(runes))
+	for i, c := range runes {
+		lower[i] = unicode.ToLower(c)
+	}
+	n := len(runes)
+	perChar := math.Log2(float64(charsetSize(value)))
+
+	// Dictionary matches, indexed by the position they end at.
+	type match struct {
+		start int
+		bits  float64
+	}
+	dictionary := make([][]match, n+1)
+	for i := range runes {
+		for _, trie := range []*wordTrie{words, commonPasswordTrie} {
+			if trie == nil {
+				continue
+			}
+			trie.match(lower, i, func(end, rank, subs int) {
+				bits := math.Log2(float64(rank)) + caseEntropy(runes[i:end]) + float64(subs)
+				dictionary[end] = append(dictionary[end], match{start: i, bits: bits})
+			})
+		}
+	}
+
+	best := make([]float64, n+1)
+	var walk keyboardWalk
+	run, seq, seqDelta := 0, 0, rune(0)
+	repeats := make([]int, maxRepeatUnit+1)
+	for j := 1; j <= n; j++ {
+		c := runes[j-1]
+		best[j] = best[j-1] + perChar
+		relax := func(i int, bits float64) {
+			best[j] = min(best[j], best[i]+bits)
+		}
+
+		for _, m := range dictionary[j] {
+			relax(m.start, m.bits)
+		}
+
+		// Runs of a single repeated character such as "aaaa".
+		if j >= 2 && c == runes[j-2] {
+			run++
+		} else {
+			run = 1
+		}
+		if run >= 3 {
+			relax(j-run, math.Log2(float64(charsetSize(string(c))*run)))
+		}
+
+		// Runs of consecutive characters such as "abcd", "4321" or "XYZ".
+		var delta rune
+		if j >= 2 {
+			delta = c - runes[j-2]
+		}
+		switch {
+		case (delta != 1 && delta != -1) || charClass(c) != charClass(runes[j-2]):
+			seq, seqDelta = 1, 0
+		case delta == seqDelta:
+			seq++
+		default:
+			seq, seqDelta = 2, delta
+		}
+		if seq >= 3 {
+			relax(j-seq, sequenceEntropy(runes[j-seq], seqDelta, seq))
+		}
+
+		// Walks across adjacent keys such as "qwerty", "asdf" or "1qaz2wsx".
+		walk.next(runes, j)
+		if walk.length >= 3 {
+			relax(j-walk.length, walk.entropy())
+		}
+
+		// Years between 1900 and 2099.
+		if j >= 4 && isYear(runes[j-4:j]) {
+			relax(j-4, math.Log2(200))
+		}
+
+		// Repeated chunks such as "abcabc" cost the first chunk plus the number of repeats.
+		for size := 2; size <= maxRepeatUnit; size++ {
+			if j-1-size >= 0 && c == runes[j-1-size] {
+				repeats[size]++
+			} else {
+				repeats[size] = 0
+			}
+			if count := (repeats[size] + size) / size; count >= 2 {
+				best[j] = min(best[j], best[j-(count-1)*size]+math.Log2(float64(count)))
+			}
+		}
+	}
+	return best[n]
+}
+
+// caseEntropy returns the extra bits for the capitalization of a dictionary word:
+// none for all lower case, one bit for a capitalized or all upper case word and
+// one bit per upper case letter otherwise.
+func caseEntropy(token []rune) float64 {
+	upper := 0
+	for _, c := range token {
+		if unicode.IsUpper(c) {
+			upper++
+		}
+	}
+	switch {
+	case upper == 0:
+		return 0
+	case upper == len(token) || (upper == 1 && unicode.IsUpper(token[0])):
+		return 1
+	default:
+		return float64(upper)
+	}
+}
+
+// sequenceEntropy scores a run of length consecutive characters starting at first, such
+// as "abcd", "4321" or "XYZ", where delta is 1 for ascending and -1 for descending runs.
+func sequenceEntropy(first, delta rune, length int) float64 {
+	var bits float64
+	switch {
+	case first == 'a' || first == 'A' || first == '0' || first == '1':
+		bits = 1
+	case unicode.IsDigit(first):
+		bits = math.Log2(10)
+	default:
+		bits = math.Log2(26)
+	}
+	if delta < 0 {
+		bits++
+	}
+	return bits + math.Log2(float64(length))
+}
+
+// keyboardWalk tracks the walk across adjacent keys ending at the current position, such
+// as "qwerty", "asdf" or "1qaz2wsx".
+type keyboardWalk struct {
+	length    int
+	turns     int
+	shifted   bool
+	direction [2]float64
+}
+
+// next extends the walk with runes[j-1], or starts a new walk if the key is not adjacent
+// to the previous one.
+func (w *keyboardWalk) next(runes []rune, j int) {
+	cur, ok := keyPositions[unicode.ToLower(runes[j-1])]
+	if !ok {
+		*w = keyboardWalk{}
+		return
+	}
+	if w.length > 0 {
+		prev := keyPositions[unicode.ToLower(runes[j-2])]
+		dx, dy := cur.x-prev.x, float64(cur.y-prev.y)
+		if (dx != 0 || dy != 0) && math.Abs(dx) <= 1 && math.Abs(dy) <= 1 {
+			if w.length > 1 && [2]float64{dx, dy} != w.direction {
+				w.turns++
+			}
+			w.length++
+			w.direction = [2]float64{dx, dy}
+			w.shifted = w.shifted || unicode.IsUpper(runes[j-1])
+			return
+		}
+	}
+	*w = keyboardWalk{length: 1}
+}
+
+// entropy scores the walk by its length, number of turns and use of the shift key.
+func (w *keyboardWalk) entropy() float64 {
+	bits := math.Log2(float64(len(keyPositions)*w.length)) + float64(w.turns)*math.Log2(6)
+	if w.shifted {
+		bits++
+	}
+	return bits
+}
+
+// isYear reports whether token is a year between 1900 and 2099.
+func isYear(token []rune) bool {
+	if string(token[:2]) != "19" && string(token[:2]) != "20" {
+		return false
+	}
+	for _, c := range token[2:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// charClass returns 0 for lower case, 1 for upper case, 2 for digits and 3 otherwise.
+func charClass(c rune) int {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return 0
+	case c >= 'A' && c <= 'Z':
+		return 1
+	case c >= '0' && c <= '9':
+		return 2
+	default:
+		return 3
+	}
+}
+
+// charsetSize returns the size of the character pool needed to brute force value.
+func charsetSize(value string) int {
+	var lower, upper, digit, symbol, other bool
+	for _, c := range value {
+		switch {
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= '0' && c <= '9':
+			digit = true
+		case c < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	size := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			size += class.size
+		}
+	}
+	return max(size, 1)
+}
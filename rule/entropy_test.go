@@ -0,0 +1,65 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *PasswordEntropyRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: random characters", rule: PasswordEntropy(50), value: "t7#Vq9!mLp", wantErr: false},
+		{name: "valid: long passphrase", rule: PasswordEntropy(50), value: "correct horse battery staple", wantErr: false},
+		{name: "valid: empty string", rule: PasswordEntropy(50), value: "", wantErr: false},
+		{name: "invalid: common password", rule: PasswordEntropy(20), value: "password", wantErr: true},
+		{name: "invalid: l33t common password with suffix", rule: PasswordEntropy(50), value: "P@ssw0rd123!", wantErr: true},
+		{name: "invalid: keyboard walk", rule: PasswordEntropy(30), value: "qwertyuiop", wantErr: true},
+		{name: "invalid: keyboard walk with turns", rule: PasswordEntropy(30), value: "1qaz2wsx", wantErr: true},
+		{name: "invalid: repeated character", rule: PasswordEntropy(30), value: "aaaaaaaaaaaa", wantErr: true},
+		{name: "invalid: repeated chunk", rule: PasswordEntropy(30), value: "xq7xq7xq7xq7", wantErr: true},
+		{name: "invalid: sequence", rule: PasswordEntropy(30), value: "abcdefgh987654", wantErr: true},
+		{name: "invalid: user dictionary word", rule: PasswordEntropy(40).Dictionary("Jonathan"), value: "Jonathan2024", wantErr: true},
+		{name: "invalid: long repeated character", rule: PasswordEntropy(50), value: strings.Repeat("a", 200), wantErr: true},
+		{name: "invalid: long repeated chunk", rule: PasswordEntropy(50), value: strings.Repeat("password", 40), wantErr: true},
+		{name: "custom error message", rule: PasswordEntropy(50).Errf("custom error"), value: "letmein", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PasswordEntropyRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The same characters score lower once a pattern is recognized.
+	assert.Less(t, passwordEntropy("Password1", nil), passwordEntropy("Pdsaowrs1", nil))
+	assert.Nil(t, PasswordEntropy(40).Validate("Jonathan2024"))
+}
+
+func TestPasswordEntropyLongInput(t *testing.T) {
+	// The whole input is scored, in linear time.
+	value := strings.Repeat("t7#Vq9!mLp", 10_000)
+	start := time.Now()
+	assert.Nil(t, PasswordEntropy(50).Dictionary("jonathan").Validate(value))
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Greater(t, passwordEntropy("t7#Vq9!mLp"+strings.Repeat("a", 200), nil), passwordEntropy("t7#Vq9!mLp", nil))
+}
+
+func TestPasswordStrengthMinEntropy(t *testing.T) {
+	assert.Nil(t, PasswordStrength().MinEntropy(50).Validate("kX9$mQ2!vL8@"))
+	assert.ErrorIs(t, PasswordStrength().MinEntropy(50).Validate("P@ssw0rd123!"), ErrPasswordStrength)
+	assert.Nil(t, PasswordStrength().Validate("P@ssw0rd123!"))
+}
+
+func TestPasswordEntropyFallback(t *testing.T) {
+	assert.ErrorIs(t, (&PasswordEntropyRule{minBits: 50}).Validate("password"), ErrPasswordEntropy)
+}
@@ -0,0 +1,150 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the Error type, which gives every rule error a stable code.
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// sentinels are the errors created with newError, for Codes.
+var sentinels []*Error
+
+// Error is a validation error with a stable, machine-readable code, such as "min" or
+// "email". The sentinel errors of this package, such as ErrMin, are *Error values.
+// Errf wraps the rule's sentinel instead of replacing it, so a custom message keeps
+// the code and errors.Is(err, ErrMin) still reports true.
+//
+// Example:
+//
+//	err := Min(18).Errf("You must be an adult").Validate(16)
+//	errors.Is(err, ErrMin)  // true
+//	ErrorCode(err)          // "min"
+//	err.Error()             // "You must be an adult"
+type Error struct {
+	// Code identifies the failed rule. It does not change between releases.
+	Code string
+	// Message is the error message, either the rule's default or the one set by Errf.
+	Message string
+
+	wrapped []error
+	custom  bool
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the sentinel error of the rule and, if the custom message wraps an
+// error with %w, that error.
+func (e *Error) Unwrap() []error {
+	return e.wrapped
+}
+
+// Custom reports whether the message was set with Errf rather than being the rule's default.
+//
+// Example:
+//
+//	var e *Error
+//	if errors.As(err, &e) && !e.Custom() {
+//	    // Replace the default message with a translation
+//	}
+func (e *Error) Custom() bool {
+	return e.custom
+}
+
+// ErrorCode returns the code of the first *Error in err's chain, or "" if there is none.
+//
+// Example:
+//
+//	if err := rule.Validate(value); err != nil {
+//	    switch ErrorCode(err) {
+//	    case "min", "max":
+//	        // Out of range
+//	    }
+//	}
+func ErrorCode(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+// Wrapf returns an error with a message formatted like fmt.Errorf that keeps the code of
+// base and wraps it, like the Errf methods of the rules of this package. It lets rules
+// defined outside this package support custom messages the same way.
+//
+// Example:
+//
+//	err := Wrapf(ErrMin, "%s must be at least %d", "Quantity", 1)
+//	errors.Is(err, ErrMin)  // true
+//	ErrorCode(err)          // "min"
+func Wrapf(base error, format string, args ...any) error {
+	return wrapf(base, format, args...)
+}
+
+// Codes returns the codes of the sentinel errors of this package and those created with
+// NewError in sorted order, such as "between" and "email", for building message catalogs
+// and documentation.
+//
+// Example:
+//
+//	for _, code := range Codes() {
+//	    if _, ok := messages[code]; !ok {
+//	        log.Printf("no translation for %s", code)
+//	    }
+//	}
+func Codes() []string {
+	codes := make([]string, 0, len(sentinels))
+	for _, e := range sentinels {
+		codes = append(codes, e.Code)
+	}
+	slices.Sort(codes)
+	return slices.Compact(codes)
+}
+
+// NewError creates a sentinel error with the given code and message, like ErrMin, and
+// registers it so that Codes returns its code. Packages that define rules outside this
+// package use it for their sentinels, so that message catalogs can cover them. It must
+// be called during package initialization, such as in a var declaration.
+//
+// Example:
+//
+//	var ErrUsername = rule.NewError("username", "invalid username")
+func NewError(code, message string) error {
+	return newError(code, message)
+}
+
+// newError creates a sentinel error with the given code and message.
+func newError(code, message string) error {
+	e := &Error{Code: code, Message: message}
+	sentinels = append(sentinels, e)
+	return e
+}
+
+// wrapf returns an error with a message formatted like fmt.Errorf that keeps the code
+// of base and wraps it, so errors.Is(err, base) holds. If base is nil, it returns the
+// formatted error as is.
+func wrapf(base error, format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if base == nil {
+		return err
+	}
+	wrapped := []error{base}
+	switch inner := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = append(wrapped, inner.Unwrap())
+	case interface{ Unwrap() []error }:
+		wrapped = append(wrapped, inner.Unwrap()...)
+	}
+	return &Error{Code: ErrorCode(base), Message: err.Error(), wrapped: wrapped, custom: true}
+}
+
+// formatError returns the default error of a rule whose message includes its parameters,
+// such as the bounds of Between. It has the code of base and wraps it.
+func formatError(base error, format string, args ...any) error {
+	return &Error{Code: ErrorCode(base), Message: fmt.Sprintf(format, args...), wrapped: []error{base}}
+}
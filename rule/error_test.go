@@ -0,0 +1,92 @@
+package rule
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrfKeepsSentinel(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		code     string
+		message  string
+	}{
+		{name: "min", err: Min(18).Errf("You must be %d or older", 18).Validate(16), sentinel: ErrMin, code: "min", message: "You must be 18 or older"},
+		{name: "email", err: IsEmail().Errf("Invalid email").Validate("x"), sentinel: ErrEmail, code: "email", message: "Invalid email"},
+		{name: "predeclared email", err: Emailv.Errf("Invalid email").Validate("x"), sentinel: ErrEmail, code: "email", message: "Invalid email"},
+		{name: "not nil", err: NotNil.Errf("missing").Validate(nil), sentinel: ErrNotNil, code: "not_nil", message: "missing"},
+		{name: "length", err: Len[string](2, 4).Errf("2-4 characters").Validate("a"), sentinel: ErrLength, code: "length", message: "2-4 characters"},
+		{name: "between default", err: Between(1, 10).Validate(11), sentinel: ErrBetween, code: "between", message: "is not between 1 and 10"},
+		{name: "errf twice", err: Max(10).Errf("first").Errf("second").Validate(11), sentinel: ErrMax, code: "max", message: "second"},
+		{name: "ipv4", err: IPv4().Validate("::1"), sentinel: ErrIPv4, code: "ipv4", message: ErrIPv4.Error()},
+		{name: "mac address", err: MACAddress().Validate("x"), sentinel: ErrMACAddress, code: "mac_address", message: ErrMACAddress.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.Equal(t, tt.code, ErrorCode(tt.err))
+			assert.EqualError(t, tt.err, tt.message)
+		})
+	}
+}
+
+func TestErrfWrappedError(t *testing.T) {
+	err := Required[string]().Errf("name: %w", io.ErrUnexpectedEOF).Validate("")
+	assert.ErrorIs(t, err, ErrRequired)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.EqualError(t, err, "name: unexpected EOF")
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "", ErrorCode(nil))
+	assert.Equal(t, "", ErrorCode(errors.New("plain")))
+	assert.Equal(t, "required", ErrorCode(errors.Join(errors.New("plain"), ErrRequired)))
+
+	var e *Error
+	assert.True(t, errors.As(Positivev[int]().Validate(-1), &e))
+	assert.Equal(t, "positive", e.Code)
+}
+
+func TestParams(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Parameterized
+		want map[string]any
+	}{
+		{name: "min", rule: Min(18), want: map[string]any{"min": 18}},
+		{name: "max", rule: Max(1.5), want: map[string]any{"max": 1.5}},
+		{name: "between", rule: Between(1, 10), want: map[string]any{"min": 1, "max": 10}},
+		{name: "len", rule: Len[string](2, 50), want: map[string]any{"min": 2, "max": 50}},
+		{name: "multiple", rule: MultipleOf(5), want: map[string]any{"base": 5}},
+		{name: "in", rule: In("red", "green"), want: map[string]any{"values": []string{"red", "green"}}},
+		{name: "file size", rule: FileSize(1, 2), want: map[string]any{"min": int64(1), "max": int64(2)}},
+		{name: "duration", rule: DurationBetween(time.Second, time.Minute), want: map[string]any{"min": "1s", "max": "1m0s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Params())
+		})
+	}
+}
+
+// errTestCode is a sentinel created like those of packages that define their own rules.
+var errTestCode = NewError("test_code", "test failed")
+
+func TestCodes(t *testing.T) {
+	codes := Codes()
+	assert.True(t, slices.IsSorted(codes))
+	assert.Equal(t, slices.Compact(slices.Clone(codes)), codes)
+	assert.Contains(t, codes, "required")
+	assert.Contains(t, codes, ErrorCode(ErrMapKeys))
+	assert.Contains(t, codes, "test_code")
+	assert.Equal(t, "test_code", ErrorCode(Wrapf(errTestCode, "custom")))
+}
@@ -2,14 +2,9 @@
 // This file contains the even number validation rule.
 package rule
 
-import (
-	"errors"
-	"fmt"
-)
-
 // Error returned when a value is not an even number
 var (
-	ErrEven = errors.New("value must be even")
+	ErrEven = newError("even", "value must be even")
 )
 
 // EvenRule validates that a number is even (divisible by 2).
@@ -64,6 +59,11 @@ func (r *EvenRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *EvenRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrEven)
+}
+
 // Errf sets a custom error message for even number validation failures.
 // This allows for context-specific error messages.
 //
@@ -73,7 +73,7 @@ func (r *EvenRule[T]) Validate(value T) error {
 //	err := rule.Validate(3)  // returns error with custom message
 func (r *EvenRule[T]) Errf(format string, args ...any) *EvenRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
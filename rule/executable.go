@@ -0,0 +1,177 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules that detect executable files by content and by file name.
+package rule
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ErrExecutable is returned when a file is an executable or script, or its name has an executable extension.
+var ErrExecutable = newError("executable", "executable files are not allowed")
+
+// executableExtensions are file extensions that Windows, macOS, Linux or the JVM
+// execute or install when the file is opened.
+var executableExtensions = map[string]bool{
+	"exe": true, "com": true, "scr": true, "pif": true, "bat": true, "cmd": true,
+	"msi": true, "msp": true, "dll": true, "cpl": true, "sys": true, "hta": true,
+	"js": true, "jse": true, "vbs": true, "vbe": true, "wsf": true, "wsh": true,
+	"ps1": true, "psm1": true, "lnk": true, "reg": true, "jar": true, "sh": true,
+	"bash": true, "run": true, "bin": true, "elf": true, "app": true, "command": true,
+	"pkg": true, "dmg": true, "apk": true, "appimage": true, "deb": true, "rpm": true,
+}
+
+// NotExecutableRule validates that a file is not a native executable or script,
+// regardless of its declared type. It detects PE, ELF and Mach-O binaries by their
+// magic numbers and scripts by their shebang line. If the reader has a file name,
+// such as *os.File, the name is checked as NotExecutableFilename does.
+//
+// Example:
+//
+//	rule := NotExecutable()
+//	err := rule.Validate(pdfFile)   // returns nil
+//	err = rule.Validate(exeFile)    // returns error, even if uploaded as "invoice.pdf"
+type NotExecutableRule struct {
+	e error
+}
+
+// NotExecutable creates a new executable detection rule for upload endpoints.
+//
+// Example:
+//
+//	rule := NotExecutable().Errf("Executable files cannot be uploaded")
+func NotExecutable() *NotExecutableRule {
+	return &NotExecutableRule{
+		e: ErrExecutable,
+	}
+}
+
+// Validate checks if the file is an executable or script.
+// If the file implements io.Seeker, its read position is restored afterwards.
+//
+// Example:
+//
+//	file, _ := os.Open("upload.bin")
+//	defer file.Close()
+//	rule := NotExecutable()
+//	err := rule.Validate(file)  // returns error if the file is a binary or a script
+func (r *NotExecutableRule) Validate(file io.Reader) error {
+	header, err := readHeader(file)
+	if err != nil {
+		return err
+	}
+	executable := isExecutable(header)
+	if named, ok := file.(interface{ Name() string }); ok {
+		executable = executable || hasExecutableExtension(filepath.Base(named.Name()))
+	}
+	if executable {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrExecutable
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NotExecutableRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrExecutable)
+}
+
+// isExecutable reports whether header is the header of a native executable (Windows PE,
+// ELF or Mach-O) or starts with a shebang.
+func isExecutable(header []byte) bool {
+	if bytes.HasPrefix(bytes.TrimPrefix(header, []byte("\xEF\xBB\xBF")), []byte("#!")) {
+		return true
+	}
+	return bytes.HasPrefix(header, []byte("\x7FELF")) || portableExecutable(header) || machO(header)
+}
+
+// hasExecutableExtension reports whether name ends in an executable extension. Trailing
+// dots and spaces, which Windows ignores, are removed first.
+func hasExecutableExtension(name string) bool {
+	name = strings.TrimRight(name, ". ")
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	return executableExtensions[ext]
+}
+
+// Errf sets a custom error message for executable detection failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NotExecutable().Errf("Please do not upload programs")
+func (r *NotExecutableRule) Errf(format string, args ...any) *NotExecutableRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// NotExecutableFilenameRule validates that a file name does not have an executable
+// extension, including double extensions such as "invoice.pdf.exe" that hide the real
+// type when extensions are not displayed.
+//
+// Example:
+//
+//	rule := NotExecutableFilename()
+//	err := rule.Validate("invoice.pdf")      // returns nil
+//	err = rule.Validate("invoice.pdf.exe")  // returns error
+type NotExecutableFilenameRule struct {
+	e error
+}
+
+// NotExecutableFilename creates a new executable file name validation rule.
+// Use it together with NotExecutable, which checks the content.
+//
+// Example:
+//
+//	type Upload struct {
+//	    Filename string
+//	}
+//
+//	rule := NotExecutableFilename().Errf("Executable files cannot be uploaded")
+func NotExecutableFilename() *NotExecutableFilenameRule {
+	return &NotExecutableFilenameRule{
+		e: ErrExecutable,
+	}
+}
+
+// Validate checks if the file name has an executable extension.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := NotExecutableFilename()
+//	err := rule.Validate("photo.jpg")      // returns nil
+//	err = rule.Validate("photo.jpg.scr")  // returns error
+//	err = rule.Validate("setup.EXE. ")    // returns error (Windows ignores trailing dots and spaces)
+func (r *NotExecutableFilenameRule) Validate(value string) error {
+	if hasExecutableExtension(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrExecutable
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NotExecutableFilenameRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrExecutable)
+}
+
+// Errf sets a custom error message for executable file name validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NotExecutableFilename().Errf("This file type is not allowed")
+func (r *NotExecutableFilenameRule) Errf(format string, args ...any) *NotExecutableFilenameRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,104 @@
+package rule
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotExecutable(t *testing.T) {
+	peHeader := make([]byte, 256)
+	copy(peHeader, "MZ\x90\x00")
+	peHeader[60] = 0x80
+	copy(peHeader[0x80:], "PE\x00\x00")
+
+	tests := []struct {
+		name    string
+		value   []byte
+		wantErr bool
+	}{
+		{name: "valid: pdf", value: []byte("%PDF-1.4\n"), wantErr: false},
+		{name: "valid: text", value: []byte("hello world"), wantErr: false},
+		{name: "valid: empty", value: []byte{}, wantErr: false},
+		{name: "valid: text starting with MZ", value: []byte("MZ is the postal code prefix"), wantErr: false},
+		{name: "valid: dos header without pe signature", value: []byte("MZ\x90\x00\x03\x00"), wantErr: false},
+		{name: "valid: java class file", value: []byte("\xCA\xFE\xBA\xBE\x00\x00\x00\x34"), wantErr: false},
+		{name: "invalid: windows pe", value: peHeader, wantErr: true},
+		{name: "invalid: elf", value: []byte("\x7FELF\x02\x01\x01"), wantErr: true},
+		{name: "invalid: mach-o 64 bit", value: []byte("\xCF\xFA\xED\xFE\x07\x00"), wantErr: true},
+		{name: "invalid: mach-o universal", value: []byte("\xCA\xFE\xBA\xBE\x00\x00\x00\x02"), wantErr: true},
+		{name: "invalid: shebang", value: []byte("#!/bin/sh\nrm -rf /\n"), wantErr: true},
+		{name: "invalid: shebang after bom", value: []byte("\xEF\xBB\xBF#!/usr/bin/env python\n"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NotExecutable().Validate(bytes.NewReader(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NotExecutableRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotExecutableFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invoice.pdf.exe")
+	assert.Nil(t, os.WriteFile(path, []byte("%PDF-1.4\n"), 0o600))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.ErrorIs(t, NotExecutable().Validate(file), ErrExecutable)
+
+	path = filepath.Join(dir, "invoice.pdf")
+	assert.Nil(t, os.WriteFile(path, []byte("%PDF-1.4\n"), 0o600))
+	file, err = os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.Nil(t, NotExecutable().Validate(file))
+	content, _ := io.ReadAll(file)
+	assert.Equal(t, "%PDF-1.4\n", string(content))
+}
+
+func TestNotExecutableFilename(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: pdf", value: "invoice.pdf", wantErr: false},
+		{name: "valid: no extension", value: "README", wantErr: false},
+		{name: "valid: executable extension not last", value: "setup.exe.txt", wantErr: false},
+		{name: "valid: empty", value: "", wantErr: false},
+		{name: "invalid: exe", value: "setup.exe", wantErr: true},
+		{name: "invalid: double extension", value: "invoice.pdf.exe", wantErr: true},
+		{name: "invalid: upper case", value: "PHOTO.JPG.SCR", wantErr: true},
+		{name: "invalid: trailing dot and space", value: "setup.exe. ", wantErr: true},
+		{name: "invalid: script", value: "deploy.sh", wantErr: true},
+		{name: "invalid: shortcut", value: "documents.lnk", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NotExecutableFilename().Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NotExecutableFilenameRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotExecutableErrf(t *testing.T) {
+	err := NotExecutable().Errf("no binaries").Validate(bytes.NewReader([]byte("\x7FELF")))
+	assert.EqualError(t, err, "no binaries")
+	assert.EqualError(t, NotExecutableFilename().Errf("no binaries").Validate("a.exe"), "no binaries")
+}
+
+func TestNotExecutableFallback(t *testing.T) {
+	assert.ErrorIs(t, (&NotExecutableRule{}).Validate(bytes.NewReader([]byte("\x7FELF"))), ErrExecutable)
+	assert.ErrorIs(t, (&NotExecutableFilenameRule{}).Validate("a.exe"), ErrExecutable)
+}
@@ -1,14 +1,15 @@
 // Package rule provides a collection of validation rules for various data types.
-// This file contains file-related validation rules for size, type, extension, and MIME type.
+// This file contains file-related validation rules for size, type, extension, MIME type, and file names.
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"io/fs"
 	"path/filepath"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // mimeSubtype extracts the subtype from a MIME type string (e.g., "image/png" -> "png").
@@ -24,22 +25,33 @@ func mimeSubtype(mimeType string) string {
 	return strings.TrimSpace(after)
 }
 
+// mimeBase strips parameters from a MIME type (e.g., "text/plain; charset=utf-8" -> "text/plain").
+func mimeBase(mimeType string) string {
+	if idx := strings.IndexByte(mimeType, ';'); idx >= 0 {
+		return strings.TrimSpace(mimeType[:idx])
+	}
+	return mimeType
+}
+
 // File validation errors
 var (
 
 	// ErrFileType is returned when a file's content type is not in the allowed list.
 	// The file type is determined by examining the file's header bytes.
-	ErrFileType = errors.New("file type is not allowed")
+	ErrFileType = newError("file_type", "file type is not allowed")
 
 	// ErrFileExtension is returned when a file's extension is not in the allowed list.
 	// The extension is extracted from the filename and compared case-insensitively.
-	ErrFileExtension = errors.New("file extension is not allowed")
+	ErrFileExtension = newError("file_extension", "file extension is not allowed")
 
 	// ErrFileMimeType is returned when a file's MIME type is not in the allowed list.
-	// The MIME type is determined using the file's extension and the mime package.
-	ErrFileMimeType = errors.New("file mime type is not allowed")
+	// The MIME type is determined by sniffing the file's content.
+	ErrFileMimeType = newError("file_mime_type", "file mime type is not allowed")
 )
 
+// ErrFileSize is returned when a file's size is not within the range. Its message includes the range.
+var ErrFileSize = newError("file_size", "file size is not between minimum and maximum")
+
 const (
 	ErrFileSizeFormat = "file size is not between %v and %v"
 )
@@ -69,13 +81,14 @@ func FileSize(min, max int64) *FileSizeRule {
 	return &FileSizeRule{
 		min: min,
 		max: max,
-		e:   fmt.Errorf(ErrFileSizeFormat, min, max),
 	}
 }
 
 // Validate checks if the given file's size falls within the specified range.
-// The file's size is determined efficiently using io.Seeker when available,
-// falling back to reading the entire file content otherwise.
+// The file is not consumed: the size is taken from Stat when the reader is an
+// *os.File or fs.File backed by a regular file, otherwise from io.Seeker, and the read
+// position is restored. Only readers that support neither are read, and then no
+// further than one byte past the maximum.
 //
 // Example:
 //
@@ -83,36 +96,53 @@ func FileSize(min, max int64) *FileSizeRule {
 //	defer file.Close()
 //	rule := FileSize(1024, 10485760)
 //	err := rule.Validate(file)  // returns nil if file size is between 1KB and 10MB
+//	_, err = io.Copy(dst, file)  // the file can still be read afterwards
 func (r *FileSizeRule) Validate(file io.Reader) error {
-	var size int64
+	size, err := r.size(file)
+	if err != nil {
+		return err
+	}
 
-	if seeker, ok := file.(io.Seeker); ok {
-		current, err := seeker.Seek(0, io.SeekCurrent)
-		if err != nil {
-			return err
+	// Check if file size is within the specified range
+	if size < r.min || (r.max > 0 && size > r.max) {
+		if r.e != nil {
+			return r.e
 		}
-		size, err = seeker.Seek(0, io.SeekEnd)
-		if err != nil {
-			return err
+		return formatError(ErrFileSize, ErrFileSizeFormat, r.min, r.max)
+	}
+
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *FileSizeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrFileSize)
+}
+
+// size returns the size of file, preferring Stat and Seek over reading the content.
+func (r *FileSizeRule) size(file io.Reader) (int64, error) {
+	if f, ok := file.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+			return info.Size(), nil
 		}
-		_, err = seeker.Seek(current, io.SeekStart)
+	}
+
+	if seeker, ok := file.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
 		if err != nil {
-			return err
+			return 0, err
 		}
-	} else {
-		var err error
-		size, err = io.Copy(io.Discard, file)
-		if err != nil {
-			return err
+		size, err := seeker.Seek(0, io.SeekEnd)
+		if _, serr := seeker.Seek(current, io.SeekStart); err == nil {
+			err = serr
 		}
+		return size, err
 	}
 
-	// Check if file size is within the specified range
-	if size < r.min || (r.max > 0 && size > r.max) {
-		return r.e
+	if r.max > 0 {
+		file = io.LimitReader(file, r.max+1)
 	}
-
-	return nil
+	return io.Copy(io.Discard, file)
 }
 
 // Errf sets a custom error message for file size validation failures.
@@ -123,17 +153,27 @@ func (r *FileSizeRule) Validate(file io.Reader) error {
 //	rule := FileSize(1024, 10485760).Errf("Uploaded file must be between 1KB and 10MB")
 func (r *FileSizeRule) Errf(format string, args ...any) *FileSizeRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(ErrFileSize, format, args...)
 	}
 	return r
 }
 
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := FileSize(1024, 1048576).Params()  // map[string]any{"min": 1024, "max": 1048576}
+func (r *FileSizeRule) Params() map[string]any {
+	return map[string]any{"min": r.min, "max": r.max}
+}
+
 // FileTypeRule validates that a file's content type matches one of the allowed types.
-// The file type is determined by examining the file's header bytes.
+// The file type is determined by matching the file's header bytes against a table of
+// magic numbers, so a text file that merely contains "PDF" is not a PDF.
 //
 // Example:
 //
-//	rule := FileType("PDF", "DOCX").Errf("Only PDF and DOCX files are allowed")
+//	rule := FileType("PDF", "PNG").Errf("Only PDF and PNG files are allowed")
 //	err := rule.Validate(fileReader)  // returns nil if file type is allowed
 type FileTypeRule struct {
 	allowedTypes []string
@@ -142,10 +182,13 @@ type FileTypeRule struct {
 
 // FileType creates a new file type validation rule.
 // The rule ensures that a file's content type matches one of the allowed types.
+// Types are given by name (e.g. "png", "jpg", "pdf", "zip", "mp4"), by MIME type
+// (e.g. "image/png") or by MIME subtype, case-insensitively. Use RegisterFileSignature
+// to detect additional types.
 //
 // Example:
 //
-//	rule := FileType("PDF", "DOCX")  // allow PDF and DOCX files
+//	rule := FileType("PDF", "ZIP")   // allow PDF and ZIP files
 //	rule := FileType("JPEG", "PNG")  // allow JPEG and PNG images
 func FileType(allowedTypes ...string) *FileTypeRule {
 	return &FileTypeRule{
@@ -155,7 +198,9 @@ func FileType(allowedTypes ...string) *FileTypeRule {
 }
 
 // Validate checks if the given file's content type matches one of the allowed types.
-// The file type is detected using magic byte signatures via http.DetectContentType.
+// The file type is detected from magic numbers, falling back to http.DetectContentType
+// for text formats such as HTML and plain text. If the file implements io.Seeker,
+// its read position is restored afterwards.
 //
 // Example:
 //
@@ -164,24 +209,35 @@ func FileType(allowedTypes ...string) *FileTypeRule {
 //	rule := FileType("pdf", "png", "jpeg")
 //	err := rule.Validate(file)  // returns nil if file type is allowed
 func (r *FileTypeRule) Validate(file io.Reader) error {
-	header := make([]byte, 512)
-	n, err := file.Read(header)
-	if err != nil && err != io.EOF {
+	header, err := readHeader(file)
+	if err != nil {
 		return err
 	}
-	header = header[:n]
+	if len(header) == 0 {
+		return r.e
+	}
 
-	detected := http.DetectContentType(header)
+	var names []string
+	var detected string
+	if sig, ok := detectSignature(header); ok {
+		names, detected = sig.names, sig.mimeType
+	} else {
+		detected = sniffContentType(header)
+	}
 
 	for _, allowed := range r.allowedTypes {
-		allowedLower := strings.ToLower(allowed)
+		// Match against type names (e.g., "jpg")
+		for _, name := range names {
+			if strings.EqualFold(name, allowed) {
+				return nil
+			}
+		}
 		// Match against full MIME type (e.g., "image/png")
-		if strings.EqualFold(detected, allowedLower) {
+		if strings.EqualFold(mimeBase(detected), allowed) {
 			return nil
 		}
 		// Match against MIME subtype (e.g., "png" from "image/png")
-		subtype := mimeSubtype(detected)
-		if strings.EqualFold(subtype, allowedLower) {
+		if strings.EqualFold(mimeSubtype(detected), allowed) {
 			return nil
 		}
 	}
@@ -189,15 +245,20 @@ func (r *FileTypeRule) Validate(file io.Reader) error {
 	return r.e
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *FileTypeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrFileType)
+}
+
 // Errf sets a custom error message for file type validation failures.
 // This allows for context-specific error messages.
 //
 // Example:
 //
-//	rule := FileType("PDF", "DOCX").Errf("Please upload only PDF or DOCX files")
+//	rule := FileType("PDF", "PNG").Errf("Please upload only PDF or PNG files")
 func (r *FileTypeRule) Errf(format string, args ...any) *FileTypeRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -258,6 +319,11 @@ func (r *FileExtensionRule) Validate(filename string) error {
 	return r.e
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *FileExtensionRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrFileExtension)
+}
+
 // Errf sets a custom error message for file extension validation failures.
 // This allows for context-specific error messages.
 //
@@ -266,17 +332,17 @@ func (r *FileExtensionRule) Validate(filename string) error {
 //	rule := FileExtension("pdf", "docx").Errf("Please upload only PDF or DOCX files")
 func (r *FileExtensionRule) Errf(format string, args ...any) *FileExtensionRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
 // FileMimeTypeRule validates that a file's MIME type is in the allowed list.
-// The MIME type is determined using the file's extension and the mime package.
+// The MIME type is determined by sniffing the file's content, never from its name.
 //
 // Example:
 //
-//	rule := FileMimeType("application/pdf", "application/vnd.openxmlformats-officedocument.wordprocessingml.document").Errf("Only PDF and DOCX files are allowed")
+//	rule := FileMimeType("application/pdf", "image/png").Errf("Only PDF and PNG files are allowed")
 //	err := rule.Validate(fileReader)  // returns nil if MIME type is allowed
 type FileMimeTypeRule struct {
 	allowedMimeTypes []string
@@ -284,12 +350,13 @@ type FileMimeTypeRule struct {
 }
 
 // FileMimeType creates a new file MIME type validation rule.
-// The rule ensures that a file's MIME type is in the allowed list.
+// The rule ensures that a file's MIME type is in the allowed list. An allowed type of
+// the form "image/*" matches every subtype.
 //
 // Example:
 //
-//	rule := FileMimeType("application/pdf", "application/vnd.openxmlformats-officedocument.wordprocessingml.document")  // allow PDF and DOCX files
-//	rule := FileMimeType("image/jpeg", "image/png")  // allow JPEG and PNG images
+//	rule := FileMimeType("application/pdf", "image/png")  // allow PDF and PNG files
+//	rule := FileMimeType("image/*")                       // allow any detected image type
 func FileMimeType(allowedMimeTypes ...string) *FileMimeTypeRule {
 	return &FileMimeTypeRule{
 		allowedMimeTypes: allowedMimeTypes,
@@ -298,7 +365,10 @@ func FileMimeType(allowedMimeTypes ...string) *FileMimeTypeRule {
 }
 
 // Validate checks if the given file's MIME type is in the allowed list.
-// The MIME type is determined using magic byte signatures via http.DetectContentType.
+// The MIME type is detected from the magic number table used by FileType (see
+// RegisterFileSignature), falling back to http.DetectContentType. Parameters such as
+// "charset=utf-8" are ignored. If the file implements io.Seeker, its read position
+// is restored afterwards so that the file can still be saved or processed.
 //
 // Example:
 //
@@ -307,42 +377,238 @@ func FileMimeType(allowedMimeTypes ...string) *FileMimeTypeRule {
 //	rule := FileMimeType("application/pdf")
 //	err := rule.Validate(file)  // returns nil if file is a PDF
 func (r *FileMimeTypeRule) Validate(file io.Reader) error {
-	header := make([]byte, 512)
-	n, err := file.Read(header)
-	if err != nil && err != io.EOF {
+	header, err := readHeader(file)
+	if err != nil {
 		return err
 	}
-	header = header[:n]
-
-	mimeType := http.DetectContentType(header)
-	if mimeType == "" {
+	if len(header) == 0 {
 		return r.e
 	}
 
-	// Parameters like "charset=utf-8" should not affect matching
-	baseType := mimeType
-	if idx := strings.IndexByte(mimeType, ';'); idx >= 0 {
-		baseType = strings.TrimSpace(mimeType[:idx])
+	var mimeType string
+	if sig, ok := detectSignature(header); ok {
+		mimeType = sig.mimeType
+	} else {
+		mimeType = sniffContentType(header)
 	}
+	// Parameters like "charset=utf-8" should not affect matching
+	baseType := mimeBase(mimeType)
 
 	for _, allowed := range r.allowedMimeTypes {
 		if strings.EqualFold(baseType, allowed) {
 			return nil
 		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok &&
+			len(baseType) > len(prefix) && strings.EqualFold(baseType[:len(prefix)+1], prefix+"/") {
+			return nil
+		}
 	}
 
 	return r.e
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *FileMimeTypeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrFileMimeType)
+}
+
 // Errf sets a custom error message for file MIME type validation failures.
 // This allows for context-specific error messages.
 //
 // Example:
 //
-//	rule := FileMimeType("application/pdf", "application/vnd.openxmlformats-officedocument.wordprocessingml.document").Errf("Please upload only PDF or DOCX files")
+//	rule := FileMimeType("application/pdf", "image/png").Errf("Please upload only PDF or PNG files")
 func (r *FileMimeTypeRule) Errf(format string, args ...any) *FileMimeTypeRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// ErrSafeFilename is returned when a file name is unsafe to use on common file systems.
+var ErrSafeFilename = newError("safe_filename", "file name is not safe")
+
+// defaultMaxFilenameLength is the maximum file name length in bytes on most file systems.
+const defaultMaxFilenameLength = 255
+
+// unsafeFilenameChars are path separators and characters reserved on Windows.
+const unsafeFilenameChars = `/\<>:"|?*`
+
+// windowsDeviceNames are reserved on Windows, with or without an extension.
+var windowsDeviceNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SafeFilenameRule validates that a user supplied file name can be used to store a file
+// without path traversal or file system specific surprises. It rejects:
+//   - path separators and characters reserved on Windows (<>:"|?*)
+//   - reserved Windows device names such as CON, NUL or COM1, also with an extension
+//   - leading dots (hidden files, "." and ".."), trailing dots and trailing spaces
+//   - control and other non-printable characters, including bidirectional overrides
+//   - names longer than 255 bytes
+//
+// Example:
+//
+//	rule := SafeFilename()
+//	err := rule.Validate("report-2024.pdf")   // returns nil
+//	err = rule.Validate("../../etc/passwd")  // returns error
+//	err = rule.Validate("nul.txt")           // returns error
+type SafeFilenameRule struct {
+	maxLength int
+	hint      bool
+	e         error
+}
+
+// SafeFilename creates a new file name safety validation rule.
+//
+// Example:
+//
+//	type Upload struct {
+//	    Filename string
+//	}
+//
+//	rule := SafeFilename().Hint().Errf("File name contains invalid characters")
+func SafeFilename() *SafeFilenameRule {
+	return &SafeFilenameRule{
+		maxLength: defaultMaxFilenameLength,
+		e:         ErrSafeFilename,
+	}
+}
+
+// MaxLength sets the maximum file name length in bytes, 255 by default.
+//
+// Example:
+//
+//	rule := SafeFilename().MaxLength(100)
+func (r *SafeFilenameRule) MaxLength(n int) *SafeFilenameRule {
+	r.maxLength = n
+	return r
+}
+
+// Hint adds a normalized, safe version of the file name to the error message, e.g.
+// `file name is not safe: try "etc_passwd"`. The returned error wraps the rule's error,
+// so errors.Is still works.
+//
+// Example:
+//
+//	rule := SafeFilename().Hint()
+//	err := rule.Validate("a/b.txt")  // returns `file name is not safe: try "a_b.txt"`
+func (r *SafeFilenameRule) Hint() *SafeFilenameRule {
+	r.hint = true
+	return r
+}
+
+// Validate checks if the given file name is safe.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := SafeFilename()
+//	err := rule.Validate("photo.jpg")   // returns nil
+//	err = rule.Validate(".htaccess")   // returns error
+//	err = rule.Validate("")            // returns nil (empty string is valid)
+func (r *SafeFilenameRule) Validate(value string) error {
+	if value == "" || r.safe(value) {
+		return nil
+	}
+	e := r.e
+	if e == nil {
+		e = ErrSafeFilename
+	}
+	if r.hint {
+		if suggestion := r.sanitize(value); suggestion != "" {
+			return fmt.Errorf("%w: try %q", e, suggestion)
+		}
+	}
+	return e
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *SafeFilenameRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrSafeFilename)
+}
+
+// limit returns the maximum file name length in bytes.
+func (r *SafeFilenameRule) limit() int {
+	if r.maxLength > 0 {
+		return r.maxLength
+	}
+	return defaultMaxFilenameLength
+}
+
+// safe reports whether value is a safe file name.
+func (r *SafeFilenameRule) safe(value string) bool {
+	if len(value) > r.limit() || !utf8.ValidString(value) {
+		return false
+	}
+	if value[0] == '.' || strings.HasSuffix(value, ".") || strings.HasSuffix(value, " ") {
+		return false
+	}
+	for _, c := range value {
+		if strings.ContainsRune(unsafeFilenameChars, c) || (!unicode.IsPrint(c) && c != ' ') {
+			return false
+		}
+	}
+	return !isWindowsDeviceName(value)
+}
+
+// isWindowsDeviceName reports whether the part of name before the first dot is a reserved device name.
+func isWindowsDeviceName(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	return windowsDeviceNames[strings.ToUpper(strings.TrimRight(stem, " "))]
+}
+
+// sanitize returns a safe version of value by replacing unsafe characters with "_",
+// trimming dots and spaces, prefixing reserved names and shortening the name while
+// keeping its extension. It returns "" if nothing usable is left.
+func (r *SafeFilenameRule) sanitize(value string) string {
+	value = strings.Map(func(c rune) rune {
+		switch {
+		case c == utf8.RuneError:
+			return '_'
+		case strings.ContainsRune(unsafeFilenameChars, c):
+			return '_'
+		case !unicode.IsPrint(c) && c != ' ':
+			return -1
+		}
+		return c
+	}, value)
+	value = strings.TrimRight(strings.TrimLeft(value, ". _"), ". ")
+	if value == "" {
+		return ""
+	}
+	if isWindowsDeviceName(value) {
+		value = "_" + value
+	}
+
+	if limit := r.limit(); len(value) > limit {
+		ext := filepath.Ext(value)
+		if len(ext) >= limit {
+			ext = ""
+		}
+		stem := value[:len(value)-len(ext)]
+		cut := limit - len(ext)
+		for cut > 0 && !utf8.RuneStart(stem[cut]) {
+			cut--
+		}
+		value = strings.TrimRight(stem[:cut], ". ") + ext
+	}
+	return value
+}
+
+// Errf sets a custom error message for file name validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := SafeFilename().Errf("Please choose a different file name")
+func (r *SafeFilenameRule) Errf(format string, args ...any) *SafeFilenameRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -5,7 +5,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -112,6 +114,67 @@ func TestFileType(t *testing.T) {
 	}
 }
 
+func TestFileTypeSignatures(t *testing.T) {
+	tarHeader := make([]byte, 512)
+	copy(tarHeader[257:], "ustar")
+	bmpHeader := []byte("BM\x46\x00\x00\x00\x00\x00\x00\x00\x36\x00\x00\x00\x28\x00\x00\x00")
+	peHeader := make([]byte, 256)
+	copy(peHeader, "MZ\x90\x00")
+	peHeader[60] = 0x80
+	copy(peHeader[0x80:], "PE\x00\x00")
+
+	tests := []struct {
+		name         string
+		allowedTypes []string
+		content      []byte
+		wantErr      bool
+	}{
+		{name: "valid: pdf", allowedTypes: []string{"PDF"}, content: []byte("%PDF-1.7\n"), wantErr: false},
+		{name: "invalid: text mentioning PDF", allowedTypes: []string{"PDF"}, content: []byte("see the attached PDF file"), wantErr: true},
+		{name: "valid: jpg alias", allowedTypes: []string{"jpg"}, content: []byte{0xFF, 0xD8, 0xFF, 0xE0}, wantErr: false},
+		{name: "valid: mime type", allowedTypes: []string{"image/gif"}, content: []byte("GIF89a"), wantErr: false},
+		{name: "valid: webp", allowedTypes: []string{"webp"}, content: []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), wantErr: false},
+		{name: "invalid: wav is not webp", allowedTypes: []string{"webp"}, content: []byte("RIFF\x00\x00\x00\x00WAVEfmt "), wantErr: true},
+		{name: "valid: mp4", allowedTypes: []string{"mp4"}, content: []byte("\x00\x00\x00\x18ftypisom"), wantErr: false},
+		{name: "invalid: heic is not mp4", allowedTypes: []string{"mp4"}, content: []byte("\x00\x00\x00\x18ftypheic"), wantErr: true},
+		{name: "valid: zip", allowedTypes: []string{"zip"}, content: []byte("PK\x03\x04\x14\x00"), wantErr: false},
+		{name: "valid: bmp", allowedTypes: []string{"bmp"}, content: bmpHeader, wantErr: false},
+		{name: "invalid: text starting with BM", allowedTypes: []string{"bmp"}, content: []byte("BMW service appointment on Monday"), wantErr: true},
+		{name: "valid: text starting with BM", allowedTypes: []string{"plain"}, content: []byte("BMW service appointment on Monday"), wantErr: false},
+		{name: "valid: exe", allowedTypes: []string{"exe"}, content: peHeader, wantErr: false},
+		{name: "invalid: text starting with MZ", allowedTypes: []string{"exe"}, content: []byte("MZ Software release notes, version 2"), wantErr: true},
+		{name: "invalid: DOS header without PE signature", allowedTypes: []string{"exe"}, content: peHeader[:0x80], wantErr: true},
+		{name: "valid: universal mach-o", allowedTypes: []string{"macho"}, content: []byte("\xCA\xFE\xBA\xBE\x00\x00\x00\x02"), wantErr: false},
+		{name: "invalid: java class file", allowedTypes: []string{"macho"}, content: []byte("\xCA\xFE\xBA\xBE\x00\x00\x00\x34"), wantErr: true},
+		{name: "valid: tar magic at offset", allowedTypes: []string{"tar"}, content: tarHeader, wantErr: false},
+		{name: "valid: plain text fallback", allowedTypes: []string{"plain"}, content: []byte("hello world"), wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FileType(tt.allowedTypes...).Validate(bytes.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileTypeRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileTypeRestoresPosition(t *testing.T) {
+	reader := bytes.NewReader([]byte("%PDF-1.7\n"))
+	assert.Nil(t, FileType("pdf").Validate(reader))
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "%PDF-1.7\n", string(content))
+}
+
+func TestRegisterFileSignature(t *testing.T) {
+	content := []byte("PAR1\x15\x04")
+	assert.Error(t, FileType("parquet").Validate(bytes.NewReader(content)))
+	RegisterFileSignature("Parquet", "application/vnd.apache.parquet", 0, []byte("PAR1"))
+	assert.Nil(t, FileType("parquet").Validate(bytes.NewReader(content)))
+	assert.Nil(t, FileType("application/vnd.apache.parquet").Validate(bytes.NewReader(content)))
+}
+
 func TestFileExtension(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -212,6 +275,40 @@ func TestFileMimeType(t *testing.T) {
 	}
 }
 
+func TestFileMimeTypeSniffing(t *testing.T) {
+	tests := []struct {
+		name             string
+		allowedMimeTypes []string
+		content          []byte
+		wantErr          bool
+	}{
+		{name: "valid: pdf", allowedMimeTypes: []string{"application/pdf"}, content: []byte("%PDF-1.4\n"), wantErr: false},
+		{name: "valid: case insensitive", allowedMimeTypes: []string{"Application/PDF"}, content: []byte("%PDF-1.4\n"), wantErr: false},
+		{name: "valid: extended signature", allowedMimeTypes: []string{"image/heic"}, content: []byte("\x00\x00\x00\x18ftypheic"), wantErr: false},
+		{name: "valid: text ignores charset", allowedMimeTypes: []string{"text/plain"}, content: []byte("hello world"), wantErr: false},
+		{name: "valid: wildcard", allowedMimeTypes: []string{"image/*"}, content: []byte("GIF89a"), wantErr: false},
+		{name: "invalid: wildcard other type", allowedMimeTypes: []string{"image/*"}, content: []byte("%PDF-1.4\n"), wantErr: true},
+		{name: "invalid: text starting with BM", allowedMimeTypes: []string{"image/bmp"}, content: []byte("BMW service appointment on Monday"), wantErr: true},
+		{name: "invalid: html disguised by name", allowedMimeTypes: []string{"image/png"}, content: []byte("<html><script>x</script></html>"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FileMimeType(tt.allowedMimeTypes...).Validate(bytes.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileMimeTypeRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileMimeTypeRestoresPosition(t *testing.T) {
+	reader := bytes.NewReader([]byte("GIF89a...."))
+	assert.Nil(t, FileMimeType("image/gif").Validate(reader))
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "GIF89a....", string(content))
+}
+
 type nonSeekerReader struct {
 	data []byte
 	pos  int
@@ -241,6 +338,36 @@ func TestFileSizeSeeker(t *testing.T) {
 	assert.Nil(t, FileSize(1, 20).Errf("size error").Validate(bytes.NewReader([]byte("hello world"))))
 }
 
+func TestFileSizeNonDestructive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	assert.Nil(t, os.WriteFile(path, []byte("hello world"), 0o600))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.Nil(t, FileSize(5, 20).Validate(file))
+	content, err := io.ReadAll(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", string(content))
+
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("hello")}}
+	fsFile, err := fsys.Open("a.txt")
+	assert.Nil(t, err)
+	assert.Error(t, FileSize(10, 20).Validate(fsFile))
+	content, err = io.ReadAll(fsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	reader := bytes.NewReader([]byte("hello world"))
+	_, _ = reader.Seek(6, io.SeekStart)
+	assert.Nil(t, FileSize(5, 20).Validate(reader))
+	content, _ = io.ReadAll(reader)
+	assert.Equal(t, "world", string(content))
+
+	stream := &nonSeekerReader{data: bytes.Repeat([]byte("x"), 100)}
+	assert.Error(t, FileSize(1, 10).Validate(stream))
+	assert.Equal(t, 11, stream.pos)
+}
+
 func TestFileSizeErrf(t *testing.T) {
 	rule := FileSize(1, 5).Errf("custom size error")
 	err := rule.Validate(bytes.NewReader([]byte("hello world")))
@@ -284,3 +411,65 @@ func TestFileMimeTypeErrf(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "custom mime error", err.Error())
 }
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *SafeFilenameRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: simple name", rule: SafeFilename(), value: "report-2024.pdf", wantErr: false},
+		{name: "valid: spaces and unicode", rule: SafeFilename(), value: "年度 报告.docx", wantErr: false},
+		{name: "valid: device name as part", rule: SafeFilename(), value: "console.log", wantErr: false},
+		{name: "valid: empty", rule: SafeFilename(), value: "", wantErr: false},
+		{name: "invalid: path traversal", rule: SafeFilename(), value: "../../etc/passwd", wantErr: true},
+		{name: "invalid: backslash", rule: SafeFilename(), value: `..\windows\win.ini`, wantErr: true},
+		{name: "invalid: reserved character", rule: SafeFilename(), value: "what?.txt", wantErr: true},
+		{name: "invalid: device name", rule: SafeFilename(), value: "CON", wantErr: true},
+		{name: "invalid: device name with extension", rule: SafeFilename(), value: "nul.txt", wantErr: true},
+		{name: "invalid: hidden file", rule: SafeFilename(), value: ".htaccess", wantErr: true},
+		{name: "invalid: trailing dot", rule: SafeFilename(), value: "file.txt.", wantErr: true},
+		{name: "invalid: trailing space", rule: SafeFilename(), value: "file.txt ", wantErr: true},
+		{name: "invalid: control character", rule: SafeFilename(), value: "file\n.txt", wantErr: true},
+		{name: "invalid: null byte", rule: SafeFilename(), value: "file.php\x00.png", wantErr: true},
+		{name: "invalid: bidi override", rule: SafeFilename(), value: "invoice\u202efdp.exe", wantErr: true},
+		{name: "invalid: invalid utf-8", rule: SafeFilename(), value: "file\xff.txt", wantErr: true},
+		{name: "invalid: too long", rule: SafeFilename(), value: strings.Repeat("a", 252) + ".txt", wantErr: true},
+		{name: "invalid: custom max length", rule: SafeFilename().MaxLength(8), value: "long-name.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SafeFilenameRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSafeFilenameHint(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "../../etc/passwd", want: `file name is not safe: try "etc_passwd"`},
+		{value: "nul.txt", want: `file name is not safe: try "_nul.txt"`},
+		{value: "report\t.pdf ", want: `file name is not safe: try "report.pdf"`},
+		{value: strings.Repeat("é", 200) + ".txt", want: `file name is not safe: try "` + strings.Repeat("é", 125) + `.txt"`},
+		{value: "...", want: "file name is not safe"},
+	}
+
+	for _, tt := range tests {
+		err := SafeFilename().Hint().Validate(tt.value)
+		assert.EqualError(t, err, tt.want)
+		assert.ErrorIs(t, err, ErrSafeFilename)
+	}
+}
+
+func TestSafeFilenameFallback(t *testing.T) {
+	err := (&SafeFilenameRule{}).Validate("a/b")
+	assert.ErrorIs(t, err, ErrSafeFilename)
+	assert.Nil(t, (&SafeFilenameRule{}).Validate("a.txt"))
+}
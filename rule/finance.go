@@ -0,0 +1,84 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating financial identifiers such as ISINs and BICs.
+package rule
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Error variables for financial identifier validation
+var (
+	// ErrISIN is returned when a string is not a valid International Securities Identification Number
+	ErrISIN = newError("isin", "invalid ISIN")
+	// ErrBIC is returned when a string is not a valid SWIFT/BIC code
+	ErrBIC = newError("bic", "invalid BIC")
+)
+
+var (
+	regexISIN = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+	regexBIC  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// isinPrefixes are the ISIN prefixes that are not ISO 3166 country codes: XS for
+// international securities, EU for securities of the European Union and XA to XD for
+// substitutes of CUSIP numbers.
+var isinPrefixes = []string{"XS", "EU", "XA", "XB", "XC", "XD"}
+
+// ISIN returns a new RegexRule that validates International Securities Identification
+// Numbers (ISO 6166): a country code, nine letters or digits and a check digit computed
+// with the Luhn algorithm over the digits of the code, with letters counted as 10 to 35.
+//
+// Example:
+//
+//	rule := ISIN()
+//	err := rule.Validate("US0378331005")  // returns nil
+//	err = rule.Validate("US0378331004")   // returns ErrISIN (wrong check digit)
+func ISIN() *RegexRule {
+	return &RegexRule{regex: regexISIN, verify: verifyISIN, strict: true, e: ErrISIN}
+}
+
+// BIC returns a new RegexRule that validates SWIFT/BIC codes (ISO 9362): a four-letter
+// institution code, an ISO 3166 country code, a two-character location code and an
+// optional three-character branch code, such as "DEUTDEFF" or "DEUTDEFF500".
+//
+// Example:
+//
+//	rule := BIC()
+//	err := rule.Validate("NEDSZAJJXXX")  // returns nil
+//	err = rule.Validate("DEUTQQFF")      // returns ErrBIC (QQ is not a country)
+func BIC() *RegexRule {
+	return &RegexRule{regex: regexBIC, verify: verifyBIC, strict: true, e: ErrBIC}
+}
+
+// verifyISIN verifies the prefix and check digit of an ISIN.
+func verifyISIN(value string) bool {
+	if !isCountryCode(value[:2]) && !slices.Contains(isinPrefixes, value[:2]) {
+		return false
+	}
+	var digits strings.Builder
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			digits.WriteByte(c)
+		}
+	}
+	return luhnValid(digits.String())
+}
+
+// verifyBIC verifies the country code of a BIC.
+func verifyBIC(value string) bool {
+	return isCountryCode(value[4:6])
+}
+
+// isCountryCode reports whether code is an ISO 3166-1 alpha-2 country code in its
+// canonical form, so that deprecated codes such as UK are rejected.
+func isCountryCode(code string) bool {
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry() && region.Canonicalize().String() == code
+}
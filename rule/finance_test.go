@@ -0,0 +1,67 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISIN(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: US", value: "US0378331005"},
+		{name: "valid: letters", value: "AU0000XVGZA3"},
+		{name: "valid: GB", value: "GB0002634946"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: check digit", value: "US0378331004", wantErr: true},
+		{name: "invalid: country", value: "QQ0378331005", wantErr: true},
+		{name: "invalid: lower case", value: "us0378331005", wantErr: true},
+		{name: "invalid: too short", value: "US037833100", wantErr: true},
+		{name: "invalid: letter check digit", value: "US037833100A", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ISIN().Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrISIN)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBIC(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: 8 characters", value: "DEUTDEFF"},
+		{name: "valid: 11 characters", value: "DEUTDEFF500"},
+		{name: "valid: digit in location", value: "BOFAUS3N"},
+		{name: "valid: primary office", value: "NEDSZAJJXXX"},
+		{name: "valid: Kosovo", value: "RBKOXKPR"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: unknown country", value: "DEUTQQFF", wantErr: true},
+		{name: "invalid: deprecated country code", value: "ABCDUK2L", wantErr: true},
+		{name: "invalid: digit in institution code", value: "DEU1DEFF", wantErr: true},
+		{name: "invalid: 9 characters", value: "DEUTDEFF5", wantErr: true},
+		{name: "invalid: lower case", value: "deutdeff", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BIC().Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrBIC)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
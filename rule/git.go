@@ -0,0 +1,188 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating git object names and reference names.
+package rule
+
+import "strings"
+
+// Git validation errors
+var (
+	// ErrGitSHA is returned when a string is not a git commit SHA.
+	ErrGitSHA = newError("git_sha", "invalid git commit SHA")
+
+	// ErrGitRefName is returned when a string is not a valid git reference name.
+	ErrGitRefName = newError("git_ref_name", "invalid git reference name")
+)
+
+// GitSHARule validates that a string is a git object name: a full or abbreviated commit
+// SHA of 7 to 40 hexadecimal digits, or a full SHA of 40 (SHA-1) or 64 (SHA-256) digits
+// in strict mode.
+//
+// Example:
+//
+//	rule := GitSHA()
+//	err := rule.Validate("3f908b2")                                   // returns nil
+//	err = rule.Validate("3f908b2c1d5e7a9b0c2d4e6f8a0b1c3d5e7f9a0b")  // returns nil
+//	err = rule.Validate("3f908")                                     // returns ErrGitSHA
+type GitSHARule struct {
+	strict bool
+	e      error
+}
+
+// GitSHA creates a rule that validates full or abbreviated git commit SHAs, such as the
+// revision a CI pipeline deploys.
+//
+// Example:
+//
+//	arbiter.Field(&deploy.Revision, rule.Required[string](), rule.GitSHA())
+func GitSHA() *GitSHARule {
+	return &GitSHARule{e: ErrGitSHA}
+}
+
+// Strict only accepts full SHAs of 40 (SHA-1) or 64 (SHA-256) hexadecimal digits, as
+// abbreviated SHAs may become ambiguous when the repository grows.
+//
+// Example:
+//
+//	rule := GitSHA().Strict()
+//	err := rule.Validate("3f908b2")  // returns ErrGitSHA
+func (r *GitSHARule) Strict() *GitSHARule {
+	r.strict = true
+	return r
+}
+
+// Validate checks if the string is a git commit SHA.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := GitSHA()
+//	err := rule.Validate("d1a9c74")  // returns nil
+//	err = rule.Validate("main")     // returns ErrGitSHA
+func (r *GitSHARule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	n := len(value)
+	valid := Ternary(r.strict, n == 40 || n == 64, n >= 7 && n <= 40)
+	if !valid || !containsOnly(value, "0123456789abcdefABCDEF") {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrGitSHA
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *GitSHARule) Describe() RuleInfo {
+	return describe(r, r.e, ErrGitSHA)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := GitSHA().Strict().Errf("Pin the action to a full commit SHA")
+func (r *GitSHARule) Errf(format string, args ...any) *GitSHARule {
+	if format != "" {
+		r.e = wrapf(ErrGitSHA, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := GitSHA().Strict().Params()  // map[string]any{"strict": true}
+func (r *GitSHARule) Params() map[string]any {
+	return map[string]any{"strict": r.strict}
+}
+
+// GitRefNameRule validates that a string is a git reference name, such as a branch or
+// tag name, following the rules of git check-ref-format with --allow-onelevel:
+//
+//   - no slash-separated component begins with '.' or ends with ".lock"
+//   - no "..", "@{" or backslash, and the name is not "@"
+//   - no control characters, spaces or any of ~ ^ : ? * [
+//   - no leading or trailing '/', no consecutive slashes and no trailing '.'
+//
+// Both short names such as "main" and full names such as "refs/heads/main" are accepted.
+//
+// Example:
+//
+//	rule := GitRefName()
+//	err := rule.Validate("feature/login")  // returns nil
+//	err = rule.Validate("feature..login")  // returns ErrGitRefName
+type GitRefNameRule struct {
+	e error
+}
+
+// GitRefName creates a rule that validates git reference names, such as the branch a CI
+// pipeline builds.
+//
+// Example:
+//
+//	arbiter.Field(&pipeline.Branch, rule.Required[string](), rule.GitRefName())
+func GitRefName() *GitRefNameRule {
+	return &GitRefNameRule{e: ErrGitRefName}
+}
+
+// Validate checks if the string is a valid git reference name.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := GitRefName()
+//	err := rule.Validate("refs/tags/v1.2.0")  // returns nil
+//	err = rule.Validate("release/1.0.lock")  // returns ErrGitRefName
+func (r *GitRefNameRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !validGitRefName(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrGitRefName
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *GitRefNameRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrGitRefName)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := GitRefName().Errf("Branch names cannot contain spaces or \"..\"")
+func (r *GitRefNameRule) Errf(format string, args ...any) *GitRefNameRule {
+	if format != "" {
+		r.e = wrapf(ErrGitRefName, format, args...)
+	}
+	return r
+}
+
+// validGitRefName reports whether name follows the rules of git check-ref-format.
+func validGitRefName(name string) bool {
+	if name == "@" || strings.HasSuffix(name, ".") ||
+		strings.Contains(name, "..") || strings.Contains(name, "@{") {
+		return false
+	}
+	for _, c := range []byte(name) {
+		if c < 0x20 || c == 0x7f || strings.IndexByte(" ~^:?*[\\", c) >= 0 {
+			return false
+		}
+	}
+	for _, component := range strings.Split(name, "/") {
+		if component == "" || component[0] == '.' || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitSHA(t *testing.T) {
+	sha1 := "3f908b2c1d5e7a9b0c2d4e6f8a0b1c3d5e7f9a0b"
+	sha256 := sha1 + "0123456789abcdef01234567"
+
+	tests := []struct {
+		name    string
+		rule    *GitSHARule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: abbreviated", rule: GitSHA(), value: "3f908b2"},
+		{name: "valid: full SHA-1", rule: GitSHA(), value: sha1},
+		{name: "valid: upper case", rule: GitSHA(), value: strings.ToUpper(sha1)},
+		{name: "valid: empty string", rule: GitSHA(), value: ""},
+		{name: "valid: strict SHA-1", rule: GitSHA().Strict(), value: sha1},
+		{name: "valid: strict SHA-256", rule: GitSHA().Strict(), value: sha256},
+		{name: "invalid: too short", rule: GitSHA(), value: "3f908b", wantErr: true},
+		{name: "invalid: SHA-256 without strict", rule: GitSHA(), value: sha256, wantErr: true},
+		{name: "invalid: not hex", rule: GitSHA(), value: "3f908g2", wantErr: true},
+		{name: "invalid: branch name", rule: GitSHA(), value: "main", wantErr: true},
+		{name: "invalid: strict abbreviated", rule: GitSHA().Strict(), value: "3f908b2", wantErr: true},
+		{name: "invalid: strict between lengths", rule: GitSHA().Strict(), value: sha1 + "00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GitSHA().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrGitSHA)
+			}
+		})
+	}
+
+	assert.EqualError(t, GitSHA().Errf("bad revision").Validate("x"), "bad revision")
+	assert.Equal(t, map[string]any{"strict": true}, GitSHA().Strict().Params())
+}
+
+func TestGitRefName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: one level", value: "main"},
+		{name: "valid: branch with slash", value: "feature/login"},
+		{name: "valid: full name", value: "refs/heads/main"},
+		{name: "valid: tag", value: "refs/tags/v1.2.0"},
+		{name: "valid: dot inside component", value: "release/1.0"},
+		{name: "valid: at sign", value: "user@host"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: leading dot", value: ".hidden", wantErr: true},
+		{name: "invalid: component with leading dot", value: "feature/.hidden", wantErr: true},
+		{name: "invalid: lock suffix", value: "release/1.0.lock", wantErr: true},
+		{name: "invalid: double dot", value: "feature..login", wantErr: true},
+		{name: "invalid: trailing dot", value: "feature.", wantErr: true},
+		{name: "invalid: leading slash", value: "/main", wantErr: true},
+		{name: "invalid: trailing slash", value: "main/", wantErr: true},
+		{name: "invalid: consecutive slashes", value: "feature//login", wantErr: true},
+		{name: "invalid: space", value: "my branch", wantErr: true},
+		{name: "invalid: control character", value: "main\x01", wantErr: true},
+		{name: "invalid: delete character", value: "main\x7f", wantErr: true},
+		{name: "invalid: tilde", value: "main~1", wantErr: true},
+		{name: "invalid: caret", value: "main^", wantErr: true},
+		{name: "invalid: colon", value: "a:b", wantErr: true},
+		{name: "invalid: glob characters", value: "feature/*", wantErr: true},
+		{name: "invalid: open bracket", value: "feature[1]", wantErr: true},
+		{name: "invalid: backslash", value: `feature\login`, wantErr: true},
+		{name: "invalid: reflog syntax", value: "main@{1}", wantErr: true},
+		{name: "invalid: single at sign", value: "@", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GitRefName().Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GitRefName().Validate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrGitRefName)
+			}
+		})
+	}
+
+	assert.EqualError(t, GitRefName().Errf("bad branch").Validate("a..b"), "bad branch")
+}
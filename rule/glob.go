@@ -0,0 +1,115 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rules matching strings against shell-style glob patterns.
+package rule
+
+import (
+	"fmt"
+	"path"
+)
+
+// ErrGlob is returned when a string does not match any of the glob patterns
+var ErrGlob = newError("glob", "does not match the pattern")
+
+// GlobRule validates that a string matches at least one shell-style glob pattern, with
+// the syntax of path.Match:
+//
+//   - '*' matches any sequence of characters except '/'
+//   - '?' matches any single character except '/'
+//   - "[a-z]" matches a single character of the class, and "[^a-z]" one outside it
+//   - a backslash matches the next character literally
+//
+// As '*' does not match '/', patterns such as "logs/*/app.log" check the segments of
+// slash-separated keys and routes. Glob is a lighter alternative to Regex for topic names,
+// bucket keys and similar identifiers.
+//
+// Example:
+//
+//	rule := Glob("orders.*.created")
+//	err := rule.Validate("orders.eu.created")  // returns nil
+//	err = rule.Validate("orders.eu.deleted")  // returns ErrGlob
+type GlobRule struct {
+	patterns   []string
+	patternErr error
+	e          error
+}
+
+// Glob creates a rule that validates strings against a glob pattern.
+// If the pattern is malformed, the rule always returns an error.
+//
+// Example:
+//
+//	arbiter.Field(&sub.Topic, rule.Required[string](), rule.Glob("events.[a-z]*"))
+func Glob(pattern string) *GlobRule {
+	return MatchesAny(pattern)
+}
+
+// MatchesAny creates a rule that validates strings matching at least one of the glob
+// patterns. If any pattern is malformed, the rule always returns an error naming it, even
+// if a custom message is set with Errf.
+//
+// Example:
+//
+//	rule := MatchesAny("/api/v1/*", "/api/v2/*", "/healthz")
+//	err := rule.Validate("/api/v2/users")  // returns nil
+//	err = rule.Validate("/admin")         // returns ErrGlob
+func MatchesAny(patterns ...string) *GlobRule {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return &GlobRule{patternErr: fmt.Errorf("invalid glob pattern %q: %w", pattern, err), e: ErrGlob}
+		}
+	}
+	return &GlobRule{patterns: patterns, e: ErrGlob}
+}
+
+// Validate checks if the string matches one of the glob patterns.
+// Returns nil if the string matches or is empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := Glob("img-??.png")
+//	err := rule.Validate("img-01.png")   // returns nil
+//	err = rule.Validate("img-001.png")  // returns ErrGlob
+func (r *GlobRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if r.patternErr != nil {
+		return r.patternErr
+	}
+	for _, pattern := range r.patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return nil
+		}
+	}
+	if r.e != nil {
+		return r.e
+	}
+	return ErrGlob
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *GlobRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrGlob)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := Glob("team-*").Errf("Bucket names must start with team-")
+func (r *GlobRule) Errf(format string, args ...any) *GlobRule {
+	if format != "" {
+		r.e = wrapf(ErrGlob, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Glob("team-*").Params()  // map[string]any{"patterns": []string{"team-*"}}
+func (r *GlobRule) Params() map[string]any {
+	return map[string]any{"patterns": r.patterns}
+}
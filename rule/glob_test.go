@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *GlobRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: star", rule: Glob("orders.*.created"), value: "orders.eu.created"},
+		{name: "valid: question mark", rule: Glob("img-??.png"), value: "img-01.png"},
+		{name: "valid: class", rule: Glob("events.[a-z]*"), value: "events.billing"},
+		{name: "valid: escaped", rule: Glob(`report\*`), value: "report*"},
+		{name: "valid: path segment", rule: Glob("logs/*/app.log"), value: "logs/2024/app.log"},
+		{name: "valid: empty string", rule: Glob("team-*"), value: ""},
+		{name: "valid: any pattern", rule: MatchesAny("/api/v1/*", "/api/v2/*", "/healthz"), value: "/api/v2/users"},
+		{name: "valid: exact pattern", rule: MatchesAny("/api/v1/*", "/healthz"), value: "/healthz"},
+		{name: "invalid: no match", rule: Glob("orders.*.created"), value: "orders.eu.deleted", wantErr: true},
+		{name: "invalid: star does not match slash", rule: Glob("logs/*"), value: "logs/2024/app.log", wantErr: true},
+		{name: "invalid: negated class", rule: Glob("[^0-9]*"), value: "1st", wantErr: true},
+		{name: "invalid: no pattern matches", rule: MatchesAny("/api/v1/*", "/healthz"), value: "/admin", wantErr: true},
+		{name: "invalid: no patterns", rule: MatchesAny(), value: "anything", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Glob().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrGlob)
+			}
+		})
+	}
+
+	err := MatchesAny("ok-*", "[unclosed").Validate("ok-1")
+	assert.ErrorContains(t, err, `invalid glob pattern "[unclosed"`)
+	err = MatchesAny("ok-*", "[unclosed").Errf("bad bucket").Validate("ok-1")
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+	assert.EqualError(t, Glob("team-*").Errf("bad bucket").Validate("x"), "bad bucket")
+	assert.Equal(t, map[string]any{"patterns": []string{"team-*"}}, Glob("team-*").Params())
+	assert.Equal(t, "glob", Glob("team-*").Describe().Name)
+}
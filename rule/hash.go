@@ -0,0 +1,404 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating stored password hash strings (bcrypt, Argon2 and PHC).
+package rule
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Password hash validation errors
+var (
+	// ErrBcryptHash is returned when a string is not a well-formed bcrypt hash with an acceptable cost.
+	ErrBcryptHash = newError("bcrypt_hash", "invalid bcrypt hash")
+
+	// ErrArgon2Hash is returned when a string is not a well-formed Argon2 hash with acceptable parameters.
+	ErrArgon2Hash = newError("argon2_hash", "invalid argon2 hash")
+
+	// ErrPHCString is returned when a string is not in the PHC string format.
+	ErrPHCString = newError("phc_string", "invalid PHC string")
+)
+
+// bcryptAlphabet is the base64 alphabet used by bcrypt.
+const bcryptAlphabet = "./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// phcAlphabet is the unpadded standard base64 alphabet used for PHC salts and hashes.
+const phcAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// BcryptHashRule validates that a string is a bcrypt hash in modular crypt format
+// ($2a$, $2b$, $2x$ or $2y$) with a cost within the accepted range.
+//
+// Example:
+//
+//	rule := BcryptHash().MinCost(10)
+//	err := rule.Validate("$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW")  // returns nil
+//	err = rule.Validate("$2b$04$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW")   // returns error (cost too low)
+type BcryptHashRule struct {
+	minCost int
+	maxCost int
+	e       error
+}
+
+// BcryptHash creates a new bcrypt hash validation rule.
+// By default any cost allowed by bcrypt (4 to 31) is accepted.
+//
+// Example:
+//
+//	type Credential struct {
+//	    PasswordHash string `json:"password_hash"`
+//	}
+//
+//	rule := BcryptHash().MinCost(10).Errf("Password hash must be bcrypt with cost 10 or higher")
+func BcryptHash() *BcryptHashRule {
+	return &BcryptHashRule{
+		minCost: 4,
+		maxCost: 31,
+		e:       ErrBcryptHash,
+	}
+}
+
+// MinCost sets the minimum accepted bcrypt cost.
+//
+// Example:
+//
+//	rule := BcryptHash().MinCost(12)
+func (r *BcryptHashRule) MinCost(cost int) *BcryptHashRule {
+	r.minCost = cost
+	return r
+}
+
+// MaxCost sets the maximum accepted bcrypt cost, e.g. to reject hashes that are too slow to verify.
+//
+// Example:
+//
+//	rule := BcryptHash().MinCost(10).MaxCost(14)
+func (r *BcryptHashRule) MaxCost(cost int) *BcryptHashRule {
+	r.maxCost = cost
+	return r
+}
+
+// Validate checks if the string is a well-formed bcrypt hash with an accepted cost.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := BcryptHash()
+//	err := rule.Validate("$2y$10$.vGA1O9wmRjrwAVXD98HNOgsNpDczlqm3Jq7KnEd1rVAGv3Fykk1a")  // returns nil
+//	err = rule.Validate("$1$abc$def")                                                    // returns error
+func (r *BcryptHashRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !r.valid(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrBcryptHash
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *BcryptHashRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrBcryptHash)
+}
+
+// valid reports whether value is a bcrypt hash with a cost in the accepted range.
+func (r *BcryptHashRule) valid(value string) bool {
+	// $2b$ + 2-digit cost + $ + 22 characters of salt + 31 characters of hash
+	if len(value) != 60 || value[0] != '$' || value[1] != '2' || !strings.ContainsRune("abxy", rune(value[2])) ||
+		value[3] != '$' || value[6] != '$' || !containsOnly(value[4:6], "0123456789") {
+		return false
+	}
+	cost, err := strconv.Atoi(value[4:6])
+	if err != nil || cost < 4 || cost > 31 || cost < r.minCost || (r.maxCost > 0 && cost > r.maxCost) {
+		return false
+	}
+	return containsOnly(value[7:], bcryptAlphabet)
+}
+
+// Errf sets a custom error message for bcrypt hash validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := BcryptHash().Errf("Invalid bcrypt hash")
+func (r *BcryptHashRule) Errf(format string, args ...any) *BcryptHashRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// phcString is a parsed PHC string: $id[$v=version][$param=value(,param=value)*][$salt[$hash]].
+type phcString struct {
+	id      string
+	version string
+	params  map[string]string
+	salt    string
+	hash    string
+}
+
+// parsePHC parses a string in the PHC string format.
+//
+//nolint:gocognit // sequential optional fields of the PHC format
+func parsePHC(value string) (*phcString, bool) {
+	if !strings.HasPrefix(value, "$") {
+		return nil, false
+	}
+	fields := strings.Split(value[1:], "$")
+	p := &phcString{id: fields[0]}
+	if !isPHCName(p.id) {
+		return nil, false
+	}
+	fields = fields[1:]
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		p.version = fields[0][2:]
+		if _, err := strconv.ParseUint(p.version, 10, 32); err != nil {
+			return nil, false
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && strings.Contains(fields[0], "=") {
+		p.params = make(map[string]string)
+		for _, param := range strings.Split(fields[0], ",") {
+			name, val, ok := strings.Cut(param, "=")
+			if _, dup := p.params[name]; !ok || dup || !isPHCName(name) || val == "" ||
+				!containsOnly(val, phcAlphabet+"-.") {
+				return nil, false
+			}
+			p.params[name] = val
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 {
+		p.salt = fields[0]
+		if p.salt == "" || !containsOnly(p.salt, phcAlphabet+".-") {
+			return nil, false
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 {
+		p.hash = fields[0]
+		if len(p.hash) < 2 || !containsOnly(p.hash, phcAlphabet) {
+			return nil, false
+		}
+		fields = fields[1:]
+	}
+	return p, len(fields) == 0
+}
+
+// isPHCName reports whether s is a valid PHC function or parameter name.
+func isPHCName(s string) bool {
+	return len(s) > 0 && len(s) <= 32 && containsOnly(s, "abcdefghijklmnopqrstuvwxyz0123456789-")
+}
+
+// PHCStringRule validates that a string follows the PHC string format
+// ($id[$v=version][$param=value(,param=value)*][$salt[$hash]]) used by Argon2, scrypt,
+// PBKDF2 and other password hashing functions.
+//
+// Example:
+//
+//	rule := PHCString()
+//	err := rule.Validate("$pbkdf2-sha256$i=600000$c2FsdHNhbHQ$aGFzaGhhc2hoYXNo")  // returns nil
+//	err = rule.Validate("pbkdf2-sha256:600000:salt:hash")                         // returns error
+type PHCStringRule struct {
+	algorithms []string
+	e          error
+}
+
+// PHCString creates a new PHC string format validation rule.
+// Any function identifier is accepted unless Algorithms is used.
+//
+// Example:
+//
+//	rule := PHCString().Algorithms("argon2id", "scrypt").Errf("Unsupported password hash")
+func PHCString() *PHCStringRule {
+	return &PHCStringRule{
+		e: ErrPHCString,
+	}
+}
+
+// Algorithms restricts the accepted function identifiers.
+//
+// Example:
+//
+//	rule := PHCString().Algorithms("argon2id", "pbkdf2-sha256")
+func (r *PHCStringRule) Algorithms(ids ...string) *PHCStringRule {
+	r.algorithms = append([]string{}, ids...)
+	return r
+}
+
+// Validate checks if the string is in the PHC string format.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := PHCString()
+//	err := rule.Validate("$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E")  // returns nil
+//	err = rule.Validate("$Argon2id$v=19")  // returns error (identifiers are lower case)
+func (r *PHCStringRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	p, ok := parsePHC(value)
+	if !ok || (len(r.algorithms) > 0 && !slices.Contains(r.algorithms, p.id)) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPHCString
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PHCStringRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPHCString)
+}
+
+// Errf sets a custom error message for PHC string validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := PHCString().Errf("Invalid password hash format")
+func (r *PHCStringRule) Errf(format string, args ...any) *PHCStringRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// Argon2HashRule validates that a string is an Argon2 hash in PHC format
+// ($argon2id$v=19$m=65536,t=3,p=4$salt$hash) with acceptable cost parameters.
+//
+// Example:
+//
+//	rule := Argon2Hash().MinMemory(19456).MinIterations(2)
+//	err := rule.Validate("$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG")  // returns nil
+//	err = rule.Validate("$argon2id$v=19$m=1024,t=1,p=1$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG")   // returns error (too cheap)
+type Argon2HashRule struct {
+	variants       []string
+	minMemory      uint64
+	minIterations  uint64
+	minParallelism uint64
+	e              error
+}
+
+// Argon2Hash creates a new Argon2 hash validation rule.
+// The argon2id, argon2i and argon2d variants are accepted by default; the memory (m, in KiB),
+// iterations (t) and parallelism (p) parameters, a salt and a hash are required.
+// OWASP recommends at least m=19456, t=2, p=1 for argon2id.
+//
+// Example:
+//
+//	type Credential struct {
+//	    PasswordHash string `json:"password_hash"`
+//	}
+//
+//	rule := Argon2Hash().Variants("argon2id").MinMemory(19456).MinIterations(2)
+func Argon2Hash() *Argon2HashRule {
+	return &Argon2HashRule{
+		variants:       []string{"argon2id", "argon2i", "argon2d"},
+		minMemory:      8,
+		minIterations:  1,
+		minParallelism: 1,
+		e:              ErrArgon2Hash,
+	}
+}
+
+// Variants restricts the accepted Argon2 variants, e.g. to argon2id only.
+//
+// Example:
+//
+//	rule := Argon2Hash().Variants("argon2id")
+func (r *Argon2HashRule) Variants(variants ...string) *Argon2HashRule {
+	r.variants = append([]string{}, variants...)
+	return r
+}
+
+// MinMemory sets the minimum accepted memory cost in KiB.
+//
+// Example:
+//
+//	rule := Argon2Hash().MinMemory(64 * 1024)  // 64 MiB
+func (r *Argon2HashRule) MinMemory(kib uint64) *Argon2HashRule {
+	r.minMemory = kib
+	return r
+}
+
+// MinIterations sets the minimum accepted number of iterations (time cost).
+//
+// Example:
+//
+//	rule := Argon2Hash().MinIterations(3)
+func (r *Argon2HashRule) MinIterations(t uint64) *Argon2HashRule {
+	r.minIterations = t
+	return r
+}
+
+// MinParallelism sets the minimum accepted degree of parallelism.
+//
+// Example:
+//
+//	rule := Argon2Hash().MinParallelism(2)
+func (r *Argon2HashRule) MinParallelism(p uint64) *Argon2HashRule {
+	r.minParallelism = p
+	return r
+}
+
+// Validate checks if the string is a well-formed Argon2 hash with acceptable parameters.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := Argon2Hash()
+//	err := rule.Validate("$argon2i$v=19$m=4096,t=3,p=1$c29tZXNhbHQ$iWh06vD8Fy27wf9npn6FXWiCX4K6pW6Ue1Bnzz07Z8A")  // returns nil
+//	err = rule.Validate("$argon2id$v=19$m=4096,t=3$c29tZXNhbHQ$iWh06vD8Fy27wf9npn6FXWiCX4K6pW6Ue1Bnzz07Z8A")  // returns error (missing p)
+func (r *Argon2HashRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !r.valid(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrArgon2Hash
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *Argon2HashRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrArgon2Hash)
+}
+
+// valid reports whether value is an Argon2 PHC string with parameters in the accepted range.
+func (r *Argon2HashRule) valid(value string) bool {
+	p, ok := parsePHC(value)
+	if !ok || !slices.Contains(r.variants, p.id) || p.salt == "" || p.hash == "" ||
+		(p.version != "" && p.version != "16" && p.version != "19") || len(p.params) != 3 {
+		return false
+	}
+	for name, min := range map[string]uint64{"m": r.minMemory, "t": r.minIterations, "p": r.minParallelism} {
+		n, err := strconv.ParseUint(p.params[name], 10, 32)
+		if err != nil || n < min || n == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Errf sets a custom error message for Argon2 hash validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := Argon2Hash().Errf("Password hash must be Argon2 with OWASP recommended parameters")
+func (r *Argon2HashRule) Errf(format string, args ...any) *Argon2HashRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
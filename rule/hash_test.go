@@ -0,0 +1,113 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBcrypt12 = "$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+	testArgon2id = "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG"
+)
+
+func TestBcryptHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *BcryptHashRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: 2b cost 12", rule: BcryptHash(), value: testBcrypt12, wantErr: false},
+		{name: "valid: 2y cost 10", rule: BcryptHash(), value: "$2y$10$.vGA1O9wmRjrwAVXD98HNOgsNpDczlqm3Jq7KnEd1rVAGv3Fykk1a", wantErr: false},
+		{name: "valid: within cost range", rule: BcryptHash().MinCost(10).MaxCost(12), value: testBcrypt12, wantErr: false},
+		{name: "valid: empty string", rule: BcryptHash(), value: "", wantErr: false},
+		{name: "invalid: cost below minimum", rule: BcryptHash().MinCost(13), value: testBcrypt12, wantErr: true},
+		{name: "invalid: cost above maximum", rule: BcryptHash().MaxCost(11), value: testBcrypt12, wantErr: true},
+		{name: "invalid: cost out of bcrypt range", rule: BcryptHash(), value: "$2b$03$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", wantErr: true},
+		{name: "invalid: signed cost", rule: BcryptHash(), value: "$2b$+5$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", wantErr: true},
+		{name: "invalid: unknown version", rule: BcryptHash(), value: "$2c$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", wantErr: true},
+		{name: "invalid: truncated", rule: BcryptHash(), value: testBcrypt12[:59], wantErr: true},
+		{name: "invalid: bad character", rule: BcryptHash(), value: "$2b$12$R9h/cIPz0gi+URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW", wantErr: true},
+		{name: "custom error message", rule: BcryptHash().Errf("custom error"), value: "$1$abc$def", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BcryptHashRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArgon2Hash(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *Argon2HashRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: argon2id", rule: Argon2Hash(), value: testArgon2id, wantErr: false},
+		{name: "valid: argon2i without version", rule: Argon2Hash(), value: "$argon2i$m=4096,t=3,p=1$c29tZXNhbHQ$iWh06vD8Fy27wf9npn6FXWiCX4K6pW6Ue1Bnzz07Z8A", wantErr: false},
+		{name: "valid: OWASP minimums", rule: Argon2Hash().Variants("argon2id").MinMemory(19456).MinIterations(2).MinParallelism(1), value: testArgon2id, wantErr: false},
+		{name: "valid: empty string", rule: Argon2Hash(), value: "", wantErr: false},
+		{name: "invalid: memory too low", rule: Argon2Hash().MinMemory(128 * 1024), value: testArgon2id, wantErr: true},
+		{name: "invalid: iterations too low", rule: Argon2Hash().MinIterations(4), value: testArgon2id, wantErr: true},
+		{name: "invalid: parallelism too low", rule: Argon2Hash().MinParallelism(8), value: testArgon2id, wantErr: true},
+		{name: "invalid: variant not allowed", rule: Argon2Hash().Variants("argon2id"), value: "$argon2i$v=19$m=4096,t=3,p=1$c29tZXNhbHQ$iWh06vD8Fy27wf9npn6FXWiCX4K6pW6Ue1Bnzz07Z8A", wantErr: true},
+		{name: "invalid: missing parameter", rule: Argon2Hash(), value: "$argon2id$v=19$m=65536,t=3$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", wantErr: true},
+		{name: "invalid: missing hash", rule: Argon2Hash(), value: "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ", wantErr: true},
+		{name: "invalid: unknown version", rule: Argon2Hash(), value: "$argon2id$v=20$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", wantErr: true},
+		{name: "invalid: not argon2", rule: Argon2Hash(), value: testBcrypt12, wantErr: true},
+		{name: "custom error message", rule: Argon2Hash().Errf("custom error"), value: "$argon2id$", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Argon2HashRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPHCString(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *PHCStringRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: argon2id", rule: PHCString(), value: testArgon2id, wantErr: false},
+		{name: "valid: pbkdf2", rule: PHCString(), value: "$pbkdf2-sha256$i=600000$c2FsdHNhbHQ$aGFzaGhhc2hoYXNo", wantErr: false},
+		{name: "valid: identifier only", rule: PHCString(), value: "$scrypt", wantErr: false},
+		{name: "valid: allowed algorithm", rule: PHCString().Algorithms("argon2id", "scrypt"), value: testArgon2id, wantErr: false},
+		{name: "valid: empty string", rule: PHCString(), value: "", wantErr: false},
+		{name: "invalid: algorithm not allowed", rule: PHCString().Algorithms("scrypt"), value: testArgon2id, wantErr: true},
+		{name: "invalid: upper case identifier", rule: PHCString(), value: "$Argon2id$v=19", wantErr: true},
+		{name: "invalid: duplicate parameter", rule: PHCString(), value: "$scrypt$ln=16,ln=8$c2FsdA$aGFzaA", wantErr: true},
+		{name: "invalid: padded hash", rule: PHCString(), value: "$scrypt$ln=16$c2FsdA$aGFzaA==", wantErr: true},
+		{name: "invalid: too many fields", rule: PHCString(), value: "$scrypt$ln=16$c2FsdA$aGFzaA$extra", wantErr: true},
+		{name: "invalid: no leading dollar", rule: PHCString(), value: "pbkdf2-sha256:600000:salt:hash", wantErr: true},
+		{name: "custom error message", rule: PHCString().Errf("custom error"), value: "$", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PHCStringRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHashFallback(t *testing.T) {
+	assert.ErrorIs(t, (&BcryptHashRule{}).Validate("$1$abc$def"), ErrBcryptHash)
+	assert.Nil(t, (&BcryptHashRule{}).Validate(testBcrypt12))
+	assert.ErrorIs(t, (&Argon2HashRule{}).Validate(testArgon2id), ErrArgon2Hash)
+	assert.ErrorIs(t, (&PHCStringRule{}).Validate("$"), ErrPHCString)
+}
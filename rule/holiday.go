@@ -0,0 +1,347 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains holiday calendar providers used by the Holiday and Workday rules.
+package rule
+
+import (
+	"slices"
+	"time"
+)
+
+// HolidayCalendar is the interface implemented by holiday providers.
+// Lookup reports whether the calendar date of t is a holiday and, if so, its name.
+// Only the year, month and day of t are considered.
+//
+// Example:
+//
+//	type CompanyCalendar struct{}
+//
+//	func (CompanyCalendar) Lookup(t time.Time) (string, bool) {
+//	    if t.Month() == time.March && t.Day() == 1 {
+//	        return "Founders' Day", true
+//	    }
+//	    return "", false
+//	}
+//
+//	rule := Workday().ExcludeHolidays(CompanyCalendar{})
+type HolidayCalendar interface {
+	Lookup(t time.Time) (name string, ok bool)
+}
+
+// AdjustedWorkdayCalendar is an optional interface for calendars that move working days
+// onto weekends, such as the make-up workdays of the Chinese statutory holiday schedule.
+// The Workday rule treats such dates as workdays even if they fall on a weekend day.
+type AdjustedWorkdayCalendar interface {
+	HolidayCalendar
+	IsAdjustedWorkday(t time.Time) bool
+}
+
+// PartialCalendar is an optional interface for calendars that only know the holidays of
+// some years, such as schedules that are announced a year at a time. Covers reports
+// whether the calendar has data for the date of t. The Workday and Holiday rules return
+// ErrHolidayUnknown for dates that are not covered instead of guessing.
+type PartialCalendar interface {
+	HolidayCalendar
+	Covers(t time.Time) bool
+}
+
+// civilDate is a calendar date without time or location, used as a map key.
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// dateOf returns the calendar date of t in its own location.
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{year: y, month: m, day: d}
+}
+
+// HolidayListCalendar is a HolidayCalendar backed by an explicit list of dates.
+//
+// Example:
+//
+//	calendar := HolidayList(
+//	    time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+//	    time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC),
+//	)
+//	name, ok := calendar.Lookup(time.Date(2024, 12, 25, 9, 0, 0, 0, time.UTC))  // "", true
+type HolidayListCalendar struct {
+	dates map[civilDate]string
+}
+
+// HolidayList creates a new HolidayCalendar from a list of dates.
+// Dates are compared by year, month and day.
+//
+// Example:
+//
+//	calendar := HolidayList(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	rule := Workday().ExcludeHolidays(calendar)
+func HolidayList(dates ...time.Time) *HolidayListCalendar {
+	c := &HolidayListCalendar{dates: make(map[civilDate]string, len(dates))}
+	for _, date := range dates {
+		c.dates[dateOf(date)] = ""
+	}
+	return c
+}
+
+// Add adds a named holiday to the calendar.
+//
+// Example:
+//
+//	calendar := HolidayList().Add(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Founders' Day")
+func (c *HolidayListCalendar) Add(date time.Time, name string) *HolidayListCalendar {
+	if c.dates == nil {
+		c.dates = make(map[civilDate]string)
+	}
+	c.dates[dateOf(date)] = name
+	return c
+}
+
+// Lookup reports whether t falls on one of the listed dates.
+func (c *HolidayListCalendar) Lookup(t time.Time) (string, bool) {
+	name, ok := c.dates[dateOf(t)]
+	return name, ok
+}
+
+// CNHolidayCalendar is a HolidayCalendar for the statutory public holidays of mainland China
+// as published annually by the State Council, including the make-up workdays (调休)
+// that move working days onto weekends.
+//
+// The schedule is only known once it has been announced, so the calendar covers a fixed
+// set of years (see CNStatutoryHolidays and Years) and implements PartialCalendar, so
+// that dates of other years are reported as unknown instead of treated as workdays.
+// Additional years can be added with AddHoliday and AddWorkday.
+type CNHolidayCalendar struct {
+	holidays map[civilDate]string
+	workdays map[civilDate]bool
+	years    map[int]bool
+}
+
+// CNStatutoryHolidays returns a calendar with the Chinese statutory holiday schedule for
+// 2024, 2025 and 2026.
+//
+// Example:
+//
+//	rule := Workday().ExcludeHolidays(CNStatutoryHolidays())
+//	err := rule.Validate(time.Date(2024, 10, 2, 10, 0, 0, 0, time.Local))  // returns error (National Day)
+//	err = rule.Validate(time.Date(2024, 9, 29, 10, 0, 0, 0, time.Local))  // returns nil (make-up workday on Sunday)
+func CNStatutoryHolidays() *CNHolidayCalendar {
+	c := &CNHolidayCalendar{
+		holidays: make(map[civilDate]string),
+		workdays: make(map[civilDate]bool),
+		years:    make(map[int]bool),
+	}
+
+	// 2024 (国办发明电〔2023〕7号)
+	c.span(2024, time.January, 1, 1, "元旦")
+	c.span(2024, time.February, 10, 8, "春节")
+	c.span(2024, time.April, 4, 3, "清明节")
+	c.span(2024, time.May, 1, 5, "劳动节")
+	c.span(2024, time.June, 10, 1, "端午节")
+	c.span(2024, time.September, 15, 3, "中秋节")
+	c.span(2024, time.October, 1, 7, "国庆节")
+	c.makeup(2024, time.February, 4)
+	c.makeup(2024, time.February, 18)
+	c.makeup(2024, time.April, 7)
+	c.makeup(2024, time.April, 28)
+	c.makeup(2024, time.May, 11)
+	c.makeup(2024, time.September, 14)
+	c.makeup(2024, time.September, 29)
+	c.makeup(2024, time.October, 12)
+
+	// 2025 (国办发明电〔2024〕12号)
+	c.span(2025, time.January, 1, 1, "元旦")
+	c.span(2025, time.January, 28, 8, "春节")
+	c.span(2025, time.April, 4, 3, "清明节")
+	c.span(2025, time.May, 1, 5, "劳动节")
+	c.span(2025, time.May, 31, 3, "端午节")
+	c.span(2025, time.October, 1, 8, "国庆节、中秋节")
+	c.makeup(2025, time.January, 26)
+	c.makeup(2025, time.February, 8)
+	c.makeup(2025, time.April, 27)
+	c.makeup(2025, time.September, 28)
+	c.makeup(2025, time.October, 11)
+
+	// 2026 (国办发明电〔2025〕7号)
+	c.span(2026, time.January, 1, 3, "元旦")
+	c.span(2026, time.February, 15, 9, "春节")
+	c.span(2026, time.April, 4, 3, "清明节")
+	c.span(2026, time.May, 1, 5, "劳动节")
+	c.span(2026, time.June, 19, 3, "端午节")
+	c.span(2026, time.September, 25, 3, "中秋节")
+	c.span(2026, time.October, 1, 7, "国庆节")
+	c.makeup(2026, time.January, 4)
+	c.makeup(2026, time.February, 14)
+	c.makeup(2026, time.February, 28)
+	c.makeup(2026, time.May, 9)
+	c.makeup(2026, time.September, 20)
+	c.makeup(2026, time.October, 10)
+
+	return c
+}
+
+// span records n consecutive holiday dates starting at the given date.
+func (c *CNHolidayCalendar) span(year int, month time.Month, day, n int, name string) {
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		c.AddHoliday(start.AddDate(0, 0, i), name)
+	}
+}
+
+// makeup records a make-up workday.
+func (c *CNHolidayCalendar) makeup(year int, month time.Month, day int) {
+	c.AddWorkday(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// AddHoliday adds a holiday to the calendar, e.g. for a newly announced year. The year
+// of the date counts as covered from then on, so the whole schedule of a new year should
+// be added.
+//
+// Example:
+//
+//	calendar := CNStatutoryHolidays().AddHoliday(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "元旦")
+func (c *CNHolidayCalendar) AddHoliday(date time.Time, name string) *CNHolidayCalendar {
+	if c.holidays == nil {
+		c.holidays = make(map[civilDate]string)
+	}
+	c.holidays[dateOf(date)] = name
+	c.cover(date)
+	return c
+}
+
+// AddWorkday adds a make-up workday to the calendar, e.g. for a newly announced year. Like
+// AddHoliday, it marks the year of the date as covered.
+//
+// Example:
+//
+//	calendar := CNStatutoryHolidays().AddWorkday(time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC))
+func (c *CNHolidayCalendar) AddWorkday(date time.Time) *CNHolidayCalendar {
+	if c.workdays == nil {
+		c.workdays = make(map[civilDate]bool)
+	}
+	c.workdays[dateOf(date)] = true
+	c.cover(date)
+	return c
+}
+
+// cover records the year of date as covered by the calendar.
+func (c *CNHolidayCalendar) cover(date time.Time) {
+	if c.years == nil {
+		c.years = make(map[int]bool)
+	}
+	c.years[dateOf(date).year] = true
+}
+
+// Years returns the years the calendar has a schedule for, in ascending order.
+//
+// Example:
+//
+//	years := CNStatutoryHolidays().Years()  // [2024 2025 2026]
+func (c *CNHolidayCalendar) Years() []int {
+	years := make([]int, 0, len(c.years))
+	for year := range c.years {
+		years = append(years, year)
+	}
+	slices.Sort(years)
+	return years
+}
+
+// Covers reports whether the calendar has the schedule of the year of t.
+func (c *CNHolidayCalendar) Covers(t time.Time) bool {
+	return c.years[dateOf(t).year]
+}
+
+// Lookup reports whether t falls on a statutory holiday and returns its name.
+func (c *CNHolidayCalendar) Lookup(t time.Time) (string, bool) {
+	name, ok := c.holidays[dateOf(t)]
+	return name, ok
+}
+
+// IsAdjustedWorkday reports whether t falls on a weekend day that has been made a workday.
+func (c *CNHolidayCalendar) IsAdjustedWorkday(t time.Time) bool {
+	return c.workdays[dateOf(t)]
+}
+
+// USFederalCalendar is a HolidayCalendar for the United States federal holidays (5 U.S.C. 6103).
+// Holidays falling on a Saturday are observed on the preceding Friday and holidays falling
+// on a Sunday are observed on the following Monday; Lookup matches the observed date.
+type USFederalCalendar struct{}
+
+// USFederalHolidays returns a calendar for the United States federal holidays.
+// The dates are computed, so any year is supported.
+//
+// Example:
+//
+//	rule := Workday().ExcludeHolidays(USFederalHolidays())
+//	err := rule.Validate(time.Date(2024, 11, 28, 10, 0, 0, 0, time.UTC))  // returns error (Thanksgiving Day)
+func USFederalHolidays() USFederalCalendar {
+	return USFederalCalendar{}
+}
+
+// Lookup reports whether t falls on the observed date of a federal holiday and returns its name.
+func (USFederalCalendar) Lookup(t time.Time) (string, bool) {
+	date := dateOf(t)
+	// New Year's Day of the following year may be observed on December 31st.
+	for _, year := range []int{date.year, date.year + 1} {
+		for _, h := range usFederalHolidays(year) {
+			if dateOf(h.date) == date {
+				return h.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// namedDate is a holiday date with its name.
+type namedDate struct {
+	name string
+	date time.Time
+}
+
+// usFederalHolidays returns the observed federal holiday dates of a year.
+func usFederalHolidays(year int) []namedDate {
+	fixed := func(month time.Month, day int) time.Time {
+		return observed(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	holidays := []namedDate{
+		{"New Year's Day", fixed(time.January, 1)},
+		{"Birthday of Martin Luther King, Jr.", nthWeekday(year, time.January, time.Monday, 3)},
+		{"Washington's Birthday", nthWeekday(year, time.February, time.Monday, 3)},
+		{"Memorial Day", nthWeekday(year, time.May, time.Monday, -1)},
+		{"Independence Day", fixed(time.July, 4)},
+		{"Labor Day", nthWeekday(year, time.September, time.Monday, 1)},
+		{"Columbus Day", nthWeekday(year, time.October, time.Monday, 2)},
+		{"Veterans Day", fixed(time.November, 11)},
+		{"Thanksgiving Day", nthWeekday(year, time.November, time.Thursday, 4)},
+		{"Christmas Day", fixed(time.December, 25)},
+	}
+	if year >= 2021 {
+		holidays = append(holidays, namedDate{"Juneteenth National Independence Day", fixed(time.June, 19)})
+	}
+	return holidays
+}
+
+// observed moves a Saturday holiday to Friday and a Sunday holiday to Monday.
+func observed(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, -1)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	default:
+		return t
+	}
+}
+
+// nthWeekday returns the n-th weekday of a month; a negative n counts from the end of the month.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset+(n+1)*7)
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
@@ -0,0 +1,125 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolidayList(t *testing.T) {
+	christmas := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+	calendar := HolidayList(christmas).Add(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Founders' Day")
+
+	_, ok := calendar.Lookup(christmas.Add(15 * time.Hour))
+	assert.True(t, ok)
+	name, ok := calendar.Lookup(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Founders' Day", name)
+	_, ok = calendar.Lookup(time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	_, ok = (&HolidayListCalendar{}).Add(christmas, "Christmas").Lookup(christmas)
+	assert.True(t, ok)
+}
+
+func TestCNStatutoryHolidays(t *testing.T) {
+	calendar := CNStatutoryHolidays()
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		holiday string
+		ok      bool
+	}{
+		{name: "spring festival 2024", date: time.Date(2024, 2, 12, 0, 0, 0, 0, time.Local), holiday: "春节", ok: true},
+		{name: "last day of national day 2025", date: time.Date(2025, 10, 8, 0, 0, 0, 0, time.Local), holiday: "国庆节、中秋节", ok: true},
+		{name: "new year 2026", date: time.Date(2026, 1, 3, 0, 0, 0, 0, time.Local), holiday: "元旦", ok: true},
+		{name: "spring festival 2026", date: time.Date(2026, 2, 23, 0, 0, 0, 0, time.Local), holiday: "春节", ok: true},
+		{name: "mid-autumn festival 2026", date: time.Date(2026, 9, 25, 0, 0, 0, 0, time.Local), holiday: "中秋节", ok: true},
+		{name: "regular workday", date: time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local), ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := calendar.Lookup(tt.date)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.holiday, name)
+		})
+	}
+
+	assert.True(t, calendar.IsAdjustedWorkday(time.Date(2024, 9, 29, 0, 0, 0, 0, time.Local)))
+	assert.False(t, calendar.IsAdjustedWorkday(time.Date(2024, 9, 22, 0, 0, 0, 0, time.Local)))
+	assert.True(t, calendar.IsAdjustedWorkday(time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local)))
+
+	assert.Equal(t, []int{2024, 2025, 2026}, calendar.Years())
+	assert.True(t, calendar.Covers(time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)))
+	assert.False(t, calendar.Covers(time.Date(2023, 12, 31, 0, 0, 0, 0, time.Local)))
+	assert.False(t, calendar.Covers(time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local)))
+
+	extended := CNStatutoryHolidays().
+		AddHoliday(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "元旦").
+		AddWorkday(time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC))
+	_, ok := extended.Lookup(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.True(t, extended.IsAdjustedWorkday(time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []int{2024, 2025, 2026, 2027}, extended.Years())
+
+	empty := (&CNHolidayCalendar{}).AddHoliday(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), "元旦").AddWorkday(time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC))
+	_, ok = empty.Lookup(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, []int{2026}, empty.Years())
+	assert.Empty(t, (&CNHolidayCalendar{}).Years())
+}
+
+func TestUSFederalHolidays(t *testing.T) {
+	calendar := USFederalHolidays()
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		holiday string
+		ok      bool
+	}{
+		{name: "martin luther king day", date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), holiday: "Birthday of Martin Luther King, Jr.", ok: true},
+		{name: "memorial day", date: time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC), holiday: "Memorial Day", ok: true},
+		{name: "juneteenth", date: time.Date(2024, 6, 19, 0, 0, 0, 0, time.UTC), holiday: "Juneteenth National Independence Day", ok: true},
+		{name: "no juneteenth before 2021", date: time.Date(2020, 6, 19, 0, 0, 0, 0, time.UTC), ok: false},
+		{name: "labor day", date: time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC), holiday: "Labor Day", ok: true},
+		{name: "thanksgiving", date: time.Date(2024, 11, 28, 0, 0, 0, 0, time.UTC), holiday: "Thanksgiving Day", ok: true},
+		{name: "independence day observed on friday", date: time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC), holiday: "Independence Day", ok: true},
+		{name: "christmas observed on monday", date: time.Date(2022, 12, 26, 0, 0, 0, 0, time.UTC), holiday: "Christmas Day", ok: true},
+		{name: "new year observed in previous year", date: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC), holiday: "New Year's Day", ok: true},
+		{name: "regular day", date: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := calendar.Lookup(tt.date)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.holiday, name)
+		})
+	}
+}
+
+func TestWorkdayExcludeHolidays(t *testing.T) {
+	rule := Workday().ExcludeHolidays(CNStatutoryHolidays())
+	assert.ErrorIs(t, rule.Validate(time.Date(2024, 10, 2, 10, 0, 0, 0, time.Local)), ErrWorkday)
+	assert.Nil(t, rule.Validate(time.Date(2024, 9, 29, 10, 0, 0, 0, time.Local)))
+	assert.ErrorIs(t, rule.Validate(time.Date(2024, 9, 22, 10, 0, 0, 0, time.Local)), ErrWorkday)
+	assert.Nil(t, rule.Validate(time.Date(2024, 3, 15, 10, 0, 0, 0, time.Local)))
+	assert.Nil(t, rule.Validate(time.Date(2026, 10, 10, 10, 0, 0, 0, time.Local)))
+	assert.ErrorIs(t, rule.Validate(time.Date(2026, 10, 7, 10, 0, 0, 0, time.Local)), ErrWorkday)
+
+	// Years without a published schedule are reported instead of treated as workdays.
+	assert.ErrorIs(t, rule.Validate(time.Date(2027, 3, 15, 10, 0, 0, 0, time.Local)), ErrHolidayUnknown)
+	assert.ErrorIs(t, rule.Validate(time.Date(2023, 3, 15, 10, 0, 0, 0, time.Local)), ErrHolidayUnknown)
+	assert.ErrorIs(t, HolidayIn(CNStatutoryHolidays()).Validate(time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local)), ErrHolidayUnknown)
+
+	us := Workday().ExcludeHolidays(USFederalHolidays())
+	assert.ErrorIs(t, us.Validate(time.Date(2024, 11, 28, 10, 0, 0, 0, time.UTC)), ErrWorkday)
+}
+
+func TestHolidayIn(t *testing.T) {
+	rule := HolidayIn(USFederalHolidays())
+	assert.Nil(t, rule.Validate(time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)))
+	assert.ErrorIs(t, rule.Validate(time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)), ErrHoliday)
+}
@@ -0,0 +1,279 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the SafeHTML rule, which validates HTML against a tag and attribute allowlist.
+package rule
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrSafeHTML is returned when HTML contains elements, attributes or URLs that are not allowed by the policy.
+var ErrSafeHTML = newError("safe_html", "input contains disallowed HTML")
+
+// defaultHTMLElements are the elements allowed by SafeHTML(), suitable for user generated rich text.
+var defaultHTMLElements = []string{
+	"a", "abbr", "b", "blockquote", "br", "caption", "cite", "code", "col", "colgroup",
+	"dd", "del", "div", "dl", "dt", "em", "figcaption", "figure", "h1", "h2", "h3", "h4",
+	"h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark", "ol", "p", "pre", "q", "s",
+	"small", "span", "strong", "sub", "sup", "table", "tbody", "td", "tfoot", "th",
+	"thead", "tr", "u", "ul",
+}
+
+// defaultHTMLAttributes are the attributes allowed by SafeHTML(), keyed by element.
+// The "*" key holds attributes allowed on every element.
+var defaultHTMLAttributes = map[string][]string{
+	"*":          {"title", "dir", "lang"},
+	"a":          {"href", "rel", "target"},
+	"img":        {"src", "alt", "width", "height"},
+	"blockquote": {"cite"},
+	"q":          {"cite"},
+	"del":        {"cite", "datetime"},
+	"ins":        {"cite", "datetime"},
+	"ol":         {"start", "type", "reversed"},
+	"li":         {"value"},
+	"td":         {"colspan", "rowspan", "headers"},
+	"th":         {"colspan", "rowspan", "headers", "scope"},
+	"col":        {"span"},
+	"colgroup":   {"span"},
+}
+
+// urlAttributes are attributes whose values are URLs and must use an allowed scheme.
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "cite": true, "action": true, "formaction": true,
+	"poster": true, "background": true, "longdesc": true, "srcset": true,
+}
+
+// SafeHTMLRule validates HTML by parsing it with an HTML tokenizer and checking every
+// element, attribute and URL against an allowlist policy, similar to bluemonday.
+// Unlike XSS(), which searches for known attack patterns, anything not explicitly
+// allowed is rejected, and harmless text such as "onclick=" outside a tag is accepted.
+//
+// Example:
+//
+//	rule := SafeHTML()
+//	err := rule.Validate(`<p>Hello <a href="https://example.com">world</a></p>`)  // returns nil
+//	err = rule.Validate(`<p onclick="steal()">Hello</p>`)                        // returns error
+//	err = rule.Validate(`<a href="javascript:alert(1)">x</a>`)                   // returns error
+type SafeHTMLRule struct {
+	elements   map[string]bool
+	attributes map[string]map[string]bool
+	schemes    map[string]bool
+	comments   bool
+	e          error
+}
+
+// SafeHTML creates a new HTML allowlist validation rule for rich-text fields.
+// The default policy allows common formatting, list, table, link and image elements
+// with their usual attributes, http, https and mailto URLs, and no inline styles,
+// event handlers, scripts, forms or embedded content.
+//
+// Example:
+//
+//	type Post struct {
+//	    Body string `json:"body"`
+//	}
+//
+//	rule := SafeHTML().AllowAttributes("*", "class").Errf("Post contains unsupported HTML")
+func SafeHTML() *SafeHTMLRule {
+	r := &SafeHTMLRule{
+		elements:   make(map[string]bool),
+		attributes: make(map[string]map[string]bool),
+		schemes:    map[string]bool{"http": true, "https": true, "mailto": true},
+		comments:   true,
+		e:          ErrSafeHTML,
+	}
+	r.AllowElements(defaultHTMLElements...)
+	for element, attrs := range defaultHTMLAttributes {
+		r.AllowAttributes(element, attrs...)
+	}
+	return r
+}
+
+// StrictHTML creates a new HTML allowlist validation rule that allows no elements at all,
+// so the input must be plain text. Use AllowElements and AllowAttributes to build a policy.
+//
+// Example:
+//
+//	rule := StrictHTML().AllowElements("b", "i", "br")
+//	err := rule.Validate("<b>bold</b>")  // returns nil
+//	err = rule.Validate("<p>para</p>")  // returns error
+func StrictHTML() *SafeHTMLRule {
+	return &SafeHTMLRule{
+		elements:   make(map[string]bool),
+		attributes: make(map[string]map[string]bool),
+		schemes:    map[string]bool{"http": true, "https": true, "mailto": true},
+		e:          ErrSafeHTML,
+	}
+}
+
+// AllowElements adds elements to the allowlist.
+//
+// Example:
+//
+//	rule := SafeHTML().AllowElements("details", "summary")
+func (r *SafeHTMLRule) AllowElements(elements ...string) *SafeHTMLRule {
+	if r.elements == nil {
+		r.elements = make(map[string]bool, len(elements))
+	}
+	for _, element := range elements {
+		r.elements[strings.ToLower(element)] = true
+	}
+	return r
+}
+
+// DisallowElements removes elements from the allowlist.
+//
+// Example:
+//
+//	rule := SafeHTML().DisallowElements("img", "table")
+func (r *SafeHTMLRule) DisallowElements(elements ...string) *SafeHTMLRule {
+	for _, element := range elements {
+		delete(r.elements, strings.ToLower(element))
+	}
+	return r
+}
+
+// AllowAttributes allows attributes on an element, or on every element if element is "*".
+// Event handler attributes (on*) are never allowed.
+//
+// Example:
+//
+//	rule := SafeHTML().AllowAttributes("*", "class").AllowAttributes("span", "style")
+func (r *SafeHTMLRule) AllowAttributes(element string, attrs ...string) *SafeHTMLRule {
+	if r.attributes == nil {
+		r.attributes = make(map[string]map[string]bool)
+	}
+	element = strings.ToLower(element)
+	if r.attributes[element] == nil {
+		r.attributes[element] = make(map[string]bool, len(attrs))
+	}
+	for _, attr := range attrs {
+		r.attributes[element][strings.ToLower(attr)] = true
+	}
+	return r
+}
+
+// AllowURLSchemes sets the URL schemes allowed in URL attributes such as href and src,
+// replacing the default http, https and mailto. Relative URLs are always allowed.
+//
+// Example:
+//
+//	rule := SafeHTML().AllowURLSchemes("https", "tel")
+func (r *SafeHTMLRule) AllowURLSchemes(schemes ...string) *SafeHTMLRule {
+	r.schemes = make(map[string]bool, len(schemes))
+	for _, scheme := range schemes {
+		r.schemes[strings.ToLower(scheme)] = true
+	}
+	return r
+}
+
+// DisallowComments rejects HTML comments, which SafeHTML() allows by default.
+//
+// Example:
+//
+//	rule := SafeHTML().DisallowComments()
+func (r *SafeHTMLRule) DisallowComments() *SafeHTMLRule {
+	r.comments = false
+	return r
+}
+
+// Validate checks if the HTML only contains allowed elements, attributes and URLs.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := SafeHTML()
+//	err := rule.Validate("<p><strong>Bold</strong> text</p>")  // returns nil
+//	err = rule.Validate("<script>alert(1)</script>")         // returns error
+//	err = rule.Validate("")                                  // returns nil (empty string is valid)
+func (r *SafeHTMLRule) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	if !r.safe(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrSafeHTML
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *SafeHTMLRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrSafeHTML)
+}
+
+// safe tokenizes value and reports whether every token is allowed by the policy.
+func (r *SafeHTMLRule) safe(value string) bool {
+	z := html.NewTokenizer(strings.NewReader(value))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return errors.Is(z.Err(), io.EOF)
+		case html.TextToken:
+		case html.CommentToken:
+			if !r.comments {
+				return false
+			}
+		case html.DoctypeToken:
+			return false
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			if !r.allowedTag(z.Token()) {
+				return false
+			}
+		}
+	}
+}
+
+// allowedTag reports whether the element and all of its attributes are allowed.
+func (r *SafeHTMLRule) allowedTag(token html.Token) bool {
+	if !r.elements[token.Data] {
+		return false
+	}
+	for _, attr := range token.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || strings.HasPrefix(key, "on") ||
+			(!r.attributes[token.Data][key] && !r.attributes["*"][key]) {
+			return false
+		}
+		if urlAttributes[key] && !r.allowedURL(attr.Val) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedURL reports whether value is a relative URL or uses an allowed scheme.
+// ASCII whitespace and control characters are removed first, as browsers ignore them
+// inside schemes such as "java\tscript:".
+func (r *SafeHTMLRule) allowedURL(value string) bool {
+	value = strings.Map(func(c rune) rune {
+		if c <= ' ' || c == 0x7f {
+			return -1
+		}
+		return c
+	}, value)
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || r.schemes[strings.ToLower(u.Scheme)]
+}
+
+// Errf sets a custom error message for HTML validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := SafeHTML().Errf("Description contains unsupported HTML")
+func (r *SafeHTMLRule) Errf(format string, args ...any) *SafeHTMLRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,59 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *SafeHTMLRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: formatted text", rule: SafeHTML(), value: "<p><strong>Bold</strong> and <em>italic</em></p>", wantErr: false},
+		{name: "valid: link", rule: SafeHTML(), value: `<a href="https://example.com" title="Example">link</a>`, wantErr: false},
+		{name: "valid: relative link", rule: SafeHTML(), value: `<a href="/docs?page=2#intro">docs</a>`, wantErr: false},
+		{name: "valid: image", rule: SafeHTML(), value: `<img src="https://example.com/cat.png" alt="cat">`, wantErr: false},
+		{name: "valid: event handler text outside tags", rule: SafeHTML(), value: "Use onclick=handler() in your template", wantErr: false},
+		{name: "valid: comment", rule: SafeHTML(), value: "<p>text</p><!-- note -->", wantErr: false},
+		{name: "valid: plain text", rule: SafeHTML(), value: "1 < 2 & 3 > 2", wantErr: false},
+		{name: "valid: empty string", rule: SafeHTML(), value: "", wantErr: false},
+		{name: "invalid: script", rule: SafeHTML(), value: "<script>alert(1)</script>", wantErr: true},
+		{name: "invalid: event handler", rule: SafeHTML(), value: `<p onclick="steal()">Hello</p>`, wantErr: true},
+		{name: "invalid: javascript URL", rule: SafeHTML(), value: `<a href="javascript:alert(1)">x</a>`, wantErr: true},
+		{name: "invalid: obfuscated javascript URL", rule: SafeHTML(), value: `<a href="java&#x09;script:alert(1)">x</a>`, wantErr: true},
+		{name: "invalid: data URL image", rule: SafeHTML(), value: `<img src="data:image/svg+xml;base64,PHN2Zz4=">`, wantErr: true},
+		{name: "invalid: inline style", rule: SafeHTML(), value: `<span style="position:fixed">x</span>`, wantErr: true},
+		{name: "invalid: iframe", rule: SafeHTML(), value: `<iframe src="https://example.com"></iframe>`, wantErr: true},
+		{name: "invalid: svg", rule: SafeHTML(), value: `<svg><circle r="1"/></svg>`, wantErr: true},
+		{name: "invalid: doctype", rule: SafeHTML(), value: "<!DOCTYPE html><p>x</p>", wantErr: true},
+		{name: "valid: allowed class attribute", rule: SafeHTML().AllowAttributes("*", "class"), value: `<p class="lead">x</p>`, wantErr: false},
+		{name: "valid: allowed element", rule: SafeHTML().AllowElements("details", "summary"), value: "<details><summary>More</summary>text</details>", wantErr: false},
+		{name: "invalid: disallowed element", rule: SafeHTML().DisallowElements("img"), value: `<img src="cat.png">`, wantErr: true},
+		{name: "valid: allowed URL scheme", rule: SafeHTML().AllowURLSchemes("tel"), value: `<a href="tel:+123456">call</a>`, wantErr: false},
+		{name: "invalid: replaced URL scheme", rule: SafeHTML().AllowURLSchemes("tel"), value: `<a href="https://example.com">x</a>`, wantErr: true},
+		{name: "invalid: event handler cannot be allowed", rule: SafeHTML().AllowAttributes("p", "onclick"), value: `<p onclick="x()">x</p>`, wantErr: true},
+		{name: "invalid: comments disallowed", rule: SafeHTML().DisallowComments(), value: "<!-- note -->", wantErr: true},
+		{name: "valid: strict plain text", rule: StrictHTML(), value: "just text", wantErr: false},
+		{name: "invalid: strict formatting", rule: StrictHTML(), value: "<b>bold</b>", wantErr: true},
+		{name: "valid: strict with allowed element", rule: StrictHTML().AllowElements("b"), value: "<b>bold</b>", wantErr: false},
+		{name: "custom error message", rule: SafeHTML().Errf("custom error"), value: "<script></script>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SafeHTMLRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSafeHTMLFallback(t *testing.T) {
+	assert.ErrorIs(t, (&SafeHTMLRule{}).Validate("<b>bold</b>"), ErrSafeHTML)
+	assert.Nil(t, (&SafeHTMLRule{}).Validate("plain text"))
+}
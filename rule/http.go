@@ -0,0 +1,220 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating HTTP header names and values and methods.
+package rule
+
+import (
+	"net/http"
+	"slices"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// Error variables for HTTP validation
+var (
+	// ErrHTTPHeaderName is returned when a string is not a valid HTTP header field name
+	ErrHTTPHeaderName = newError("http_header_name", "invalid HTTP header name")
+	// ErrHTTPHeaderValue is returned when a string is not a valid HTTP header field value
+	ErrHTTPHeaderValue = newError("http_header_value", "invalid HTTP header value")
+	// ErrHTTPMethod is returned when a string is not an allowed HTTP method
+	ErrHTTPMethod = newError("http_method", "HTTP method is not allowed")
+)
+
+// standardMethods are the methods defined by RFC 9110 and RFC 5789, accepted by
+// HTTPMethod without arguments.
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// HTTPHeaderNameRule validates that a string is an HTTP header field name: a token of
+// letters, digits and the characters !#$%&'*+-.^_`|~, as defined by RFC 9110.
+//
+// Example:
+//
+//	rule := HTTPHeaderName()
+//	err := rule.Validate("X-Request-Id")  // returns nil
+//	err = rule.Validate("X Request")      // returns ErrHTTPHeaderName
+type HTTPHeaderNameRule struct {
+	e error
+}
+
+// HTTPHeaderName creates a rule that validates HTTP header field names, such as the
+// custom headers a webhook sends.
+//
+// Example:
+//
+//	arbiter.Field(&hook.SignatureHeader, rule.Required[string](), rule.HTTPHeaderName())
+func HTTPHeaderName() *HTTPHeaderNameRule {
+	return &HTTPHeaderNameRule{e: ErrHTTPHeaderName}
+}
+
+// Validate checks if the string is a valid HTTP header field name.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := HTTPHeaderName()
+//	err := rule.Validate("Content-Type")  // returns nil
+//	err = rule.Validate("Content-Type:")  // returns ErrHTTPHeaderName
+func (r *HTTPHeaderNameRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !httpguts.ValidHeaderFieldName(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrHTTPHeaderName
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *HTTPHeaderNameRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHTTPHeaderName)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := HTTPHeaderName().Errf("Header names may only contain letters, digits and dashes")
+func (r *HTTPHeaderNameRule) Errf(format string, args ...any) *HTTPHeaderNameRule {
+	if format != "" {
+		r.e = wrapf(ErrHTTPHeaderName, format, args...)
+	}
+	return r
+}
+
+// HTTPHeaderValueRule validates that a string is an HTTP header field value. Control
+// characters other than horizontal tab are rejected, in particular CR and LF, so that a
+// configured value cannot inject headers or split a response.
+//
+// Example:
+//
+//	rule := HTTPHeaderValue()
+//	err := rule.Validate("Bearer abc123")                // returns nil
+//	err = rule.Validate("abc\r\nSet-Cookie: session=1")  // returns ErrHTTPHeaderValue
+type HTTPHeaderValueRule struct {
+	e error
+}
+
+// HTTPHeaderValue creates a rule that validates HTTP header field values.
+//
+// Example:
+//
+//	arbiter.Field(&hook.AuthorizationHeader, rule.HTTPHeaderValue())
+func HTTPHeaderValue() *HTTPHeaderValueRule {
+	return &HTTPHeaderValueRule{e: ErrHTTPHeaderValue}
+}
+
+// Validate checks if the string is a valid HTTP header field value.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := HTTPHeaderValue()
+//	err := rule.Validate("text/html; charset=utf-8")  // returns nil
+//	err = rule.Validate("a\x00b")                     // returns ErrHTTPHeaderValue
+func (r *HTTPHeaderValueRule) Validate(value string) error {
+	if !httpguts.ValidHeaderFieldValue(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrHTTPHeaderValue
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *HTTPHeaderValueRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHTTPHeaderValue)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := HTTPHeaderValue().Errf("Header values must not contain line breaks")
+func (r *HTTPHeaderValueRule) Errf(format string, args ...any) *HTTPHeaderValueRule {
+	if format != "" {
+		r.e = wrapf(ErrHTTPHeaderValue, format, args...)
+	}
+	return r
+}
+
+// HTTPMethodRule validates that a string is one of a set of HTTP methods. Methods are
+// case-sensitive, so "get" is not GET.
+//
+// Example:
+//
+//	rule := HTTPMethod(http.MethodGet, http.MethodPost)
+//	err := rule.Validate("POST")   // returns nil
+//	err = rule.Validate("DELETE")  // returns ErrHTTPMethod
+type HTTPMethodRule struct {
+	methods []string
+	e       error
+}
+
+// HTTPMethod creates a rule that accepts the given methods or, without arguments, the
+// standard methods GET, HEAD, POST, PUT, PATCH, DELETE, CONNECT, OPTIONS and TRACE.
+//
+// Example:
+//
+//	arbiter.Field(&hook.Method, rule.HTTPMethod(http.MethodPost, http.MethodPut))
+func HTTPMethod(allowed ...string) *HTTPMethodRule {
+	if len(allowed) == 0 {
+		allowed = standardMethods
+	}
+	return &HTTPMethodRule{methods: allowed, e: ErrHTTPMethod}
+}
+
+// Validate checks if the string is an allowed HTTP method.
+// Returns nil if the string is allowed or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := HTTPMethod()
+//	err := rule.Validate("PATCH")  // returns nil
+//	err = rule.Validate("get")     // returns ErrHTTPMethod
+func (r *HTTPMethodRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !slices.Contains(r.methods, value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrHTTPMethod
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *HTTPMethodRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHTTPMethod)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := HTTPMethod(http.MethodPost).Errf("Webhooks are sent with POST")
+func (r *HTTPMethodRule) Errf(format string, args ...any) *HTTPMethodRule {
+	if format != "" {
+		r.e = wrapf(ErrHTTPMethod, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := HTTPMethod("GET", "POST").Params()  // map[string]any{"methods": []string{"GET", "POST"}}
+func (r *HTTPMethodRule) Params() map[string]any {
+	return map[string]any{"methods": r.methods}
+}
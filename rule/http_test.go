@@ -0,0 +1,93 @@
+package rule
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHeaderName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: standard header", value: "Content-Type"},
+		{name: "valid: token characters", value: "X-Custom_Header.v1!"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: space", value: "X Request", wantErr: true},
+		{name: "invalid: colon", value: "Content-Type:", wantErr: true},
+		{name: "invalid: CRLF", value: "X-A\r\nX-B", wantErr: true},
+		{name: "invalid: non-ASCII", value: "X-Ünicode", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HTTPHeaderName().Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrHTTPHeaderName)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPHeaderValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: token", value: "Bearer abc123"},
+		{name: "valid: tab", value: "a\tb"},
+		{name: "valid: empty string", value: ""},
+		{name: "invalid: CRLF", value: "abc\r\nSet-Cookie: session=1", wantErr: true},
+		{name: "invalid: LF", value: "abc\nX: y", wantErr: true},
+		{name: "invalid: CR", value: "abc\r", wantErr: true},
+		{name: "invalid: NUL", value: "a\x00b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HTTPHeaderValue().Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrHTTPHeaderValue)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.EqualError(t, HTTPHeaderValue().Errf("No line breaks").Validate("\n"), "No line breaks")
+}
+
+func TestHTTPMethod(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *HTTPMethodRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: standard method", rule: HTTPMethod(), value: "PATCH"},
+		{name: "valid: empty string", rule: HTTPMethod(), value: ""},
+		{name: "invalid: lower case", rule: HTTPMethod(), value: "get", wantErr: true},
+		{name: "invalid: unknown method", rule: HTTPMethod(), value: "PROPFIND", wantErr: true},
+		{name: "valid: allowed method", rule: HTTPMethod(http.MethodPost, "PROPFIND"), value: "PROPFIND"},
+		{name: "invalid: method not allowed", rule: HTTPMethod(http.MethodPost), value: http.MethodGet, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrHTTPMethod)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, map[string]any{"methods": []string{"GET", "POST"}}, HTTPMethod("GET", "POST").Params())
+}
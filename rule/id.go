@@ -0,0 +1,262 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating sortable identifier formats such as ULID, KSUID and NanoID.
+package rule
+
+import (
+	"strings"
+)
+
+// Error variables for identifier validation
+var (
+	// ErrULID is returned when a string is not a valid ULID
+	ErrULID = newError("ulid", "invalid ULID format")
+	// ErrKSUID is returned when a string is not a valid KSUID
+	ErrKSUID = newError("ksuid", "invalid KSUID format")
+	// ErrNanoID is returned when a string is not a valid NanoID
+	ErrNanoID = newError("nano_id", "invalid NanoID format")
+)
+
+const (
+	// ulidAlphabet is the Crockford base32 alphabet used by ULIDs.
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLength   = 26
+
+	// ksuidAlphabet is the base62 alphabet used by KSUIDs, in ascending byte order.
+	ksuidAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidLength   = 27
+	// ksuidMax is the base62 encoding of the largest 160-bit KSUID.
+	ksuidMax = "aWgEPTl1tmebfsQzooKDeXrdBEs"
+
+	// DefaultNanoIDAlphabet is the URL-safe alphabet used by NanoID by default.
+	DefaultNanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// DefaultNanoIDLength is the default NanoID length.
+	DefaultNanoIDLength = 21
+)
+
+// ULIDRule validates that a string is a valid ULID (Universally Unique Lexicographically Sortable Identifier).
+// A ULID is 26 characters of Crockford base32, case-insensitive, e.g. 01ARZ3NDEKTSV4RRFFQ69G5FAV.
+//
+// Example:
+//
+//	rule := ULID()
+//	err := rule.Validate("01ARZ3NDEKTSV4RRFFQ69G5FAV")  // returns nil
+//	err = rule.Validate("01ARZ3NDEKTSV4RRFFQ69G5FAU")   // returns error (U is not in the alphabet)
+//	err = rule.Validate("81ARZ3NDEKTSV4RRFFQ69G5FAV")   // returns error (timestamp overflow)
+type ULIDRule struct {
+	e error
+}
+
+// ULID creates a new ULID validation rule.
+//
+// Example:
+//
+//	rule := ULID()
+//	err := rule.Validate("01ARZ3NDEKTSV4RRFFQ69G5FAV")  // returns nil
+func ULID() *ULIDRule {
+	return &ULIDRule{
+		e: ErrULID,
+	}
+}
+
+// Validate checks if the string is a valid ULID.
+// Returns nil if the string is a valid ULID or empty, or an error otherwise.
+//
+// A valid ULID must:
+// - Be exactly 26 characters long
+// - Contain only Crockford base32 characters (case-insensitive)
+// - Start with a character between 0 and 7, so the 48-bit timestamp does not overflow
+//
+// Example:
+//
+//	rule := ULID()
+//	err := rule.Validate("01arz3ndektsv4rrffq69g5fav")  // returns nil (lowercase is accepted)
+//	err = rule.Validate("01ARZ3NDEKTSV4RRFFQ69G5FA")    // returns error (too short)
+func (r *ULIDRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if len(value) != ulidLength || value[0] > '7' || !containsOnly(strings.ToUpper(value), ulidAlphabet) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrULID
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ULIDRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrULID)
+}
+
+// Errf sets a custom error message for ULID validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := ULID().Errf("The order ID must be a valid ULID")
+func (r *ULIDRule) Errf(format string, args ...any) *ULIDRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// KSUIDRule validates that a string is a valid KSUID (K-Sortable Unique Identifier).
+// A KSUID is 27 characters of base62 encoding a 160-bit value, e.g. 0ujtsYcgvSTl8PAuAdqWYSMnLOv.
+//
+// Example:
+//
+//	rule := KSUID()
+//	err := rule.Validate("0ujtsYcgvSTl8PAuAdqWYSMnLOv")  // returns nil
+//	err = rule.Validate("0ujtsYcgvSTl8PAuAdqWYSMnLO")    // returns error (too short)
+type KSUIDRule struct {
+	e error
+}
+
+// KSUID creates a new KSUID validation rule.
+//
+// Example:
+//
+//	rule := KSUID()
+//	err := rule.Validate("0ujtsYcgvSTl8PAuAdqWYSMnLOv")  // returns nil
+func KSUID() *KSUIDRule {
+	return &KSUIDRule{
+		e: ErrKSUID,
+	}
+}
+
+// Validate checks if the string is a valid KSUID.
+// Returns nil if the string is a valid KSUID or empty, or an error otherwise.
+//
+// A valid KSUID must:
+// - Be exactly 27 characters long
+// - Contain only base62 characters (0-9, A-Z, a-z)
+// - Not exceed the maximum 160-bit value (aWgEPTl1tmebfsQzooKDeXrdBEs)
+//
+// Example:
+//
+//	rule := KSUID()
+//	err := rule.Validate("aWgEPTl1tmebfsQzooKDeXrdBEs")  // returns nil (maximum value)
+//	err = rule.Validate("zzzzzzzzzzzzzzzzzzzzzzzzzzz")   // returns error (overflow)
+func (r *KSUIDRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	// The base62 alphabet is in ascending byte order, so a plain string
+	// comparison is equivalent to a numeric comparison for equal lengths.
+	if len(value) != ksuidLength || !containsOnly(value, ksuidAlphabet) || value > ksuidMax {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrKSUID
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *KSUIDRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrKSUID)
+}
+
+// Errf sets a custom error message for KSUID validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := KSUID().Errf("The event ID must be a valid KSUID")
+func (r *KSUIDRule) Errf(format string, args ...any) *KSUIDRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// NanoIDRule validates that a string is a valid NanoID for a given alphabet and length.
+//
+// Example:
+//
+//	rule := NanoID("", 0)  // default alphabet and length (21)
+//	err := rule.Validate("V1StGXR8_Z5jdHi6B-myT")  // returns nil
+//	err = rule.Validate("V1StGXR8_Z5jdHi6B-my")    // returns error (too short)
+type NanoIDRule struct {
+	alphabet string
+	length   int
+	e        error
+}
+
+// NanoID creates a new NanoID validation rule.
+// An empty alphabet selects DefaultNanoIDAlphabet and a non-positive length selects DefaultNanoIDLength.
+//
+// Example:
+//
+//	// Default NanoID (21 URL-safe characters)
+//	rule := NanoID("", 0)
+//
+//	// Custom NanoID (12 lowercase hexadecimal characters)
+//	rule = NanoID("0123456789abcdef", 12)
+func NanoID(alphabet string, length int) *NanoIDRule {
+	return &NanoIDRule{
+		alphabet: Ternary(alphabet == "", DefaultNanoIDAlphabet, alphabet),
+		length:   Ternary(length <= 0, DefaultNanoIDLength, length),
+		e:        ErrNanoID,
+	}
+}
+
+// Validate checks if the string is a valid NanoID.
+// Returns nil if the string is a valid NanoID or empty, or an error otherwise.
+// The length is measured in characters (runes), so multi-byte alphabets are supported.
+//
+// Example:
+//
+//	rule := NanoID("0123456789abcdef", 12)
+//	err := rule.Validate("4f90d13a42bc")  // returns nil
+//	err = rule.Validate("4F90D13A42BC")   // returns error (uppercase is not in the alphabet)
+func (r *NanoIDRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	var n int
+	for _, char := range value {
+		if !strings.ContainsRune(r.alphabet, char) {
+			n = -1
+			break
+		}
+		n++
+	}
+	if n != r.length {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrNanoID
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NanoIDRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrNanoID)
+}
+
+// Errf sets a custom error message for NanoID validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NanoID("", 0).Errf("The share link ID is invalid")
+func (r *NanoIDRule) Errf(format string, args ...any) *NanoIDRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// containsOnly reports whether every byte of value is present in the ASCII alphabet.
+func containsOnly(value, alphabet string) bool {
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(alphabet, value[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
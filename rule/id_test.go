@@ -0,0 +1,94 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestULID(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *ULIDRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: canonical ulid", rule: ULID(), value: "01ARZ3NDEKTSV4RRFFQ69G5FAV", wantErr: false},
+		{name: "valid: lowercase ulid", rule: ULID(), value: "01arz3ndektsv4rrffq69g5fav", wantErr: false},
+		{name: "valid: maximum ulid", rule: ULID(), value: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", wantErr: false},
+		{name: "valid: empty string", rule: ULID(), value: "", wantErr: false},
+		{name: "invalid: too short", rule: ULID(), value: "01ARZ3NDEKTSV4RRFFQ69G5FA", wantErr: true},
+		{name: "invalid: excluded letter", rule: ULID(), value: "01ARZ3NDEKTSV4RRFFQ69G5FAU", wantErr: true},
+		{name: "invalid: timestamp overflow", rule: ULID(), value: "81ARZ3NDEKTSV4RRFFQ69G5FAV", wantErr: true},
+		{name: "custom error message", rule: ULID().Errf("custom error"), value: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ULIDRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKSUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *KSUIDRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: ksuid", rule: KSUID(), value: "0ujtsYcgvSTl8PAuAdqWYSMnLOv", wantErr: false},
+		{name: "valid: minimum ksuid", rule: KSUID(), value: "000000000000000000000000000", wantErr: false},
+		{name: "valid: maximum ksuid", rule: KSUID(), value: "aWgEPTl1tmebfsQzooKDeXrdBEs", wantErr: false},
+		{name: "valid: empty string", rule: KSUID(), value: "", wantErr: false},
+		{name: "invalid: too short", rule: KSUID(), value: "0ujtsYcgvSTl8PAuAdqWYSMnLO", wantErr: true},
+		{name: "invalid: non base62 character", rule: KSUID(), value: "0ujtsYcgvSTl8PAuAdqWYSMnLO-", wantErr: true},
+		{name: "invalid: overflow", rule: KSUID(), value: "aWgEPTl1tmebfsQzooKDeXrdBEt", wantErr: true},
+		{name: "custom error message", rule: KSUID().Errf("custom error"), value: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KSUIDRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNanoID(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *NanoIDRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: default nanoid", rule: NanoID("", 0), value: "V1StGXR8_Z5jdHi6B-myT", wantErr: false},
+		{name: "valid: custom alphabet", rule: NanoID("0123456789abcdef", 12), value: "4f90d13a42bc", wantErr: false},
+		{name: "valid: multi-byte alphabet", rule: NanoID("甲乙丙丁", 4), value: "甲乙丙丁", wantErr: false},
+		{name: "valid: empty string", rule: NanoID("", 0), value: "", wantErr: false},
+		{name: "invalid: too short", rule: NanoID("", 0), value: "V1StGXR8_Z5jdHi6B-my", wantErr: true},
+		{name: "invalid: too long", rule: NanoID("", 0), value: "V1StGXR8_Z5jdHi6B-myTT", wantErr: true},
+		{name: "invalid: character outside alphabet", rule: NanoID("0123456789abcdef", 12), value: "4F90D13A42BC", wantErr: true},
+		{name: "custom error message", rule: NanoID("", 0).Errf("custom error"), value: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NanoIDRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIDFallback(t *testing.T) {
+	assert.ErrorIs(t, (&ULIDRule{}).Validate("invalid"), ErrULID)
+	assert.ErrorIs(t, (&KSUIDRule{}).Validate("invalid"), ErrKSUID)
+	assert.ErrorIs(t, (&NanoIDRule{alphabet: DefaultNanoIDAlphabet, length: DefaultNanoIDLength}).Validate("invalid"), ErrNanoID)
+}
@@ -0,0 +1,165 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the ImageFormat rule, which verifies that uploaded images actually decode.
+package rule
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"  // register the GIF decoder
+	_ "image/jpeg" // register the JPEG decoder
+	_ "image/png"  // register the PNG decoder
+	"io"
+	"strings"
+)
+
+// ErrImageFormat is returned when a file does not decode as an image in one of the allowed formats.
+var ErrImageFormat = newError("image_format", "file is not a valid image in an allowed format")
+
+// defaultMaxImagePixels is the default limit of the width times height of an image.
+const defaultMaxImagePixels = 25_000_000
+
+// imageFormatAliases maps alternative format names to the names reported by the image package.
+var imageFormatAliases = map[string]string{
+	"jpg": "jpeg",
+	"tif": "tiff",
+}
+
+// ImageFormatRule validates that a file is an image in one of the allowed formats by
+// decoding it completely. Unlike FileType, which only checks the magic number, a file
+// that starts with an image header but is not a valid image, such as a polyglot
+// GIF/JavaScript file, is rejected.
+//
+// Example:
+//
+//	rule := ImageFormat("png", "jpeg")
+//	err := rule.Validate(pngFile)   // returns nil
+//	err = rule.Validate(fakeFile)   // returns error (PNG header followed by garbage)
+type ImageFormatRule struct {
+	formats   []string
+	maxPixels int64
+	e         error
+}
+
+// ImageFormat creates a new image format validation rule.
+// Supported formats are "png", "jpeg" (or "jpg") and "gif", compared case-insensitively.
+// Formats registered with image.RegisterFormat are also supported; import package
+// github.com/byteweap/arbiter/rule/ximage for "webp", "bmp" and "tiff" (or "tif").
+// Images of more than 25 megapixels are rejected unless MaxPixels sets another limit.
+//
+// Example:
+//
+//	type Profile struct {
+//	    Avatar multipart.File
+//	}
+//
+//	rule := ImageFormat("png", "jpeg", "webp").MaxPixels(4096 * 4096).Errf("Avatar must be a PNG, JPEG or WebP image")
+func ImageFormat(formats ...string) *ImageFormatRule {
+	normalized := make([]string, len(formats))
+	for i, format := range formats {
+		format = strings.ToLower(format)
+		if alias, ok := imageFormatAliases[format]; ok {
+			format = alias
+		}
+		normalized[i] = format
+	}
+	return &ImageFormatRule{
+		formats:   normalized,
+		maxPixels: defaultMaxImagePixels,
+		e:         ErrImageFormat,
+	}
+}
+
+// MaxPixels limits the width times height of the image, which is checked from the image
+// header before the image is decoded. This protects against decompression bombs, which
+// is why there always is a limit: 25 megapixels by default. Values less than or equal to
+// zero are ignored.
+//
+// Example:
+//
+//	rule := ImageFormat("png").MaxPixels(4096 * 4096)  // at most 16 megapixels
+func (r *ImageFormatRule) MaxPixels(n int64) *ImageFormatRule {
+	if n > 0 {
+		r.maxPixels = n
+	}
+	return r
+}
+
+// Validate checks if the file decodes as an image in one of the allowed formats.
+// The file is read completely; if it implements io.Seeker, its read position is
+// restored afterwards.
+//
+// Example:
+//
+//	file, _ := os.Open("avatar.png")
+//	defer file.Close()
+//	rule := ImageFormat("png", "jpeg")
+//	err := rule.Validate(file)  // returns nil if the file is a valid PNG or JPEG image
+func (r *ImageFormatRule) Validate(file io.Reader) error {
+	seeker, seekable := file.(io.Seeker)
+	var current int64
+	if seekable {
+		var err error
+		if current, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	ok := r.decodes(file)
+
+	if seekable {
+		if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	if !ok {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrImageFormat
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *ImageFormatRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrImageFormat)
+}
+
+// decodes reports whether file is an image in an allowed format within the pixel limit.
+func (r *ImageFormatRule) decodes(file io.Reader) bool {
+	// Keep the bytes read for the header so that the image can be decoded afterwards.
+	var buf bytes.Buffer
+	config, format, err := image.DecodeConfig(io.TeeReader(file, &buf))
+	if err != nil || !r.allowed(format) {
+		return false
+	}
+	limit := Ternary(r.maxPixels > 0, r.maxPixels, defaultMaxImagePixels)
+	if int64(config.Width)*int64(config.Height) > limit {
+		return false
+	}
+	_, _, err = image.Decode(io.MultiReader(&buf, file))
+	return err == nil
+}
+
+// allowed reports whether format is one of the allowed formats.
+func (r *ImageFormatRule) allowed(format string) bool {
+	for _, f := range r.formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Errf sets a custom error message for image format validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := ImageFormat("png", "jpeg").Errf("Please upload a PNG or JPEG image")
+func (r *ImageFormatRule) Errf(format string, args ...any) *ImageFormatRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
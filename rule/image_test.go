@@ -0,0 +1,95 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeImage encodes a w x h image with the given encoder.
+func encodeImage(t *testing.T, w, h int, encode func(io.Writer, image.Image) error) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pngHeader returns the signature and IHDR chunk of a w x h PNG image without its data,
+// which is enough for image.DecodeConfig.
+func pngHeader(t *testing.T, w, h uint32) []byte {
+	t.Helper()
+	ihdr := binary.BigEndian.AppendUint32(nil, w)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, h)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0) // 8-bit RGB
+	chunk := append([]byte("IHDR"), ihdr...)
+	data := append([]byte("\x89PNG\r\n\x1a\n"), binary.BigEndian.AppendUint32(nil, uint32(len(ihdr)))...)
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
+
+func TestImageFormat(t *testing.T) {
+	pngData := encodeImage(t, 4, 4, png.Encode)
+	jpegData := encodeImage(t, 4, 4, func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) })
+	gifData := encodeImage(t, 4, 4, func(w io.Writer, img image.Image) error { return gif.Encode(w, img, nil) })
+	pngBomb := pngHeader(t, 10000, 10000)
+
+	tests := []struct {
+		name    string
+		rule    *ImageFormatRule
+		value   []byte
+		wantErr bool
+	}{
+		{name: "valid: png", rule: ImageFormat("png", "jpeg"), value: pngData, wantErr: false},
+		{name: "valid: jpg alias", rule: ImageFormat("JPG"), value: jpegData, wantErr: false},
+		{name: "invalid: gif not allowed", rule: ImageFormat("png", "jpeg"), value: gifData, wantErr: true},
+		{name: "invalid: truncated png", rule: ImageFormat("png"), value: pngData[:len(pngData)/2], wantErr: true},
+		{name: "invalid: png header only", rule: ImageFormat("png"), value: []byte("\x89PNG\r\n\x1a\n<script>alert(1)</script>"), wantErr: true},
+		{name: "invalid: gif polyglot", rule: ImageFormat("gif"), value: []byte("GIF89a=1;alert(document.domain)//"), wantErr: true},
+		{name: "invalid: text", rule: ImageFormat("png"), value: []byte("hello"), wantErr: true},
+		{name: "invalid: empty", rule: ImageFormat("png"), value: []byte{}, wantErr: true},
+		{name: "valid: within pixel limit", rule: ImageFormat("png").MaxPixels(16), value: pngData, wantErr: false},
+		{name: "invalid: exceeds pixel limit", rule: ImageFormat("png").MaxPixels(15), value: pngData, wantErr: true},
+		{name: "invalid: exceeds default pixel limit", rule: ImageFormat("png").MaxPixels(0), value: pngBomb, wantErr: true},
+		{name: "invalid: fallback pixel limit", rule: &ImageFormatRule{formats: []string{"png"}}, value: pngBomb, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(bytes.NewReader(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImageFormatRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageFormatRestoresPosition(t *testing.T) {
+	pngData := encodeImage(t, 2, 2, png.Encode)
+	reader := bytes.NewReader(pngData)
+	assert.Nil(t, ImageFormat("png").Validate(reader))
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, pngData, content)
+}
+
+func TestImageFormatErrf(t *testing.T) {
+	err := ImageFormat("png").Errf("custom image error").Validate(bytes.NewReader([]byte("hello")))
+	assert.EqualError(t, err, "custom image error")
+}
+
+func TestImageFormatFallback(t *testing.T) {
+	err := (&ImageFormatRule{}).Validate(bytes.NewReader([]byte("hello")))
+	assert.ErrorIs(t, err, ErrImageFormat)
+}
@@ -3,17 +3,15 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"slices"
 )
 
 // Error variables for in/not in validation
 var (
 	// ErrIn is returned when a value must be in a list but is not found
-	ErrIn = errors.New("must be in the list")
+	ErrIn = newError("in", "must be in the list")
 	// ErrNotIn is returned when a value must not be in a list but is found
-	ErrNotIn = errors.New("must not be in the list")
+	ErrNotIn = newError("not_in", "must not be in the list")
 )
 
 // InRule validates if a value is in or not in a list of values.
@@ -121,6 +119,11 @@ func (r *InRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *InRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, Ternary(r.notIn, ErrNotIn, ErrIn))
+}
+
 // Errf sets a custom error message for validation failures.
 // This allows for context-specific error messages.
 //
@@ -133,7 +136,29 @@ func (r *InRule[T]) Validate(value T) error {
 //	err = rule.Validate("admin")    // returns error with custom message
 func (r *InRule[T]) Errf(format string, args ...any) *InRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := In("red", "green").Params()  // map[string]any{"values": []string{"red", "green"}}
+func (r *InRule[T]) Params() map[string]any {
+	return map[string]any{"values": r.values}
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule, an enum of the values, or
+// an enum inside not for NotIn.
+//
+// Example:
+//
+//	keywords := In("red", "green").JSONSchema()  // map[string]any{"enum": []string{"red", "green"}}
+func (r *InRule[T]) JSONSchema() map[string]any {
+	if r.notIn {
+		return map[string]any{"not": map[string]any{"enum": r.values}}
+	}
+	return map[string]any{"enum": r.values}
+}
@@ -3,23 +3,21 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"net"
 )
 
 var (
 	// ErrIP is returned when a string is not a valid IP address.
 	// This error is used for general IP address validation.
-	ErrIP = errors.New("invalid IP address format")
+	ErrIP = newError("ip", "invalid IP address format")
 
 	// ErrIPv4 is returned when a string is not a valid IPv4 address.
 	// The address must be in the format x.x.x.x where x is a number between 0 and 255.
-	ErrIPv4 = errors.New("invalid IPv4 address format")
+	ErrIPv4 = newError("ipv4", "invalid IPv4 address format")
 
 	// ErrIPv6 is returned when a string is not a valid IPv6 address.
 	// The address must be in the standard IPv6 format with hexadecimal numbers.
-	ErrIPv6 = errors.New("invalid IPv6 address format")
+	ErrIPv6 = newError("ipv6", "invalid IPv6 address format")
 )
 
 // IPRule validates that a string is a valid IP address (either IPv4 or IPv6).
@@ -60,7 +58,7 @@ func IP() *IPRule {
 //	err = rule.Validate("invalid")         // returns error
 //	err = rule.Validate("")                // returns nil (empty string is valid)
 func (r *IPRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	if net.ParseIP(value) == nil {
@@ -72,6 +70,11 @@ func (r *IPRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *IPRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrIP)
+}
+
 // Errf sets a custom error message for IP validation failures.
 // This allows for context-specific error messages.
 //
@@ -80,7 +83,7 @@ func (r *IPRule) Validate(value string) error {
 //	rule := IP().Errf("Please enter a valid IP address")
 func (r *IPRule) Errf(format string, args ...any) *IPRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -123,7 +126,7 @@ func IPv4() *IPv4Rule {
 //	err = rule.Validate("invalid")      // returns error
 //	err = rule.Validate("")             // returns nil (empty string is valid)
 func (r *IPv4Rule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	ip := net.ParseIP(value)
@@ -136,6 +139,11 @@ func (r *IPv4Rule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *IPv4Rule) Describe() RuleInfo {
+	return describe(r, r.e, ErrIPv4)
+}
+
 // Errf sets a custom error message for IPv4 validation failures.
 // This allows for context-specific error messages.
 //
@@ -144,7 +152,7 @@ func (r *IPv4Rule) Validate(value string) error {
 //	rule := IPv4().Errf("Please enter a valid IPv4 address")
 func (r *IPv4Rule) Errf(format string, args ...any) *IPv4Rule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -187,7 +195,7 @@ func IPv6() *IPv6Rule {
 //	err = rule.Validate("invalid")         // returns error
 //	err = rule.Validate("")                // returns nil (empty string is valid)
 func (r *IPv6Rule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	ip := net.ParseIP(value)
@@ -200,6 +208,11 @@ func (r *IPv6Rule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *IPv6Rule) Describe() RuleInfo {
+	return describe(r, r.e, ErrIPv6)
+}
+
 // Errf sets a custom error message for IPv6 validation failures.
 // This allows for context-specific error messages.
 //
@@ -208,7 +221,7 @@ func (r *IPv6Rule) Validate(value string) error {
 //	rule := IPv6().Errf("Please enter a valid IPv6 address")
 func (r *IPv6Rule) Errf(format string, args ...any) *IPv6Rule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
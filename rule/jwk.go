@@ -0,0 +1,316 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating JSON Web Keys and JSON Web Key Sets.
+package rule
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+)
+
+// Error variables for JSON Web Key validation
+var (
+	// ErrJWK is returned when a value is not a valid JSON Web Key
+	ErrJWK = newError("jwk", "invalid JSON Web Key")
+	// ErrJWKS is returned when a value is not a valid JSON Web Key Set
+	ErrJWKS = newError("jwks", "invalid JSON Web Key Set")
+)
+
+// jwkCurveSizes maps the curves of EC keys (RFC 7518) and OKP keys (RFC 8037) to the
+// length of their coordinates and private keys in bytes.
+var jwkCurveSizes = map[string]map[string]int{
+	"EC":  {"P-256": 32, "P-384": 48, "P-521": 66},
+	"OKP": {"Ed25519": 32, "Ed448": 57, "X25519": 32, "X448": 56},
+}
+
+// jwkRSAPrivateMembers are the members of an RSA private key besides "d", which must be
+// present together, as defined by RFC 7518.
+var jwkRSAPrivateMembers = []string{"p", "q", "dp", "dq", "qi"}
+
+// jwkKeyOps are the key operations defined by RFC 7517.
+var jwkKeyOps = []string{"sign", "verify", "encrypt", "decrypt", "wrapKey", "unwrapKey", "deriveKey", "deriveBits"}
+
+// JWKRule validates that a value is a JSON Web Key (RFC 7517): a JSON object whose "kty"
+// is EC, RSA, oct or OKP, with the members required by its key type. Key material must be
+// unpadded base64url and, for EC and OKP keys, of the size of the curve. The optional
+// "use", "key_ops", "alg", "kid", "x5c" and "x5t" members must be well formed.
+//
+// Example:
+//
+//	rule := JWK[string]()
+//	err := rule.Validate(`{"kty":"oct","k":"c2VjcmV0"}`)  // returns nil
+//	err = rule.Validate(`{"kty":"RSA","n":"0vx7"}`)      // returns ErrJWK (missing "e")
+type JWKRule[T Bytes] struct {
+	public bool
+	e      error
+}
+
+// JWK creates a rule that validates JSON Web Keys stored in a string or byte slice
+// field, such as json.RawMessage.
+//
+// Example:
+//
+//	arbiter.Field(&provider.SigningKey, rule.Required[string](), rule.JWK[string]())
+func JWK[T Bytes]() *JWKRule[T] {
+	return &JWKRule[T]{e: ErrJWK}
+}
+
+// Public rejects private and symmetric keys, so that a secret is not published by
+// mistake, such as the "d" member of an EC key or an "oct" key.
+//
+// Example:
+//
+//	rule := JWK[string]().Public()
+//	err := rule.Validate(ecPrivateJWK)  // returns ErrJWK
+func (r *JWKRule[T]) Public() *JWKRule[T] {
+	r.public = true
+	return r
+}
+
+// Validate checks if the value is a valid JSON Web Key.
+// Returns nil if the value is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := JWK[string]()
+//	err := rule.Validate(`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`)  // returns nil
+//	err = rule.Validate(`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKx"}`)                                      // returns ErrJWK
+func (r *JWKRule[T]) Validate(value T) error {
+	if skipEmpty(string(value)) {
+		return nil
+	}
+	var key map[string]any
+	if json.Unmarshal([]byte(value), &key) != nil || !validJWK(key, r.public) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrJWK
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *JWKRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrJWK)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := JWK[string]().Errf("Please paste the key as a JSON Web Key")
+func (r *JWKRule[T]) Errf(format string, args ...any) *JWKRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrJWK, format, args...)
+	}
+	return r
+}
+
+// JWKSRule validates that a value is a JSON Web Key Set (RFC 7517): a JSON object whose
+// "keys" member is an array of valid JSON Web Keys, as served by the jwks_uri of an
+// identity provider. Keys of the same type must not share a "kid".
+//
+// Example:
+//
+//	rule := JWKS[string]()
+//	err := rule.Validate(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`)  // returns nil
+//	err = rule.Validate(`[{"kty":"oct","k":"c2VjcmV0"}]`)           // returns ErrJWKS
+type JWKSRule[T Bytes] struct {
+	public bool
+	e      error
+}
+
+// JWKS creates a rule that validates JSON Web Key Sets stored in a string or byte slice
+// field.
+//
+// Example:
+//
+//	arbiter.Field(&provider.JWKS, rule.Required[[]byte](), rule.JWKS[[]byte]().Public())
+func JWKS[T Bytes]() *JWKSRule[T] {
+	return &JWKSRule[T]{e: ErrJWKS}
+}
+
+// Public rejects sets with private or symmetric keys, as a set published by an identity
+// provider must only hold public keys.
+//
+// Example:
+//
+//	rule := JWKS[string]().Public()
+func (r *JWKSRule[T]) Public() *JWKSRule[T] {
+	r.public = true
+	return r
+}
+
+// Validate checks if the value is a valid JSON Web Key Set.
+// Returns nil if the value is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := JWKS[string]()
+//	err := rule.Validate(`{"keys":[]}`)  // returns nil
+//	err = rule.Validate(`{}`)            // returns ErrJWKS
+func (r *JWKSRule[T]) Validate(value T) error {
+	if skipEmpty(string(value)) {
+		return nil
+	}
+	var set struct {
+		Keys *[]map[string]any `json:"keys"`
+	}
+	if json.Unmarshal([]byte(value), &set) != nil || set.Keys == nil {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrJWKS
+	}
+	kids := make(map[[2]string]bool, len(*set.Keys))
+	for _, key := range *set.Keys {
+		if !validJWK(key, r.public) {
+			if r.e != nil {
+				return r.e
+			}
+			return ErrJWKS
+		}
+		if kid, ok := key["kid"].(string); ok {
+			id := [2]string{key["kty"].(string), kid}
+			if kids[id] {
+				if r.e != nil {
+					return r.e
+				}
+				return ErrJWKS
+			}
+			kids[id] = true
+		}
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *JWKSRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrJWKS)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := JWKS[string]().Errf("The identity provider returned an invalid key set")
+func (r *JWKSRule[T]) Errf(format string, args ...any) *JWKSRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrJWKS, format, args...)
+	}
+	return r
+}
+
+// validJWK reports whether key is a valid JSON Web Key and, if public is set, not a
+// private or symmetric key.
+func validJWK(key map[string]any, public bool) bool {
+	if key == nil || !validJWKMetadata(key) {
+		return false
+	}
+	kty, _ := key["kty"].(string)
+	switch kty {
+	case "EC", "OKP":
+		crv, _ := key["crv"].(string)
+		size, ok := jwkCurveSizes[kty][crv]
+		if !ok || !jwkBytes(key, "x", size) || kty == "EC" && !jwkBytes(key, "y", size) {
+			return false
+		}
+		if _, private := key["d"]; private {
+			return !public && jwkBytes(key, "d", size)
+		}
+		return true
+	case "RSA":
+		if !jwkBytes(key, "n", -1) || !jwkBytes(key, "e", -1) {
+			return false
+		}
+		if _, private := key["d"]; !private {
+			return !hasAny(key, jwkRSAPrivateMembers)
+		}
+		if public || !jwkBytes(key, "d", -1) {
+			return false
+		}
+		if !hasAny(key, jwkRSAPrivateMembers) {
+			return true
+		}
+		for _, member := range jwkRSAPrivateMembers {
+			if !jwkBytes(key, member, -1) {
+				return false
+			}
+		}
+		return true
+	case "oct":
+		return !public && jwkBytes(key, "k", -1)
+	}
+	return false
+}
+
+// validJWKMetadata reports whether the optional members of key common to all key types
+// are well formed.
+func validJWKMetadata(key map[string]any) bool {
+	if use, ok := key["use"]; ok && use != "sig" && use != "enc" {
+		return false
+	}
+	for _, member := range []string{"alg", "kid"} {
+		if v, ok := key[member]; ok {
+			if _, ok := v.(string); !ok {
+				return false
+			}
+		}
+	}
+	if ops, ok := key["key_ops"]; ok {
+		list, ok := ops.([]any)
+		if !ok {
+			return false
+		}
+		seen := make(map[string]bool, len(list))
+		for _, op := range list {
+			s, _ := op.(string)
+			if !slices.Contains(jwkKeyOps, s) || seen[s] {
+				return false
+			}
+			seen[s] = true
+		}
+	}
+	if chain, ok := key["x5c"]; ok {
+		certs, ok := chain.([]any)
+		if !ok || len(certs) == 0 {
+			return false
+		}
+		for _, cert := range certs {
+			s, _ := cert.(string)
+			if _, err := base64.StdEncoding.DecodeString(s); err != nil || s == "" {
+				return false
+			}
+		}
+	}
+	if _, ok := key["x5t"]; ok && !jwkBytes(key, "x5t", 20) {
+		return false
+	}
+	if _, ok := key["x5t#S256"]; ok && !jwkBytes(key, "x5t#S256", 32) {
+		return false
+	}
+	return true
+}
+
+// jwkBytes reports whether member of key is a non-empty unpadded base64url string that
+// decodes to size bytes, or to any number of bytes if size is negative.
+func jwkBytes(key map[string]any, member string, size int) bool {
+	s, ok := key[member].(string)
+	if !ok || s == "" {
+		return false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	return err == nil && (size < 0 || len(b) == size)
+}
+
+// hasAny reports whether key has any of the members.
+func hasAny(key map[string]any, members []string) bool {
+	for _, member := range members {
+		if _, ok := key[member]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Keys from RFC 7517 Appendix A and RFC 8037 Appendix A.
+const (
+	testECPublicJWK  = `{"kty":"EC","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM","use":"enc","kid":"1"}`
+	testECPrivateJWK = `{"kty":"EC","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM","d":"870MB6gfuTJ4HtUnUvYMyJpr5eUZNP4Bk43bVdj3eAE"}`
+	testRSAPublicJWK = `{"kty":"RSA","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB","alg":"RS256","kid":"2011-04-29"}`
+	testOKPPublicJWK = `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`
+	testOctJWK       = `{"kty":"oct","k":"GawgguFyGrWKav7AX4VKUg","key_ops":["sign","verify"]}`
+)
+
+func TestJWK(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *JWKRule[string]
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: EC public key", rule: JWK[string](), value: testECPublicJWK},
+		{name: "valid: EC private key", rule: JWK[string](), value: testECPrivateJWK},
+		{name: "valid: RSA public key", rule: JWK[string](), value: testRSAPublicJWK},
+		{name: "valid: RSA private key without CRT members", rule: JWK[string](), value: `{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQAB"}`},
+		{name: "valid: RSA private key with CRT members", rule: JWK[string](), value: `{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQ","p":"AQ","q":"AQ","dp":"AQ","dq":"AQ","qi":"AQ"}`},
+		{name: "valid: OKP public key", rule: JWK[string](), value: testOKPPublicJWK},
+		{name: "valid: symmetric key", rule: JWK[string](), value: testOctJWK},
+		{name: "valid: empty string", rule: JWK[string](), value: ""},
+		{name: "valid: public key in public mode", rule: JWK[string]().Public(), value: testECPublicJWK},
+		{name: "valid: x5t thumbprint", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","x5t":"AAAAAAAAAAAAAAAAAAAAAAAAAAA","x5c":["MIIB"]}`},
+		{name: "invalid: private key in public mode", rule: JWK[string]().Public(), value: testECPrivateJWK, wantErr: true},
+		{name: "invalid: symmetric key in public mode", rule: JWK[string]().Public(), value: testOctJWK, wantErr: true},
+		{name: "invalid: RSA private key in public mode", rule: JWK[string]().Public(), value: `{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQAB"}`, wantErr: true},
+		{name: "invalid: missing kty", rule: JWK[string](), value: `{"k":"AQ"}`, wantErr: true},
+		{name: "invalid: unknown kty", rule: JWK[string](), value: `{"kty":"DSA","k":"AQ"}`, wantErr: true},
+		{name: "invalid: missing RSA exponent", rule: JWK[string](), value: `{"kty":"RSA","n":"0vx7"}`, wantErr: true},
+		{name: "invalid: partial CRT members", rule: JWK[string](), value: `{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQ","p":"AQ"}`, wantErr: true},
+		{name: "invalid: CRT members without d", rule: JWK[string](), value: `{"kty":"RSA","n":"AQAB","e":"AQAB","p":"AQ"}`, wantErr: true},
+		{name: "invalid: unknown curve", rule: JWK[string](), value: `{"kty":"EC","crv":"P-192","x":"AQ","y":"AQ"}`, wantErr: true},
+		{name: "invalid: coordinate of wrong size", rule: JWK[string](), value: `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKx"}`, wantErr: true},
+		{name: "invalid: EC key without y", rule: JWK[string](), value: `{"kty":"EC","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4"}`, wantErr: true},
+		{name: "invalid: padded base64url", rule: JWK[string](), value: `{"kty":"oct","k":"AQ=="}`, wantErr: true},
+		{name: "invalid: standard base64", rule: JWK[string](), value: `{"kty":"oct","k":"a+b/"}`, wantErr: true},
+		{name: "invalid: empty key", rule: JWK[string](), value: `{"kty":"oct","k":""}`, wantErr: true},
+		{name: "invalid: use", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","use":"auth"}`, wantErr: true},
+		{name: "invalid: key_ops", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","key_ops":["sign","sign"]}`, wantErr: true},
+		{name: "invalid: key_ops not an array", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","key_ops":"sign"}`, wantErr: true},
+		{name: "invalid: kid not a string", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","kid":1}`, wantErr: true},
+		{name: "invalid: x5c not base64", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","x5c":["not base64"]}`, wantErr: true},
+		{name: "invalid: x5t of wrong size", rule: JWK[string](), value: `{"kty":"oct","k":"AQ","x5t":"AQ"}`, wantErr: true},
+		{name: "invalid: not an object", rule: JWK[string](), value: `["kty"]`, wantErr: true},
+		{name: "invalid: null", rule: JWK[string](), value: `null`, wantErr: true},
+		{name: "invalid: not JSON", rule: JWK[string](), value: `kty=oct`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JWK().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrJWK)
+			}
+		})
+	}
+
+	assert.Nil(t, JWK[[]byte]().Validate([]byte(testOKPPublicJWK)))
+	assert.EqualError(t, JWK[string]().Errf("bad key").Validate("{}"), "bad key")
+}
+
+func TestJWKS(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *JWKSRule[string]
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: public keys", rule: JWKS[string]().Public(), value: `{"keys":[` + testECPublicJWK + `,` + testRSAPublicJWK + `]}`},
+		{name: "valid: private keys", rule: JWKS[string](), value: `{"keys":[` + testECPrivateJWK + `,` + testOctJWK + `]}`},
+		{name: "valid: no keys", rule: JWKS[string](), value: `{"keys":[]}`},
+		{name: "valid: same kid for different types", rule: JWKS[string](), value: `{"keys":[{"kty":"oct","k":"AQ","kid":"a"},{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","kid":"a"}]}`},
+		{name: "valid: empty string", rule: JWKS[string](), value: ""},
+		{name: "invalid: private key in public mode", rule: JWKS[string]().Public(), value: `{"keys":[` + testECPrivateJWK + `]}`, wantErr: true},
+		{name: "invalid: invalid key", rule: JWKS[string](), value: `{"keys":[` + testECPublicJWK + `,{"kty":"RSA"}]}`, wantErr: true},
+		{name: "invalid: duplicate kid", rule: JWKS[string](), value: `{"keys":[{"kty":"oct","k":"AQ","kid":"a"},{"kty":"oct","k":"Ag","kid":"a"}]}`, wantErr: true},
+		{name: "invalid: missing keys", rule: JWKS[string](), value: `{}`, wantErr: true},
+		{name: "invalid: keys not an array", rule: JWKS[string](), value: `{"keys":{}}`, wantErr: true},
+		{name: "invalid: null key", rule: JWKS[string](), value: `{"keys":[null]}`, wantErr: true},
+		{name: "invalid: bare array", rule: JWKS[string](), value: `[` + testOctJWK + `]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JWKS().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrJWKS)
+			}
+		})
+	}
+
+	assert.EqualError(t, JWKS[[]byte]().Errf("bad key set").Validate([]byte("{}")), "bad key set")
+}
@@ -7,6 +7,9 @@ import (
 	"unicode/utf8"
 )
 
+// ErrLength is returned when a length is not within the range. Its message includes the range.
+var ErrLength = newError("length", "length is not between minimum and maximum")
+
 // ErrLengthFormat is the format string for length validation errors.
 const ErrLengthFormat = "length is not between %v and %v"
 
@@ -23,13 +26,16 @@ const ErrLengthFormat = "length is not between %v and %v"
 //	err = rule.Validate([]int{1, 2})  // returns nil
 //	err = rule.Validate([]int{1})     // returns ErrLength
 type LengthRule[T any] struct {
-	min int
-	max int
-	e   error
+	min  int
+	max  int
+	size func(T) int
+	e    error
 }
 
 // Len creates a new length validation rule with the specified minimum and maximum lengths.
 // The rule can be used with any type that has a measurable length (strings, slices, arrays, maps).
+// Custom slice and map types are measured with reflection unless they implement Lengther;
+// use SliceLen, MapLen or LenOf to avoid reflection and allocations entirely.
 //
 // Example:
 //
@@ -39,13 +45,66 @@ type LengthRule[T any] struct {
 //	// Create a rule for arrays (1-5 elements)
 //	arrayRule := Len[[]int](1, 5).Errf("Array must have 1-5 elements")
 func Len[T any](min, max int) *LengthRule[T] {
-	return &LengthRule[T]{min: min, max: max, e: fmt.Errorf(ErrLengthFormat, min, max)}
+	return &LengthRule[T]{min: min, max: max}
+}
+
+// SliceLen creates a length validation rule for any slice type, including slices of
+// structs and named slice types, that counts elements without reflection.
+//
+// Example:
+//
+//	type Item struct{ SKU string }
+//
+//	rule := SliceLen[[]Item](1, 50).Errf("Order must contain 1-50 items")
+func SliceLen[S ~[]E, E any](min, max int) *LengthRule[S] {
+	r := Len[S](min, max)
+	r.size = func(v S) int { return len(v) }
+	return r
+}
+
+// MapLen creates a length validation rule for any map type that counts key-value pairs
+// without reflection.
+//
+// Example:
+//
+//	rule := MapLen[map[string][]string](0, 20).Errf("At most 20 headers are allowed")
+func MapLen[M ~map[K]V, K comparable, V any](min, max int) *LengthRule[M] {
+	r := Len[M](min, max)
+	r.size = func(v M) int { return len(v) }
+	return r
+}
+
+// LenOf creates a length validation rule for a type implementing Lengther that calls
+// its Len method directly, without reflection or allocations.
+//
+// Example:
+//
+//	rule := LenOf[*bytes.Buffer](1, 1024)
+func LenOf[T Lengther](min, max int) *LengthRule[T] {
+	r := Len[T](min, max)
+	r.size = func(v T) int { return v.Len() }
+	return r
+}
+
+// Lengther is implemented by types that report their own length, such as custom
+// collections, bytes.Buffer or strings.Builder. Len uses it instead of reflection.
+//
+// Example:
+//
+//	type Tags []string
+//
+//	func (t Tags) Len() int { return len(t) }
+//
+//	rule := Len[Tags](1, 5)  // measured via Tags.Len, without reflection
+type Lengther interface {
+	Len() int
 }
 
 // Validate checks if the value's length falls within the specified range.
 // For strings, it counts Unicode characters (runes).
 // For slices and arrays, it counts elements.
 // For maps, it counts key-value pairs.
+// For types implementing Lengther, it uses the Len method.
 // Returns nil if the length is valid, or an error if it's outside the range.
 //
 // Example:
@@ -59,95 +118,140 @@ func Len[T any](min, max int) *LengthRule[T] {
 //	if err := rule.Validate([]int{1, 2, 3}); err != nil {
 //	    // Handle validation error
 //	}
-
-//nolint:gocyclo // type dispatch for all Go types is inherently complex
 func (r *LengthRule[T]) Validate(value T) error {
-	var (
-		length int
-		err    error
-	)
-	switch v := any(value).(type) {
+	var length int
+	if r.size != nil {
+		length = r.size(value)
+	} else if l, ok := lengthOf(value); ok {
+		// Common types are matched without the value escaping, so this does not allocate.
+		length = l
+	} else {
+		l, err := dynamicLength(value)
+		if err != nil {
+			return err
+		}
+		length = l
+	}
+	if length < r.min || length > r.max {
+		if r.e != nil {
+			return r.e
+		}
+		return formatError(ErrLength, ErrLengthFormat, r.min, r.max)
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *LengthRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrLength)
+}
+
+// dynamicLength returns the length of value via Lengther or, failing that, reflection.
+// Nil pointers have no length, even if their type implements Lengther, as calling Len
+// on them would panic for types such as *bytes.Buffer.
+func dynamicLength(value any) (int, error) {
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return 0, fmt.Errorf("cannot get length of nil %v", val.Type())
+	}
+	if l, ok := value.(Lengther); ok {
+		return l.Len(), nil
+	}
+	switch val.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		return val.Len(), nil
+	default:
+		return 0, fmt.Errorf("cannot get length of %v", val.Kind())
+	}
+}
+
+// lengthOf returns the length of common string, slice and map types without reflection.
+// It reports false for any other type.
+//
+//nolint:gocyclo // type dispatch for common Go types is inherently long
+func lengthOf(value any) (int, bool) {
+	switch v := value.(type) {
 	case string:
-		length = utf8.RuneCountInString(v) // Count Unicode characters
+		return utf8.RuneCountInString(v), true // Count Unicode characters
 	case *string:
-		length = Ternary(v == nil, 0, utf8.RuneCountInString(*v))
+		if v == nil {
+			return 0, true
+		}
+		return utf8.RuneCountInString(*v), true
+	case []string:
+		return len(v), true
+	case []*string:
+		return len(v), true
 	case []int:
-		length = len(v)
+		return len(v), true
 	case []*int:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []int8:
-		length = len(v)
+		return len(v), true
 	case []*int8:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []int16:
-		length = len(v)
+		return len(v), true
 	case []*int16:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []int32:
-		length = len(v)
+		return len(v), true
 	case []*int32:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []int64:
-		length = len(v)
+		return len(v), true
 	case []*int64:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []uint:
-		length = len(v)
+		return len(v), true
 	case []*uint:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []uint8:
-		length = len(v)
+		return len(v), true
 	case []*uint8:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []uint16:
-		length = len(v)
+		return len(v), true
 	case []*uint16:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []uint32:
-		length = len(v)
+		return len(v), true
 	case []*uint32:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []uint64:
-		length = len(v)
+		return len(v), true
 	case []*uint64:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []float32:
-		length = len(v)
+		return len(v), true
 	case []*float32:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []float64:
-		length = len(v)
+		return len(v), true
 	case []*float64:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []bool:
-		length = len(v)
+		return len(v), true
 	case []*bool:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []any:
-		length = len(v)
+		return len(v), true
 	case []*any:
-		length = Ternary(v == nil, 0, len(v))
+		return len(v), true
 	case []struct{}:
-		length = len(v)
+		return len(v), true
 	case []*struct{}:
-		length = Ternary(v == nil, 0, len(v))
-	default:
-		// Use reflection for other types
-		val := reflect.ValueOf(value)
-		switch val.Kind() {
-		case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-			length = val.Len()
-		default:
-			err = fmt.Errorf("cannot get length of %v", val.Kind())
-		}
+		return len(v), true
+	case map[string]string:
+		return len(v), true
+	case map[string]int:
+		return len(v), true
+	case map[string]any:
+		return len(v), true
+	case map[string]bool:
+		return len(v), true
 	}
-	if err != nil {
-		return err
-	}
-	if length < r.min || length > r.max {
-		return r.e
-	}
-	return nil
+	return 0, false
 }
 
 // Errf sets a custom error message for the validation rule.
@@ -159,7 +263,35 @@ func (r *LengthRule[T]) Validate(value T) error {
 //	err := rule.Validate("hi")  // returns error with custom message
 func (r *LengthRule[T]) Errf(format string, args ...any) *LengthRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(ErrLength, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Len[string](2, 50).Params()  // map[string]any{"min": 2, "max": 50}
+func (r *LengthRule[T]) Params() map[string]any {
+	return map[string]any{"min": r.min, "max": r.max}
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule: minLength and maxLength for
+// strings, minItems and maxItems for slices and arrays, and minProperties and
+// maxProperties for maps.
+//
+// Example:
+//
+//	keywords := Len[string](2, 50).JSONSchema()  // map[string]any{"minLength": 2, "maxLength": 50}
+func (r *LengthRule[T]) JSONSchema() map[string]any {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		return map[string]any{"minLength": r.min, "maxLength": r.max}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"minItems": r.min, "maxItems": r.max}
+	case reflect.Map:
+		return map[string]any{"minProperties": r.min, "maxProperties": r.max}
+	}
+	return nil
+}
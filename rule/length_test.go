@@ -1,6 +1,8 @@
 package rule
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -115,6 +117,102 @@ func TestLengthRuleErrf(t *testing.T) {
 	assert.Equal(t, "custom length error", err.Error())
 }
 
+// lengthTags is a custom slice type that implements Lengther.
+type lengthTags []string
+
+func (t lengthTags) Len() int { return len(t) }
+
+// lengthIDs is a custom slice type measured via reflection.
+type lengthIDs []int
+
+func TestLengthRuleLengther(t *testing.T) {
+	assert.Nil(t, Len[lengthTags](1, 2).Validate(lengthTags{"a", "b"}))
+	assert.Error(t, Len[lengthTags](1, 2).Validate(lengthTags{"a", "b", "c"}))
+	assert.Nil(t, Len[lengthIDs](1, 2).Validate(lengthIDs{1}))
+
+	var sb strings.Builder
+	sb.WriteString("hello")
+	assert.Nil(t, Len[*strings.Builder](5, 5).Validate(&sb))
+
+	var nilString *string
+	assert.Nil(t, Len[*string](0, 5).Validate(nilString))
+	var nilBuffer *bytes.Buffer
+	assert.EqualError(t, Len[*bytes.Buffer](0, 5).Validate(nilBuffer), "cannot get length of nil *bytes.Buffer")
+	assert.Error(t, Len[int](0, 5).Validate(1))
+
+	assert.Nil(t, LenOf[lengthTags](1, 2).Validate(lengthTags{"a", "b"}))
+	assert.Error(t, LenOf[*strings.Builder](6, 10).Validate(&sb))
+	assert.Nil(t, SliceLen[lengthIDs](1, 3).Validate(lengthIDs{1, 2, 3}))
+	assert.Error(t, SliceLen[[]struct{ ID int }](1, 3).Validate(nil))
+	assert.Nil(t, MapLen[map[int][]string](1, 1).Validate(map[int][]string{1: nil}))
+	assert.Error(t, MapLen[map[int][]string](1, 1).Errf("too many").Validate(map[int][]string{1: nil, 2: nil}))
+}
+
+func TestLengthRuleAllocations(t *testing.T) {
+	tags := lengthTags{"a", "b"}
+	ids := lengthIDs{1, 2}
+	ints := []int{1, 2}
+	tagsRule := LenOf[lengthTags](1, 5)
+	idsRule := SliceLen[lengthIDs](1, 5)
+	intsRule := Len[[]int](1, 5)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = tagsRule.Validate(tags) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = idsRule.Validate(ids) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = intsRule.Validate(ints) }))
+}
+
+func TestLengthRuleLazyError(t *testing.T) {
+	rule := Len[string](2, 4)
+	assert.Nil(t, rule.e)
+	assert.EqualError(t, rule.Validate("a"), "length is not between 2 and 4")
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = Len[string](2, 4).Validate("abc") }))
+}
+
+// BenchmarkLengthRuleReflect measures a named slice type, which Len measures with reflection.
+func BenchmarkLengthRuleReflect(b *testing.B) {
+	rule := Len[lengthIDs](1, 10)
+	ids := lengthIDs{1, 2, 3}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate(ids)
+	}
+}
+
+// BenchmarkLengthRuleLengther measures a Lengther, which Len measures without reflection.
+func BenchmarkLengthRuleLengther(b *testing.B) {
+	rule := Len[lengthTags](1, 10)
+	tags := lengthTags{"a", "b", "c"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate(tags)
+	}
+}
+
+// BenchmarkSliceLen measures the same named slice type as BenchmarkLengthRuleReflect
+// without reflection or allocations.
+func BenchmarkSliceLen(b *testing.B) {
+	rule := SliceLen[lengthIDs](1, 10)
+	ids := lengthIDs{1, 2, 3}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate(ids)
+	}
+}
+
+// BenchmarkLenOf measures a Lengther called directly, without reflection or allocations.
+func BenchmarkLenOf(b *testing.B) {
+	rule := LenOf[lengthTags](1, 10)
+	tags := lengthTags{"a", "b", "c"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate(tags)
+	}
+}
+
 func BenchmarkLengthRule(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -0,0 +1,246 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the magic number table used to detect file types from their content.
+package rule
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// sniffLength is the number of header bytes read to detect a file type.
+// It covers the 512 bytes used by http.DetectContentType and the tar magic at offset 257.
+const sniffLength = 512
+
+// fileSignature identifies a file type by its magic number.
+type fileSignature struct {
+	names    []string
+	mimeType string
+	match    func(header []byte) bool
+}
+
+var (
+	// customSignatures are registered with RegisterFileSignature and checked before the built-in ones.
+	customSignatures []fileSignature
+	signatureMutex   sync.RWMutex
+)
+
+// magicAt returns a matcher for magic bytes at the given offset. Any of the magic
+// values may match.
+func magicAt(offset int, magic ...string) func([]byte) bool {
+	return func(header []byte) bool {
+		if len(header) < offset {
+			return false
+		}
+		for _, m := range magic {
+			if bytes.HasPrefix(header[offset:], []byte(m)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// riff returns a matcher for RIFF containers of the given form type, e.g. "WEBP".
+func riff(form string) func([]byte) bool {
+	return func(header []byte) bool {
+		return len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == form
+	}
+}
+
+// isoBrand returns a matcher for ISO base media files (MP4, HEIC, AVIF, ...) whose
+// major brand is one of brands. An empty brand list matches any brand.
+func isoBrand(brands ...string) func([]byte) bool {
+	return func(header []byte) bool {
+		if len(header) < 12 || string(header[4:8]) != "ftyp" {
+			return false
+		}
+		if len(brands) == 0 {
+			return true
+		}
+		major := string(header[8:12])
+		for _, brand := range brands {
+			if major == brand {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matroska matches Matroska containers whose EBML doc type is docType.
+func matroska(docType string) func([]byte) bool {
+	return func(header []byte) bool {
+		return bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}) &&
+			bytes.Contains(header[:min(len(header), 64)], []byte(docType))
+	}
+}
+
+// bmp matches BMP images: the "BM" magic followed by the file header and a DIB header of
+// one of the known sizes, so that text starting with "BM" is not taken for an image.
+func bmp(header []byte) bool {
+	if len(header) < 18 || string(header[:2]) != "BM" {
+		return false
+	}
+	switch binary.LittleEndian.Uint32(header[14:18]) {
+	case 12, 16, 40, 52, 56, 64, 108, 124:
+		return true
+	}
+	return false
+}
+
+// portableExecutable matches Windows PE files: the "MZ" DOS header whose e_lfanew field
+// points to the "PE\0\0" signature within the header, so that text starting with "MZ" is
+// not taken for an executable.
+func portableExecutable(header []byte) bool {
+	if len(header) < 64 || string(header[:2]) != "MZ" {
+		return false
+	}
+	offset := binary.LittleEndian.Uint32(header[60:64])
+	return offset >= 64 && uint64(offset)+4 <= uint64(len(header)) && string(header[offset:offset+4]) == "PE\x00\x00"
+}
+
+// machO matches Mach-O binaries: thin binaries of either word size and byte order, and
+// universal binaries. Universal binaries share the magic 0xCAFEBABE with Java class
+// files, whose version follows where universal binaries store their number of
+// architectures, so that number must be below 45, the first class file version.
+func machO(header []byte) bool {
+	if len(header) < 4 {
+		return false
+	}
+	switch string(header[:4]) {
+	case "\xFE\xED\xFA\xCE", "\xFE\xED\xFA\xCF", "\xCE\xFA\xED\xFE", "\xCF\xFA\xED\xFE":
+		return true
+	case "\xCA\xFE\xBA\xBE":
+		if len(header) < 8 {
+			return false
+		}
+		archs := binary.BigEndian.Uint32(header[4:8])
+		return archs > 0 && archs < 45
+	}
+	return false
+}
+
+// sniffContentType returns the MIME type http.DetectContentType detects for a header that
+// matches no signature. http.DetectContentType takes any content starting with "BM" for a
+// BMP image, which bmp has ruled out, so the magic is ignored in that case.
+func sniffContentType(header []byte) string {
+	detected := http.DetectContentType(header)
+	if detected == "image/bmp" {
+		detected = http.DetectContentType(append([]byte("  "), header[2:]...))
+	}
+	return detected
+}
+
+// builtinSignatures are the file types detected by FileType and FileMimeType.
+// More specific signatures come before the generic ones they overlap with.
+var builtinSignatures = []fileSignature{
+	// Images
+	{names: []string{"png"}, mimeType: "image/png", match: magicAt(0, "\x89PNG\r\n\x1a\n")},
+	{names: []string{"jpeg", "jpg"}, mimeType: "image/jpeg", match: magicAt(0, "\xFF\xD8\xFF")},
+	{names: []string{"gif"}, mimeType: "image/gif", match: magicAt(0, "GIF87a", "GIF89a")},
+	{names: []string{"webp"}, mimeType: "image/webp", match: riff("WEBP")},
+	{names: []string{"bmp"}, mimeType: "image/bmp", match: bmp},
+	{names: []string{"tiff", "tif"}, mimeType: "image/tiff", match: magicAt(0, "II*\x00", "MM\x00*")},
+	{names: []string{"ico"}, mimeType: "image/x-icon", match: magicAt(0, "\x00\x00\x01\x00")},
+	{names: []string{"heic", "heif"}, mimeType: "image/heic", match: isoBrand("heic", "heix", "heim", "heis", "mif1", "msf1")},
+	{names: []string{"avif"}, mimeType: "image/avif", match: isoBrand("avif", "avis")},
+	// Video and audio
+	{names: []string{"mov", "quicktime"}, mimeType: "video/quicktime", match: isoBrand("qt  ")},
+	{names: []string{"m4a"}, mimeType: "audio/mp4", match: isoBrand("M4A ", "M4B ")},
+	{names: []string{"mp4", "m4v"}, mimeType: "video/mp4", match: isoBrand()},
+	{names: []string{"webm"}, mimeType: "video/webm", match: matroska("webm")},
+	{names: []string{"mkv", "matroska"}, mimeType: "video/x-matroska", match: matroska("matroska")},
+	{names: []string{"avi"}, mimeType: "video/x-msvideo", match: riff("AVI ")},
+	{names: []string{"wav"}, mimeType: "audio/wav", match: riff("WAVE")},
+	{names: []string{"mp3"}, mimeType: "audio/mpeg", match: magicAt(0, "ID3", "\xFF\xFB", "\xFF\xF3", "\xFF\xF2")},
+	{names: []string{"ogg"}, mimeType: "audio/ogg", match: magicAt(0, "OggS")},
+	{names: []string{"flac"}, mimeType: "audio/flac", match: magicAt(0, "fLaC")},
+	// Documents
+	{names: []string{"pdf"}, mimeType: "application/pdf", match: magicAt(0, "%PDF-")},
+	{names: []string{"rtf"}, mimeType: "text/rtf", match: magicAt(0, `{\rtf`)},
+	{names: []string{"ps", "postscript"}, mimeType: "application/postscript", match: magicAt(0, "%!PS")},
+	{names: []string{"cfb", "ole"}, mimeType: "application/x-cfb", match: magicAt(0, "\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")},
+	{names: []string{"sqlite"}, mimeType: "application/vnd.sqlite3", match: magicAt(0, "SQLite format 3\x00")},
+	// Archives
+	{names: []string{"zip"}, mimeType: "application/zip", match: magicAt(0, "PK\x03\x04", "PK\x05\x06", "PK\x07\x08")},
+	{names: []string{"gzip", "gz"}, mimeType: "application/gzip", match: magicAt(0, "\x1F\x8B")},
+	{names: []string{"bzip2", "bz2"}, mimeType: "application/x-bzip2", match: magicAt(0, "BZh")},
+	{names: []string{"xz"}, mimeType: "application/x-xz", match: magicAt(0, "\xFD7zXZ\x00")},
+	{names: []string{"7z"}, mimeType: "application/x-7z-compressed", match: magicAt(0, "7z\xBC\xAF\x27\x1C")},
+	{names: []string{"rar"}, mimeType: "application/vnd.rar", match: magicAt(0, "Rar!\x1A\x07")},
+	{names: []string{"tar"}, mimeType: "application/x-tar", match: magicAt(257, "ustar")},
+	// Fonts
+	{names: []string{"woff"}, mimeType: "font/woff", match: magicAt(0, "wOFF")},
+	{names: []string{"woff2"}, mimeType: "font/woff2", match: magicAt(0, "wOF2")},
+	{names: []string{"ttf"}, mimeType: "font/ttf", match: magicAt(0, "\x00\x01\x00\x00\x00")},
+	{names: []string{"otf"}, mimeType: "font/otf", match: magicAt(0, "OTTO")},
+	// Executables
+	{names: []string{"exe", "dll"}, mimeType: "application/vnd.microsoft.portable-executable", match: portableExecutable},
+	{names: []string{"elf"}, mimeType: "application/x-elf", match: magicAt(0, "\x7FELF")},
+	{names: []string{"macho"}, mimeType: "application/x-mach-binary", match: machO},
+	{names: []string{"wasm"}, mimeType: "application/wasm", match: magicAt(0, "\x00asm")},
+}
+
+// RegisterFileSignature registers a magic number for a file type so that FileType and
+// FileMimeType can detect it. name is the type name accepted by FileType, and magic is
+// expected at the given byte offset within the first 512 bytes. Registered signatures
+// take precedence over the built-in ones. It is safe for concurrent use.
+//
+// Example:
+//
+//	// Detect Parquet files as "parquet" / "application/vnd.apache.parquet"
+//	rule.RegisterFileSignature("parquet", "application/vnd.apache.parquet", 0, []byte("PAR1"))
+//	r := rule.FileType("parquet")
+func RegisterFileSignature(name, mimeType string, offset int, magic []byte) {
+	signatureMutex.Lock()
+	defer signatureMutex.Unlock()
+	customSignatures = append(customSignatures, fileSignature{
+		names:    []string{strings.ToLower(name)},
+		mimeType: mimeType,
+		match:    magicAt(offset, string(magic)),
+	})
+}
+
+// detectSignature returns the file signature matching header, or false if the type is unknown.
+func detectSignature(header []byte) (fileSignature, bool) {
+	signatureMutex.RLock()
+	defer signatureMutex.RUnlock()
+	for _, signatures := range [][]fileSignature{customSignatures, builtinSignatures} {
+		for _, sig := range signatures {
+			if sig.match(header) {
+				return sig, true
+			}
+		}
+	}
+	return fileSignature{}, false
+}
+
+// readHeader reads up to sniffLength bytes from the start of file's remaining content.
+// If file implements io.Seeker, the read position is restored afterwards.
+func readHeader(file io.Reader) ([]byte, error) {
+	seeker, seekable := file.(io.Seeker)
+	var current int64
+	if seekable {
+		var err error
+		if current, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	if seekable {
+		if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return header[:n], nil
+}
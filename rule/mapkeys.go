@@ -0,0 +1,173 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating the keys of maps, such as labels and metadata.
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Error variables for map key validation
+var (
+	// ErrMapKeys is returned when a key of a map does not match the pattern
+	ErrMapKeys = newError("map_keys", "map key does not match the pattern")
+	// ErrRequiredKeys is returned when a map is missing required keys
+	ErrRequiredKeys = newError("required_keys", "map is missing required keys")
+)
+
+// MapKeysRule validates that every key of a map with string keys matches a regular
+// expression, such as a naming policy for labels.
+//
+// Example:
+//
+//	rule := MapKeysMatch[string](`^[a-z][a-z0-9_]*$`)
+//	err := rule.Validate(map[string]string{"team": "core"})  // returns nil
+//	err = rule.Validate(map[string]string{"Team": "core"})   // returns ErrMapKeys
+type MapKeysRule[V any] struct {
+	pattern string
+	regex   *regexp.Regexp
+	e       error
+}
+
+// MapKeysMatch creates a rule that checks that every key of a map[string]V matches
+// pattern. Patterns are compiled once and cached like those of Regex; if the pattern is
+// invalid, the rule always returns an error.
+//
+// Example:
+//
+//	// Webhook metadata keys must be lower snake case
+//	metadataRule := MapKeysMatch[any](`^[a-z][a-z0-9_]{0,39}$`)
+func MapKeysMatch[V any](pattern string) *MapKeysRule[V] {
+	regex, err := getCompiledRegex(pattern)
+	if err != nil {
+		return &MapKeysRule[V]{pattern: pattern, e: fmt.Errorf("invalid regular expression: %w", err)}
+	}
+	return &MapKeysRule[V]{pattern: pattern, regex: regex}
+}
+
+// Validate checks that every key of value matches the pattern. The default error names
+// the first mismatching key in sorted order and wraps ErrMapKeys.
+//
+// Example:
+//
+//	err := MapKeysMatch[int](`^[a-z]+$`).Validate(map[string]int{"a": 1, "B": 2})
+//	// err.Error() is `map key "B" does not match the pattern`
+func (r *MapKeysRule[V]) Validate(value map[string]V) error {
+	if r.regex == nil {
+		return r.e
+	}
+	var invalid []string
+	for key := range value {
+		if !r.regex.MatchString(key) {
+			invalid = append(invalid, key)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	if r.e != nil {
+		return r.e
+	}
+	return formatError(ErrMapKeys, "map key %q does not match the pattern", slices.Min(invalid))
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *MapKeysRule[V]) Describe() RuleInfo {
+	return describe(r, r.e, ErrMapKeys)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := MapKeysMatch[string](`^[a-z_]+$`).Errf("Label names must be lower case")
+func (r *MapKeysRule[V]) Errf(format string, args ...any) *MapKeysRule[V] {
+	if format != "" && r.regex != nil {
+		r.e = wrapf(ErrMapKeys, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := MapKeysMatch[any](`^[a-z]+$`).Params()  // map[string]any{"pattern": "^[a-z]+$"}
+func (r *MapKeysRule[V]) Params() map[string]any {
+	return map[string]any{"pattern": r.pattern}
+}
+
+// RequiredKeysRule validates that a map with string keys has all of a set of keys.
+//
+// Example:
+//
+//	rule := RequiredKeys[any]("id", "name")
+//	err := rule.Validate(map[string]any{"id": 1, "name": "Ada"})  // returns nil
+//	err = rule.Validate(map[string]any{"id": 1})                  // returns ErrRequiredKeys
+type RequiredKeysRule[V any] struct {
+	keys []string
+	e    error
+}
+
+// RequiredKeys creates a rule that checks that a map[string]V has every key in keys.
+// The values of the keys are not checked, so a key with a nil or zero value is present.
+//
+// Example:
+//
+//	payloadRule := RequiredKeys[any]("event", "created_at")
+func RequiredKeys[V any](keys ...string) *RequiredKeysRule[V] {
+	return &RequiredKeysRule[V]{keys: keys}
+}
+
+// Validate checks that value has all required keys. The default error lists the missing
+// keys in the order they were given and wraps ErrRequiredKeys.
+//
+// Example:
+//
+//	err := RequiredKeys[any]("id", "name").Validate(map[string]any{})
+//	// err.Error() is "map is missing required keys: id, name"
+func (r *RequiredKeysRule[V]) Validate(value map[string]V) error {
+	var missing []string
+	for _, key := range r.keys {
+		if _, ok := value[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if r.e != nil {
+		return r.e
+	}
+	return formatError(ErrRequiredKeys, "map is missing required keys: %s", strings.Join(missing, ", "))
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *RequiredKeysRule[V]) Describe() RuleInfo {
+	return describe(r, r.e, ErrRequiredKeys)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := RequiredKeys[any]("id").Errf("The payload must have an id")
+func (r *RequiredKeysRule[V]) Errf(format string, args ...any) *RequiredKeysRule[V] {
+	if format != "" {
+		r.e = wrapf(ErrRequiredKeys, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := RequiredKeys[any]("id", "name").Params()  // map[string]any{"keys": []string{"id", "name"}}
+func (r *RequiredKeysRule[V]) Params() map[string]any {
+	return map[string]any{"keys": r.keys}
+}
@@ -0,0 +1,71 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapKeysMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]any
+		wantErr string
+	}{
+		{name: "valid", value: map[string]any{"team": "core", "cost_center": 42}},
+		{name: "empty", value: map[string]any{}},
+		{name: "nil", value: nil},
+		{name: "invalid", value: map[string]any{"team": "core", "Env": "prod", "9lives": true},
+			wantErr: `map key "9lives" does not match the pattern`},
+	}
+
+	rule := MapKeysMatch[any](`^[a-z][a-z0-9_]*$`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rule.Validate(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrMapKeys)
+		})
+	}
+
+	err := MapKeysMatch[string](`^[a-z]+$`).Errf("Label names must be lower case").Validate(map[string]string{"A": ""})
+	assert.EqualError(t, err, "Label names must be lower case")
+	assert.ErrorIs(t, err, ErrMapKeys)
+	assert.ErrorContains(t, MapKeysMatch[string](`[`).Validate(nil), "invalid regular expression")
+	assert.Equal(t, map[string]any{"pattern": "^[a-z]+$"}, MapKeysMatch[int](`^[a-z]+$`).Params())
+}
+
+func TestRequiredKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]any
+		wantErr string
+	}{
+		{name: "valid", value: map[string]any{"id": 1, "name": "Ada", "extra": true}},
+		{name: "nil value", value: map[string]any{"id": nil, "name": ""}},
+		{name: "one missing", value: map[string]any{"id": 1}, wantErr: "map is missing required keys: name"},
+		{name: "all missing", value: nil, wantErr: "map is missing required keys: id, name"},
+	}
+
+	rule := RequiredKeys[any]("id", "name")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rule.Validate(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrRequiredKeys)
+			assert.Equal(t, "required_keys", ErrorCode(err))
+		})
+	}
+
+	err := RequiredKeys[string]("id").Errf("The payload must have an id").Validate(map[string]string{})
+	assert.EqualError(t, err, "The payload must have an id")
+	assert.Equal(t, map[string]any{"keys": []string{"id", "name"}}, rule.Params())
+}
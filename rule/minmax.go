@@ -3,16 +3,15 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 )
 
 // Error variables for min/max validation
 var (
 	// ErrMin is returned when a value is less than the minimum allowed value
-	ErrMin = errors.New("value is less than minimum")
+	ErrMin = newError("min", "value is less than minimum")
 	// ErrMax is returned when a value is greater than the maximum allowed value
-	ErrMax = errors.New("value is greater than maximum")
+	ErrMax = newError("max", "value is greater than maximum")
 )
 
 // MinRule validates that a value is greater than or equal to a minimum value.
@@ -69,6 +68,11 @@ func (r *MinRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *MinRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrMin)
+}
+
 // Errf sets a custom error message for minimum value validation failures.
 // This allows for context-specific error messages.
 //
@@ -78,11 +82,29 @@ func (r *MinRule[T]) Validate(value T) error {
 //	err := rule.Validate(16)  // returns error with custom message
 func (r *MinRule[T]) Errf(format string, args ...any) *MinRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Min(18).Params()  // map[string]any{"min": 18}
+func (r *MinRule[T]) Params() map[string]any {
+	return map[string]any{"min": r.min}
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule.
+//
+// Example:
+//
+//	keywords := Min(18).JSONSchema()  // map[string]any{"minimum": 18}
+func (r *MinRule[T]) JSONSchema() map[string]any {
+	return map[string]any{"minimum": r.min}
+}
+
 // MaxRule validates that a value is less than or equal to a maximum value.
 // This rule works with any ordered type (numbers, strings, etc.).
 //
@@ -137,6 +159,11 @@ func (r *MaxRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *MaxRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrMax)
+}
+
 // Errf sets a custom error message for maximum value validation failures.
 // This allows for context-specific error messages.
 //
@@ -146,7 +173,25 @@ func (r *MaxRule[T]) Validate(value T) error {
 //	err := rule.Validate(150)  // returns error with custom message
 func (r *MaxRule[T]) Errf(format string, args ...any) *MaxRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Max(100).Params()  // map[string]any{"max": 100}
+func (r *MaxRule[T]) Params() map[string]any {
+	return map[string]any{"max": r.max}
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule.
+//
+// Example:
+//
+//	keywords := Max(100).JSONSchema()  // map[string]any{"maximum": 100}
+func (r *MaxRule[T]) JSONSchema() map[string]any {
+	return map[string]any{"maximum": r.max}
+}
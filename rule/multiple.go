@@ -2,9 +2,11 @@
 // This file contains rules for validating if a number is a multiple of another number.
 package rule
 
-import "fmt"
+// ErrMultiple is returned when a value is not a multiple of the specified base number.
+// Its message includes the base number.
+var ErrMultiple = newError("multiple", "value is not a multiple of the base number")
 
-// ErrMultiple is returned when a value is not a multiple of the specified base number
+// ErrMultipleFormat is the format string for multiple validation errors.
 const ErrMultipleFormat = "is not a multiple of %v"
 
 // MultipleRule validates that a number is a multiple of a specified base number.
@@ -39,7 +41,7 @@ type MultipleRule struct {
 //	err = rule.Validate(15)   // returns nil (15 is divisible by 5)
 //	err = rule.Validate(16)   // returns error (16 is not divisible by 5)
 func MultipleOf(base int) *MultipleRule {
-	return &MultipleRule{base: base, e: fmt.Errorf(ErrMultipleFormat, base)}
+	return &MultipleRule{base: base}
 }
 
 // Validate checks if the value is divisible by the base number.
@@ -53,11 +55,19 @@ func MultipleOf(base int) *MultipleRule {
 //	err = rule.Validate(0)    // returns nil (0 is divisible by any number)
 func (r *MultipleRule) Validate(value int) error {
 	if value%r.base != 0 {
-		return r.e
+		if r.e != nil {
+			return r.e
+		}
+		return formatError(ErrMultiple, ErrMultipleFormat, r.base)
 	}
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *MultipleRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrMultiple)
+}
+
 // Errf sets a custom error message for multiple validation failures.
 // This allows for context-specific error messages.
 //
@@ -70,7 +80,16 @@ func (r *MultipleRule) Validate(value int) error {
 //	err = rule.Validate(7)  // returns error with custom message
 func (r *MultipleRule) Errf(format string, args ...any) *MultipleRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(ErrMultiple, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := MultipleOf(5).Params()  // map[string]any{"base": 5}
+func (r *MultipleRule) Params() map[string]any {
+	return map[string]any{"base": r.base}
+}
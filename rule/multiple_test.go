@@ -14,7 +14,7 @@ func TestMultipleRule(t *testing.T) {
 	assert.Nil(t, err)
 
 	err = MultipleOf(2).Validate(3)
-	assert.Equal(t, fmt.Errorf(ErrMultipleFormat, 2), err)
+	assert.EqualError(t, err, fmt.Sprintf(ErrMultipleFormat, 2))
 
 	err = MultipleOf(2).Errf("custom multiple error").Validate(3)
 	assert.Equal(t, "custom multiple error", err.Error())
@@ -0,0 +1,160 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating national identification numbers of other
+// jurisdictions than mainland China, whose ID card numbers are validated by IsIDCard.
+package rule
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Error variables for national identification number validation
+var (
+	// ErrSSN is returned when a string is not a valid US Social Security number
+	ErrSSN = newError("ssn", "invalid social security number")
+	// ErrNINO is returned when a string is not a valid UK National Insurance number
+	ErrNINO = newError("nino", "invalid national insurance number")
+	// ErrAadhaar is returned when a string is not a valid Indian Aadhaar number
+	ErrAadhaar = newError("aadhaar", "invalid Aadhaar number")
+	// ErrHKID is returned when a string is not a valid Hong Kong identity card number
+	ErrHKID = newError("hkid", "invalid Hong Kong identity card number")
+)
+
+var (
+	regexSSN     = regexp.MustCompile(`^(\d{3}-\d{2}-\d{4}|\d{9})$`)
+	regexNINO    = regexp.MustCompile(`^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?\d{2} ?\d{2} ?\d{2} ?[A-D]$`)
+	regexAadhaar = regexp.MustCompile(`^[2-9]\d{3} ?\d{4} ?\d{4}$`)
+	regexHKID    = regexp.MustCompile(`^[A-Z]{1,2}\d{6}(\([0-9A]\)|[0-9A])$`)
+)
+
+// SSN returns a new RegexRule that validates US Social Security numbers, written as
+// "123-45-6789" or "123456789". Numbers that are never issued, with the area 000, 666
+// or 900 to 999, the group 00 or the serial number 0000, are rejected.
+//
+// Example:
+//
+//	rule := SSN()
+//	err := rule.Validate("123-45-6789")  // returns nil
+//	err = rule.Validate("666-45-6789")   // returns ErrSSN
+func SSN() *RegexRule {
+	return &RegexRule{regex: regexSSN, verify: verifySSN, strict: true, e: ErrSSN}
+}
+
+// NINO returns a new RegexRule that validates UK National Insurance numbers, such as
+// "QQ123456C" or "QQ 12 34 56 C". The prefix letters D, F, I, Q, U and V, O as the
+// second letter, and the prefixes BG, GB, KN, NK, NT, TN and ZZ are never allocated
+// and are rejected.
+//
+// Example:
+//
+//	rule := NINO()
+//	err := rule.Validate("AB 12 34 56 C")  // returns nil
+//	err = rule.Validate("GB123456A")       // returns ErrNINO
+func NINO() *RegexRule {
+	return &RegexRule{regex: regexNINO, verify: verifyNINO, strict: true, e: ErrNINO}
+}
+
+// Aadhaar returns a new RegexRule that validates Indian Aadhaar numbers: 12 digits, not
+// starting with 0 or 1, optionally grouped by four with spaces, whose last digit is the
+// Verhoeff check digit of the others.
+//
+// Example:
+//
+//	rule := Aadhaar()
+//	err := rule.Validate("2341 2341 2346")  // returns nil
+//	err = rule.Validate("234123412345")     // returns ErrAadhaar (wrong check digit)
+func Aadhaar() *RegexRule {
+	return &RegexRule{regex: regexAadhaar, verify: verifyAadhaar, strict: true, e: ErrAadhaar}
+}
+
+// HKID returns a new RegexRule that validates Hong Kong identity card numbers: one or
+// two letters and six digits followed by the check digit, optionally in parentheses,
+// such as "A123456(3)". The check digit is verified.
+//
+// Example:
+//
+//	rule := HKID()
+//	err := rule.Validate("A123456(3)")  // returns nil
+//	err = rule.Validate("A1234564")     // returns ErrHKID (wrong check digit)
+func HKID() *RegexRule {
+	return &RegexRule{regex: regexHKID, verify: verifyHKID, strict: true, e: ErrHKID}
+}
+
+// verifySSN rejects the areas, groups and serial numbers that are never issued.
+func verifySSN(value string) bool {
+	value = strings.ReplaceAll(value, "-", "")
+	area, group, serial := value[:3], value[3:5], value[5:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// verifyNINO rejects the prefixes that are never allocated.
+func verifyNINO(value string) bool {
+	switch value[:2] {
+	case "BG", "GB", "KN", "NK", "NT", "TN", "ZZ":
+		return false
+	}
+	return true
+}
+
+// verhoeffMultiplication and verhoeffPermutation are the tables of the Verhoeff
+// algorithm: the multiplication table of the dihedral group D5 and the permutations
+// applied to each digit by its position.
+var (
+	verhoeffMultiplication = [10][10]byte{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffPermutation = [8][10]byte{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 8, 6, 7, 0},
+		{4, 2, 8, 6, 5, 7, 0, 3, 9, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// verifyAadhaar verifies the Verhoeff check digit of an Aadhaar number.
+func verifyAadhaar(value string) bool {
+	value = strings.ReplaceAll(value, " ", "")
+	var c byte
+	for i := 0; i < len(value); i++ {
+		digit := value[len(value)-1-i] - '0'
+		c = verhoeffMultiplication[c][verhoeffPermutation[i%8][digit]]
+	}
+	return c == 0
+}
+
+// verifyHKID verifies the check digit of a Hong Kong identity card number. Letters
+// count as 10 to 35 and a missing second letter as 36; the weighted sum of the
+// letters, digits and check digit, with A as 10, must be divisible by 11.
+func verifyHKID(value string) bool {
+	value = strings.Trim(strings.ReplaceAll(value, "(", ""), ")")
+	if len(value) == 8 {
+		value = " " + value
+	}
+	sum := 0
+	for i := 0; i < len(value); i++ {
+		var n int
+		switch c := value[i]; {
+		case c == ' ':
+			n = 36
+		case c >= 'A' && c <= 'Z':
+			n = int(c-'A') + 10
+		default:
+			n = int(c - '0')
+		}
+		sum += n * (9 - i)
+	}
+	return sum%11 == 0
+}
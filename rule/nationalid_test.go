@@ -0,0 +1,58 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNationalID(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *RegexRule
+		value   string
+		wantErr error
+	}{
+		{name: "ssn: valid", rule: SSN(), value: "123-45-6789"},
+		{name: "ssn: valid without dashes", rule: SSN(), value: "123456789"},
+		{name: "ssn: empty string", rule: SSN(), value: ""},
+		{name: "ssn: mixed dashes", rule: SSN(), value: "123-456789", wantErr: ErrSSN},
+		{name: "ssn: area 000", rule: SSN(), value: "000-45-6789", wantErr: ErrSSN},
+		{name: "ssn: area 666", rule: SSN(), value: "666-45-6789", wantErr: ErrSSN},
+		{name: "ssn: area 9xx", rule: SSN(), value: "912-45-6789", wantErr: ErrSSN},
+		{name: "ssn: group 00", rule: SSN(), value: "123-00-6789", wantErr: ErrSSN},
+		{name: "ssn: serial 0000", rule: SSN(), value: "123-45-0000", wantErr: ErrSSN},
+		{name: "nino: valid", rule: NINO(), value: "AB123456C"},
+		{name: "nino: valid with spaces", rule: NINO(), value: "AB 12 34 56 C"},
+		{name: "nino: invalid first letter", rule: NINO(), value: "DA123456C", wantErr: ErrNINO},
+		{name: "nino: invalid second letter", rule: NINO(), value: "AO123456C", wantErr: ErrNINO},
+		{name: "nino: unallocated prefix", rule: NINO(), value: "GB123456A", wantErr: ErrNINO},
+		{name: "nino: invalid suffix", rule: NINO(), value: "AB123456E", wantErr: ErrNINO},
+		{name: "nino: lower case", rule: NINO(), value: "ab123456c", wantErr: ErrNINO},
+		{name: "aadhaar: valid", rule: Aadhaar(), value: "234123412346"},
+		{name: "aadhaar: valid with spaces", rule: Aadhaar(), value: "2341 2341 2346"},
+		{name: "aadhaar: wrong check digit", rule: Aadhaar(), value: "234123412345", wantErr: ErrAadhaar},
+		{name: "aadhaar: leading 1", rule: Aadhaar(), value: "134123412346", wantErr: ErrAadhaar},
+		{name: "aadhaar: too short", rule: Aadhaar(), value: "23412341234", wantErr: ErrAadhaar},
+		{name: "hkid: valid", rule: HKID(), value: "A123456(3)"},
+		{name: "hkid: valid without parentheses", rule: HKID(), value: "A1234563"},
+		{name: "hkid: valid two letters", rule: HKID(), value: "AB987654(3)"},
+		{name: "hkid: valid check digit A", rule: HKID(), value: "A123458(A)"},
+		{name: "hkid: wrong check digit", rule: HKID(), value: "A123456(4)", wantErr: ErrHKID},
+		{name: "hkid: unbalanced parentheses", rule: HKID(), value: "A123456(3", wantErr: ErrHKID},
+		{name: "hkid: too few digits", rule: HKID(), value: "A12345(3)", wantErr: ErrHKID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.EqualError(t, SSN().Errf("Invalid SSN").Validate("000-00-0000"), "Invalid SSN")
+}
@@ -2,12 +2,11 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 )
 
 // ErrNegative is returned when a value is not negative (less than zero).
-var ErrNegative = errors.New("value must be negative")
+var ErrNegative = newError("negative", "value must be negative")
 
 // NegativeRule is a validation rule that checks if a value is negative (less than zero).
 // It supports any ordered numeric type through generics.
@@ -23,7 +22,8 @@ var ErrNegative = errors.New("value must be negative")
 //	err = rule.Validate(-3.14)  // returns nil
 //	err = rule.Validate(0.0)    // returns ErrNegative
 type NegativeRule[T Ordered] struct {
-	e error
+	frozen bool
+	e      error
 }
 
 // Negative creates a new negative number validation rule.
@@ -62,6 +62,11 @@ func (r *NegativeRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *NegativeRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrNegative)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -71,7 +76,32 @@ func (r *NegativeRule[T]) Validate(value T) error {
 //	err := rule.Validate(0)  // returns error with message "Value must be less than zero"
 func (r *NegativeRule[T]) Errf(format string, args ...any) *NegativeRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *NegativeRule[T]) mutable() *NegativeRule[T] {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
+
+// Negativev returns the predeclared, immutable negative number rule for T. It returns the same
+// instance on every call, can be shared and used concurrently, and does not allocate.
+// Errf returns a copy instead of modifying it.
+//
+// Example:
+//
+//	err := arbiter.Validate(delta, rule.Negativev[float64]())
+func Negativev[T Ordered]() *NegativeRule[T] {
+	return sharedRule(func() *NegativeRule[T] {
+		return &NegativeRule[T]{e: ErrNegative, frozen: true}
+	})
+}
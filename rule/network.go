@@ -3,8 +3,6 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"net"
 	"strconv"
 	"strings"
@@ -14,19 +12,19 @@ import (
 var (
 	// ErrDomain is returned when a domain name fails validation rules.
 	// This includes length constraints, format requirements, and character restrictions.
-	ErrDomain = errors.New("invalid domain name")
+	ErrDomain = newError("domain", "invalid domain name")
 
 	// ErrPort is returned when a port number is invalid.
 	// Valid port numbers must be integers between 0 and 65535.
-	ErrPort = errors.New("invalid port number")
+	ErrPort = newError("port", "invalid port number")
 
 	// ErrMACAddress is returned when a MAC address format is invalid.
 	// MAC addresses must follow standard IEEE 802 MAC-48, EUI-48, or EUI-64 formats.
-	ErrMACAddress = errors.New("invalid MAC address")
+	ErrMACAddress = newError("mac_address", "invalid MAC address")
 
 	// ErrSubnetMask is returned when a subnet mask is invalid.
 	// Valid subnet masks must be IPv4 addresses with continuous 1s followed by continuous 0s in binary.
-	ErrSubnetMask = errors.New("invalid subnet mask")
+	ErrSubnetMask = newError("subnet_mask", "invalid subnet mask")
 )
 
 // DomainRule provides validation rules for domain names according to DNS standards.
@@ -39,7 +37,8 @@ var (
 //	err = rule.Validate("invalid..domain")   // returns error
 //	err = rule.Validate("no-tld")           // returns error
 type DomainRule struct {
-	e error
+	wildcard bool
+	e        error
 }
 
 // Domain creates a new domain name validation rule.
@@ -60,6 +59,21 @@ func Domain() *DomainRule {
 	}
 }
 
+// AllowWildcard also accepts wildcard domain names such as "*.example.com", as used in
+// TLS certificate SAN entries and CORS origin configurations. Only a single leading "*"
+// label is allowed, and the rest must be a valid domain name, so that "*.com",
+// "a.*.example.com" and "*foo.example.com" are rejected.
+//
+// Example:
+//
+//	rule := Domain().AllowWildcard()
+//	err := rule.Validate("*.example.com")    // returns nil
+//	err = rule.Validate("*.*.example.com")  // returns error
+func (r *DomainRule) AllowWildcard() *DomainRule {
+	r.wildcard = true
+	return r
+}
+
 // Validate checks if the given domain name is valid according to DNS naming rules.
 // It performs comprehensive validation including:
 // - Total length (1-255 characters)
@@ -79,6 +93,9 @@ func (r *DomainRule) Validate(domain string) error {
 	if len(domain) == 0 || len(domain) > 255 {
 		return r.e
 	}
+	if r.wildcard {
+		domain = strings.TrimPrefix(domain, "*.")
+	}
 
 	// 检查域名格式
 	parts := strings.Split(domain, ".")
@@ -102,6 +119,11 @@ func (r *DomainRule) Validate(domain string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *DomainRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDomain)
+}
+
 // Errf sets a custom error message for domain validation failures.
 // This allows for context-specific error messages.
 //
@@ -111,7 +133,7 @@ func (r *DomainRule) Validate(domain string) error {
 //	err := rule.Validate("invalid..com")  // returns custom error message
 func (r *DomainRule) Errf(format string, args ...any) *DomainRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -171,6 +193,11 @@ func (r *PortRule) Validate(port string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *PortRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPort)
+}
+
 // Errf sets a custom error message for port validation failures.
 // This allows for context-specific error messages.
 //
@@ -180,7 +207,7 @@ func (r *PortRule) Validate(port string) error {
 //	err := rule.Validate("70000")  // returns custom error message
 func (r *PortRule) Errf(format string, args ...any) *PortRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -232,6 +259,11 @@ func (r *MACAddressRule) Validate(mac string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *MACAddressRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrMACAddress)
+}
+
 // Errf sets a custom error message for MAC address validation failures.
 // This allows for context-specific error messages.
 //
@@ -241,7 +273,7 @@ func (r *MACAddressRule) Validate(mac string) error {
 //	err := rule.Validate("invalid")  // returns custom error message
 func (r *MACAddressRule) Errf(format string, args ...any) *MACAddressRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -327,6 +359,11 @@ func (r *SubnetMaskRule) Validate(mask string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *SubnetMaskRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrSubnetMask)
+}
+
 // Errf sets a custom error message for subnet mask validation failures.
 // This allows for context-specific error messages.
 //
@@ -336,7 +373,7 @@ func (r *SubnetMaskRule) Validate(mask string) error {
 //	err := rule.Validate("invalid")  // returns custom error message
 func (r *SubnetMaskRule) Errf(format string, args ...any) *SubnetMaskRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -156,3 +156,31 @@ func TestSubnetMaskRuleErrf(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "custom mask error", err.Error())
 }
+
+func TestDomainAllowWildcard(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		wantErr bool
+	}{
+		{name: "wildcard", domain: "*.example.com"},
+		{name: "wildcard subdomain", domain: "*.api.example.com"},
+		{name: "plain domain", domain: "example.com"},
+		{name: "wildcard top-level domain", domain: "*.com", wantErr: true},
+		{name: "wildcard only", domain: "*", wantErr: true},
+		{name: "double wildcard", domain: "*.*.example.com", wantErr: true},
+		{name: "inner wildcard", domain: "api.*.example.com", wantErr: true},
+		{name: "partial wildcard", domain: "*api.example.com", wantErr: true},
+		{name: "trailing wildcard", domain: "example.*", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Domain().AllowWildcard().Validate(tt.domain)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Domain().AllowWildcard().Validate(%q) error = %v, wantErr %v", tt.domain, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.Error(t, Domain().Validate("*.example.com"))
+}
@@ -3,7 +3,6 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 )
@@ -11,17 +10,19 @@ import (
 // Error variables for nil validation
 var (
 	// ErrNil is returned when a value must be nil but is not
-	ErrNil = errors.New("must be nil")
+	ErrNil = newError("nil", "must be nil")
 	// ErrNotNil is returned when a value must not be nil but is
-	ErrNotNil = errors.New("must not be nil")
+	ErrNotNil = newError("not_nil", "must not be nil")
 )
 
 // Predefined rules for common nil validation scenarios
 var (
-	// Nil is a predefined rule that validates a value must be nil
-	Nil = &NilRule[any]{e: ErrNil}
-	// NotNil is a predefined rule that validates a value must not be nil
-	NotNil = &NilRule[any]{e: ErrNotNil, not: true}
+	// Nil is a predefined rule that validates a value must be nil.
+	// It is immutable: Errf returns a copy instead of modifying it.
+	Nil = &NilRule[any]{e: ErrNil, frozen: true}
+	// NotNil is a predefined rule that validates a value must not be nil.
+	// It is immutable: Errf returns a copy instead of modifying it.
+	NotNil = &NilRule[any]{e: ErrNotNil, not: true, frozen: true}
 )
 
 // NilRule validates if a value is nil or not nil.
@@ -42,8 +43,9 @@ var (
 //	err = Nil.Validate(ptr)            // returns nil (pointer is nil)
 //	err = NotNil.Validate(ptr)         // returns error (pointer is nil)
 type NilRule[T any] struct {
-	not bool
-	e   error
+	not    bool
+	frozen bool
+	e      error
 }
 
 // Validate checks if the value is nil or not nil, depending on the rule type.
@@ -142,6 +144,11 @@ func (r *NilRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *NilRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, Ternary(r.not, ErrNotNil, ErrNil))
+}
+
 // Errf sets a custom error message for nil validation failures.
 // This allows for context-specific error messages.
 //
@@ -154,7 +161,19 @@ func (r *NilRule[T]) Validate(value T) error {
 //	err = rule.Validate("not empty")  // returns error with custom message
 func (r *NilRule[T]) Errf(format string, args ...any) *NilRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(Ternary(r.e != nil, r.e, Ternary(r.not, ErrNotNil, ErrNil)), format, args...)
 	}
 	return r
 }
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *NilRule[T]) mutable() *NilRule[T] {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
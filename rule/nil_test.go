@@ -95,3 +95,10 @@ func BenchmarkNilRule(b *testing.B) {
 		_ = Nil.Validate(nil)
 	}
 }
+
+func TestNilRuleErrfDoesNotModifyPredeclared(t *testing.T) {
+	_ = NotNil.Errf("custom notnil error")
+	assert.ErrorIs(t, NotNil.Validate(nil), ErrNotNil)
+	_ = Nil.Errf("custom nil error")
+	assert.ErrorIs(t, Nil.Validate(1), ErrNil)
+}
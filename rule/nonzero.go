@@ -3,15 +3,13 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"reflect"
 )
 
 // Error variable for non-zero validation
 var (
 	// ErrNonZero is returned when a value must be non-zero but is zero
-	ErrNonZero = errors.New("value must be non-zero")
+	ErrNonZero = newError("non_zero", "value must be non-zero")
 )
 
 // NonZeroRule validates that a value is non-zero.
@@ -74,6 +72,7 @@ func NonZero[T any]() *NonZeroRule[T] {
 // - Booleans: Must be true
 // - Slices/Maps: Must not be nil and must have length > 0
 // - Arrays: Must have length > 0
+// - Null types of database/sql, such as sql.NullString: Must be valid with a non-zero inner value
 // - Structs: At least one field must be non-zero
 //
 // Example:
@@ -148,6 +147,9 @@ func (r *NonZeroRule[T]) Validate(value T) error {
 			return r.e
 		}
 	case reflect.Struct:
+		if zero, ok := sqlNullZero(v); ok {
+			return Ternary(zero, r.e, nil)
+		}
 		for i := 0; i < v.NumField(); i++ {
 			field := v.Field(i)
 			if !field.IsZero() {
@@ -161,6 +163,11 @@ func (r *NonZeroRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *NonZeroRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrNonZero)
+}
+
 // Errf sets a custom error message for non-zero validation failures.
 // This allows for context-specific error messages.
 //
@@ -173,7 +180,7 @@ func (r *NonZeroRule[T]) Validate(value T) error {
 //	err = rule.Validate("")  // returns error with custom message
 func (r *NonZeroRule[T]) Errf(format string, args ...any) *NonZeroRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
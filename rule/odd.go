@@ -2,15 +2,10 @@
 // This file contains rules for validating that a number is odd.
 package rule
 
-import (
-	"errors"
-	"fmt"
-)
-
 // Error variable for odd validation
 var (
 	// ErrOdd is returned when a value must be odd but is even
-	ErrOdd = errors.New("value must be odd")
+	ErrOdd = newError("odd", "value must be odd")
 )
 
 // OddRule validates that a number is odd.
@@ -91,6 +86,11 @@ func (r *OddRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *OddRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrOdd)
+}
+
 // Errf sets a custom error message for odd validation failures.
 // This allows for context-specific error messages.
 //
@@ -103,7 +103,7 @@ func (r *OddRule[T]) Validate(value T) error {
 //	err = rule.Validate(6)  // returns error with custom message
 func (r *OddRule[T]) Errf(format string, args ...any) *OddRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -0,0 +1,51 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rule that makes other rules optional.
+package rule
+
+// OptionalRule accepts zero values, such as "", 0 or nil, and applies its rules to any
+// other value. It states explicitly that a field may be left empty, whatever its rules
+// do with empty values.
+//
+// Example:
+//
+//	rule := Optional(StartWith("https://"))
+//	err := rule.Validate("")                     // returns nil
+//	err = rule.Validate("https://example.com")   // returns nil
+//	err = rule.Validate("ftp://example.com")     // returns ErrStartsWith
+type OptionalRule[T any] struct {
+	rules []Rule[T]
+}
+
+// Optional creates a rule that returns nil for the zero value of T and otherwise applies
+// rules, in order, and returns the first error. Values are zero as for Zero, so empty
+// slices and maps and NULL database/sql values are zero too.
+//
+// Example:
+//
+//	websiteRule := Optional(URL(), Len[string](0, 200))
+func Optional[T any](rules ...Rule[T]) *OptionalRule[T] {
+	return &OptionalRule[T]{rules: rules}
+}
+
+// Validate applies the rules to value unless it is zero.
+//
+// Example:
+//
+//	err := Optional(Min(18)).Validate(0)   // returns nil
+//	err = Optional(Min(18)).Validate(16)   // returns ErrMin
+func (r *OptionalRule[T]) Validate(value T) error {
+	if isZero[any](value) {
+		return nil
+	}
+	for _, rule := range r.rules {
+		if err := rule.Validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns the description of the rule, with its inner rules.
+func (r *OptionalRule[T]) Describe() RuleInfo {
+	return RuleInfo{Name: "optional", Rules: describeRules(r.rules)}
+}
@@ -0,0 +1,37 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "empty string", err: Optional(StartWith("https://")).Validate("")},
+		{name: "valid string", err: Optional(StartWith("https://")).Validate("https://example.com")},
+		{name: "invalid string", err: Optional(StartWith("https://")).Validate("ftp://example.com"), wantErr: ErrStartsWith},
+		{name: "zero int", err: Optional(Min(18)).Validate(0)},
+		{name: "invalid int", err: Optional(Min(18)).Validate(16), wantErr: ErrMin},
+		{name: "rules in order", err: Optional(Min(18), Max(10)).Validate(5), wantErr: ErrMin},
+		{name: "zero time", err: Optional[time.Time](After(time.Now())).Validate(time.Time{})},
+		{name: "invalid time", err: Optional[time.Time](After(time.Now())).Validate(time.Unix(0, 0)), wantErr: ErrAfter},
+		{name: "nil slice", err: Optional[[]string](Len[[]string](1, 2)).Validate(nil)},
+		{name: "no rules", err: Optional[string]().Validate("value")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				assert.NoError(t, tt.err)
+				return
+			}
+			assert.ErrorIs(t, tt.err, tt.wantErr)
+		})
+	}
+}
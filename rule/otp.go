@@ -0,0 +1,231 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for two-factor authentication inputs such as TOTP secrets and recovery codes.
+package rule
+
+import (
+	"slices"
+	"strings"
+)
+
+// Two-factor authentication validation errors
+var (
+	// ErrTOTPSecret is returned when a string is not a base32 TOTP secret of an accepted length.
+	ErrTOTPSecret = newError("totp_secret", "invalid TOTP secret")
+
+	// ErrRecoveryCode is returned when a string does not match the recovery code format.
+	ErrRecoveryCode = newError("recovery_code", "invalid recovery code")
+)
+
+// base32Alphabet is the RFC 4648 base32 alphabet used for TOTP secrets.
+const base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// TOTPSecretRule validates that a string is a base32 encoded TOTP (RFC 6238) shared secret.
+//
+// Example:
+//
+//	rule := TOTPSecret()
+//	err := rule.Validate("JBSWY3DPEHPK3PXP")                  // returns nil (80 bits)
+//	err = rule.Validate("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")  // returns nil (160 bits)
+//	err = rule.Validate("JBSWY3DPEHPK3PX1")                  // returns error (1 is not base32)
+type TOTPSecretRule struct {
+	lengths []int
+	e       error
+}
+
+// TOTPSecret creates a new TOTP secret validation rule for 2FA enrollment endpoints.
+// By default secrets of 16, 26 or 32 base32 characters (80, 128 or 160 bits) are accepted.
+// Lower case letters and "=" padding are accepted, as many authenticator apps produce them.
+//
+// Example:
+//
+//	type Enroll2FA struct {
+//	    Secret string `json:"secret"`
+//	    Code   string `json:"code"`
+//	}
+//
+//	rule := TOTPSecret().Errf("Invalid authenticator secret")
+func TOTPSecret() *TOTPSecretRule {
+	return &TOTPSecretRule{
+		lengths: []int{16, 26, 32},
+		e:       ErrTOTPSecret,
+	}
+}
+
+// Lengths sets the accepted secret lengths in base32 characters, excluding padding.
+//
+// Example:
+//
+//	rule := TOTPSecret().Lengths(32, 52)  // 160 or 256 bit secrets
+func (r *TOTPSecretRule) Lengths(lengths ...int) *TOTPSecretRule {
+	r.lengths = append([]int{}, lengths...)
+	return r
+}
+
+// Validate checks if the string is a base32 secret of an accepted length.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := TOTPSecret()
+//	err := rule.Validate("jbswy3dpehpk3pxp")  // returns nil
+//	err = rule.Validate("JBSWY3DP")          // returns error (too short)
+//	err = rule.Validate("")                  // returns nil (empty string is valid)
+func (r *TOTPSecretRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if !r.valid(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrTOTPSecret
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *TOTPSecretRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrTOTPSecret)
+}
+
+// valid reports whether value is base32 with an accepted length and correct padding.
+func (r *TOTPSecretRule) valid(value string) bool {
+	secret := strings.TrimRight(value, "=")
+	if padding := len(value) - len(secret); padding > 0 && len(value)%8 != 0 {
+		return false
+	}
+	lengths := r.lengths
+	if lengths == nil {
+		lengths = []int{16, 26, 32}
+	}
+	return slices.Contains(lengths, len(secret)) && containsOnly(strings.ToUpper(secret), base32Alphabet)
+}
+
+// Errf sets a custom error message for TOTP secret validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := TOTPSecret().Errf("Please scan the QR code again")
+func (r *TOTPSecretRule) Errf(format string, args ...any) *TOTPSecretRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// RecoveryCodeRule validates that a string matches a 2FA recovery code format.
+//
+// Example:
+//
+//	rule := RecoveryCode("XXXXX-XXXXX")
+//	err := rule.Validate("a1b2c-3d4e5")  // returns nil
+//	err = rule.Validate("a1b2c3d4e5")    // returns error (missing separator)
+type RecoveryCodeRule struct {
+	format           string
+	ignoreSeparators bool
+	e                error
+}
+
+// RecoveryCode creates a new recovery code validation rule. In format, "X" matches a letter
+// or digit, "#" matches a digit and any other character must appear literally.
+//
+// Example:
+//
+//	RecoveryCode("XXXXX-XXXXX")  // GitHub style: a1b2c-3d4e5
+//	RecoveryCode("####-####")    // numeric: 1234-5678
+//	RecoveryCode("XXXX XXXX XXXX")
+func RecoveryCode(format string) *RecoveryCodeRule {
+	return &RecoveryCodeRule{
+		format: format,
+		e:      ErrRecoveryCode,
+	}
+}
+
+// IgnoreSeparators also accepts codes typed without the literal characters of the format,
+// e.g. "a1b2c3d4e5" for "XXXXX-XXXXX".
+//
+// Example:
+//
+//	rule := RecoveryCode("XXXXX-XXXXX").IgnoreSeparators()
+func (r *RecoveryCodeRule) IgnoreSeparators() *RecoveryCodeRule {
+	r.ignoreSeparators = true
+	return r
+}
+
+// Validate checks if the string matches the recovery code format.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := RecoveryCode("####-####")
+//	err := rule.Validate("1234-5678")  // returns nil
+//	err = rule.Validate("1234-567a")   // returns error
+func (r *RecoveryCodeRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if r.format == "" || !(matchCodeFormat(r.format, value) || (r.ignoreSeparators && matchCodeFormat(stripLiterals(r.format), value))) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrRecoveryCode
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *RecoveryCodeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrRecoveryCode)
+}
+
+// matchCodeFormat reports whether value matches format, where "X" is a letter or digit,
+// "#" is a digit and any other character is literal.
+func matchCodeFormat(format, value string) bool {
+	if len(format) != len(value) {
+		return false
+	}
+	for i := 0; i < len(format); i++ {
+		c := value[i]
+		isDigit := c >= '0' && c <= '9'
+		isAlnum := isDigit || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		switch format[i] {
+		case 'X':
+			if !isAlnum {
+				return false
+			}
+		case '#':
+			if !isDigit {
+				return false
+			}
+		default:
+			if c != format[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stripLiterals removes the literal characters from a code format.
+func stripLiterals(format string) string {
+	return strings.Map(func(c rune) rune {
+		if c == 'X' || c == '#' {
+			return c
+		}
+		return -1
+	}, format)
+}
+
+// Errf sets a custom error message for recovery code validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := RecoveryCode("XXXXX-XXXXX").Errf("Recovery codes look like a1b2c-3d4e5")
+func (r *RecoveryCodeRule) Errf(format string, args ...any) *RecoveryCodeRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
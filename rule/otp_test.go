@@ -0,0 +1,75 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *TOTPSecretRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: 80 bit", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PXP", wantErr: false},
+		{name: "valid: 128 bit", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PXPJBSWY3DPEH", wantErr: false},
+		{name: "valid: 160 bit", rule: TOTPSecret(), value: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", wantErr: false},
+		{name: "valid: padded", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PXPJBSWY3DPEH======", wantErr: false},
+		{name: "valid: lower case", rule: TOTPSecret(), value: "jbswy3dpehpk3pxp", wantErr: false},
+		{name: "valid: custom length", rule: TOTPSecret().Lengths(8), value: "JBSWY3DP", wantErr: false},
+		{name: "valid: empty string", rule: TOTPSecret(), value: "", wantErr: false},
+		{name: "invalid: too short", rule: TOTPSecret(), value: "JBSWY3DP", wantErr: true},
+		{name: "invalid: unsupported length", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PXPJB", wantErr: true},
+		{name: "invalid: not base32", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PX1", wantErr: true},
+		{name: "invalid: wrong padding", rule: TOTPSecret(), value: "JBSWY3DPEHPK3PXPJBSWY3DPEH==", wantErr: true},
+		{name: "custom error message", rule: TOTPSecret().Errf("custom error"), value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TOTPSecretRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *RecoveryCodeRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: alphanumeric groups", rule: RecoveryCode("XXXXX-XXXXX"), value: "a1b2c-3d4e5", wantErr: false},
+		{name: "valid: numeric groups", rule: RecoveryCode("####-####"), value: "1234-5678", wantErr: false},
+		{name: "valid: space separated", rule: RecoveryCode("XXXX XXXX XXXX"), value: "AB12 CD34 EF56", wantErr: false},
+		{name: "valid: separators ignored", rule: RecoveryCode("XXXXX-XXXXX").IgnoreSeparators(), value: "a1b2c3d4e5", wantErr: false},
+		{name: "valid: separators still accepted", rule: RecoveryCode("XXXXX-XXXXX").IgnoreSeparators(), value: "a1b2c-3d4e5", wantErr: false},
+		{name: "valid: empty string", rule: RecoveryCode("XXXXX-XXXXX"), value: "", wantErr: false},
+		{name: "invalid: missing separator", rule: RecoveryCode("XXXXX-XXXXX"), value: "a1b2c3d4e5", wantErr: true},
+		{name: "invalid: letter in numeric code", rule: RecoveryCode("####-####"), value: "1234-567a", wantErr: true},
+		{name: "invalid: wrong length", rule: RecoveryCode("####-####"), value: "1234-56789", wantErr: true},
+		{name: "invalid: symbol", rule: RecoveryCode("XXXXX-XXXXX"), value: "a1b2c-3d4e!", wantErr: true},
+		{name: "invalid: empty format", rule: RecoveryCode(""), value: "1234", wantErr: true},
+		{name: "custom error message", rule: RecoveryCode("####").Errf("custom error"), value: "abcd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecoveryCodeRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOTPFallback(t *testing.T) {
+	assert.ErrorIs(t, (&TOTPSecretRule{}).Validate("abc"), ErrTOTPSecret)
+	assert.Nil(t, (&TOTPSecretRule{}).Validate("JBSWY3DPEHPK3PXP"))
+	assert.ErrorIs(t, (&RecoveryCodeRule{format: "####"}).Validate("abcd"), ErrRecoveryCode)
+}
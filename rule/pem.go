@@ -0,0 +1,466 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating PEM-encoded certificates and keys.
+package rule
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"slices"
+	"time"
+)
+
+// Error variables for certificate and key validation
+var (
+	// ErrPEMCertificate is returned when a value is not a PEM-encoded X.509 certificate
+	// chain meeting the requirements of the rule
+	ErrPEMCertificate = newError("pem_certificate", "invalid PEM certificate")
+	// ErrCertificateExpired is returned when a certificate has expired or is not yet valid
+	ErrCertificateExpired = newError("certificate_expired", "certificate has expired or is not yet valid")
+	// ErrPEMPrivateKey is returned when a value is not a PEM-encoded private key of an
+	// accepted type and size
+	ErrPEMPrivateKey = newError("pem_private_key", "invalid PEM private key")
+	// ErrPEMPublicKey is returned when a value is not a PEM-encoded public key of an
+	// accepted type and size
+	ErrPEMPublicKey = newError("pem_public_key", "invalid PEM public key")
+)
+
+// The key types accepted by PEMPrivateKey and PEMPublicKey.
+const (
+	KeyRSA     = "rsa"
+	KeyECDSA   = "ecdsa"
+	KeyEd25519 = "ed25519"
+)
+
+// PEMCertificateRule validates that a value holds one or more PEM "CERTIFICATE" blocks,
+// the leaf certificate first, each of which is a parseable X.509 certificate within its
+// validity period. Other PEM blocks, such as a private key pasted along with the
+// certificate, and text outside the blocks are rejected.
+//
+// Example:
+//
+//	rule := PEMCertificate[string]()
+//	err := rule.Validate(certPEM)      // returns nil
+//	err = rule.Validate(expiredPEM)    // returns ErrCertificateExpired
+//	err = rule.Validate("not a cert")  // returns ErrPEMCertificate
+type PEMCertificateRule[T Bytes] struct {
+	keyUsage    x509.KeyUsage
+	extKeyUsage []x509.ExtKeyUsage
+	maxChain    int
+	now         func() time.Time
+	e           error
+}
+
+// PEMCertificate creates a rule that validates PEM-encoded certificates stored in a
+// string or byte slice field, such as certificates uploaded by customers for their
+// custom domains.
+//
+// Example:
+//
+//	arbiter.Field(&domain.CertificatePEM, rule.Required[string](), rule.PEMCertificate[string]())
+func PEMCertificate[T Bytes]() *PEMCertificateRule[T] {
+	return &PEMCertificateRule[T]{now: time.Now}
+}
+
+// KeyUsage requires the leaf certificate to have all of the given key usages, such as
+// x509.KeyUsageDigitalSignature. Certificates without a key usage extension fail.
+//
+// Example:
+//
+//	rule := PEMCertificate[string]().KeyUsage(x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment)
+func (r *PEMCertificateRule[T]) KeyUsage(usage x509.KeyUsage) *PEMCertificateRule[T] {
+	r.keyUsage = usage
+	return r
+}
+
+// ExtKeyUsage requires the leaf certificate to have each of the given extended key
+// usages, such as x509.ExtKeyUsageServerAuth for TLS server certificates. A certificate
+// with x509.ExtKeyUsageAny satisfies every usage.
+//
+// Example:
+//
+//	rule := PEMCertificate[[]byte]().ExtKeyUsage(x509.ExtKeyUsageServerAuth)
+func (r *PEMCertificateRule[T]) ExtKeyUsage(usages ...x509.ExtKeyUsage) *PEMCertificateRule[T] {
+	r.extKeyUsage = usages
+	return r
+}
+
+// MaxChainLength limits the number of certificates in the value, including the leaf.
+// Values less than or equal to zero remove the limit.
+//
+// Example:
+//
+//	rule := PEMCertificate[string]().MaxChainLength(3)  // leaf and up to two intermediates
+func (r *PEMCertificateRule[T]) MaxChainLength(n int) *PEMCertificateRule[T] {
+	r.maxChain = max(n, 0)
+	return r
+}
+
+// Clock sets the function used to obtain the current time, which is time.Now by default.
+// This is useful for deterministic tests or when validating against a reference time.
+//
+// Example:
+//
+//	ref := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := PEMCertificate[string]().Clock(func() time.Time { return ref })
+func (r *PEMCertificateRule[T]) Clock(now func() time.Time) *PEMCertificateRule[T] {
+	if now != nil {
+		r.now = now
+	}
+	return r
+}
+
+// Validate checks if the value holds valid certificates meeting the requirements.
+// Returns nil if the value is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := PEMCertificate[string]().ExtKeyUsage(x509.ExtKeyUsageServerAuth)
+//	err := rule.Validate(serverCertPEM)  // returns nil
+//	err = rule.Validate(clientCertPEM)   // returns ErrPEMCertificate
+func (r *PEMCertificateRule[T]) Validate(value T) error {
+	if skipEmpty(string(value)) {
+		return nil
+	}
+	certs, ok := parsePEMCertificates([]byte(value))
+	if !ok || r.maxChain > 0 && len(certs) > r.maxChain || !r.usable(certs[0]) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPEMCertificate
+	}
+	now := Ternary(r.now == nil, time.Now, r.now)()
+	for _, cert := range certs {
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			if r.e != nil {
+				return r.e
+			}
+			return ErrCertificateExpired
+		}
+	}
+	return nil
+}
+
+// usable reports whether the leaf certificate has the required key usages.
+func (r *PEMCertificateRule[T]) usable(leaf *x509.Certificate) bool {
+	if leaf.KeyUsage&r.keyUsage != r.keyUsage {
+		return false
+	}
+	for _, usage := range r.extKeyUsage {
+		found := false
+		for _, u := range leaf.ExtKeyUsage {
+			if u == usage || u == x509.ExtKeyUsageAny {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PEMCertificateRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrPEMCertificate)
+}
+
+// Errf sets a custom error message for the validation rule, returned both for invalid
+// and for expired certificates.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := PEMCertificate[string]().Errf("Please upload a valid, unexpired certificate")
+func (r *PEMCertificateRule[T]) Errf(format string, args ...any) *PEMCertificateRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrPEMCertificate, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := PEMCertificate[string]().MaxChainLength(3).Params()  // map[string]any{"max_chain_length": 3}
+func (r *PEMCertificateRule[T]) Params() map[string]any {
+	return map[string]any{"max_chain_length": r.maxChain}
+}
+
+// parsePEMCertificates parses data as a sequence of PEM "CERTIFICATE" blocks separated
+// by white space. It reports false if data has no blocks, other blocks or other text,
+// or a block that is not a valid certificate.
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, bool) {
+	blocks, ok := decodePEMBlocks(data)
+	if !ok {
+		return nil, false
+	}
+	certs := make([]*x509.Certificate, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			return nil, false
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, false
+		}
+		certs = append(certs, cert)
+	}
+	return certs, true
+}
+
+// decodePEMBlocks decodes data as a sequence of PEM blocks separated by white space.
+// It reports false if data has no blocks or text outside the blocks.
+func decodePEMBlocks(data []byte) ([]*pem.Block, bool) {
+	var blocks []*pem.Block
+	rest := bytes.TrimSpace(data)
+	for len(rest) > 0 {
+		block, next := pem.Decode(rest)
+		if block == nil || !bytes.HasPrefix(rest, []byte("-----BEGIN ")) {
+			return nil, false
+		}
+		blocks = append(blocks, block)
+		rest = bytes.TrimSpace(next)
+	}
+	return blocks, len(blocks) > 0
+}
+
+// PEMPrivateKeyRule validates that a value holds a single unencrypted PEM private key: a
+// PKCS #8 "PRIVATE KEY", a PKCS #1 "RSA PRIVATE KEY" or a SEC 1 "EC PRIVATE KEY" block
+// holding an RSA, ECDSA or Ed25519 key.
+//
+// Example:
+//
+//	rule := PEMPrivateKey[string](KeyECDSA, KeyEd25519)
+//	err := rule.Validate(ed25519PEM)  // returns nil
+//	err = rule.Validate(rsaPEM)       // returns ErrPEMPrivateKey
+type PEMPrivateKeyRule[T Bytes] struct {
+	types   []string
+	minBits int
+	e       error
+}
+
+// PEMPrivateKey creates a rule that validates PEM private keys of the given types, such
+// as KeyRSA, or of any supported type if none are given.
+//
+// Example:
+//
+//	arbiter.Field(&app.SigningKey, rule.Required[[]byte](), rule.PEMPrivateKey[[]byte]().MinBits(2048))
+func PEMPrivateKey[T Bytes](types ...string) *PEMPrivateKeyRule[T] {
+	return &PEMPrivateKeyRule[T]{types: types, e: ErrPEMPrivateKey}
+}
+
+// MinBits sets the minimum key size: the modulus length of RSA keys and the curve size of
+// ECDSA keys. Ed25519 keys count as 256 bits.
+//
+// Example:
+//
+//	rule := PEMPrivateKey[string](KeyRSA).MinBits(3072)
+func (r *PEMPrivateKeyRule[T]) MinBits(bits int) *PEMPrivateKeyRule[T] {
+	r.minBits = bits
+	return r
+}
+
+// Validate checks if the value holds a private key of an accepted type and size.
+// Returns nil if the value is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := PEMPrivateKey[string]()
+//	err := rule.Validate(rsaPEM)        // returns nil
+//	err = rule.Validate(encryptedPEM)  // returns ErrPEMPrivateKey
+func (r *PEMPrivateKeyRule[T]) Validate(value T) error {
+	if skipEmpty(string(value)) {
+		return nil
+	}
+	key, ok := parsePEMPrivateKey([]byte(value))
+	if !ok || !acceptedKey(key, r.types, r.minBits) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPEMPrivateKey
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PEMPrivateKeyRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrPEMPrivateKey)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := PEMPrivateKey[string](KeyRSA).MinBits(2048).Errf("Please upload an RSA key of at least 2048 bits")
+func (r *PEMPrivateKeyRule[T]) Errf(format string, args ...any) *PEMPrivateKeyRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrPEMPrivateKey, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := PEMPrivateKey[string](KeyRSA).MinBits(2048).Params()
+//	// map[string]any{"types": []string{"rsa"}, "min_bits": 2048}
+func (r *PEMPrivateKeyRule[T]) Params() map[string]any {
+	return map[string]any{"types": r.types, "min_bits": r.minBits}
+}
+
+// PEMPublicKeyRule validates that a value holds a single PEM public key: a PKIX "PUBLIC
+// KEY" or a PKCS #1 "RSA PUBLIC KEY" block holding an RSA, ECDSA or Ed25519 key.
+//
+// Example:
+//
+//	rule := PEMPublicKey[string]()
+//	err := rule.Validate(publicPEM)   // returns nil
+//	err = rule.Validate(privatePEM)   // returns ErrPEMPublicKey
+type PEMPublicKeyRule[T Bytes] struct {
+	types   []string
+	minBits int
+	e       error
+}
+
+// PEMPublicKey creates a rule that validates PEM public keys of the given types, such as
+// KeyEd25519, or of any supported type if none are given.
+//
+// Example:
+//
+//	arbiter.Field(&client.PublicKey, rule.Required[string](), rule.PEMPublicKey[string]())
+func PEMPublicKey[T Bytes](types ...string) *PEMPublicKeyRule[T] {
+	return &PEMPublicKeyRule[T]{types: types, e: ErrPEMPublicKey}
+}
+
+// MinBits sets the minimum key size: the modulus length of RSA keys and the curve size of
+// ECDSA keys. Ed25519 keys count as 256 bits.
+//
+// Example:
+//
+//	rule := PEMPublicKey[string]().MinBits(2048)  // rejects 1024-bit RSA keys
+func (r *PEMPublicKeyRule[T]) MinBits(bits int) *PEMPublicKeyRule[T] {
+	r.minBits = bits
+	return r
+}
+
+// Validate checks if the value holds a public key of an accepted type and size.
+// Returns nil if the value is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := PEMPublicKey[string](KeyRSA)
+//	err := rule.Validate(rsaPublicPEM)      // returns nil
+//	err = rule.Validate(ed25519PublicPEM)  // returns ErrPEMPublicKey
+func (r *PEMPublicKeyRule[T]) Validate(value T) error {
+	if skipEmpty(string(value)) {
+		return nil
+	}
+	key, ok := parsePEMPublicKey([]byte(value))
+	if !ok || !acceptedKey(key, r.types, r.minBits) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPEMPublicKey
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *PEMPublicKeyRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrPEMPublicKey)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := PEMPublicKey[string]().Errf("Please paste your public key in PEM format")
+func (r *PEMPublicKeyRule[T]) Errf(format string, args ...any) *PEMPublicKeyRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrPEMPublicKey, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := PEMPublicKey[string]().MinBits(256).Params()  // map[string]any{"types": []string(nil), "min_bits": 256}
+func (r *PEMPublicKeyRule[T]) Params() map[string]any {
+	return map[string]any{"types": r.types, "min_bits": r.minBits}
+}
+
+// parsePEMPrivateKey parses data as a single unencrypted PEM private key block.
+func parsePEMPrivateKey(data []byte) (any, bool) {
+	blocks, ok := decodePEMBlocks(data)
+	if !ok || len(blocks) != 1 || len(blocks[0].Headers) > 0 {
+		return nil, false
+	}
+	var key any
+	var err error
+	switch block := blocks[0]; block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, false
+	}
+	return key, err == nil
+}
+
+// parsePEMPublicKey parses data as a single PEM public key block.
+func parsePEMPublicKey(data []byte) (any, bool) {
+	blocks, ok := decodePEMBlocks(data)
+	if !ok || len(blocks) != 1 {
+		return nil, false
+	}
+	var key any
+	var err error
+	switch block := blocks[0]; block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, false
+	}
+	return key, err == nil
+}
+
+// acceptedKey reports whether key is one of types, or of any supported type if types is
+// empty, and has at least minBits bits.
+func acceptedKey(key any, types []string, minBits int) bool {
+	typ, bits := keyTypeAndSize(key)
+	return typ != "" && (len(types) == 0 || slices.Contains(types, typ)) && bits >= minBits
+}
+
+// keyTypeAndSize returns the type and size of an RSA, ECDSA or Ed25519 key, or an empty
+// type for other keys.
+func keyTypeAndSize(key any) (string, int) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return KeyRSA, k.N.BitLen()
+	case *rsa.PublicKey:
+		return KeyRSA, k.N.BitLen()
+	case *ecdsa.PrivateKey:
+		return KeyECDSA, k.Curve.Params().BitSize
+	case *ecdsa.PublicKey:
+		return KeyECDSA, k.Curve.Params().BitSize
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return KeyEd25519, 256
+	}
+	return "", 0
+}
@@ -2,12 +2,11 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 )
 
 // ErrPositive is returned when a value is not positive (greater than zero).
-var ErrPositive = errors.New("value must be positive")
+var ErrPositive = newError("positive", "value must be positive")
 
 // PositiveRule is a validation rule that checks if a value is positive (greater than zero).
 // It supports any ordered numeric type through generics.
@@ -23,7 +22,8 @@ var ErrPositive = errors.New("value must be positive")
 //	err = rule.Validate(3.14)  // returns nil
 //	err = rule.Validate(0.0)   // returns ErrPositive
 type PositiveRule[T Ordered] struct {
-	e error
+	frozen bool
+	e      error
 }
 
 // Positive creates a new positive number validation rule.
@@ -62,6 +62,11 @@ func (r *PositiveRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *PositiveRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrPositive)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -71,7 +76,36 @@ func (r *PositiveRule[T]) Validate(value T) error {
 //	err := rule.Validate(0)  // returns error with message "Value must be greater than zero"
 func (r *PositiveRule[T]) Errf(format string, args ...any) *PositiveRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *PositiveRule[T]) mutable() *PositiveRule[T] {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
+
+// Positivev returns the predeclared, immutable positive number rule for T. It returns the same
+// instance on every call, can be shared and used concurrently, and does not allocate.
+// Errf returns a copy instead of modifying it.
+//
+// Example:
+//
+//	for _, item := range items {
+//	    if err := arbiter.Validate(item.Quantity, rule.Positivev[int]()); err != nil {
+//	        return err
+//	    }
+//	}
+func Positivev[T Ordered]() *PositiveRule[T] {
+	return sharedRule(func() *PositiveRule[T] {
+		return &PositiveRule[T]{e: ErrPositive, frozen: true}
+	})
+}
@@ -42,3 +42,16 @@ func BenchmarkPositiveRule(b *testing.B) {
 		_ = Positive[int]().Validate(1)
 	}
 }
+
+func TestPositivev(t *testing.T) {
+	assert.Same(t, Positivev[int](), Positivev[int]())
+	assert.Nil(t, Positivev[int]().Validate(1))
+	assert.ErrorIs(t, Positivev[float64]().Validate(0), ErrPositive)
+
+	custom := Positivev[int]().Errf("must be positive")
+	assert.NotSame(t, Positivev[int](), custom)
+	assert.EqualError(t, custom.Validate(0), "must be positive")
+	assert.ErrorIs(t, Positivev[int]().Validate(0), ErrPositive)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = Positivev[int]().Validate(1) }))
+}
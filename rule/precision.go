@@ -3,8 +3,6 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -12,7 +10,11 @@ import (
 // Error variable for precision validation
 var (
 	// ErrPrecision is returned when a number's decimal places exceed the specified precision
-	ErrPrecision = errors.New("number precision exceeds the specified limit")
+	ErrPrecision = newError("precision", "number precision exceeds the specified limit")
+
+	// ErrDecimal is returned by the rules of package decimalrule when a decimal value cannot
+	// be parsed as a number.
+	ErrDecimal = newError("decimal", "value is not a decimal number")
 )
 
 // PrecisionRule validates that a float64 number's decimal places do not exceed
@@ -103,6 +105,11 @@ func (r *PrecisionRule) Validate(value float64) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *PrecisionRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPrecision)
+}
+
 // Errf sets a custom error message for precision validation failures.
 // This allows for context-specific error messages.
 //
@@ -115,11 +122,20 @@ func (r *PrecisionRule) Validate(value float64) error {
 //	err = rule.Validate(3.14)  // returns error with custom message
 func (r *PrecisionRule) Errf(format string, args ...any) *PrecisionRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Precision(2).Params()  // map[string]any{"precision": 2}
+func (r *PrecisionRule) Params() map[string]any {
+	return map[string]any{"precision": r.precision}
+}
+
 // Float32PrecisionRule validates that a float32 number's decimal places do not exceed
 // a specified precision. This rule ensures that 32-bit floating-point numbers maintain
 // a consistent level of precision.
@@ -208,6 +224,11 @@ func (r *Float32PrecisionRule) Validate(value float32) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *Float32PrecisionRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPrecision)
+}
+
 // Errf sets a custom error message for precision validation failures.
 // This allows for context-specific error messages.
 //
@@ -220,7 +241,16 @@ func (r *Float32PrecisionRule) Validate(value float32) error {
 //	err = rule.Validate(3.14)  // returns error with custom message
 func (r *Float32PrecisionRule) Errf(format string, args ...any) *Float32PrecisionRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := Float32Precision(2).Params()  // map[string]any{"precision": 2}
+func (r *Float32PrecisionRule) Params() map[string]any {
+	return map[string]any{"precision": r.precision}
+}
@@ -3,15 +3,13 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"math"
 )
 
 // Error variable for prime validation
 var (
 	// ErrPrime is returned when a value must be prime but is not
-	ErrPrime = errors.New("value is not a prime number")
+	ErrPrime = newError("prime", "value is not a prime number")
 )
 
 // PrimeRule validates that a number is prime.
@@ -114,6 +112,11 @@ func (r *PrimeRule) Validate(value int) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *PrimeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPrime)
+}
+
 // Errf sets a custom error message for prime validation failures.
 // This allows for context-specific error messages.
 //
@@ -126,7 +129,7 @@ func (r *PrimeRule) Validate(value int) error {
 //	err = rule.Validate(6)  // returns error with custom message
 func (r *PrimeRule) Errf(format string, args ...any) *PrimeRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -0,0 +1,7 @@
+//go:build race
+
+package rule
+
+// raceEnabled reports whether the tests run with the race detector, which makes
+// the regexp package allocate.
+const raceEnabled = true
@@ -0,0 +1,695 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating iCalendar (RFC 5545) recurrence rules.
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence validation errors
+var (
+	// ErrRecurrence is returned when a string is not a valid RFC 5545 RRULE.
+	ErrRecurrence = newError("recurrence", "invalid recurrence rule")
+
+	// ErrRecurrenceMismatch is returned when a time is not an occurrence of the recurrence rule.
+	ErrRecurrenceMismatch = newError("recurrence_mismatch", "time does not match the recurrence rule")
+
+	// ErrRecurrenceLimit is returned when checking whether a time is an occurrence would
+	// take too long, such as for a SECONDLY rule with COUNT and a time years after the start.
+	ErrRecurrenceLimit = newError("recurrence_limit", "recurrence rule is too expensive to evaluate")
+)
+
+// maxRecurrenceSteps bounds the number of days examined when checking an occurrence, so
+// that a large COUNT or a distant time cannot make a validation arbitrarily slow.
+const maxRecurrenceSteps = 100000
+
+// errRecurrenceLimit is returned by includes when maxRecurrenceSteps is exceeded.
+var errRecurrenceLimit = errors.New("recurrence rule exceeds the evaluation limit")
+
+// recurrenceFreq is the FREQ of a recurrence rule, ordered from the finest to the coarsest.
+type recurrenceFreq int
+
+const (
+	freqSecondly recurrenceFreq = iota
+	freqMinutely
+	freqHourly
+	freqDaily
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var recurrenceFreqs = map[string]recurrenceFreq{
+	"SECONDLY": freqSecondly,
+	"MINUTELY": freqMinutely,
+	"HOURLY":   freqHourly,
+	"DAILY":    freqDaily,
+	"WEEKLY":   freqWeekly,
+	"MONTHLY":  freqMonthly,
+	"YEARLY":   freqYearly,
+}
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// byDay is a BYDAY entry such as "MO", "+1MO" or "-1FR"; n is 0 when no ordinal is given.
+type byDay struct {
+	n       int
+	weekday time.Weekday
+}
+
+// rrule is a parsed RFC 5545 recurrence rule.
+type rrule struct {
+	freq       recurrenceFreq
+	interval   int
+	count      int
+	until      time.Time
+	hasUntil   bool
+	bySecond   []int
+	byMinute   []int
+	byHour     []int
+	byDay      []byDay
+	byMonthDay []int
+	byYearDay  []int
+	byWeekNo   []int
+	byMonth    []int
+	bySetPos   []int
+	wkst       time.Weekday
+}
+
+// parseRRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// The "RRULE:" property prefix is optional.
+//
+//nolint:gocyclo,gocognit // one case per RRULE part is inherently complex
+func parseRRule(value string) (*rrule, error) {
+	value = strings.TrimPrefix(value, "RRULE:")
+	r := &rrule{interval: 1, wkst: time.Monday, freq: -1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || name == "" || val == "" {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			f, ok := recurrenceFreqs[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("invalid FREQ %q", val)
+			}
+			r.freq = f
+		case "INTERVAL":
+			r.interval, err = parsePositive(val)
+		case "COUNT":
+			r.count, err = parsePositive(val)
+		case "UNTIL":
+			r.until, err = parseRRuleTime(val)
+			r.hasUntil = true
+		case "BYSECOND":
+			r.bySecond, err = parseIntList(val, 0, 60, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseIntList(val, 0, 59, false)
+		case "BYHOUR":
+			r.byHour, err = parseIntList(val, 0, 23, false)
+		case "BYDAY":
+			r.byDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseIntList(val, 1, 31, true)
+		case "BYYEARDAY":
+			r.byYearDay, err = parseIntList(val, 1, 366, true)
+		case "BYWEEKNO":
+			r.byWeekNo, err = parseIntList(val, 1, 53, true)
+		case "BYMONTH":
+			r.byMonth, err = parseIntList(val, 1, 12, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseIntList(val, 1, 366, true)
+		case "WKST":
+			wd, ok := recurrenceWeekdays[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %q", val)
+			}
+			r.wkst = wd
+		default:
+			if !strings.HasPrefix(name, "X-") {
+				return nil, fmt.Errorf("unknown part %s", name)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	// Clock values are located by binary search, see recurrenceSet.
+	for _, list := range []*[]int{&r.bySecond, &r.byMinute, &r.byHour} {
+		slices.Sort(*list)
+		*list = slices.Compact(*list)
+	}
+	return r, r.check()
+}
+
+// check verifies the combinations of parts allowed by RFC 5545 section 3.3.10.
+func (r *rrule) check() error {
+	switch {
+	case r.freq < 0:
+		return errors.New("FREQ is required")
+	case r.count > 0 && r.hasUntil:
+		return errors.New("COUNT and UNTIL are mutually exclusive")
+	case len(r.byMonthDay) > 0 && r.freq == freqWeekly:
+		return errors.New("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	case len(r.byYearDay) > 0 && (r.freq == freqDaily || r.freq == freqWeekly || r.freq == freqMonthly):
+		return errors.New("BYYEARDAY is not allowed with FREQ=DAILY, WEEKLY or MONTHLY")
+	case len(r.byWeekNo) > 0 && r.freq != freqYearly:
+		return errors.New("BYWEEKNO is only allowed with FREQ=YEARLY")
+	case len(r.bySetPos) > 0 && !r.hasByRule():
+		return errors.New("BYSETPOS requires another BYxxx part")
+	}
+	for _, d := range r.byDay {
+		if d.n != 0 && (r.freq < freqMonthly || (r.freq == freqYearly && len(r.byWeekNo) > 0)) {
+			return errors.New("numeric BYDAY is only allowed with FREQ=MONTHLY or YEARLY without BYWEEKNO")
+		}
+	}
+	return nil
+}
+
+// hasByRule reports whether any BYxxx part other than BYSETPOS is present.
+func (r *rrule) hasByRule() bool {
+	return len(r.bySecond)+len(r.byMinute)+len(r.byHour)+len(r.byDay)+len(r.byMonthDay)+
+		len(r.byYearDay)+len(r.byWeekNo)+len(r.byMonth) > 0
+}
+
+// parsePositive parses a positive integer.
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return n, nil
+}
+
+// parseIntList parses a comma separated list of integers whose absolute values are in [min, max].
+// Negative values are only accepted when signed is true.
+func parseIntList(value string, min, max int, signed bool) ([]int, error) {
+	parts := strings.Split(value, ",")
+	list := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", part)
+		}
+		abs := n
+		if n < 0 {
+			if !signed {
+				return nil, fmt.Errorf("%d must not be negative", n)
+			}
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+// parseByDay parses a BYDAY list such as "MO,-1FR,+2TU".
+func parseByDay(value string) ([]byDay, error) {
+	parts := strings.Split(value, ",")
+	list := make([]byDay, 0, len(parts))
+	for _, part := range parts {
+		if len(part) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", part)
+		}
+		wd, ok := recurrenceWeekdays[strings.ToUpper(part[len(part)-2:])]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", part)
+		}
+		d := byDay{weekday: wd}
+		if ord := part[:len(part)-2]; ord != "" {
+			n, err := strconv.Atoi(ord)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid ordinal %q", part)
+			}
+			d.n = n
+		}
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// parseRRuleTime parses an UNTIL value in DATE or DATE-TIME form.
+// A DATE value includes the whole day.
+func parseRRuleTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a DATE or DATE-TIME", value)
+}
+
+// RecurrenceRule validates that a string is a well-formed iCalendar (RFC 5545) RRULE value
+// and optionally that a given time is one of its occurrences.
+//
+// Example:
+//
+//	rule := Recurrence()
+//	err := rule.Validate("FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=10")  // returns nil
+//	err = rule.Validate("FREQ=WEEKLY;COUNT=5;UNTIL=20240101")    // returns error (COUNT and UNTIL)
+//	err = rule.Validate("BYDAY=MO")                              // returns error (FREQ is required)
+type RecurrenceRule struct {
+	dtstart   time.Time
+	at        time.Time
+	match     bool
+	e         error
+	mismatchE error
+	limitE    error
+}
+
+// Recurrence creates a new recurrence rule validation rule.
+// Parts are validated according to RFC 5545 section 3.3.10, including value ranges
+// and the combinations allowed for each FREQ. The "RRULE:" prefix is optional and
+// extension parts starting with "X-" are accepted.
+//
+// Example:
+//
+//	type Meeting struct {
+//	    Start time.Time
+//	    RRule string
+//	}
+//
+//	rule := Recurrence().Errf("Invalid repeat setting")
+func Recurrence() *RecurrenceRule {
+	return &RecurrenceRule{
+		e:         ErrRecurrence,
+		mismatchE: ErrRecurrenceMismatch,
+		limitE:    ErrRecurrenceLimit,
+	}
+}
+
+// Includes additionally requires at to be an occurrence of the recurrence starting at dtstart.
+// Unspecified parts are taken from dtstart as described in RFC 5545 (e.g. FREQ=WEEKLY without
+// BYDAY repeats on the weekday of dtstart) and at is compared in the location of dtstart.
+// The UNTIL value is interpreted in UTC. With COUNT, the occurrences up to at are counted,
+// and ErrRecurrenceLimit is returned if that would examine more than 100000 days.
+//
+// Example:
+//
+//	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)  // Monday 09:00
+//	rule := Recurrence().Includes(dtstart, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+//	err := rule.Validate("FREQ=WEEKLY;INTERVAL=2")  // returns nil
+//	err = rule.Validate("FREQ=WEEKLY;INTERVAL=3")   // returns ErrRecurrenceMismatch
+func (r *RecurrenceRule) Includes(dtstart, at time.Time) *RecurrenceRule {
+	r.dtstart = dtstart
+	r.at = at
+	r.match = true
+	return r
+}
+
+// Validate checks if the string is a valid RRULE and, when Includes was used,
+// whether the configured time is one of its occurrences.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := Recurrence()
+//	err := rule.Validate("RRULE:FREQ=MONTHLY;BYDAY=-1FR")  // returns nil (last Friday of each month)
+//	err = rule.Validate("FREQ=DAILY;BYDAY=1MO")           // returns error (ordinal requires MONTHLY or YEARLY)
+func (r *RecurrenceRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	rr, err := parseRRule(value)
+	if err != nil {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrRecurrence
+	}
+	if !r.match {
+		return nil
+	}
+	ok, err := rr.includes(r.dtstart, r.at)
+	if err != nil {
+		if r.limitE != nil {
+			return r.limitE
+		}
+		return ErrRecurrenceLimit
+	}
+	if !ok {
+		if r.mismatchE != nil {
+			return r.mismatchE
+		}
+		return ErrRecurrenceMismatch
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *RecurrenceRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrRecurrence)
+}
+
+// Errf sets a custom error message for recurrence validation failures.
+// The message replaces the format, the occurrence mismatch and the limit error.
+//
+// Example:
+//
+//	rule := Recurrence().Errf("Please enter a valid repeat rule")
+func (r *RecurrenceRule) Errf(format string, args ...any) *RecurrenceRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+		r.mismatchE = wrapf(r.mismatchE, format, args...)
+		r.limitE = wrapf(r.limitE, format, args...)
+	}
+	return r
+}
+
+// includes reports whether at is an occurrence of the rule starting at dtstart. It returns
+// errRecurrenceLimit when deciding would examine more than maxRecurrenceSteps days.
+func (r *rrule) includes(dtstart, at time.Time) (bool, error) {
+	at = at.In(dtstart.Location())
+	if at.Before(dtstart) || (r.hasUntil && at.After(r.until)) || at.Nanosecond() != 0 {
+		return false, nil
+	}
+	startPeriod := r.period(dtstart)
+	period := r.period(at)
+	if (period-startPeriod)%r.interval != 0 {
+		return false, nil
+	}
+	// Instances within the second of dtstart but before it are not occurrences.
+	from := recurrenceWall(Ternary(dtstart.Nanosecond() > 0, dtstart.Truncate(time.Second).Add(time.Second), dtstart))
+	to := recurrenceWall(at)
+	steps := 0
+	set, err := r.periodSet(period, dtstart, &steps)
+	if err != nil || set.count(to, to) == 0 || set.count(from, to) == 0 {
+		return false, err
+	}
+	if r.count == 0 {
+		return true, nil
+	}
+	// Count every occurrence from dtstart up to and including at.
+	n := 0
+	for p := startPeriod; p <= period; p += r.interval {
+		set, err := r.periodSet(p, dtstart, &steps)
+		if err != nil {
+			return false, err
+		}
+		if n += set.count(from, to); n > r.count {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// daysSinceEpoch returns the number of civil days between 1970-01-01 and the date of t.
+func daysSinceEpoch(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// period returns the index of the FREQ period containing t.
+func (r *rrule) period(t time.Time) int {
+	days := daysSinceEpoch(t)
+	switch r.freq {
+	case freqYearly:
+		return t.Year()
+	case freqMonthly:
+		return t.Year()*12 + int(t.Month()) - 1
+	case freqWeekly:
+		offset := (int(t.Weekday()) - int(r.wkst) + 7) % 7
+		return floorDiv(days-offset+3, 7) // 1970-01-01 is a Thursday
+	case freqDaily:
+		return days
+	case freqHourly:
+		return days*24 + t.Hour()
+	case freqMinutely:
+		return (days*24+t.Hour())*60 + t.Minute()
+	default:
+		return ((days*24+t.Hour())*60+t.Minute())*60 + t.Second()
+	}
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// periodDays returns the first day of period p and the number of days it spans.
+func (r *rrule) periodDays(p int, loc *time.Location) (time.Time, int) {
+	switch r.freq {
+	case freqYearly:
+		start := time.Date(p, time.January, 1, 0, 0, 0, 0, loc)
+		return start, daysIn(start, 1, 0)
+	case freqMonthly:
+		start := time.Date(floorDiv(p, 12), time.Month(p-floorDiv(p, 12)*12+1), 1, 0, 0, 0, 0, loc)
+		return start, daysIn(start, 0, 1)
+	case freqWeekly:
+		// Period p starts on the WKST day within [7p-3, 7p+3], 7p-3 being a Monday.
+		offset := (int(r.wkst) - int(time.Monday) + 7) % 7
+		return time.Date(1970, time.January, 1, 0, 0, 0, 0, loc).AddDate(0, 0, p*7-3+offset), 7
+	case freqDaily:
+		return time.Date(1970, time.January, 1, 0, 0, 0, 0, loc).AddDate(0, 0, p), 1
+	default:
+		// Sub-daily periods lie within a single day.
+		perDay := Ternary(r.freq == freqHourly, 24, Ternary(r.freq == freqMinutely, 1440, 86400))
+		return time.Date(1970, time.January, 1, 0, 0, 0, 0, loc).AddDate(0, 0, floorDiv(p, perDay)), 1
+	}
+}
+
+// daysIn returns the number of days between start and start shifted by years and months.
+func daysIn(start time.Time, years, months int) int {
+	return daysSinceEpoch(start.AddDate(years, months, 0)) - daysSinceEpoch(start)
+}
+
+// recurrenceSet holds the occurrences of one period: every combination of its matching
+// days and clock values in chronological order, of which BYSETPOS may pick some. The
+// instances are never expanded; they are located by their index in that order, so that
+// long BYxxx lists cost no more than their length.
+type recurrenceSet struct {
+	days    []int // days since the epoch
+	hours   []int
+	minutes []int
+	seconds []int
+	setPos  bool  // whether BYSETPOS applies
+	picked  []int // sorted indices of the instances picked by BYSETPOS
+}
+
+// recurrenceWall returns the wall clock time of t as days since the epoch, hour, minute
+// and second.
+func recurrenceWall(t time.Time) [4]int {
+	return [4]int{daysSinceEpoch(t), t.Hour(), t.Minute(), t.Second()}
+}
+
+// periodSet returns the occurrences of period p, adding the days it examines to steps.
+func (r *rrule) periodSet(p int, dtstart time.Time, steps *int) (*recurrenceSet, error) {
+	first, n := r.periodDays(p, dtstart.Location())
+	if *steps += n; *steps > maxRecurrenceSteps {
+		return nil, errRecurrenceLimit
+	}
+	set := &recurrenceSet{}
+	for i := 0; i < n; i++ {
+		if day := first.AddDate(0, 0, i); r.matchDay(day, dtstart) {
+			set.days = append(set.days, daysSinceEpoch(day))
+		}
+	}
+
+	// For sub-daily frequencies the period fixes the coarser clock parts.
+	var second int
+	switch r.freq {
+	case freqHourly:
+		second = (p - floorDiv(p, 24)*24) * 3600
+	case freqMinutely:
+		second = (p - floorDiv(p, 1440)*1440) * 60
+	case freqSecondly:
+		second = p - floorDiv(p, 86400)*86400
+	}
+	set.hours = clockPart(r.byHour, r.freq <= freqHourly, second/3600, dtstart.Hour())
+	set.minutes = clockPart(r.byMinute, r.freq <= freqMinutely, second/60%60, dtstart.Minute())
+	set.seconds = clockPart(r.bySecond, r.freq == freqSecondly, second%60, dtstart.Second())
+
+	if len(r.bySetPos) > 0 {
+		set.setPos = true
+		size := len(set.days) * len(set.hours) * len(set.minutes) * len(set.seconds)
+		for _, pos := range r.bySetPos {
+			if idx := Ternary(pos > 0, pos-1, size+pos); idx >= 0 && idx < size {
+				set.picked = append(set.picked, idx)
+			}
+		}
+		slices.Sort(set.picked)
+		set.picked = slices.Compact(set.picked)
+	}
+	return set, nil
+}
+
+// before returns the number of instances of the set earlier than the wall clock time w.
+func (s *recurrenceSet) before(w [4]int) int {
+	n := 0
+	size := len(s.days) * len(s.hours) * len(s.minutes) * len(s.seconds)
+	for i, list := range [][]int{s.days, s.hours, s.minutes, s.seconds} {
+		size /= max(len(list), 1)
+		idx, found := slices.BinarySearch(list, w[i])
+		if n += idx * size; !found {
+			break
+		}
+	}
+	return n
+}
+
+// count returns the number of occurrences in the set between the wall clock times from
+// and to, both included.
+func (s *recurrenceSet) count(from, to [4]int) int {
+	lo, hi := s.before(from), s.before(to)
+	if slices.Contains(s.days, to[0]) && slices.Contains(s.hours, to[1]) &&
+		slices.Contains(s.minutes, to[2]) && slices.Contains(s.seconds, to[3]) {
+		hi++
+	}
+	if !s.setPos {
+		return max(hi-lo, 0)
+	}
+	start, _ := slices.BinarySearch(s.picked, lo)
+	end, _ := slices.BinarySearch(s.picked, hi)
+	return max(end-start, 0)
+}
+
+// clockPart returns the sorted values of one clock part. When fixed is true the period
+// determines the value and the BYxxx list only limits it; otherwise the list expands the
+// period and defaults to the value of dtstart.
+func clockPart(list []int, fixed bool, value, start int) []int {
+	switch {
+	case fixed && (len(list) == 0 || slices.Contains(list, value)):
+		return []int{value}
+	case fixed:
+		return nil
+	case len(list) == 0:
+		return []int{start}
+	default:
+		return list
+	}
+}
+
+// matchDay reports whether day passes the day-level BYxxx parts, inheriting the
+// month, day of month or weekday from dtstart where RFC 5545 requires it.
+func (r *rrule) matchDay(day, dtstart time.Time) bool {
+	if len(r.byMonth) > 0 && !slices.Contains(r.byMonth, int(day.Month())) {
+		return false
+	}
+	if len(r.byWeekNo) > 0 && !matchWeekNo(r.byWeekNo, day, r.wkst) {
+		return false
+	}
+	if len(r.byYearDay) > 0 && !matchSigned(r.byYearDay, day.YearDay(), daysIn(time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC), 1, 0)) {
+		return false
+	}
+	if len(r.byMonthDay) > 0 && !matchSigned(r.byMonthDay, day.Day(), daysIn(time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC), 0, 1)) {
+		return false
+	}
+	if len(r.byDay) > 0 && !r.matchByDay(day) {
+		return false
+	}
+	return r.matchInherited(day, dtstart)
+}
+
+// matchInherited applies the implicit limits taken from dtstart when no BYxxx part selects days.
+func (r *rrule) matchInherited(day, dtstart time.Time) bool {
+	noDayParts := len(r.byDay) == 0 && len(r.byMonthDay) == 0 && len(r.byYearDay) == 0
+	switch r.freq {
+	case freqWeekly:
+		return len(r.byDay) > 0 || day.Weekday() == dtstart.Weekday()
+	case freqMonthly:
+		return !noDayParts || day.Day() == dtstart.Day()
+	case freqYearly:
+		if !noDayParts {
+			return true
+		}
+		if len(r.byWeekNo) > 0 {
+			return day.Weekday() == dtstart.Weekday()
+		}
+		if len(r.byMonth) == 0 && day.Month() != dtstart.Month() {
+			return false
+		}
+		return day.Day() == dtstart.Day()
+	default:
+		return true
+	}
+}
+
+// matchByDay reports whether day matches one of the BYDAY entries. Ordinals are relative
+// to the month for FREQ=MONTHLY (or YEARLY with BYMONTH) and to the year otherwise.
+func (r *rrule) matchByDay(day time.Time) bool {
+	inMonth := r.freq == freqMonthly || (r.freq == freqYearly && len(r.byMonth) > 0)
+	for _, d := range r.byDay {
+		if d.weekday != day.Weekday() {
+			continue
+		}
+		if d.n == 0 {
+			return true
+		}
+		pos, total := day.YearDay(), daysIn(time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC), 1, 0)
+		if inMonth {
+			pos, total = day.Day(), daysIn(time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC), 0, 1)
+		}
+		if (d.n > 0 && (pos-1)/7+1 == d.n) || (d.n < 0 && (total-pos)/7+1 == -d.n) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSigned reports whether pos (1-based, out of total) matches one of the signed positions.
+func matchSigned(list []int, pos, total int) bool {
+	for _, n := range list {
+		if n == pos || (n < 0 && total+n+1 == pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekNo reports whether day falls in one of the week numbers. As RFC 5545 defines
+// them, weeks start on wkst and week 1 is the first week with at least four days in the
+// year, so days at the start or end of a year may belong to the last week of the previous
+// year or to week 1 of the next one, and match those week numbers.
+func matchWeekNo(list []int, day time.Time, wkst time.Weekday) bool {
+	days := daysSinceEpoch(day)
+	year := day.Year()
+	if days < firstWeekStart(year, wkst) {
+		year--
+	} else if days >= firstWeekStart(year+1, wkst) {
+		year++
+	}
+	start := firstWeekStart(year, wkst)
+	weeks := (firstWeekStart(year+1, wkst) - start) / 7
+	return matchSigned(list, (days-start)/7+1, weeks)
+}
+
+// firstWeekStart returns the first day of week 1 of year, in days since the epoch: the
+// wkst day on or before January 1st if that week has at least four days in the year,
+// or the one after it otherwise.
+func firstWeekStart(year int, wkst time.Weekday) int {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan1.Weekday()) - int(wkst) + 7) % 7
+	return daysSinceEpoch(jan1) - offset + Ternary(offset > 3, 7, 0)
+}
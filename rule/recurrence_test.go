@@ -0,0 +1,173 @@
+package rule
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *RecurrenceRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: weekly by day", rule: Recurrence(), value: "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=10", wantErr: false},
+		{name: "valid: property prefix", rule: Recurrence(), value: "RRULE:FREQ=MONTHLY;BYDAY=-1FR", wantErr: false},
+		{name: "valid: until date-time", rule: Recurrence(), value: "FREQ=DAILY;UNTIL=20241231T235959Z;INTERVAL=2", wantErr: false},
+		{name: "valid: yearly by week number", rule: Recurrence(), value: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", wantErr: false},
+		{name: "valid: set position", rule: Recurrence(), value: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", wantErr: false},
+		{name: "valid: extension part", rule: Recurrence(), value: "FREQ=DAILY;X-NAME=value", wantErr: false},
+		{name: "valid: empty string", rule: Recurrence(), value: "", wantErr: false},
+		{name: "invalid: missing FREQ", rule: Recurrence(), value: "BYDAY=MO", wantErr: true},
+		{name: "invalid: unknown FREQ", rule: Recurrence(), value: "FREQ=FORTNIGHTLY", wantErr: true},
+		{name: "invalid: COUNT and UNTIL", rule: Recurrence(), value: "FREQ=WEEKLY;COUNT=5;UNTIL=20240101", wantErr: true},
+		{name: "invalid: duplicate part", rule: Recurrence(), value: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "invalid: zero interval", rule: Recurrence(), value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "invalid: hour out of range", rule: Recurrence(), value: "FREQ=DAILY;BYHOUR=24", wantErr: true},
+		{name: "invalid: ordinal weekday with daily", rule: Recurrence(), value: "FREQ=DAILY;BYDAY=1MO", wantErr: true},
+		{name: "invalid: month day with weekly", rule: Recurrence(), value: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{name: "invalid: week number with monthly", rule: Recurrence(), value: "FREQ=MONTHLY;BYWEEKNO=1", wantErr: true},
+		{name: "invalid: set position alone", rule: Recurrence(), value: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+		{name: "invalid: malformed until", rule: Recurrence(), value: "FREQ=DAILY;UNTIL=2024-01-01", wantErr: true},
+		{name: "invalid: unknown part", rule: Recurrence(), value: "FREQ=DAILY;EVERY=2", wantErr: true},
+		{name: "custom error message", rule: Recurrence().Errf("custom error"), value: "FREQ=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecurrenceRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, Recurrence().Validate("FREQ=HOURLY;BYMONTHDAY=0"), ErrRecurrence)
+}
+
+func TestRecurrenceIncludes(t *testing.T) {
+	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC) // Monday
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		rrule   string
+		at      time.Time
+		wantErr bool
+	}{
+		{name: "valid: start is an occurrence", rrule: "FREQ=DAILY", at: dtstart, wantErr: false},
+		{name: "valid: every other week", rrule: "FREQ=WEEKLY;INTERVAL=2", at: at(time.January, 15, 9, 0), wantErr: false},
+		{name: "valid: weekly by day", rrule: "FREQ=WEEKLY;BYDAY=MO,WE", at: at(time.January, 10, 9, 0), wantErr: false},
+		{name: "valid: last Friday of month", rrule: "FREQ=MONTHLY;BYDAY=-1FR", at: at(time.March, 29, 9, 0), wantErr: false},
+		{name: "valid: last weekday via set position", rrule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", at: at(time.August, 30, 9, 0), wantErr: false},
+		{name: "valid: negative month day", rrule: "FREQ=MONTHLY;BYMONTHDAY=-1", at: at(time.February, 29, 9, 0), wantErr: false},
+		{name: "valid: yearly inherits month and day", rrule: "FREQ=YEARLY", at: time.Date(2027, 1, 1, 9, 0, 0, 0, time.UTC), wantErr: false},
+		{name: "valid: hourly with minute list", rrule: "FREQ=HOURLY;INTERVAL=3;BYMINUTE=0,30", at: at(time.January, 1, 12, 30), wantErr: false},
+		{name: "valid: within count", rrule: "FREQ=DAILY;COUNT=3", at: at(time.January, 3, 9, 0), wantErr: false},
+		{name: "valid: until date includes the day", rrule: "FREQ=DAILY;UNTIL=20240105", at: at(time.January, 5, 9, 0), wantErr: false},
+		{name: "invalid: before start", rrule: "FREQ=DAILY", at: dtstart.Add(-24 * time.Hour), wantErr: true},
+		{name: "invalid: wrong time of day", rrule: "FREQ=DAILY", at: at(time.January, 2, 10, 0), wantErr: true},
+		{name: "invalid: off-interval week", rrule: "FREQ=WEEKLY;INTERVAL=2", at: at(time.January, 8, 9, 0), wantErr: true},
+		{name: "invalid: wrong weekday", rrule: "FREQ=WEEKLY;BYDAY=MO,WE", at: at(time.January, 9, 9, 0), wantErr: true},
+		{name: "invalid: not the last Friday", rrule: "FREQ=MONTHLY;BYDAY=-1FR", at: at(time.March, 22, 9, 0), wantErr: true},
+		{name: "invalid: beyond count", rrule: "FREQ=DAILY;COUNT=3", at: at(time.January, 4, 9, 0), wantErr: true},
+		{name: "invalid: after until", rrule: "FREQ=DAILY;UNTIL=20240105", at: at(time.January, 6, 9, 0), wantErr: true},
+		{name: "invalid: hourly off interval", rrule: "FREQ=HOURLY;INTERVAL=3;BYMINUTE=0,30", at: at(time.January, 1, 13, 0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Recurrence().Includes(dtstart, tt.at).Validate(tt.rrule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecurrenceRule.Includes().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, Recurrence().Includes(dtstart, at(time.January, 2, 10, 0)).Validate("FREQ=DAILY"), ErrRecurrenceMismatch)
+	assert.EqualError(t, Recurrence().Includes(dtstart, at(time.January, 2, 10, 0)).Errf("custom error").Validate("FREQ=DAILY"), "custom error")
+}
+
+func TestRecurrenceWeekNo(t *testing.T) {
+	dtstart := time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC)
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		rrule   string
+		at      time.Time
+		wantErr bool
+	}{
+		{name: "valid: week 1 starting in december", rrule: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO", at: at(1997, time.December, 29), wantErr: false},
+		{name: "valid: week 1 starting in january", rrule: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO", at: at(1999, time.January, 4), wantErr: false},
+		{name: "invalid: last monday of 1998 is in week 53", rrule: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO", at: at(1998, time.December, 28), wantErr: true},
+		{name: "valid: week 52 ending in january", rrule: "FREQ=YEARLY;BYWEEKNO=52;BYDAY=SU", at: at(2000, time.January, 2), wantErr: false},
+		{name: "valid: last week ending in january", rrule: "FREQ=YEARLY;BYWEEKNO=-1;BYDAY=SU", at: at(1999, time.January, 3), wantErr: false},
+		{name: "valid: week 53", rrule: "FREQ=YEARLY;BYWEEKNO=53;BYDAY=MO", at: at(2004, time.December, 27), wantErr: false},
+		{name: "invalid: no week 53", rrule: "FREQ=YEARLY;BYWEEKNO=53;BYDAY=MO", at: at(2005, time.December, 26), wantErr: true},
+		{name: "valid: weeks starting on sunday", rrule: "FREQ=YEARLY;BYWEEKNO=2;BYDAY=SU;WKST=SU", at: at(2024, time.January, 7), wantErr: false},
+		{name: "invalid: weeks starting on sunday", rrule: "FREQ=YEARLY;BYWEEKNO=2;BYDAY=SU;WKST=SU", at: at(2024, time.January, 14), wantErr: true},
+		{name: "valid: weeks starting on monday", rrule: "FREQ=YEARLY;BYWEEKNO=2;BYDAY=SU", at: at(2024, time.January, 14), wantErr: false},
+		{name: "invalid: weeks starting on monday", rrule: "FREQ=YEARLY;BYWEEKNO=2;BYDAY=SU", at: at(2024, time.January, 7), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Recurrence().Includes(dtstart, tt.at).Validate(tt.rrule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecurrenceRule.Includes().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Weeks starting on Monday are the ISO 8601 weeks.
+	for day := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() < 2031; day = day.AddDate(0, 0, 1) {
+		_, week := day.ISOWeek()
+		if !matchWeekNo([]int{week}, day, time.Monday) {
+			t.Fatalf("matchWeekNo(%d, %s) = false, want true", week, day.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestRecurrenceIncludesLimits(t *testing.T) {
+	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	list := func(n int) string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = strconv.Itoa(i)
+		}
+		return strings.Join(values, ",")
+	}
+	full := "FREQ=YEARLY;BYMONTH=1,2,3,4,5,6,7,8,9,10,11,12;BYHOUR=" + list(24) + ";BYMINUTE=" + list(60) + ";BYSECOND=" + list(60)
+
+	// Every second of the first day of each month is an occurrence, but none is expanded.
+	at := time.Date(2024, 12, 1, 23, 59, 59, 0, time.UTC)
+	assert.Nil(t, Recurrence().Includes(dtstart, at).Validate(full))
+	assert.Nil(t, Recurrence().Includes(dtstart, at).Validate(full+";BYSETPOS=-1"))
+	assert.ErrorIs(t, Recurrence().Includes(dtstart, at.Add(-time.Second)).Validate(full+";BYSETPOS=-1"), ErrRecurrenceMismatch)
+	assert.Nil(t, Recurrence().Includes(dtstart, dtstart.Add(time.Hour)).Validate(full+";COUNT=3601"))
+	assert.ErrorIs(t, Recurrence().Includes(dtstart, dtstart.Add(time.Hour)).Validate(full+";COUNT=3600"), ErrRecurrenceMismatch)
+
+	// Counting occurrences over too many days is reported instead of assumed valid.
+	far := dtstart.AddDate(300, 0, 0)
+	assert.ErrorIs(t, Recurrence().Includes(dtstart, far).Validate("FREQ=DAILY;COUNT=1000000"), ErrRecurrenceLimit)
+	assert.ErrorIs(t, Recurrence().Includes(dtstart, far).Validate("FREQ=SECONDLY;COUNT=1000000000"), ErrRecurrenceLimit)
+	assert.Nil(t, Recurrence().Includes(dtstart, far).Validate("FREQ=DAILY"))
+	assert.EqualError(t, Recurrence().Includes(dtstart, far).Errf("custom error").Validate("FREQ=DAILY;COUNT=5"), "custom error")
+}
+
+func TestRecurrenceFallback(t *testing.T) {
+	assert.ErrorIs(t, (&RecurrenceRule{}).Validate("FREQ"), ErrRecurrence)
+	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := &RecurrenceRule{dtstart: dtstart, at: dtstart.Add(time.Hour), match: true}
+	assert.ErrorIs(t, rule.Validate("FREQ=DAILY"), ErrRecurrenceMismatch)
+}
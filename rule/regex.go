@@ -4,24 +4,35 @@ package rule
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"reflect"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 )
 
 var (
 	// ErrRegex is returned when a string does not match the specified regular expression.
-	ErrRegex        = errors.New("does not match the regular expression")
-	ErrIDCard       = errors.New("invalid ID card number format")
-	ErrPassport     = errors.New("invalid passport number format")
-	ErrBankCard     = errors.New("invalid bank card number format")
-	ErrTaxNumber    = errors.New("invalid tax number format")
-	ErrSocialCredit = errors.New("invalid social credit code format")
-	ErrPhone        = errors.New("invalid phone number format")
-	ErrEmail        = errors.New("invalid email format")
+	ErrRegex        = newError("regex", "does not match the regular expression")
+	ErrIDCard       = newError("id_card", "invalid ID card number format")
+	ErrPassport     = newError("passport", "invalid passport number format")
+	ErrBankCard     = newError("bank_card", "invalid bank card number format")
+	ErrTaxNumber    = newError("tax_number", "invalid tax number format")
+	ErrSocialCredit = newError("social_credit", "invalid social credit code format")
+	ErrPhone        = newError("phone", "invalid phone number format")
+	ErrEmail        = newError("email", "invalid email format")
+
+	// Emailv and Phonev are predeclared, immutable rules equivalent to IsEmail() and
+	// IsPhone() that can be shared and used concurrently. Errf returns a copy instead of
+	// modifying them.
+	Emailv = &RegexRule{regex: regexEmail, e: ErrEmail, frozen: true}
+	Phonev = &RegexRule{regex: regexPhone, e: ErrPhone, frozen: true}
 
 	// compiledRegexes is a map of compiled regular expressions.
 	// It caches compiled regexes to avoid re-compiling the same pattern multiple times.
-	compiledRegexes = make(map[string]*regexp.Regexp)
+	// Writes take regexMutex's write lock; see getCompiledRegex.
+	compiledRegexes = make(map[string]cachedRegex)
 	regexMutex      sync.RWMutex
 
 	// Pre-compiled regexes for commonly used patterns.
@@ -44,34 +55,90 @@ const (
 	idCardPattern       = `^[1-9]\d{5}(19|20)\d{2}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])\d{3}[\dXx]$`
 )
 
+// maxCachedRegexes caps the number of cached patterns, so that rules built from
+// user-controlled patterns cannot grow the cache without bound.
+const maxCachedRegexes = 1024
+
+// cachedRegex is a compiled regular expression in the cache. Pinned entries were added
+// by PrecompilePatterns and are never evicted.
+type cachedRegex struct {
+	re     *regexp.Regexp
+	pinned bool
+}
+
 // getCompiledRegex returns a compiled regular expression for the given pattern.
-// It caches compiled regexes using double-checked locking to avoid re-compiling.
+// It caches compiled regexes using double-checked locking to avoid re-compiling:
+// lookups share a read lock and only inserting a new pattern takes the write lock.
+// When the cache is full, an arbitrary unpinned entry is evicted.
 //
 // Example:
 //
 //	re, err := getCompiledRegex("^[A-Z][a-z]+$")
 func getCompiledRegex(pattern string) (*regexp.Regexp, error) {
+	return cacheRegex(pattern, false)
+}
+
+// cacheRegex returns the cached regex for pattern, compiling and caching it if needed.
+// If pin is true, the entry is never evicted.
+func cacheRegex(pattern string, pin bool) (*regexp.Regexp, error) {
 	regexMutex.RLock()
-	re, ok := compiledRegexes[pattern]
+	cached, ok := compiledRegexes[pattern]
 	regexMutex.RUnlock()
-	if ok {
-		return re, nil
+	if ok && (cached.pinned || !pin) {
+		return cached.re, nil
+	}
+
+	// Compile outside the lock; a concurrent caller may compile the same pattern,
+	// in which case the first result stored wins.
+	re := cached.re
+	if re == nil {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return nil, err
+		}
 	}
 
 	regexMutex.Lock()
 	defer regexMutex.Unlock()
 
-	if re, ok := compiledRegexes[pattern]; ok {
-		return re, nil
+	if cached, ok := compiledRegexes[pattern]; ok {
+		compiledRegexes[pattern] = cachedRegex{re: cached.re, pinned: cached.pinned || pin}
+		return cached.re, nil
 	}
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, err
+	if len(compiledRegexes) >= maxCachedRegexes {
+		for p, c := range compiledRegexes {
+			if !c.pinned {
+				delete(compiledRegexes, p)
+				break
+			}
+		}
 	}
-	compiledRegexes[pattern] = re
+	compiledRegexes[pattern] = cachedRegex{re: re, pinned: pin}
 	return re, nil
 }
 
+// PrecompilePatterns compiles and caches regular expressions at startup, so that later
+// calls such as Regex(pattern) neither compile the pattern nor take a write lock.
+// Precompiled patterns are never evicted from the cache. It returns an error for every
+// invalid pattern; valid patterns are cached regardless.
+//
+// Example:
+//
+//	func init() {
+//	    if err := rule.PrecompilePatterns(`^[a-z0-9_]{3,16}$`, `^SKU-\d{6}$`); err != nil {
+//	        panic(err)
+//	    }
+//	}
+func PrecompilePatterns(patterns ...string) error {
+	var errs []error
+	for _, pattern := range patterns {
+		if _, err := cacheRegex(pattern, true); err != nil {
+			errs = append(errs, fmt.Errorf("invalid regular expression %q: %w", pattern, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // RegexRule is a validation rule that checks if a string matches a regular expression pattern.
 // It can be used for custom pattern matching or with predefined patterns like email and phone.
 //
@@ -82,7 +149,15 @@ func getCompiledRegex(pattern string) (*regexp.Regexp, error) {
 //	err = rule.Validate("hello")  // returns ErrRegex
 type RegexRule struct {
 	regex *regexp.Regexp
-	e     error
+	// verify is an optional check beyond the pattern (e.g. a checksum), enabled by Strict.
+	verify func(string) bool
+	strict bool
+	// rfc5322 parses email addresses with net/mail instead of matching the pattern.
+	rfc5322 bool
+	// capture stores the named groups of a valid value, set by Capture.
+	capture func(match []string)
+	frozen  bool
+	e       error
 }
 
 // IsEmail returns a new RegexRule that validates email addresses.
@@ -111,17 +186,24 @@ func IsPhone() *RegexRule {
 
 // IsSocialCredit returns a new RegexRule that validates social credit codes.
 // The rule checks for the standard format of social credit codes.
+// Call Strict() to additionally verify the GB 32100-2015 check character and the
+// registration authority and organization type prefix.
 //
 // Example:
 //
 //	rule := IsSocialCredit()
 //	err := rule.Validate("123456789012345678")  // returns nil
+//
+//	rule = IsSocialCredit().Strict()
+//	err = rule.Validate("91350100M000100Y43")  // returns nil
+//	err = rule.Validate("91350100M000100Y44")  // returns error (wrong check character)
 func IsSocialCredit() *RegexRule {
-	return &RegexRule{regex: regexSocialCredit, e: ErrSocialCredit}
+	return &RegexRule{regex: regexSocialCredit, verify: verifySocialCredit, e: ErrSocialCredit}
 }
 
 // IsTaxNumber returns a new RegexRule that validates tax numbers.
 // The rule checks for the standard format of tax numbers.
+// Use TaxID to validate the tax identification numbers of a specific country.
 //
 // Example:
 //
@@ -156,13 +238,18 @@ func IsPassport() *RegexRule {
 
 // IsIDCard returns a new RegexRule that validates ID card numbers.
 // The rule checks for the standard format of ID card numbers.
+// Call Strict() to additionally verify the GB 11643 check digit and birth date.
 //
 // Example:
 //
 //	rule := IsIDCard()
 //	err := rule.Validate("123456789012345678")  // returns nil
+//
+//	rule = IsIDCard().Strict()
+//	err = rule.Validate("11010519491231002X")  // returns nil
+//	err = rule.Validate("110105194912310021")  // returns error (wrong check digit)
 func IsIDCard() *RegexRule {
-	return &RegexRule{regex: regexIDCard, e: ErrIDCard}
+	return &RegexRule{regex: regexIDCard, verify: verifyIDCard, e: ErrIDCard}
 }
 
 // Regex creates a new RegexRule with a custom regular expression pattern.
@@ -189,6 +276,115 @@ func Regex(pattern string) *RegexRule {
 	}
 }
 
+// Strict enables the additional verification supported by the rule, such as the
+// check digit of IsIDCard or IsSocialCredit. It has no effect on rules created with Regex.
+//
+// Example:
+//
+//	rule := IsIDCard().Strict()
+//	err := rule.Validate("11010519491231002X")  // returns nil
+//	err = rule.Validate("110105194912310021")  // returns error (wrong check digit)
+func (r *RegexRule) Strict() *RegexRule {
+	r = r.mutable()
+	r.strict = true
+	return r
+}
+
+// RFC5322 makes IsEmail parse addresses with net/mail according to RFC 5322 instead of
+// matching the fast default pattern, so that valid addresses such as quoted local parts
+// ("john doe"@example.com) and domains without a dot are accepted, while addresses that
+// the pattern accepts but are invalid, such as a local part with consecutive dots, are
+// rejected. Display names, angle brackets and comments are rejected. It has no effect
+// on other rules.
+//
+// Example:
+//
+//	rule := IsEmail().RFC5322()
+//	err := rule.Validate(`"john doe"@example.com`)  // returns nil
+//	err = rule.Validate("john..doe@example.com")    // returns ErrEmail
+//	err = rule.Validate("John <john@example.com>")  // returns ErrEmail
+func (r *RegexRule) RFC5322() *RegexRule {
+	r = r.mutable()
+	r.rfc5322 = true
+	return r
+}
+
+// Capture stores the named groups of the pattern when a value is valid, so that the
+// components of a value are extracted by the rule that validates it. The target is either
+// a map[string]*string, whose entries receive the groups of the same name, or a pointer
+// to a struct, whose string fields receive the group named by their `regex` tag or, for
+// fields without a tag, the group whose name equals the field name case-insensitively.
+// Groups without a target are ignored, and targets are left unchanged when validation
+// fails or the value is empty.
+//
+// A rule with Capture writes to its target on every validation, so it must not be shared
+// between goroutines. If the target is of another type, the rule always returns an error.
+//
+// Example:
+//
+//	var order struct {
+//	    Prefix   string
+//	    Sequence string `regex:"seq"`
+//	}
+//	rule := Regex(`^(?P<prefix>[A-Z]{2})-(?P<seq>\d{6})$`).Capture(&order)
+//	err := rule.Validate("SO-000042")  // returns nil; order.Prefix is "SO", order.Sequence "000042"
+func (r *RegexRule) Capture(target any) *RegexRule {
+	r = r.mutable()
+	if r.regex == nil {
+		return r
+	}
+	capture, err := captureInto(r.regex, target)
+	if err != nil {
+		r.regex, r.e = nil, err
+		return r
+	}
+	r.capture = capture
+	return r
+}
+
+// captureInto returns a function that stores the named groups of a match of re in
+// target, a map[string]*string or a pointer to a struct.
+func captureInto(re *regexp.Regexp, target any) (func(match []string), error) {
+	names := re.SubexpNames()
+	if m, ok := target.(map[string]*string); ok {
+		return func(match []string) {
+			for i, name := range names {
+				if p := m[name]; name != "" && p != nil {
+					*p = match[i]
+				}
+			}
+		}, nil
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("capture target must be a map[string]*string or a pointer to a struct, got %T", target)
+	}
+	v = v.Elem()
+	fields := make(map[int]int)
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		for j := 0; j < v.NumField(); j++ {
+			field := v.Type().Field(j)
+			if !field.IsExported() || field.Type.Kind() != reflect.String {
+				continue
+			}
+			tag, tagged := field.Tag.Lookup("regex")
+			if tagged && tag == name || !tagged && strings.EqualFold(field.Name, name) {
+				fields[i] = j
+				break
+			}
+		}
+	}
+	return func(match []string) {
+		for i, j := range fields {
+			v.Field(j).SetString(match[i])
+		}
+	}, nil
+}
+
 // Validate checks if the string matches the regular expression pattern.
 // Returns nil if the string matches, or an error if it doesn't.
 // Empty strings are considered valid (use Required() if needed).
@@ -200,7 +396,7 @@ func Regex(pattern string) *RegexRule {
 //	    // Handle validation error
 //	}
 func (r *RegexRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	if r.regex == nil {
@@ -209,15 +405,56 @@ func (r *RegexRule) Validate(value string) error {
 		}
 		return fmt.Errorf("regex is nil")
 	}
-	if !r.regex.MatchString(value) {
+	if !r.matches(value) || (r.strict && r.verify != nil && !r.verify(value)) {
 		if r.e != nil {
 			return r.e
 		}
 		return ErrRegex
 	}
+	if r.capture != nil {
+		if match := r.regex.FindStringSubmatch(value); match != nil {
+			r.capture(match)
+		}
+	}
 	return nil
 }
 
+// matches reports whether value matches the pattern or, in RFC5322 mode of IsEmail, is
+// a valid address.
+func (r *RegexRule) matches(value string) bool {
+	if r.rfc5322 && r.regex == regexEmail {
+		return isRFC5322Email(value)
+	}
+	return r.regex.MatchString(value)
+}
+
+// isRFC5322Email reports whether value is a bare RFC 5322 address. net/mail also accepts
+// display names, angle brackets and comments, so the parsed address must equal value
+// with the quotes of a quoted local part removed.
+func isRFC5322Email(value string) bool {
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" {
+		return false
+	}
+	at := strings.LastIndexByte(value, '@')
+	if at < 0 {
+		return false
+	}
+	local := value[:at]
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		local = quotedPairs.Replace(local[1 : len(local)-1])
+	}
+	return addr.Address == local+value[at:]
+}
+
+// quotedPairs unescapes the quoted pairs of a quoted string.
+var quotedPairs = strings.NewReplacer(`\\`, `\`, `\"`, `"`)
+
+// Describe returns the name, parameters and message of the rule.
+func (r *RegexRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrRegex)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -227,7 +464,108 @@ func (r *RegexRule) Validate(value string) error {
 //	err := rule.Validate("hello")  // returns error with message "Name must start with a capital letter"
 func (r *RegexRule) Errf(format string, args ...any) *RegexRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// JSONSchema returns the JSON Schema keywords of the rule: the pattern, which also
+// accepts the empty string like Validate, and the email format for IsEmail. In RFC5322
+// mode, IsEmail only has the format. An invalid pattern has no keywords.
+//
+// Example:
+//
+//	keywords := Regex(`^[a-z]+$`).JSONSchema()  // map[string]any{"pattern": "^$|(?:^[a-z]+$)"}
+func (r *RegexRule) JSONSchema() map[string]any {
+	if r.regex == nil {
+		return nil
+	}
+	if r.rfc5322 && r.regex == regexEmail {
+		return map[string]any{"format": "email"}
+	}
+	keywords := map[string]any{"pattern": "^$|(?:" + r.regex.String() + ")"}
+	if r.regex == regexEmail {
+		keywords["format"] = "email"
+	}
+	return keywords
+}
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *RegexRule) mutable() *RegexRule {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
+
+// idCardWeights are the GB 11643-1999 weights applied to the first 17 digits.
+var idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// idCardCheckDigits maps the weighted sum modulo 11 to the expected check digit.
+const idCardCheckDigits = "10X98765432"
+
+// verifyIDCard verifies the check digit and birth date of an 18-digit ID card
+// number that has already matched idCardPattern.
+func verifyIDCard(value string) bool {
+	sum := 0
+	for i, w := range idCardWeights {
+		sum += int(value[i]-'0') * w
+	}
+	check := value[17]
+	if check == 'x' {
+		check = 'X'
+	}
+	if idCardCheckDigits[sum%11] != check {
+		return false
+	}
+
+	// The pattern only bounds each date component, so reject dates such as
+	// February 30th that time.Parse would refuse, and dates in the future.
+	birth, err := time.Parse("20060102", value[6:14])
+	if err != nil {
+		return false
+	}
+	return !birth.After(time.Now())
+}
+
+// socialCreditCharset holds the characters of unified social credit codes in the order
+// of their values, from 0 to 30.
+const socialCreditCharset = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+// socialCreditWeights are the GB 32100-2015 weights applied to the first 17 characters.
+var socialCreditWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// socialCreditTypes maps the registration authority codes, the first character of a
+// code, to the organization type codes, the second character, they assign.
+var socialCreditTypes = map[byte]string{
+	'1': "1239",   // institutional establishment
+	'2': "19",     // foreign affairs
+	'3': "123459", // judicial administration
+	'4': "19",     // culture
+	'5': "1239",   // civil affairs
+	'6': "129",    // tourism
+	'7': "129",    // religious affairs
+	'8': "19",     // trade unions
+	'9': "123",    // market regulation
+	'A': "19",     // central military commission
+	'N': "1239",   // agriculture
+	'Y': "1",      // other
+}
+
+// verifySocialCredit verifies the prefix and check character of a unified social
+// credit code that has already matched socialCreditPattern.
+func verifySocialCredit(value string) bool {
+	types, ok := socialCreditTypes[value[0]]
+	if !ok || strings.IndexByte(types, value[1]) < 0 {
+		return false
+	}
+	sum := 0
+	for i, w := range socialCreditWeights {
+		sum += strings.IndexByte(socialCreditCharset, value[i]) * w
+	}
+	return socialCreditCharset[(31-sum%31)%31] == value[17]
+}
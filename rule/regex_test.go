@@ -1,7 +1,9 @@
 package rule
 
 import (
+	"fmt"
 	"regexp"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +63,48 @@ func TestIsEmail(t *testing.T) {
 	assert.Error(t, IsEmail().Validate("not-an-email"))
 }
 
+func TestIsEmailRFC5322(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: simple", value: "user@example.com", wantErr: false},
+		{name: "valid: plus addressing", value: "user+tag+more@example.com", wantErr: false},
+		{name: "valid: quoted local part", value: `"john doe"@example.com`, wantErr: false},
+		{name: "valid: quoted pair in local part", value: `"john\"doe"@example.com`, wantErr: false},
+		{name: "valid: special characters", value: "o'brien!#$&*=?^{|}~@example.com", wantErr: false},
+		{name: "valid: domain without a dot", value: "admin@localhost", wantErr: false},
+		{name: "valid: empty string", value: "", wantErr: false},
+		{name: "invalid: consecutive dots", value: "john..doe@example.com", wantErr: true},
+		{name: "invalid: leading dot", value: ".john@example.com", wantErr: true},
+		{name: "invalid: display name", value: "John <john@example.com>", wantErr: true},
+		{name: "invalid: angle brackets", value: "<john@example.com>", wantErr: true},
+		{name: "invalid: comment", value: "john@example.com (John)", wantErr: true},
+		{name: "invalid: no domain", value: "john@", wantErr: true},
+		{name: "invalid: no at sign", value: "john.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := IsEmail().RFC5322().Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsEmail().RFC5322().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrEmail)
+			}
+		})
+	}
+
+	// The default mode keeps the pattern, and the shared Emailv is not modified.
+	assert.Error(t, IsEmail().Validate(`"john doe"@example.com`))
+	assert.Nil(t, Emailv.RFC5322().Validate(`"john doe"@example.com`))
+	assert.Error(t, Emailv.Validate(`"john doe"@example.com`))
+	assert.Equal(t, map[string]any{"format": "email"}, IsEmail().RFC5322().JSONSchema())
+	assert.Nil(t, Regex(`^a$`).RFC5322().Validate("a"))
+}
+
 func TestIsPhone(t *testing.T) {
 	assert.Nil(t, IsPhone().Validate("+8613800138000"))
 	assert.Error(t, IsPhone().Validate("abc"))
@@ -71,6 +115,31 @@ func TestIsSocialCredit(t *testing.T) {
 	assert.Error(t, IsSocialCredit().Validate("123"))
 }
 
+func TestIsSocialCreditStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: numeric check character", value: "91350100M000100Y43", wantErr: false},
+		{name: "valid: letter check character", value: "91110000802100433B", wantErr: false},
+		{name: "valid: empty string", value: "", wantErr: false},
+		{name: "invalid: wrong check character", value: "91350100M000100Y44", wantErr: true},
+		{name: "invalid: unknown registration authority", value: "B1350100M000100Y43", wantErr: true},
+		{name: "invalid: organization type of another authority", value: "95350100M000100Y43", wantErr: true},
+		{name: "invalid: format", value: "123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := IsSocialCredit().Strict().Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsSocialCredit().Strict().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestIsTaxNumber(t *testing.T) {
 	assert.Nil(t, IsTaxNumber().Validate("123456789012345"))
 	assert.Error(t, IsTaxNumber().Validate("abc"))
@@ -91,8 +160,131 @@ func TestIsIDCard(t *testing.T) {
 	assert.Error(t, IsIDCard().Validate("123"))
 }
 
+func TestIsIDCardStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: check digit X", value: "11010519491231002X", wantErr: false},
+		{name: "valid: lowercase check digit", value: "11010519491231002x", wantErr: false},
+		{name: "valid: numeric check digit", value: "110101199003077774", wantErr: false},
+		{name: "valid: empty string", value: "", wantErr: false},
+		{name: "invalid: wrong check digit", value: "11010119900307777X", wantErr: true},
+		{name: "invalid: impossible birth date", value: "110101199002300014", wantErr: true},
+		{name: "invalid: birth date in the future", value: "110101209912310015", wantErr: true},
+		{name: "invalid: format", value: "123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := IsIDCard().Strict().Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsIDCard().Strict().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Strict has no effect on custom patterns.
+	assert.Nil(t, Regex(`^\d+$`).Strict().Validate("123"))
+}
+
 func TestRegexFallback(t *testing.T) {
 	re := regexp.MustCompile(`^[a-z]+$`)
 	err := (&RegexRule{regex: re}).Validate("123")
 	assert.Error(t, err)
 }
+
+func TestRegexCacheConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				pattern := fmt.Sprintf(`^concurrent%d$`, (i+j)%10)
+				assert.Nil(t, Regex(pattern).Validate(fmt.Sprintf("concurrent%d", (i+j)%10)))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRegexCacheCap(t *testing.T) {
+	assert.Nil(t, PrecompilePatterns(`^pinned$`))
+	pinned, _ := getCompiledRegex(`^pinned$`)
+	for i := 0; i < maxCachedRegexes+10; i++ {
+		_, err := getCompiledRegex(fmt.Sprintf(`^evict%d$`, i))
+		assert.Nil(t, err)
+	}
+
+	regexMutex.RLock()
+	size := len(compiledRegexes)
+	cached, ok := compiledRegexes[`^pinned$`]
+	regexMutex.RUnlock()
+	assert.LessOrEqual(t, size, maxCachedRegexes)
+	assert.True(t, ok)
+	assert.Same(t, pinned, cached.re)
+}
+
+func TestPrecompilePatterns(t *testing.T) {
+	assert.Nil(t, PrecompilePatterns(`^[a-z]{3}$`, `^\d{4}$`))
+	assert.Nil(t, Regex(`^\d{4}$`).Validate("2024"))
+
+	err := PrecompilePatterns(`^ok$`, `[invalid`, `(unclosed`)
+	assert.ErrorContains(t, err, `"[invalid"`)
+	assert.ErrorContains(t, err, `"(unclosed"`)
+	assert.Nil(t, Regex(`^ok$`).Validate("ok"))
+}
+
+func TestRegexCapture(t *testing.T) {
+	const pattern = `^(?P<prefix>[A-Z]{2})-(?P<seq>\d{6})(?:-(?P<suffix>[a-z]+))?$`
+
+	t.Run("map", func(t *testing.T) {
+		var prefix, seq string
+		rule := Regex(pattern).Capture(map[string]*string{"prefix": &prefix, "seq": &seq, "missing": nil})
+		assert.Nil(t, rule.Validate("SO-000042"))
+		assert.Equal(t, "SO", prefix)
+		assert.Equal(t, "000042", seq)
+
+		assert.Error(t, rule.Validate("so-000042"))
+		assert.Nil(t, rule.Validate(""))
+		assert.Equal(t, "SO", prefix, "targets are unchanged by invalid and empty values")
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		var order struct {
+			Prefix   string
+			Sequence string `regex:"seq"`
+			Seq      string `regex:"-"`
+			Suffix   int
+			suffix   string
+		}
+		rule := Regex(pattern).Capture(&order)
+		assert.Nil(t, rule.Validate("PO-123456-eu"))
+		assert.Equal(t, "PO", order.Prefix)
+		assert.Equal(t, "123456", order.Sequence)
+		assert.Empty(t, order.Seq)
+		assert.Zero(t, order.Suffix)
+		assert.Empty(t, order.suffix)
+
+		assert.Nil(t, rule.Validate("PO-654321"))
+		assert.Equal(t, "654321", order.Sequence)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		var s struct{ Prefix string }
+		for _, target := range []any{s, (*struct{})(nil), map[string]string{}, new(string)} {
+			err := Regex(pattern).Capture(target).Validate("SO-000042")
+			assert.ErrorContains(t, err, "capture target")
+		}
+		assert.Error(t, Regex(`[invalid`).Capture(&s).Validate("x"))
+	})
+
+	t.Run("shared rule", func(t *testing.T) {
+		var local string
+		rule := Emailv.Capture(map[string]*string{"local": &local})
+		assert.NotSame(t, Emailv, rule)
+		assert.Nil(t, Emailv.capture)
+	})
+}
@@ -2,12 +2,12 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
+	"reflect"
 )
 
 // ErrRequired is returned when a required value is empty or zero.
-var ErrRequired = errors.New("required")
+var ErrRequired = newError("required", "required")
 
 // RequiredRule is a validation rule that checks if a value is required (non-empty/non-zero).
 // It supports both value types and their pointer variants.
@@ -22,7 +22,8 @@ var ErrRequired = errors.New("required")
 //	err = rule.Validate(42)       // returns nil
 //	err = rule.Validate(0)        // returns ErrRequired
 type RequiredRule[T RequiredType] struct {
-	e error
+	frozen bool
+	e      error
 }
 
 // Required creates a new required validation rule.
@@ -43,6 +44,8 @@ func Required[T RequiredType]() *RequiredRule[T] {
 // For strings, it checks if the string is non-empty.
 // For numbers, it checks if the value is non-zero.
 // For pointers, it checks if the pointer is non-nil and the value is non-empty/non-zero.
+// For the Null types of database/sql, such as sql.NullString, it checks if the value is
+// valid and its inner value is non-empty/non-zero; use NullRequired to only reject NULL.
 //
 // Example:
 //
@@ -108,7 +111,11 @@ func (r *RequiredRule[T]) Validate(value T) error {
 	case *float64:
 		ok = v != nil && *v != 0
 	default:
-		return fmt.Errorf("unsupported type: %T", v)
+		zero, isNull := sqlNullZero(reflect.ValueOf(value))
+		if !isNull {
+			return fmt.Errorf("unsupported type: %T", value)
+		}
+		ok = !zero
 	}
 	if !ok {
 		if r.e != nil {
@@ -119,6 +126,11 @@ func (r *RequiredRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *RequiredRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrRequired)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 // If msg is empty, the default error message 'required' will be used.
@@ -129,7 +141,49 @@ func (r *RequiredRule[T]) Validate(value T) error {
 //	err := rule.Validate("")  // returns error with message "This field is required"
 func (r *RequiredRule[T]) Errf(format string, args ...any) *RequiredRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(ErrRequired, format, args...)
 	}
 	return r
 }
+
+// JSONSchema returns the JSON Schema keywords of the rule. Besides marking the field as
+// required, it rejects the empty string or zero, like Validate.
+//
+// Example:
+//
+//	keywords := Required[string]().JSONSchema()  // map[string]any{"required": true, "minLength": 1}
+func (r *RequiredRule[T]) JSONSchema() map[string]any {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String {
+		return map[string]any{"required": true, "minLength": 1}
+	}
+	return map[string]any{"required": true, "not": map[string]any{"const": 0}}
+}
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *RequiredRule[T]) mutable() *RequiredRule[T] {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
+
+// Requiredv returns the predeclared, immutable required rule for T. It returns the same
+// instance on every call, can be shared and used concurrently, and does not allocate.
+// Errf returns a copy instead of modifying it.
+//
+// Example:
+//
+//	err := arbiter.Validate(name, rule.Requiredv[string]())
+func Requiredv[T RequiredType]() *RequiredRule[T] {
+	return sharedRule(func() *RequiredRule[T] {
+		return &RequiredRule[T]{frozen: true}
+	})
+}
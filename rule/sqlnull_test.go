@@ -0,0 +1,55 @@
+package rule
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLNull(t *testing.T) {
+	now := time.Now()
+
+	// Required
+	assert.NoError(t, Required[sql.NullString]().Validate(sql.NullString{String: "a", Valid: true}))
+	assert.ErrorIs(t, Required[sql.NullString]().Validate(sql.NullString{Valid: true}), ErrRequired)
+	assert.ErrorIs(t, Required[sql.NullString]().Validate(sql.NullString{String: "a"}), ErrRequired)
+	assert.NoError(t, Required[sql.NullInt32]().Validate(sql.NullInt32{Int32: 1, Valid: true}))
+	assert.ErrorIs(t, Required[sql.NullInt64]().Validate(sql.NullInt64{}), ErrRequired)
+	assert.NoError(t, Required[sql.NullTime]().Validate(sql.NullTime{Time: now, Valid: true}))
+	assert.ErrorIs(t, Required[sql.NullTime]().Validate(sql.NullTime{Valid: true}), ErrRequired)
+
+	// NonZero and Zero
+	assert.NoError(t, NonZero[sql.NullFloat64]().Validate(sql.NullFloat64{Float64: 1.5, Valid: true}))
+	assert.ErrorIs(t, NonZero[sql.NullString]().Validate(sql.NullString{String: "a"}), ErrNonZero)
+	assert.ErrorIs(t, NonZero[sql.Null[int]]().Validate(sql.Null[int]{Valid: true}), ErrNonZero)
+	assert.NoError(t, Zero[sql.NullString]().Validate(sql.NullString{String: "a"}))
+	assert.NoError(t, Zero[sql.NullBool]().Validate(sql.NullBool{Valid: true}))
+	assert.ErrorIs(t, Zero[sql.NullTime]().Validate(sql.NullTime{Time: now, Valid: true}), ErrZero)
+
+	// NullRequired
+	assert.NoError(t, NullRequired[sql.NullInt64]().Validate(sql.NullInt64{Valid: true}))
+	assert.ErrorIs(t, NullRequired[sql.NullInt64]().Validate(sql.NullInt64{}), ErrNullRequired)
+	assert.NoError(t, NullRequired[sql.Null[string]]().Validate(sql.Null[string]{Valid: true}))
+	err := NullRequired[sql.NullTime]().Errf("deleted_at must be set").Validate(sql.NullTime{})
+	assert.EqualError(t, err, "deleted_at must be set")
+	assert.ErrorIs(t, err, ErrNullRequired)
+
+	// Nullable
+	tests := []struct {
+		name    string
+		value   sql.Null[string]
+		wantErr error
+	}{
+		{name: "null", value: sql.Null[string]{V: "x"}},
+		{name: "valid", value: sql.Null[string]{V: "Ada", Valid: true}},
+		{name: "invalid", value: sql.Null[string]{V: "A", Valid: true}, wantErr: ErrLength},
+	}
+	rule := Nullable[string](Len[string](2, 50))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, rule.Validate(tt.value), tt.wantErr)
+		})
+	}
+}
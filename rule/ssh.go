@@ -0,0 +1,195 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the rule for validating SSH public keys.
+package rule
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// ErrSSHPublicKey is returned when a string is not an SSH public key of an accepted type
+// and size
+var ErrSSHPublicKey = newError("ssh_public_key", "invalid SSH public key")
+
+// sshCurves maps the ECDSA key types to their curve identifiers and curves.
+var sshCurves = map[string]struct {
+	name  string
+	curve ecdh.Curve
+	bits  int
+}{
+	"ecdsa-sha2-nistp256": {name: "nistp256", curve: ecdh.P256(), bits: 256},
+	"ecdsa-sha2-nistp384": {name: "nistp384", curve: ecdh.P384(), bits: 384},
+	"ecdsa-sha2-nistp521": {name: "nistp521", curve: ecdh.P521(), bits: 521},
+}
+
+// SSHPublicKeyRule validates that a string is an SSH public key in the format of an
+// authorized_keys or .pub file line: a key type, the base64-encoded key and an optional
+// comment, such as "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... user@host". The encoded
+// key must be of the declared type and well formed: an Ed25519 key of 32 bytes, an RSA
+// key with a positive modulus or an ECDSA key whose point is on the curve.
+// Lines with authorized_keys options, such as from="10.0.0.1", are rejected.
+//
+// Example:
+//
+//	rule := SSHPublicKey()
+//	err := rule.Validate("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB... deploy@ci")  // returns nil
+//	err = rule.Validate("ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIB...")               // returns ErrSSHPublicKey
+type SSHPublicKeyRule struct {
+	types   []string
+	minBits int
+	e       error
+}
+
+// SSHPublicKey creates a rule that validates SSH public keys of the given types, such as
+// "ssh-ed25519", or of the types ssh-ed25519, ssh-rsa and ecdsa-sha2-nistp256, -nistp384
+// and -nistp521 if none are given.
+//
+// Example:
+//
+//	arbiter.Field(&deployKey.PublicKey, rule.Required[string](), rule.SSHPublicKey())
+func SSHPublicKey(types ...string) *SSHPublicKeyRule {
+	return &SSHPublicKeyRule{types: types, e: ErrSSHPublicKey}
+}
+
+// MinBits sets the minimum key size: the modulus length of RSA keys and the curve size of
+// ECDSA keys. Ed25519 keys count as 256 bits.
+//
+// Example:
+//
+//	rule := SSHPublicKey().MinBits(3072)  // rejects 2048-bit RSA keys
+func (r *SSHPublicKeyRule) MinBits(bits int) *SSHPublicKeyRule {
+	r.minBits = bits
+	return r
+}
+
+// Validate checks if the string is an SSH public key of an accepted type and size.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := SSHPublicKey("ssh-ed25519")
+//	err := rule.Validate(ed25519Key)  // returns nil
+//	err = rule.Validate(rsaKey)       // returns ErrSSHPublicKey
+func (r *SSHPublicKeyRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	fields := strings.Fields(value)
+	if len(fields) < 2 || len(r.types) > 0 && !slices.Contains(r.types, fields[0]) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrSSHPublicKey
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrSSHPublicKey
+	}
+	bits, ok := parseSSHPublicKey(fields[0], blob)
+	if !ok || bits < r.minBits {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrSSHPublicKey
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *SSHPublicKeyRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrSSHPublicKey)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := SSHPublicKey().Errf("Please paste the contents of your id_ed25519.pub file")
+func (r *SSHPublicKeyRule) Errf(format string, args ...any) *SSHPublicKeyRule {
+	if format != "" {
+		r.e = wrapf(ErrSSHPublicKey, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := SSHPublicKey("ssh-ed25519").Params()  // map[string]any{"types": []string{"ssh-ed25519"}, "min_bits": 0}
+func (r *SSHPublicKeyRule) Params() map[string]any {
+	return map[string]any{"types": r.types, "min_bits": r.minBits}
+}
+
+// parseSSHPublicKey parses blob, an SSH public key in the wire format of RFC 4253, and
+// reports its size and whether it is a well-formed key of type typ.
+func parseSSHPublicKey(typ string, blob []byte) (int, bool) {
+	name, rest, ok := readSSHString(blob)
+	if !ok || string(name) != typ {
+		return 0, false
+	}
+	switch typ {
+	case "ssh-ed25519":
+		key, rest, ok := readSSHString(rest)
+		return 256, ok && len(key) == 32 && len(rest) == 0
+	case "ssh-rsa":
+		e, rest, ok := readSSHString(rest)
+		if !ok {
+			return 0, false
+		}
+		n, rest, ok := readSSHString(rest)
+		if !ok || len(rest) > 0 || !positiveMPInt(e) || !positiveMPInt(n) {
+			return 0, false
+		}
+		return new(big.Int).SetBytes(n).BitLen(), true
+	}
+	curve, ok := sshCurves[typ]
+	if !ok {
+		return 0, false
+	}
+	id, rest, ok := readSSHString(rest)
+	if !ok || string(id) != curve.name {
+		return 0, false
+	}
+	point, rest, ok := readSSHString(rest)
+	if !ok || len(rest) > 0 {
+		return 0, false
+	}
+	if _, err := curve.curve.NewPublicKey(point); err != nil {
+		return 0, false
+	}
+	return curve.bits, true
+}
+
+// readSSHString reads a string of the SSH wire format: a 32-bit big-endian length
+// followed by that many bytes.
+func readSSHString(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(data)
+	if uint64(n) > uint64(len(data)-4) {
+		return nil, nil, false
+	}
+	return data[4 : 4+n], data[4+n:], true
+}
+
+// positiveMPInt reports whether b, a multiple precision integer of the SSH wire format,
+// is greater than zero and has no superfluous leading zero byte.
+func positiveMPInt(b []byte) bool {
+	if len(b) == 0 || b[0]&0x80 != 0 {
+		return false
+	}
+	if b[0] == 0 {
+		return len(b) > 1 && b[1]&0x80 != 0
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package rule
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sshKey returns an authorized_keys line of type typ with the given wire format fields.
+func sshKey(typ string, fields ...[]byte) string {
+	var blob []byte
+	for _, f := range append([][]byte{[]byte(typ)}, fields...) {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(f)))
+		blob = append(blob, f...)
+	}
+	return typ + " " + base64.StdEncoding.EncodeToString(blob)
+}
+
+// mpint returns the SSH wire format encoding of a positive integer.
+func mpint(n *big.Int) []byte {
+	b := n.Bytes()
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+func TestSSHPublicKey(t *testing.T) {
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ed := sshKey("ssh-ed25519", edPublic)
+	rsaLine := sshKey("ssh-rsa", mpint(big.NewInt(int64(rsaKey.E))), mpint(rsaKey.N))
+	ecdsa := sshKey("ecdsa-sha2-nistp256", []byte("nistp256"), ecKey.PublicKey().Bytes())
+	offCurve := append([]byte{4}, make([]byte, 64)...)
+
+	tests := []struct {
+		name    string
+		rule    *SSHPublicKeyRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: ed25519", rule: SSHPublicKey(), value: ed},
+		{name: "valid: ed25519 with comment", rule: SSHPublicKey(), value: ed + " deploy@ci server"},
+		{name: "valid: rsa", rule: SSHPublicKey(), value: rsaLine},
+		{name: "valid: ecdsa", rule: SSHPublicKey(), value: ecdsa + "\n"},
+		{name: "valid: empty string", rule: SSHPublicKey(), value: ""},
+		{name: "valid: accepted type", rule: SSHPublicKey("ssh-ed25519"), value: ed},
+		{name: "valid: minimum size", rule: SSHPublicKey().MinBits(2048), value: rsaLine},
+		{name: "invalid: type not accepted", rule: SSHPublicKey("ssh-ed25519"), value: rsaLine, wantErr: true},
+		{name: "invalid: too small", rule: SSHPublicKey().MinBits(3072), value: rsaLine, wantErr: true},
+		{name: "invalid: declared type mismatch", rule: SSHPublicKey(), value: "ssh-rsa" + ed[len("ssh-ed25519"):], wantErr: true},
+		{name: "invalid: unknown type", rule: SSHPublicKey(), value: sshKey("ssh-dss", []byte{1}, []byte{1}, []byte{1}, []byte{1}), wantErr: true},
+		{name: "invalid: short ed25519 key", rule: SSHPublicKey(), value: sshKey("ssh-ed25519", edPublic[:31]), wantErr: true},
+		{name: "invalid: trailing data", rule: SSHPublicKey(), value: sshKey("ssh-ed25519", edPublic, []byte{0}), wantErr: true},
+		{name: "invalid: negative modulus", rule: SSHPublicKey(), value: sshKey("ssh-rsa", []byte{3}, []byte{0x80, 1}), wantErr: true},
+		{name: "invalid: point not on curve", rule: SSHPublicKey(), value: sshKey("ecdsa-sha2-nistp256", []byte("nistp256"), offCurve), wantErr: true},
+		{name: "invalid: wrong curve", rule: SSHPublicKey(), value: sshKey("ecdsa-sha2-nistp384", []byte("nistp256"), ecKey.PublicKey().Bytes()), wantErr: true},
+		{name: "invalid: truncated", rule: SSHPublicKey(), value: ed[:len(ed)-8], wantErr: true},
+		{name: "invalid: not base64", rule: SSHPublicKey(), value: "ssh-ed25519 not*base64", wantErr: true},
+		{name: "invalid: missing key", rule: SSHPublicKey(), value: "ssh-ed25519", wantErr: true},
+		{name: "invalid: options", rule: SSHPublicKey(), value: `from="10.0.0.1" ` + ed, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SSHPublicKey().Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrSSHPublicKey)
+			}
+		})
+	}
+
+	assert.EqualError(t, SSHPublicKey().Errf("bad key").Validate("x"), "bad key")
+	assert.Equal(t, map[string]any{"types": []string{"ssh-ed25519"}, "min_bits": 0}, SSHPublicKey("ssh-ed25519").Params())
+}
@@ -0,0 +1,34 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the strict mode of format rules for empty strings.
+package rule
+
+import "sync/atomic"
+
+// strictEmpty is set by SetStrictEmpty.
+var strictEmpty atomic.Bool
+
+// SetStrictEmpty sets whether format rules reject empty strings. By default, format rules
+// such as IP, UUID, URL, DateFormat, IsEmail and the other rules created with Regex
+// accept "", so that optional fields can be left empty, while rules such as StartWith
+// and Len do not. In strict mode, the format rules check "" like any other value and
+// return their error; fields that may be empty are then wrapped in Optional. The setting
+// applies to all rules and is safe for concurrent use, but is meant to be set once at
+// start-up.
+//
+// Example:
+//
+//	func init() {
+//	    rule.SetStrictEmpty(true)
+//	}
+//
+//	err := rule.IP().Validate("")                // returns ErrIP
+//	err = rule.Optional(rule.IP()).Validate("")  // returns nil
+func SetStrictEmpty(strict bool) {
+	strictEmpty.Store(strict)
+}
+
+// skipEmpty reports whether a format rule accepts value without checking it, because it
+// is empty and strict mode is off.
+func skipEmpty(value string) bool {
+	return value == "" && !strictEmpty.Load()
+}
@@ -0,0 +1,52 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetStrictEmpty(t *testing.T) {
+	var n int
+	var b bool
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rules := map[string]Rule[string]{
+		"IP":                IP(),
+		"IPv4":              IPv4(),
+		"IPv6":              IPv6(),
+		"UUID":              UUID(),
+		"URL":               URL(),
+		"IsEmail":           IsEmail(),
+		"Regex":             Regex(`^[a-z]+$`),
+		"DateFormat":        DateFormat("2006-01-02"),
+		"TimeFormat":        TimeFormat("15:04"),
+		"DateTimeFormat":    DateTimeFormat("2006-01-02 15:04"),
+		"DateStringBetween": DateStringBetween("2006-01-02", day, day.AddDate(1, 0, 0)),
+		"DurationString":    DurationString(time.Second, time.Hour),
+		"ULID":              ULID(),
+		"KSUID":             KSUID(),
+		"NanoID":            NanoID("abc", 4),
+		"BcryptHash":        BcryptHash(),
+		"PHCString":         PHCString(),
+		"Argon2Hash":        Argon2Hash(),
+		"TOTPSecret":        TOTPSecret(),
+		"RecoveryCode":      RecoveryCode("####"),
+		"Recurrence":        Recurrence(),
+		"CoerceInt":         CoerceInt(&n),
+		"CoerceBool":        CoerceBool(&b),
+	}
+
+	for name, r := range rules {
+		assert.NoError(t, r.Validate(""), name)
+	}
+
+	SetStrictEmpty(true)
+	defer SetStrictEmpty(false)
+	for name, r := range rules {
+		assert.Error(t, r.Validate(""), name)
+		assert.NoError(t, Optional(r).Validate(""), name)
+	}
+	assert.ErrorIs(t, IP().Validate(""), ErrIP)
+	assert.NoError(t, IP().Validate("10.0.0.1"))
+}
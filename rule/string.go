@@ -2,8 +2,6 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"strings"
 	"unicode"
 )
@@ -58,17 +56,17 @@ func isHalfWidth(r rune) bool {
 
 // Common string validation errors
 var (
-	ErrStartsWith     = errors.New("string must start with the specified prefix")
-	ErrEndsWith       = errors.New("string must end with the specified suffix")
-	ErrChineseOnly    = errors.New("string must contain only Chinese characters")
-	ErrFullWidthOnly  = errors.New("string must contain only full-width characters")
-	ErrHalfWidthOnly  = errors.New("string must contain only half-width characters")
-	ErrUpperCaseOnly  = errors.New("string must contain only uppercase letters")
-	ErrLowerCaseOnly  = errors.New("string must contain only lowercase letters")
-	ErrSpecialChars   = errors.New("string must not contain special characters")
-	ErrNoSpecialChars = errors.New("string must contain special characters")
-	ErrContains       = errors.New("string must contain the specified substring")
-	ErrNotContains    = errors.New("string must not contain the specified substring")
+	ErrStartsWith     = newError("starts_with", "string must start with the specified prefix")
+	ErrEndsWith       = newError("ends_with", "string must end with the specified suffix")
+	ErrChineseOnly    = newError("chinese_only", "string must contain only Chinese characters")
+	ErrFullWidthOnly  = newError("full_width_only", "string must contain only full-width characters")
+	ErrHalfWidthOnly  = newError("half_width_only", "string must contain only half-width characters")
+	ErrUpperCaseOnly  = newError("upper_case_only", "string must contain only uppercase letters")
+	ErrLowerCaseOnly  = newError("lower_case_only", "string must contain only lowercase letters")
+	ErrSpecialChars   = newError("special_chars", "string must not contain special characters")
+	ErrNoSpecialChars = newError("no_special_chars", "string must contain special characters")
+	ErrContains       = newError("contains", "string must contain the specified substring")
+	ErrNotContains    = newError("not_contains", "string must not contain the specified substring")
 )
 
 // StartWithRule validates that a string starts with a specific prefix.
@@ -119,6 +117,11 @@ func (r *StartWithRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *StartWithRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrStartsWith)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -128,7 +131,7 @@ func (r *StartWithRule) Validate(value string) error {
 //	err := rule.Validate("http://example.com")  // returns error with custom message
 func (r *StartWithRule) Errf(format string, args ...any) *StartWithRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -181,6 +184,11 @@ func (r *EndWithRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *EndWithRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrEndsWith)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -190,7 +198,7 @@ func (r *EndWithRule) Validate(value string) error {
 //	err := rule.Validate("main.py")  // returns error with custom message
 func (r *EndWithRule) Errf(format string, args ...any) *EndWithRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -240,6 +248,11 @@ func (r *ChineseOnlyRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *ChineseOnlyRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrChineseOnly)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -249,7 +262,7 @@ func (r *ChineseOnlyRule) Validate(value string) error {
 //	err := rule.Validate("Hello")  // returns error with custom message
 func (r *ChineseOnlyRule) Errf(format string, args ...any) *ChineseOnlyRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -299,6 +312,11 @@ func (r *FullWidthRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *FullWidthRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrFullWidthOnly)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -308,7 +326,7 @@ func (r *FullWidthRule) Validate(value string) error {
 //	err := rule.Validate("Hello")  // returns error with custom message
 func (r *FullWidthRule) Errf(format string, args ...any) *FullWidthRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -358,6 +376,11 @@ func (r *HalfWidthRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *HalfWidthRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHalfWidthOnly)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -367,7 +390,7 @@ func (r *HalfWidthRule) Validate(value string) error {
 //	err := rule.Validate("Ｈｅｌｌｏ")  // returns error with custom message
 func (r *HalfWidthRule) Errf(format string, args ...any) *HalfWidthRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -418,6 +441,11 @@ func (r *UpperCaseRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *UpperCaseRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrUpperCaseOnly)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -427,7 +455,7 @@ func (r *UpperCaseRule) Validate(value string) error {
 //	err := rule.Validate("Hello")  // returns error with custom message
 func (r *UpperCaseRule) Errf(format string, args ...any) *UpperCaseRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -478,6 +506,11 @@ func (r *LowerCaseRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *LowerCaseRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrLowerCaseOnly)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -487,7 +520,7 @@ func (r *LowerCaseRule) Validate(value string) error {
 //	err := rule.Validate("Hello")  // returns error with custom message
 func (r *LowerCaseRule) Errf(format string, args ...any) *LowerCaseRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -564,6 +597,11 @@ func (r *SpecialCharsRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *SpecialCharsRule) Describe() RuleInfo {
+	return describe(r, r.e, Ternary(r.allowSpecial, ErrNoSpecialChars, ErrSpecialChars))
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -573,7 +611,7 @@ func (r *SpecialCharsRule) Validate(value string) error {
 //	err := rule.Validate("Hello")  // returns error with custom message
 func (r *SpecialCharsRule) Errf(format string, args ...any) *SpecialCharsRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -623,6 +661,11 @@ func (r *ContainsRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *ContainsRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrContains)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -632,7 +675,7 @@ func (r *ContainsRule) Validate(value string) error {
 //	err := rule.Validate("bar")  // returns error with custom message
 func (r *ContainsRule) Errf(format string, args ...any) *ContainsRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -682,6 +725,11 @@ func (r *NotContainsRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *NotContainsRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrNotContains)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -691,7 +739,7 @@ func (r *NotContainsRule) Validate(value string) error {
 //	err := rule.Validate("bar")  // returns error with custom message
 func (r *NotContainsRule) Errf(format string, args ...any) *NotContainsRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
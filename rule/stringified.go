@@ -0,0 +1,70 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains the adapter that applies string rules to fmt.Stringer types.
+package rule
+
+import "fmt"
+
+// StringifiedRule validates values of a fmt.Stringer type T, such as uuid.UUID or a
+// typed ID, by applying a string rule to their String form.
+//
+// Example:
+//
+//	rule := Stringified[uuid.UUID](UUID())
+//	err := rule.Validate(uuid.New())  // returns nil
+//	err = rule.Validate(uuid.Nil)     // returns nil, as the nil UUID is well-formed
+type StringifiedRule[T fmt.Stringer] struct {
+	rule Rule[string]
+}
+
+// Stringified adapts the string rule r to values of the fmt.Stringer type T, so that
+// strongly-typed ID fields can use rules such as UUID, ULID or Len without converting
+// the value first. The error is the error of r.
+//
+// Example:
+//
+//	type OrderID struct{ id uuid.UUID }
+//	func (o OrderID) String() string { return o.id.String() }
+//
+//	err := arbiter.Validate(order.ID, rule.Stringified[OrderID](rule.UUID()))
+func Stringified[T fmt.Stringer](r Rule[string]) *StringifiedRule[T] {
+	return &StringifiedRule[T]{rule: r}
+}
+
+// Validate applies the string rule to value.String().
+//
+// Example:
+//
+//	err := Stringified[uuid.UUID](Len[string](36, 36)).Validate(uuid.New())  // returns nil
+func (r *StringifiedRule[T]) Validate(value T) error {
+	return r.rule.Validate(value.String())
+}
+
+// Describe returns the description of the rule, with the rule of the string form.
+func (r *StringifiedRule[T]) Describe() RuleInfo {
+	return RuleInfo{Name: "stringified", Rules: []RuleInfo{Describe(r.rule)}}
+}
+
+// Params returns the parameters of the string rule, if it has any.
+//
+// Example:
+//
+//	params := Stringified[OrderID](Len[string](1, 64)).Params()  // map[string]any{"min": 1, "max": 64}
+func (r *StringifiedRule[T]) Params() map[string]any {
+	if p, ok := r.rule.(Parameterized); ok {
+		return p.Params()
+	}
+	return nil
+}
+
+// JSONSchema returns the JSON Schema keywords of the string rule, if it has any, as the
+// value is represented by its String form.
+//
+// Example:
+//
+//	keywords := Stringified[OrderID](Len[string](1, 64)).JSONSchema()  // map[string]any{"minLength": 1, "maxLength": 64}
+func (r *StringifiedRule[T]) JSONSchema() map[string]any {
+	if s, ok := r.rule.(JSONSchemaRule); ok {
+		return s.JSONSchema()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package rule
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testUUID is a UUID type like uuid.UUID of github.com/google/uuid.
+type testUUID [16]byte
+
+func (u testUUID) String() string {
+	h := hex.EncodeToString(u[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// testOrderID is a typed ID whose String form is not a UUID.
+type testOrderID int
+
+func (id testOrderID) String() string {
+	return "ord_" + string(rune('0'+int(id)))
+}
+
+func TestStringified(t *testing.T) {
+	id := testUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	assert.NoError(t, Stringified[testUUID](UUID()).Validate(id))
+	assert.NoError(t, Stringified[testUUID](UUIDv).Validate(testUUID{}))
+	assert.ErrorIs(t, Stringified[testOrderID](UUID()).Validate(testOrderID(1)), ErrUUID)
+	assert.ErrorIs(t, Stringified[testOrderID](Len[string](6, 10)).Validate(testOrderID(1)), ErrLength)
+
+	r := Stringified[testOrderID](Len[string](1, 64))
+	assert.Equal(t, map[string]any{"min": 1, "max": 64}, r.Params())
+	assert.Equal(t, map[string]any{"minLength": 1, "maxLength": 64}, r.JSONSchema())
+	assert.Nil(t, Stringified[testUUID](UUID()).Params())
+	assert.Nil(t, Stringified[testUUID](UUID()).JSONSchema())
+}
@@ -0,0 +1,224 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains rules for validating taxpayer identification numbers by country.
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrEIN is returned when a string is not a valid US Employer Identification Number
+var ErrEIN = newError("ein", "invalid employer identification number")
+
+var (
+	regexEIN     = regexp.MustCompile(`^(\d{2}-\d{7}|\d{9})$`)
+	regexPAN     = regexp.MustCompile(`^[A-Z]{3}[ABCFGHJLPT][A-Z]\d{4}[A-Z]$`)
+	regexUTR     = regexp.MustCompile(`^\d{10}$`)
+	regexFRTaxID = regexp.MustCompile(`^[0-3]\d{12}$`)
+	regexDETaxID = regexp.MustCompile(`^[1-9]\d{10}$`)
+	regexTFN     = regexp.MustCompile(`^\d{9}$`)
+)
+
+// einPrefixes are the two-digit prefixes the IRS assigns to Employer Identification
+// Numbers.
+var einPrefixes = []string{
+	"01", "02", "03", "04", "05", "06", "10", "11", "12", "13", "14", "15", "16",
+	"20", "21", "22", "23", "24", "25", "26", "27", "30", "31", "32", "33", "34", "35",
+	"36", "37", "38", "39", "40", "41", "42", "43", "44", "45", "46", "47", "48", "50",
+	"51", "52", "53", "54", "55", "56", "57", "58", "59", "60", "61", "62", "63", "64",
+	"65", "66", "67", "68", "71", "72", "73", "74", "75", "76", "77", "80", "81", "82",
+	"83", "84", "85", "86", "87", "88", "90", "91", "92", "93", "94", "95", "98", "99",
+}
+
+// taxIDFormats maps the ISO 3166-1 alpha-2 codes of the countries supported by TaxID to
+// the functions that check their tax identification numbers.
+var taxIDFormats = map[string]func(string) bool{
+	// Employer Identification Number, Social Security number or ITIN
+	"US": func(value string) bool {
+		return regexEIN.MatchString(value) && verifyEIN(value) ||
+			regexSSN.MatchString(value) && (verifySSN(value) || isITIN(value))
+	},
+	// Unified social credit code
+	"CN": func(value string) bool {
+		return regexSocialCredit.MatchString(value) && verifySocialCredit(value)
+	},
+	// Steuerliche Identifikationsnummer
+	"DE": func(value string) bool {
+		return regexDETaxID.MatchString(value) && verifyDETaxID(value)
+	},
+	// Numéro fiscal de référence
+	"FR": regexFRTaxID.MatchString,
+	// Unique Taxpayer Reference
+	"GB": regexUTR.MatchString,
+	// Permanent Account Number
+	"IN": regexPAN.MatchString,
+	// Tax File Number
+	"AU": func(value string) bool {
+		value = strings.ReplaceAll(value, " ", "")
+		return regexTFN.MatchString(value) && verifyTFN(value)
+	},
+}
+
+// EIN returns a new RegexRule that validates US Employer Identification Numbers, written
+// as "12-3456789" or "123456789", whose two-digit prefix is assigned by the IRS.
+//
+// Example:
+//
+//	rule := EIN()
+//	err := rule.Validate("12-3456789")  // returns nil
+//	err = rule.Validate("07-3456789")   // returns ErrEIN (07 is not assigned)
+func EIN() *RegexRule {
+	return &RegexRule{regex: regexEIN, verify: verifyEIN, strict: true, e: ErrEIN}
+}
+
+// TaxIDRule validates that a string is a taxpayer identification number of a country.
+//
+// Example:
+//
+//	rule := TaxID("IN")
+//	err := rule.Validate("ABCPE1234F")  // returns nil
+//	err = rule.Validate("ABCXE1234F")   // returns ErrTaxNumber
+type TaxIDRule struct {
+	country string
+	check   func(string) bool
+	e       error
+}
+
+// TaxID creates a rule that validates the taxpayer identification numbers of country,
+// an ISO 3166-1 alpha-2 code, instead of the generic format of IsTaxNumber:
+//
+//   - US: an Employer Identification Number, Social Security number or ITIN
+//   - CN: a unified social credit code, with its check character
+//   - DE: a Steuerliche Identifikationsnummer, with its check digit
+//   - FR: a numéro fiscal de référence of 13 digits
+//   - GB: a Unique Taxpayer Reference of 10 digits
+//   - IN: a Permanent Account Number, such as ABCPE1234F
+//   - AU: a Tax File Number of 9 digits, with its check digit
+//
+// If the country is not supported, the rule always returns an error.
+//
+// Example:
+//
+//	rule := TaxID("DE")
+//	err := rule.Validate("86095742719")  // returns nil
+//	err = rule.Validate("86095742718")   // returns ErrTaxNumber (wrong check digit)
+func TaxID(country string) *TaxIDRule {
+	country = strings.ToUpper(country)
+	check, ok := taxIDFormats[country]
+	if !ok {
+		return &TaxIDRule{country: country, e: fmt.Errorf("unsupported tax ID country %q", country)}
+	}
+	return &TaxIDRule{country: country, check: check, e: ErrTaxNumber}
+}
+
+// Validate checks if the string is a taxpayer identification number of the country.
+// Returns nil if the string is valid or empty, or an error otherwise.
+//
+// Example:
+//
+//	rule := TaxID("US")
+//	err := rule.Validate("12-3456789")   // returns nil (EIN)
+//	err = rule.Validate("123-45-6789")  // returns nil (SSN)
+func (r *TaxIDRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	if r.check == nil {
+		return r.e
+	}
+	if !r.check(value) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrTaxNumber
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *TaxIDRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrTaxNumber)
+}
+
+// Errf sets a custom error message for the validation rule.
+// Returns the rule instance for method chaining.
+//
+// Example:
+//
+//	rule := TaxID("GB").Errf("Please enter your 10-digit UTR")
+func (r *TaxIDRule) Errf(format string, args ...any) *TaxIDRule {
+	if format != "" && r.check != nil {
+		r.e = wrapf(ErrTaxNumber, format, args...)
+	}
+	return r
+}
+
+// Params returns the parameters of the rule, for use in error messages and payloads.
+//
+// Example:
+//
+//	params := TaxID("us").Params()  // map[string]any{"country": "US"}
+func (r *TaxIDRule) Params() map[string]any {
+	return map[string]any{"country": r.country}
+}
+
+// verifyEIN checks that the prefix of an EIN is assigned.
+func verifyEIN(value string) bool {
+	return slices.Contains(einPrefixes, value[:2])
+}
+
+// isITIN reports whether value, which has already matched regexSSN, is a US Individual
+// Taxpayer Identification Number: it starts with 9 and its fourth and fifth digits are
+// 50 to 65, 70 to 88, 90 to 92 or 94 to 99.
+func isITIN(value string) bool {
+	value = strings.ReplaceAll(value, "-", "")
+	group := int(value[3]-'0')*10 + int(value[4]-'0')
+	return value[0] == '9' && (group >= 50 && group <= 65 || group >= 70 && group <= 88 ||
+		group >= 90 && group <= 92 || group >= 94)
+}
+
+// verifyDETaxID verifies a German tax identification number: in the first ten digits,
+// exactly one digit occurs two or three times, but not three times in a row, and the
+// last digit is the ISO 7064 MOD 11,10 check digit.
+func verifyDETaxID(value string) bool {
+	var counts [10]int
+	for i := 0; i < 10; i++ {
+		counts[value[i]-'0']++
+	}
+	repeated := 0
+	for d, n := range counts {
+		if n > 3 || n == 3 && strings.Contains(value[:10], strings.Repeat(string(rune('0'+d)), 3)) {
+			return false
+		}
+		if n > 1 {
+			repeated++
+		}
+	}
+	if repeated != 1 {
+		return false
+	}
+
+	product := 10
+	for i := 0; i < 10; i++ {
+		sum := (int(value[i]-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = sum * 2 % 11
+	}
+	return (11-product)%10 == int(value[10]-'0')
+}
+
+// tfnWeights are the weights of the digits of an Australian Tax File Number.
+var tfnWeights = [9]int{1, 4, 3, 7, 5, 8, 6, 9, 10}
+
+// verifyTFN verifies the check digit of a 9-digit Australian Tax File Number.
+func verifyTFN(value string) bool {
+	sum := 0
+	for i, w := range tfnWeights {
+		sum += int(value[i]-'0') * w
+	}
+	return sum%11 == 0
+}
@@ -0,0 +1,65 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEIN(t *testing.T) {
+	assert.NoError(t, EIN().Validate("12-3456789"))
+	assert.NoError(t, EIN().Validate("123456789"))
+	assert.NoError(t, EIN().Validate(""))
+	assert.ErrorIs(t, EIN().Validate("07-3456789"), ErrEIN)
+	assert.ErrorIs(t, EIN().Validate("123-456789"), ErrEIN)
+	assert.ErrorIs(t, EIN().Validate("12-345678"), ErrEIN)
+	assert.EqualError(t, EIN().Errf("Invalid EIN").Validate("00-0000000"), "Invalid EIN")
+}
+
+func TestTaxID(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		value   string
+		wantErr bool
+	}{
+		{name: "us: ein", country: "US", value: "12-3456789"},
+		{name: "us: ssn", country: "US", value: "123-45-6789"},
+		{name: "us: itin", country: "us", value: "912-70-1234"},
+		{name: "us: unassigned ein prefix", country: "US", value: "07-3456789", wantErr: true},
+		{name: "us: invalid itin group", country: "US", value: "912-45-1234", wantErr: true},
+		{name: "cn: social credit code", country: "CN", value: "91350100M000100Y43"},
+		{name: "cn: wrong check character", country: "CN", value: "91350100M000100Y44", wantErr: true},
+		{name: "de: tax id", country: "DE", value: "86095742719"},
+		{name: "de: digit three times", country: "DE", value: "47036892816"},
+		{name: "de: wrong check digit", country: "DE", value: "86095742718", wantErr: true},
+		{name: "de: no repeated digit", country: "DE", value: "12345678903", wantErr: true},
+		{name: "de: leading zero", country: "DE", value: "06095742719", wantErr: true},
+		{name: "fr: numéro fiscal", country: "FR", value: "3023217600053"},
+		{name: "fr: invalid first digit", country: "FR", value: "4023217600053", wantErr: true},
+		{name: "gb: utr", country: "GB", value: "1234567890"},
+		{name: "gb: too short", country: "GB", value: "123456789", wantErr: true},
+		{name: "in: pan", country: "IN", value: "ABCPE1234F"},
+		{name: "in: invalid holder type", country: "IN", value: "ABCXE1234F", wantErr: true},
+		{name: "au: tfn", country: "AU", value: "123 456 782"},
+		{name: "au: wrong check digit", country: "AU", value: "123456789", wantErr: true},
+		{name: "empty string", country: "GB", value: ""},
+		{name: "unsupported country", country: "ZZ", value: "123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TaxID(tt.country).Validate(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, TaxID("GB").Validate("abc"), ErrTaxNumber)
+	assert.NotErrorIs(t, TaxID("ZZ").Validate("abc"), ErrTaxNumber)
+	assert.EqualError(t, TaxID("GB").Errf("Invalid UTR").Validate("abc"), "Invalid UTR")
+	assert.Equal(t, map[string]any{"country": "US"}, TaxID("us").Params())
+}
@@ -3,8 +3,8 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,36 +12,53 @@ import (
 var (
 	// ErrTimeBetween is returned when a time value is outside a specified range.
 	// The time must be after the start time and before the end time.
-	ErrTimeBetween = errors.New("time must be between the specified times")
+	ErrTimeBetween = newError("time_between", "time must be between the specified times")
 
 	// ErrBefore is returned when a time value is not before a specified time.
 	// By default, the time must be strictly before the reference time.
-	ErrBefore = errors.New("time must be before the specified time")
+	ErrBefore = newError("before", "time must be before the specified time")
 
 	// ErrAfter is returned when a time value is not after a specified time.
 	// By default, the time must be strictly after the reference time.
-	ErrAfter = errors.New("time must be after the specified time")
+	ErrAfter = newError("after", "time must be after the specified time")
 
 	// ErrDateFormat is returned when a string does not match the expected date format.
 	// The format should follow Go's time format specification.
-	ErrDateFormat = errors.New("invalid date format")
+	ErrDateFormat = newError("date_format", "invalid date format")
 
 	// ErrTimeFormat is returned when a string does not match the expected time format.
 	// The format should follow Go's time format specification.
-	ErrTimeFormat = errors.New("invalid time format")
+	ErrTimeFormat = newError("time_format", "invalid time format")
 
 	// ErrDateTimeFormat is returned when a string does not match the expected datetime format.
 	// The format should follow Go's time format specification.
-	ErrDateTimeFormat = errors.New("invalid datetime format")
+	ErrDateTimeFormat = newError("date_time_format", "invalid datetime format")
 
 	// ErrWeekend is returned when a time value is not a weekend day (Saturday or Sunday).
-	ErrWeekend = errors.New("time must be a weekend")
+	ErrWeekend = newError("weekend", "time must be a weekend")
 
 	// ErrWorkday is returned when a time value is not a workday (Monday through Friday).
-	ErrWorkday = errors.New("time must be a workday")
+	ErrWorkday = newError("workday", "time must be a workday")
 
 	// ErrHoliday is returned when a time value is not in the list of specified holidays.
-	ErrHoliday = errors.New("time must be a holiday")
+	ErrHoliday = newError("holiday", "time must be a holiday")
+
+	// ErrHolidayUnknown is returned when a holiday calendar implementing PartialCalendar
+	// has no data for the year of a time value, so it is unknown whether it is a holiday.
+	ErrHolidayUnknown = newError("holiday_unknown", "holidays are unknown for the year of the time")
+
+	// ErrWithinLast is returned when a time value is not within the given duration before now.
+	ErrWithinLast = newError("within_last", "time must be within the specified period before now")
+
+	// ErrWithinNext is returned when a time value is not within the given duration after now.
+	ErrWithinNext = newError("within_next", "time must be within the specified period after now")
+
+	// ErrBusinessHours is returned when a time value is outside the configured operating hours.
+	ErrBusinessHours = newError("business_hours", "time must be within business hours")
+
+	// ErrBusinessHoursConfig is returned by BusinessHours when its opening hours are invalid,
+	// such as a malformed clock time or a closing time that is not after the opening time.
+	ErrBusinessHoursConfig = newError("hours_config", "invalid business hours")
 )
 
 // TimeBetweenRule validates that a time falls within a specified range.
@@ -54,9 +71,12 @@ var (
 //	rule := TimeBetween(start, end).Errf("Date must be in 2023")
 //	err := rule.Validate(time.Now())  // returns nil if current time is in 2023
 type TimeBetweenRule struct {
-	start time.Time
-	end   time.Time
-	e     error
+	start          time.Time
+	end            time.Time
+	exclusiveStart bool
+	exclusiveEnd   bool
+	loc            *time.Location
+	e              error
 }
 
 // TimeBetween creates a new time range validation rule.
@@ -75,8 +95,63 @@ func TimeBetween(start, end time.Time) *TimeBetweenRule {
 	}
 }
 
+// ExclusiveStart excludes the start time from the range, so the time must be strictly after it.
+//
+// Example:
+//
+//	// Half-open interval (2023-01-01, 2024-01-01]
+//	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := TimeBetween(start, end).ExclusiveStart()
+func (r *TimeBetweenRule) ExclusiveStart() *TimeBetweenRule {
+	r.exclusiveStart = true
+	return r
+}
+
+// ExclusiveEnd excludes the end time from the range, so the time must be strictly before it.
+//
+// Example:
+//
+//	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := TimeBetween(start, end).ExclusiveEnd()
+//	err := rule.Validate(end)  // returns error
+func (r *TimeBetweenRule) ExclusiveEnd() *TimeBetweenRule {
+	r.exclusiveEnd = true
+	return r
+}
+
+// InLocation converts the validated value to loc, like Weekend and Weekday do, and
+// compares its wall clock with the wall clock of the start and end times, regardless of
+// the location they were created in. This allows bounds such as "2024-01-01 00:00" to mean
+// midnight in a specific zone whatever zone the validated value carries.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("Asia/Shanghai")
+//	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+//	rule := TimeBetween(start, end).ExclusiveEnd().InLocation(loc)  // January 2024 in Shanghai time
+func (r *TimeBetweenRule) InLocation(loc *time.Location) *TimeBetweenRule {
+	r.loc = loc
+	return r
+}
+
+// UTC compares the wall clock of the validated value in UTC with the wall clock of the
+// start and end times. It is shorthand for InLocation(time.UTC).
+//
+// Example:
+//
+//	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+//	end := time.Date(2024, 12, 31, 23, 59, 59, 0, time.Local)
+//	rule := TimeBetween(start, end).UTC()
+func (r *TimeBetweenRule) UTC() *TimeBetweenRule {
+	return r.InLocation(time.UTC)
+}
+
 // Validate checks if the given time falls within the specified range.
-// Returns nil if the time is between start and end (inclusive), or an error otherwise.
+// Returns nil if the time is between start and end (inclusive unless ExclusiveStart or
+// ExclusiveEnd was used), or an error otherwise.
 //
 // Example:
 //
@@ -86,7 +161,10 @@ func TimeBetween(start, end time.Time) *TimeBetweenRule {
 //	err := rule.Validate(time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC))  // returns nil
 //	err = rule.Validate(time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC))  // returns error
 func (r *TimeBetweenRule) Validate(value time.Time) error {
-	if value.Before(r.start) || value.After(r.end) {
+	start, end := wallClock(r.start, r.loc), wallClock(r.end, r.loc)
+	value = wallClock(valueIn(value, r.loc), r.loc)
+	if value.Before(start) || value.After(end) ||
+		(r.exclusiveStart && value.Equal(start)) || (r.exclusiveEnd && value.Equal(end)) {
 		if r.e != nil {
 			return r.e
 		}
@@ -95,6 +173,11 @@ func (r *TimeBetweenRule) Validate(value time.Time) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *TimeBetweenRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrTimeBetween)
+}
+
 // Errf sets a custom error message for time range validation failures.
 // This allows for context-specific error messages.
 //
@@ -105,7 +188,7 @@ func (r *TimeBetweenRule) Validate(value time.Time) error {
 //	rule := TimeBetween(start, end).Errf("Event date must be in 2023")
 func (r *TimeBetweenRule) Errf(format string, args ...any) *TimeBetweenRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -121,6 +204,7 @@ func (r *TimeBetweenRule) Errf(format string, args ...any) *TimeBetweenRule {
 type BeforeRule struct {
 	t           time.Time
 	includeTime bool
+	loc         *time.Location
 	e           error
 }
 
@@ -151,6 +235,29 @@ func (r *BeforeRule) IncludeTime() *BeforeRule {
 	return r
 }
 
+// InLocation converts the validated value to loc and compares its wall clock with the
+// wall clock of the reference time, regardless of the location it was created in.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	deadline := time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC)
+//	rule := Before(deadline).InLocation(loc)  // 2023-12-31 23:59:59 New York time
+func (r *BeforeRule) InLocation(loc *time.Location) *BeforeRule {
+	r.loc = loc
+	return r
+}
+
+// UTC compares the wall clock of the validated value in UTC with the wall clock of the
+// reference time. It is shorthand for InLocation(time.UTC).
+//
+// Example:
+//
+//	rule := Before(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)).UTC()
+func (r *BeforeRule) UTC() *BeforeRule {
+	return r.InLocation(time.UTC)
+}
+
 // Validate checks if the given time is before the reference time.
 // If IncludeTime() was called, the time can also be equal to the reference time.
 //
@@ -164,15 +271,16 @@ func (r *BeforeRule) IncludeTime() *BeforeRule {
 //	rule = Before(deadline).IncludeTime()
 //	err = rule.Validate(deadline)  // returns nil
 func (r *BeforeRule) Validate(value time.Time) error {
+	t, value := wallClock(r.t, r.loc), wallClock(valueIn(value, r.loc), r.loc)
 	if r.includeTime {
-		if !value.Before(r.t) && !value.Equal(r.t) {
+		if !value.Before(t) && !value.Equal(t) {
 			if r.e != nil {
 				return r.e
 			}
 			return ErrBefore
 		}
 	} else {
-		if !value.Before(r.t) {
+		if !value.Before(t) {
 			if r.e != nil {
 				return r.e
 			}
@@ -182,6 +290,11 @@ func (r *BeforeRule) Validate(value time.Time) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *BeforeRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrBefore)
+}
+
 // Errf sets a custom error message for "before time" validation failures.
 // This allows for context-specific error messages.
 //
@@ -191,7 +304,7 @@ func (r *BeforeRule) Validate(value time.Time) error {
 //	rule := Before(deadline).Errf("Submission must be before the deadline")
 func (r *BeforeRule) Errf(format string, args ...any) *BeforeRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -207,6 +320,7 @@ func (r *BeforeRule) Errf(format string, args ...any) *BeforeRule {
 type AfterRule struct {
 	t           time.Time
 	includeTime bool
+	loc         *time.Location
 	e           error
 }
 
@@ -237,6 +351,29 @@ func (r *AfterRule) IncludeTime() *AfterRule {
 	return r
 }
 
+// InLocation converts the validated value to loc and compares its wall clock with the
+// wall clock of the reference time, regardless of the location it was created in.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	startDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := After(startDate).InLocation(loc)  // 2023-01-01 00:00 New York time
+func (r *AfterRule) InLocation(loc *time.Location) *AfterRule {
+	r.loc = loc
+	return r
+}
+
+// UTC compares the wall clock of the validated value in UTC with the wall clock of the
+// reference time. It is shorthand for InLocation(time.UTC).
+//
+// Example:
+//
+//	rule := After(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)).UTC()
+func (r *AfterRule) UTC() *AfterRule {
+	return r.InLocation(time.UTC)
+}
+
 // Validate checks if the given time is after the reference time.
 // If IncludeTime() was called, the time can also be equal to the reference time.
 //
@@ -250,15 +387,16 @@ func (r *AfterRule) IncludeTime() *AfterRule {
 //	rule = After(startDate).IncludeTime()
 //	err = rule.Validate(startDate)  // returns nil
 func (r *AfterRule) Validate(value time.Time) error {
+	t, value := wallClock(r.t, r.loc), wallClock(valueIn(value, r.loc), r.loc)
 	if r.includeTime {
-		if !value.After(r.t) && !value.Equal(r.t) {
+		if !value.After(t) && !value.Equal(t) {
 			if r.e != nil {
 				return r.e
 			}
 			return ErrAfter
 		}
 	} else {
-		if !value.After(r.t) {
+		if !value.After(t) {
 			if r.e != nil {
 				return r.e
 			}
@@ -268,6 +406,11 @@ func (r *AfterRule) Validate(value time.Time) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *AfterRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrAfter)
+}
+
 // Errf sets a custom error message for "after time" validation failures.
 // This allows for context-specific error messages.
 //
@@ -277,11 +420,29 @@ func (r *AfterRule) Validate(value time.Time) error {
 //	rule := After(startDate).Errf("Event must start after January 1, 2023")
 func (r *AfterRule) Errf(format string, args ...any) *AfterRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
+// valueIn returns the validated value t converted to loc, or t if loc is nil.
+func valueIn(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// wallClock returns the wall clock of t as a time in UTC, so that wall clocks of times
+// in different locations can be compared. If loc is nil, t is returned unchanged and
+// times are compared as instants.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
 // DateFormatRule validates that a string matches a specified date format.
 // The format should follow Go's time format specification.
 //
@@ -319,7 +480,7 @@ func DateFormat(format string) *DateFormatRule {
 //	err = rule.Validate("12/31/2023")  // returns error
 //	err = rule.Validate("")  // returns nil (empty string is valid)
 func (r *DateFormatRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	_, err := time.Parse(r.format, value)
@@ -332,6 +493,11 @@ func (r *DateFormatRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *DateFormatRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDateFormat)
+}
+
 // Errf sets a custom error message for date format validation failures.
 // This allows for context-specific error messages.
 //
@@ -340,7 +506,7 @@ func (r *DateFormatRule) Validate(value string) error {
 //	rule := DateFormat("2006-01-02").Errf("Please enter the date in YYYY-MM-DD format")
 func (r *DateFormatRule) Errf(format string, args ...any) *DateFormatRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -382,7 +548,7 @@ func TimeFormat(format string) *TimeFormatRule {
 //	err = rule.Validate("2:30 PM")  // returns error
 //	err = rule.Validate("")  // returns nil (empty string is valid)
 func (r *TimeFormatRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	_, err := time.Parse(r.format, value)
@@ -395,6 +561,11 @@ func (r *TimeFormatRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *TimeFormatRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrTimeFormat)
+}
+
 // Errf sets a custom error message for time format validation failures.
 // This allows for context-specific error messages.
 //
@@ -403,7 +574,7 @@ func (r *TimeFormatRule) Validate(value string) error {
 //	rule := TimeFormat("15:04").Errf("Please enter the time in 24-hour format (HH:MM)")
 func (r *TimeFormatRule) Errf(format string, args ...any) *TimeFormatRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -445,7 +616,7 @@ func DateTimeFormat(format string) *DateTimeFormatRule {
 //	err = rule.Validate("12/31/2023 2:30 PM")  // returns error
 //	err = rule.Validate("")  // returns nil (empty string is valid)
 func (r *DateTimeFormatRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	_, err := time.Parse(r.format, value)
@@ -458,6 +629,11 @@ func (r *DateTimeFormatRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *DateTimeFormatRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrDateTimeFormat)
+}
+
 // Errf sets a custom error message for datetime format validation failures.
 // This allows for context-specific error messages.
 //
@@ -466,12 +642,131 @@ func (r *DateTimeFormatRule) Validate(value string) error {
 //	rule := DateTimeFormat("2006-01-02 15:04:05").Errf("Please enter the date and time in YYYY-MM-DD HH:MM:SS format")
 func (r *DateTimeFormatRule) Errf(format string, args ...any) *DateTimeFormatRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// DateStringBetweenRule validates that a string matches a specified date layout and that
+// the parsed time falls within an inclusive range.
+//
+// Example:
+//
+//	min := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	max := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+//	rule := DateStringBetween("2006-01-02", min, max)
+//	err := rule.Validate("2024-06-15")  // returns nil
+//	err = rule.Validate("2025-01-01")   // returns ErrTimeBetween
+//	err = rule.Validate("15/06/2024")   // returns ErrDateFormat
+type DateStringBetweenRule struct {
+	format  string
+	min     time.Time
+	max     time.Time
+	loc     *time.Location
+	e       error
+	formatE error
+}
+
+// DateStringBetween creates a new rule that parses a string with the given layout and
+// then ensures the result is between min and max (inclusive), so the value does not have
+// to be parsed once for DateFormat and again for TimeBetween.
+// Strings without zone information are parsed as UTC unless InLocation is used.
+//
+// Example:
+//
+//	type Booking struct {
+//	    CheckIn string `json:"check_in"`
+//	}
+//
+//	today := time.Now().Truncate(24 * time.Hour)
+//	rule := DateStringBetween(time.DateOnly, today, today.AddDate(0, 6, 0)).Errf("Check-in must be within the next 6 months")
+func DateStringBetween(format string, min, max time.Time) *DateStringBetweenRule {
+	return &DateStringBetweenRule{
+		format:  format,
+		min:     min,
+		max:     max,
+		e:       ErrTimeBetween,
+		formatE: ErrDateFormat,
+	}
+}
+
+// InLocation parses strings without zone information in loc instead of UTC.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("Asia/Shanghai")
+//	rule := DateStringBetween("2006-01-02 15:04", min, max).InLocation(loc)
+func (r *DateStringBetweenRule) InLocation(loc *time.Location) *DateStringBetweenRule {
+	r.loc = loc
+	return r
+}
+
+// Validate checks if the string matches the layout and the parsed time is within range.
+// Empty strings are considered valid (use Required() if needed).
+// A custom error set via Errf is returned for both parse and range failures.
+//
+// Example:
+//
+//	rule := DateStringBetween("2006-01-02", min, max)
+//	err := rule.Validate("2024-06-15")  // returns nil
+//	err = rule.Validate("")             // returns nil (empty string is valid)
+func (r *DateStringBetweenRule) Validate(value string) error {
+	if skipEmpty(value) {
+		return nil
+	}
+	t, err := time.ParseInLocation(r.format, value, Ternary(r.loc != nil, r.loc, time.UTC))
+	if err != nil {
+		if r.formatE != nil {
+			return r.formatE
+		}
+		return ErrDateFormat
+	}
+	if t.Before(r.min) || t.After(r.max) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrTimeBetween
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *DateStringBetweenRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrTimeBetween)
+}
+
+// Errf sets a custom error message for date string validation failures.
+// The message replaces both the format and the range error.
+//
+// Example:
+//
+//	rule := DateStringBetween("2006-01-02", min, max).Errf("Please enter a date in 2024 as YYYY-MM-DD")
+func (r *DateStringBetweenRule) Errf(format string, args ...any) *DateStringBetweenRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+		r.formatE = wrapf(r.formatE, format, args...)
 	}
 	return r
 }
 
-// WeekendRule validates that a time falls on a weekend day (Saturday or Sunday).
+// defaultWeekendDays are the weekend days used when none are configured.
+var defaultWeekendDays = []time.Weekday{time.Saturday, time.Sunday}
+
+// isWeekendDay reports whether day is one of the weekend days.
+// A nil days slice means the default Saturday/Sunday weekend.
+func isWeekendDay(days []time.Weekday, day time.Weekday) bool {
+	if days == nil {
+		days = defaultWeekendDays
+	}
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// WeekendRule validates that a time falls on a weekend day (Saturday or Sunday by default).
 //
 // Example:
 //
@@ -480,11 +775,14 @@ func (r *DateTimeFormatRule) Errf(format string, args ...any) *DateTimeFormatRul
 //	err = rule.Validate(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))  // returns nil (Sunday)
 //	err = rule.Validate(time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC))  // returns error (Friday)
 type WeekendRule struct {
-	e error
+	days []time.Weekday
+	loc  *time.Location
+	e    error
 }
 
 // Weekend creates a new weekend validation rule.
-// The rule ensures that a time value falls on a Saturday or Sunday.
+// The rule ensures that a time value falls on a Saturday or Sunday,
+// unless other weekend days are configured with WeekendDays.
 //
 // Example:
 //
@@ -495,7 +793,41 @@ func Weekend() *WeekendRule {
 	}
 }
 
-// Validate checks if the given time falls on a weekend day (Saturday or Sunday).
+// WeekendDays sets the days that make up the weekend, replacing the default Saturday and Sunday.
+//
+// Example:
+//
+//	// Friday/Saturday weekend used in parts of the Middle East
+//	rule := Weekend().WeekendDays(time.Friday, time.Saturday)
+func (r *WeekendRule) WeekendDays(days ...time.Weekday) *WeekendRule {
+	r.days = append([]time.Weekday{}, days...)
+	return r
+}
+
+// InLocation converts the validated value to loc before determining its weekday,
+// instead of using the location the value carries.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("Asia/Tokyo")
+//	rule := Weekend().InLocation(loc)
+//	err := rule.Validate(time.Date(2023, 12, 29, 20, 0, 0, 0, time.UTC))  // returns nil (Saturday in Tokyo)
+func (r *WeekendRule) InLocation(loc *time.Location) *WeekendRule {
+	r.loc = loc
+	return r
+}
+
+// UTC determines the weekday of the validated value in UTC.
+// It is shorthand for InLocation(time.UTC).
+//
+// Example:
+//
+//	rule := Weekend().UTC()
+func (r *WeekendRule) UTC() *WeekendRule {
+	return r.InLocation(time.UTC)
+}
+
+// Validate checks if the given time falls on a weekend day.
 //
 // Example:
 //
@@ -504,8 +836,10 @@ func Weekend() *WeekendRule {
 //	err = rule.Validate(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))  // returns nil (Sunday)
 //	err = rule.Validate(time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC))  // returns error (Friday)
 func (r *WeekendRule) Validate(value time.Time) error {
-	weekday := value.Weekday()
-	if weekday != time.Saturday && weekday != time.Sunday {
+	if r.loc != nil {
+		value = value.In(r.loc)
+	}
+	if !isWeekendDay(r.days, value.Weekday()) {
 		if r.e != nil {
 			return r.e
 		}
@@ -514,6 +848,11 @@ func (r *WeekendRule) Validate(value time.Time) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *WeekendRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrWeekend)
+}
+
 // Errf sets a custom error message for weekend validation failures.
 // This allows for context-specific error messages.
 //
@@ -522,12 +861,13 @@ func (r *WeekendRule) Validate(value time.Time) error {
 //	rule := Weekend().Errf("This event is only available on weekends")
 func (r *WeekendRule) Errf(format string, args ...any) *WeekendRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
-// WorkdayRule validates that a time falls on a workday (Monday through Friday).
+// WorkdayRule validates that a time falls on a workday (Monday through Friday by default).
+// Holidays can be excluded so that they are not treated as workdays.
 //
 // Example:
 //
@@ -535,11 +875,14 @@ func (r *WeekendRule) Errf(format string, args ...any) *WeekendRule {
 //	err := rule.Validate(time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC))  // returns nil (Friday)
 //	err = rule.Validate(time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC))  // returns error (Saturday)
 type WorkdayRule struct {
-	e error
+	days      []time.Weekday
+	calendars []HolidayCalendar
+	e         error
 }
 
 // Workday creates a new workday validation rule.
-// The rule ensures that a time value falls on a Monday through Friday.
+// The rule ensures that a time value falls on a Monday through Friday,
+// unless other weekend days are configured with WeekendDays.
 //
 // Example:
 //
@@ -550,7 +893,44 @@ func Workday() *WorkdayRule {
 	}
 }
 
-// Validate checks if the given time falls on a workday (Monday through Friday).
+// WeekendDays sets the days that are not workdays, replacing the default Saturday and Sunday.
+//
+// Example:
+//
+//	// Sunday to Thursday working week
+//	rule := Workday().WeekendDays(time.Friday, time.Saturday)
+func (r *WorkdayRule) WeekendDays(days ...time.Weekday) *WorkdayRule {
+	r.days = append([]time.Weekday{}, days...)
+	return r
+}
+
+// Holidays adds dates that are not workdays even if they fall on a regular working day.
+// Dates are compared by year, month and day.
+//
+// Example:
+//
+//	newYear := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	christmas := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+//	rule := Workday().Holidays(newYear, christmas)
+func (r *WorkdayRule) Holidays(dates ...time.Time) *WorkdayRule {
+	return r.ExcludeHolidays(HolidayList(dates...))
+}
+
+// ExcludeHolidays adds holiday calendars whose holidays are not workdays.
+// Calendars implementing AdjustedWorkdayCalendar can also turn weekend days into workdays.
+// Dates that a calendar implementing PartialCalendar does not cover fail with
+// ErrHolidayUnknown.
+//
+// Example:
+//
+//	rule := Workday().ExcludeHolidays(CNStatutoryHolidays())
+//	rule = Workday().ExcludeHolidays(USFederalHolidays(), HolidayList(companyDays...))
+func (r *WorkdayRule) ExcludeHolidays(calendars ...HolidayCalendar) *WorkdayRule {
+	r.calendars = append(r.calendars, calendars...)
+	return r
+}
+
+// Validate checks if the given time falls on a workday that is not an excluded holiday.
 //
 // Example:
 //
@@ -559,8 +939,10 @@ func Workday() *WorkdayRule {
 //	err = rule.Validate(time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC))  // returns error (Saturday)
 //	err = rule.Validate(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))  // returns error (Sunday)
 func (r *WorkdayRule) Validate(value time.Time) error {
-	weekday := value.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
+	if !covered(value, r.calendars...) {
+		return ErrHolidayUnknown
+	}
+	if !r.isWorkday(value) {
 		if r.e != nil {
 			return r.e
 		}
@@ -569,6 +951,39 @@ func (r *WorkdayRule) Validate(value time.Time) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *WorkdayRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrWorkday)
+}
+
+// isWorkday reports whether value is a working day according to the weekend days and calendars.
+func (r *WorkdayRule) isWorkday(value time.Time) bool {
+	for _, calendar := range r.calendars {
+		if _, ok := calendar.Lookup(value); ok {
+			return false
+		}
+	}
+	if !isWeekendDay(r.days, value.Weekday()) {
+		return true
+	}
+	for _, calendar := range r.calendars {
+		if adjusted, ok := calendar.(AdjustedWorkdayCalendar); ok && adjusted.IsAdjustedWorkday(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// covered reports whether every calendar implementing PartialCalendar covers value.
+func covered(value time.Time, calendars ...HolidayCalendar) bool {
+	for _, calendar := range calendars {
+		if partial, ok := calendar.(PartialCalendar); ok && !partial.Covers(value) {
+			return false
+		}
+	}
+	return true
+}
+
 // Errf sets a custom error message for workday validation failures.
 // This allows for context-specific error messages.
 //
@@ -577,12 +992,12 @@ func (r *WorkdayRule) Validate(value time.Time) error {
 //	rule := Workday().Errf("Appointments are only available on weekdays")
 func (r *WorkdayRule) Errf(format string, args ...any) *WorkdayRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
 
-// HolidayRule validates that a time falls on one of the specified holidays.
+// HolidayRule validates that a time falls on a holiday of a HolidayCalendar.
 //
 // Example:
 //
@@ -593,12 +1008,13 @@ func (r *WorkdayRule) Errf(format string, args ...any) *WorkdayRule {
 //	err = rule.Validate(newYear)    // returns nil
 //	err = rule.Validate(time.Now()) // returns error if not a holiday
 type HolidayRule struct {
-	holidays []time.Time
+	calendar HolidayCalendar
 	e        error
 }
 
 // Holiday creates a new holiday validation rule.
 // The rule ensures that a time value falls on one of the specified holidays.
+// It is a shorthand for HolidayIn(HolidayList(holidays...)).
 //
 // Example:
 //
@@ -606,13 +1022,25 @@ type HolidayRule struct {
 //	newYear := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 //	rule := Holiday(christmas, newYear)
 func Holiday(holidays ...time.Time) *HolidayRule {
+	return HolidayIn(HolidayList(holidays...))
+}
+
+// HolidayIn creates a new holiday validation rule backed by a holiday calendar.
+// Dates that a calendar implementing PartialCalendar does not cover fail with
+// ErrHolidayUnknown.
+//
+// Example:
+//
+//	rule := HolidayIn(USFederalHolidays())
+//	err := rule.Validate(time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC))  // returns nil (Independence Day)
+func HolidayIn(calendar HolidayCalendar) *HolidayRule {
 	return &HolidayRule{
-		holidays: holidays,
+		calendar: calendar,
 		e:        ErrHoliday,
 	}
 }
 
-// Validate checks if the given time falls on one of the specified holidays.
+// Validate checks if the given time falls on a holiday of the calendar.
 // The comparison is done by checking if the year, month, and day match.
 //
 // Example:
@@ -624,10 +1052,11 @@ func Holiday(holidays ...time.Time) *HolidayRule {
 //	err = rule.Validate(newYear)    // returns nil
 //	err = rule.Validate(time.Date(2023, 12, 26, 0, 0, 0, 0, time.UTC))  // returns error
 func (r *HolidayRule) Validate(value time.Time) error {
-	valueYear, valueMonth, valueDay := value.Date()
-	for _, holiday := range r.holidays {
-		holidayYear, holidayMonth, holidayDay := holiday.Date()
-		if valueYear == holidayYear && valueMonth == holidayMonth && valueDay == holidayDay {
+	if r.calendar != nil {
+		if !covered(value, r.calendar) {
+			return ErrHolidayUnknown
+		}
+		if _, ok := r.calendar.Lookup(value); ok {
 			return nil
 		}
 	}
@@ -637,6 +1066,11 @@ func (r *HolidayRule) Validate(value time.Time) error {
 	return ErrHoliday
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *HolidayRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrHoliday)
+}
+
 // Errf sets a custom error message for holiday validation failures.
 // This allows for context-specific error messages.
 //
@@ -647,7 +1081,339 @@ func (r *HolidayRule) Validate(value time.Time) error {
 //	rule := Holiday(christmas, newYear).Errf("This service is only available on holidays")
 func (r *HolidayRule) Errf(format string, args ...any) *HolidayRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// WithinLastRule validates that a time falls within a sliding window ending now,
+// i.e. between now-d and now (inclusive).
+//
+// Example:
+//
+//	rule := WithinLast(24 * time.Hour).Errf("Report must be from the last 24 hours")
+//	err := rule.Validate(time.Now().Add(-time.Hour))     // returns nil
+//	err = rule.Validate(time.Now().Add(-48 * time.Hour))  // returns error
+type WithinLastRule struct {
+	d   time.Duration
+	now func() time.Time
+	e   error
+}
+
+// WithinLast creates a new rule that validates a time falls within the last d.
+// Times in the future are rejected.
+//
+// Example:
+//
+//	rule := WithinLast(30 * 24 * time.Hour)  // within the last 30 days
+func WithinLast(d time.Duration) *WithinLastRule {
+	return &WithinLastRule{
+		d:   d,
+		now: time.Now,
+		e:   ErrWithinLast,
+	}
+}
+
+// Clock sets the function used to obtain the current time, which is time.Now by default.
+// This is useful for deterministic tests or when validating against a reference time.
+//
+// Example:
+//
+//	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := WithinLast(time.Hour).Clock(func() time.Time { return ref })
+func (r *WithinLastRule) Clock(now func() time.Time) *WithinLastRule {
+	if now != nil {
+		r.now = now
+	}
+	return r
+}
+
+// Validate checks if the given time is between now-d and now (inclusive).
+//
+// Example:
+//
+//	rule := WithinLast(time.Hour)
+//	err := rule.Validate(time.Now().Add(-30 * time.Minute))  // returns nil
+//	err = rule.Validate(time.Now().Add(time.Minute))         // returns error (in the future)
+func (r *WithinLastRule) Validate(value time.Time) error {
+	now := Ternary(r.now == nil, time.Now, r.now)()
+	if value.Before(now.Add(-r.d)) || value.After(now) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrWithinLast
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *WithinLastRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrWithinLast)
+}
+
+// Errf sets a custom error message for sliding window validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := WithinLast(7 * 24 * time.Hour).Errf("Receipt must be from the last 7 days")
+func (r *WithinLastRule) Errf(format string, args ...any) *WithinLastRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// WithinNextRule validates that a time falls within a sliding window starting now,
+// i.e. between now and now+d (inclusive).
+//
+// Example:
+//
+//	rule := WithinNext(90 * 24 * time.Hour).Errf("Event must start within 90 days")
+//	err := rule.Validate(time.Now().Add(24 * time.Hour))   // returns nil
+//	err = rule.Validate(time.Now().Add(-24 * time.Hour))   // returns error
+type WithinNextRule struct {
+	d   time.Duration
+	now func() time.Time
+	e   error
+}
+
+// WithinNext creates a new rule that validates a time falls within the next d.
+// Times in the past are rejected.
+//
+// Example:
+//
+//	rule := WithinNext(90 * 24 * time.Hour)  // within the next 90 days
+func WithinNext(d time.Duration) *WithinNextRule {
+	return &WithinNextRule{
+		d:   d,
+		now: time.Now,
+		e:   ErrWithinNext,
+	}
+}
+
+// Clock sets the function used to obtain the current time, which is time.Now by default.
+// This is useful for deterministic tests or when validating against a reference time.
+//
+// Example:
+//
+//	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	rule := WithinNext(time.Hour).Clock(func() time.Time { return ref })
+func (r *WithinNextRule) Clock(now func() time.Time) *WithinNextRule {
+	if now != nil {
+		r.now = now
+	}
+	return r
+}
+
+// Validate checks if the given time is between now and now+d (inclusive).
+//
+// Example:
+//
+//	rule := WithinNext(time.Hour)
+//	err := rule.Validate(time.Now().Add(30 * time.Minute))  // returns nil
+//	err = rule.Validate(time.Now().Add(2 * time.Hour))      // returns error
+func (r *WithinNextRule) Validate(value time.Time) error {
+	now := Ternary(r.now == nil, time.Now, r.now)()
+	if value.Before(now) || value.After(now.Add(r.d)) {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrWithinNext
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *WithinNextRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrWithinNext)
+}
+
+// Errf sets a custom error message for sliding window validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := WithinNext(90 * 24 * time.Hour).Errf("Event must start within 90 days")
+func (r *WithinNextRule) Errf(format string, args ...any) *WithinNextRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
+	}
+	return r
+}
+
+// clockRange is a half-open [open, close) range of seconds since midnight.
+type clockRange struct {
+	open  int
+	close int
+}
+
+// parseClock parses an "HH:MM" or "HH:MM:SS" clock time into seconds since midnight.
+// "24:00" is accepted to express the end of the day.
+func parseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, wrapf(ErrBusinessHoursConfig, "invalid clock time %q", value)
+	}
+	limits := []int{24, 59, 59}
+	secs := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || len(part) != 2 || n < 0 || n > limits[i] {
+			return 0, wrapf(ErrBusinessHoursConfig, "invalid clock time %q", value)
+		}
+		secs = secs*60 + n
+	}
+	if len(parts) == 2 {
+		secs *= 60
+	}
+	if secs > 24*60*60 {
+		return 0, wrapf(ErrBusinessHoursConfig, "invalid clock time %q", value)
+	}
+	return secs, nil
+}
+
+// BusinessHoursRule validates that a time falls within configurable operating hours.
+// Each weekday has its own opening hours; days without hours are treated as closed.
+// The opening time is inclusive and the closing time is exclusive.
+//
+// Example:
+//
+//	rule := BusinessHours("09:00", "18:00")  // Monday to Friday, 09:00-18:00
+//	err := rule.Validate(time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC))  // returns nil (Friday 10:00)
+//	err = rule.Validate(time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC))   // returns error (closed at 18:00)
+//	err = rule.Validate(time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC))   // returns error (Saturday)
+type BusinessHoursRule struct {
+	schedule [7]*clockRange
+	loc      *time.Location
+	invalid  error
+	e        error
+}
+
+// BusinessHours creates a new business hours validation rule.
+// open and close are clock times in "HH:MM" or "HH:MM:SS" format ("24:00" is allowed as close).
+// If no days are given, Monday through Friday are used.
+// An invalid clock time or a close time that is not after the open time makes the rule always
+// fail with ErrBusinessHoursConfig.
+//
+// Example:
+//
+//	// Weekdays 09:00-17:30
+//	rule := BusinessHours("09:00", "17:30")
+//
+//	// Every day 10:00-22:00 in Shanghai time
+//	loc, _ := time.LoadLocation("Asia/Shanghai")
+//	rule = BusinessHours("10:00", "22:00",
+//	    time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+//	    time.Friday, time.Saturday, time.Sunday,
+//	).InLocation(loc)
+func BusinessHours(open, close string, days ...time.Weekday) *BusinessHoursRule {
+	r := &BusinessHoursRule{e: ErrBusinessHours}
+	if len(days) == 0 {
+		days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	for _, day := range days {
+		r.Day(day, open, close)
+	}
+	return r
+}
+
+// Day sets the opening hours for a single weekday, replacing any hours set before.
+// This allows per-weekday schedules such as shorter hours on Saturday.
+//
+// Example:
+//
+//	rule := BusinessHours("09:00", "18:00").
+//	    Day(time.Friday, "09:00", "15:00").
+//	    Day(time.Saturday, "10:00", "14:00")
+func (r *BusinessHoursRule) Day(day time.Weekday, open, close string) *BusinessHoursRule {
+	if day < time.Sunday || day > time.Saturday {
+		r.invalid = wrapf(ErrBusinessHoursConfig, "invalid weekday %d", day)
+		return r
+	}
+	o, err := parseClock(open)
+	if err != nil {
+		r.invalid = err
+		return r
+	}
+	c, err := parseClock(close)
+	if err != nil {
+		r.invalid = err
+		return r
+	}
+	if c <= o {
+		r.invalid = wrapf(ErrBusinessHoursConfig, "closing time %q must be after opening time %q", close, open)
+		return r
+	}
+	r.schedule[day] = &clockRange{open: o, close: c}
+	return r
+}
+
+// Closed marks the given weekdays as closed.
+//
+// Example:
+//
+//	rule := BusinessHours("09:00", "18:00").Closed(time.Wednesday)
+func (r *BusinessHoursRule) Closed(days ...time.Weekday) *BusinessHoursRule {
+	for _, day := range days {
+		if day >= time.Sunday && day <= time.Saturday {
+			r.schedule[day] = nil
+		}
+	}
+	return r
+}
+
+// InLocation sets the time zone in which the weekday and clock time are evaluated.
+// By default the location carried by the validated value is used.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	rule := BusinessHours("09:00", "17:00").InLocation(loc)
+func (r *BusinessHoursRule) InLocation(loc *time.Location) *BusinessHoursRule {
+	r.loc = loc
+	return r
+}
+
+// Validate checks if the given time falls within the opening hours of its weekday.
+//
+// Example:
+//
+//	rule := BusinessHours("09:00", "18:00")
+//	err := rule.Validate(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))    // returns nil
+//	err = rule.Validate(time.Date(2024, 3, 15, 8, 59, 0, 0, time.UTC))   // returns error
+func (r *BusinessHoursRule) Validate(value time.Time) error {
+	if r.invalid != nil {
+		return r.invalid
+	}
+	if r.loc != nil {
+		value = value.In(r.loc)
+	}
+	hours := r.schedule[value.Weekday()]
+	clock := value.Hour()*3600 + value.Minute()*60 + value.Second()
+	if hours == nil || clock < hours.open || clock >= hours.close {
+		if r.e != nil {
+			return r.e
+		}
+		return ErrBusinessHours
+	}
+	return nil
+}
+
+// Describe returns the name, parameters and message of the rule.
+func (r *BusinessHoursRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrBusinessHours)
+}
+
+// Errf sets a custom error message for business hours validation failures.
+// This allows for context-specific error messages.
+//
+// Example:
+//
+//	rule := BusinessHours("09:00", "18:00").Errf("Orders can only be placed during business hours")
+func (r *BusinessHoursRule) Errf(format string, args ...any) *BusinessHoursRule {
+	if format != "" {
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
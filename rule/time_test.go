@@ -36,6 +36,30 @@ func TestTimeBetween(t *testing.T) {
 			value:   after,
 			wantErr: true,
 		},
+		{
+			name:    "valid: start is inclusive by default",
+			rule:    TimeBetween(before, after),
+			value:   before,
+			wantErr: false,
+		},
+		{
+			name:    "invalid: exclusive start",
+			rule:    TimeBetween(before, after).ExclusiveStart(),
+			value:   before,
+			wantErr: true,
+		},
+		{
+			name:    "valid: exclusive end allows start",
+			rule:    TimeBetween(before, after).ExclusiveEnd(),
+			value:   before,
+			wantErr: false,
+		},
+		{
+			name:    "invalid: exclusive end",
+			rule:    TimeBetween(before, after).ExclusiveEnd(),
+			value:   after,
+			wantErr: true,
+		},
 		{
 			name:    "custom error message",
 			rule:    TimeBetween(before, after).Errf("custom error"),
@@ -555,3 +579,236 @@ func TestAfterIncludeFallback(t *testing.T) {
 	err := (&AfterRule{t: now, includeTime: true}).Validate(before)
 	assert.Error(t, err)
 }
+
+func TestWithinLast(t *testing.T) {
+	ref := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return ref }
+
+	tests := []struct {
+		name    string
+		rule    *WithinLastRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: inside window", rule: WithinLast(24 * time.Hour).Clock(clock), value: ref.Add(-time.Hour), wantErr: false},
+		{name: "valid: window start", rule: WithinLast(24 * time.Hour).Clock(clock), value: ref.Add(-24 * time.Hour), wantErr: false},
+		{name: "valid: now", rule: WithinLast(24 * time.Hour).Clock(clock), value: ref, wantErr: false},
+		{name: "invalid: before window", rule: WithinLast(24 * time.Hour).Clock(clock), value: ref.Add(-25 * time.Hour), wantErr: true},
+		{name: "invalid: in the future", rule: WithinLast(24 * time.Hour).Clock(clock), value: ref.Add(time.Second), wantErr: true},
+		{name: "custom error message", rule: WithinLast(time.Hour).Clock(clock).Errf("custom error"), value: ref.Add(-2 * time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithinLastRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.Nil(t, WithinLast(time.Hour).Validate(time.Now().Add(-time.Minute)))
+}
+
+func TestWithinNext(t *testing.T) {
+	ref := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return ref }
+
+	tests := []struct {
+		name    string
+		rule    *WithinNextRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: inside window", rule: WithinNext(90 * 24 * time.Hour).Clock(clock), value: ref.Add(24 * time.Hour), wantErr: false},
+		{name: "valid: window end", rule: WithinNext(90 * 24 * time.Hour).Clock(clock), value: ref.Add(90 * 24 * time.Hour), wantErr: false},
+		{name: "invalid: after window", rule: WithinNext(90 * 24 * time.Hour).Clock(clock), value: ref.Add(91 * 24 * time.Hour), wantErr: true},
+		{name: "invalid: in the past", rule: WithinNext(90 * 24 * time.Hour).Clock(clock), value: ref.Add(-time.Second), wantErr: true},
+		{name: "custom error message", rule: WithinNext(time.Hour).Clock(clock).Errf("custom error"), value: ref.Add(2 * time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithinNextRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.Nil(t, WithinNext(time.Hour).Validate(time.Now().Add(time.Minute)))
+}
+
+func TestWithinFallback(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	assert.ErrorIs(t, (&WithinLastRule{d: time.Minute}).Validate(past), ErrWithinLast)
+	assert.ErrorIs(t, (&WithinNextRule{d: time.Minute}).Validate(past), ErrWithinNext)
+}
+
+func TestBusinessHours(t *testing.T) {
+	friday := func(hour, minute int) time.Time { return time.Date(2024, 3, 15, hour, minute, 0, 0, time.UTC) }
+	saturday := func(hour, minute int) time.Time { return time.Date(2024, 3, 16, hour, minute, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		rule    *BusinessHoursRule
+		value   time.Time
+		wantErr bool
+	}{
+		{name: "valid: during opening hours", rule: BusinessHours("09:00", "18:00"), value: friday(10, 0), wantErr: false},
+		{name: "valid: opening time is inclusive", rule: BusinessHours("09:00", "18:00"), value: friday(9, 0), wantErr: false},
+		{name: "invalid: closing time is exclusive", rule: BusinessHours("09:00", "18:00"), value: friday(18, 0), wantErr: true},
+		{name: "invalid: before opening", rule: BusinessHours("09:00", "18:00"), value: friday(8, 59), wantErr: true},
+		{name: "invalid: weekend by default", rule: BusinessHours("09:00", "18:00"), value: saturday(10, 0), wantErr: true},
+		{name: "valid: explicit days", rule: BusinessHours("09:00", "18:00", time.Saturday), value: saturday(10, 0), wantErr: false},
+		{name: "valid: per-day schedule", rule: BusinessHours("09:00", "18:00").Day(time.Saturday, "10:00", "14:00"), value: saturday(13, 59), wantErr: false},
+		{name: "invalid: per-day schedule", rule: BusinessHours("09:00", "18:00").Day(time.Friday, "09:00", "15:00"), value: friday(16, 0), wantErr: true},
+		{name: "invalid: closed day", rule: BusinessHours("09:00", "18:00").Closed(time.Friday), value: friday(10, 0), wantErr: true},
+		{name: "valid: end of day", rule: BusinessHours("00:00", "24:00"), value: friday(23, 59), wantErr: false},
+		{name: "valid: seconds precision", rule: BusinessHours("09:00:30", "18:00"), value: friday(9, 1), wantErr: false},
+		{name: "valid: in location", rule: BusinessHours("09:00", "18:00").InLocation(time.FixedZone("UTC+8", 8*3600)), value: friday(2, 0), wantErr: false},
+		{name: "invalid: in location", rule: BusinessHours("09:00", "18:00").InLocation(time.FixedZone("UTC+8", 8*3600)), value: friday(12, 0), wantErr: true},
+		{name: "invalid: malformed clock", rule: BusinessHours("9am", "18:00"), value: friday(10, 0), wantErr: true},
+		{name: "invalid: close before open", rule: BusinessHours("18:00", "09:00"), value: friday(10, 0), wantErr: true},
+		{name: "invalid: out of range clock", rule: BusinessHours("09:00", "24:01"), value: friday(10, 0), wantErr: true},
+		{name: "invalid: weekday", rule: BusinessHours("09:00", "18:00", time.Weekday(7)), value: friday(10, 0), wantErr: true},
+		{name: "custom error message", rule: BusinessHours("09:00", "18:00").Errf("custom error"), value: friday(20, 0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BusinessHoursRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBusinessHoursFallback(t *testing.T) {
+	err := (&BusinessHoursRule{}).Validate(time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrBusinessHours)
+}
+
+func TestBusinessHoursConfig(t *testing.T) {
+	friday := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rule *BusinessHoursRule
+		want string
+	}{
+		{name: "malformed clock", rule: BusinessHours("9am", "18:00"), want: `invalid clock time "9am"`},
+		{name: "out of range clock", rule: BusinessHours("09:00", "24:01"), want: `invalid clock time "24:01"`},
+		{name: "close before open", rule: BusinessHours("18:00", "09:00"), want: `closing time "09:00" must be after opening time "18:00"`},
+		{name: "weekday", rule: BusinessHours("09:00", "18:00", time.Weekday(7)), want: "invalid weekday 7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(friday)
+			assert.EqualError(t, err, tt.want)
+			assert.ErrorIs(t, err, ErrBusinessHoursConfig)
+			assert.Equal(t, "hours_config", ErrorCode(err))
+		})
+	}
+}
+
+func TestWeekendDays(t *testing.T) {
+	thursday := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	weekend := Weekend().WeekendDays(time.Friday, time.Saturday)
+	assert.Nil(t, weekend.Validate(friday))
+	assert.ErrorIs(t, weekend.Validate(sunday), ErrWeekend)
+
+	workday := Workday().WeekendDays(time.Friday, time.Saturday)
+	assert.Nil(t, workday.Validate(sunday))
+	assert.Nil(t, workday.Validate(thursday))
+	assert.ErrorIs(t, workday.Validate(friday), ErrWorkday)
+}
+
+func TestWorkdayHolidays(t *testing.T) {
+	newYear := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC)
+
+	rule := Workday().Holidays(newYear)
+	assert.ErrorIs(t, rule.Validate(newYear.Add(10*time.Hour)), ErrWorkday)
+	assert.Nil(t, rule.Validate(nextDay))
+	assert.EqualError(t, Workday().Holidays(newYear).Errf("custom error").Validate(newYear), "custom error")
+}
+
+func TestTimeInLocation(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	// 2023-12-31 20:00 UTC is already 2024-01-01 04:00 in Shanghai.
+	value := time.Date(2023, 12, 31, 20, 0, 0, 0, time.UTC)
+	assert.Error(t, TimeBetween(start, end).Validate(value))
+	assert.Nil(t, TimeBetween(start, end).InLocation(shanghai).Validate(value))
+	// Bounds created in Shanghai time are read as 08:00 UTC.
+	assert.Error(t, TimeBetween(start.In(shanghai), end.In(shanghai)).UTC().Validate(start))
+	assert.Nil(t, TimeBetween(start.In(shanghai), end.In(shanghai)).UTC().Validate(start.Add(8*time.Hour)))
+
+	assert.Nil(t, Before(start).Validate(value))
+	assert.Error(t, Before(start).InLocation(shanghai).Validate(value))
+	assert.Error(t, After(start).Validate(value))
+	assert.Nil(t, After(start).InLocation(shanghai).Validate(value))
+	assert.Error(t, After(start.In(shanghai)).UTC().Validate(start.Add(time.Second)))
+	assert.Nil(t, Before(start.In(shanghai)).UTC().Validate(start.Add(time.Second)))
+
+	// The value is converted to the location, whatever location it carries.
+	between := TimeBetween(start, end).ExclusiveEnd().InLocation(shanghai)
+	assert.Nil(t, between.Validate(time.Date(2023, 12, 31, 16, 30, 0, 0, time.UTC)))
+	assert.Error(t, between.Validate(time.Date(2023, 12, 31, 15, 30, 0, 0, time.UTC)))
+	assert.Error(t, between.Validate(time.Date(2024, 1, 31, 17, 30, 0, 0, time.UTC)))
+	assert.Nil(t, between.Validate(time.Date(2024, 1, 31, 23, 30, 0, 0, shanghai)))
+	assert.Error(t, TimeBetween(start, end).UTC().Validate(time.Date(2024, 1, 1, 0, 30, 0, 0, shanghai)))
+	assert.Nil(t, Before(end).InLocation(shanghai).Validate(time.Date(2024, 1, 31, 15, 30, 0, 0, time.UTC)))
+	assert.Error(t, Before(end).InLocation(shanghai).Validate(time.Date(2024, 1, 31, 16, 30, 0, 0, time.UTC)))
+	assert.Nil(t, After(start).UTC().Validate(time.Date(2024, 1, 1, 8, 30, 0, 0, shanghai)))
+	assert.Error(t, After(start).UTC().Validate(time.Date(2024, 1, 1, 7, 30, 0, 0, shanghai)))
+
+	// Friday 20:00 UTC is Saturday in Shanghai.
+	friday := time.Date(2023, 12, 29, 20, 0, 0, 0, time.UTC)
+	assert.Error(t, Weekend().Validate(friday))
+	assert.Nil(t, Weekend().InLocation(shanghai).Validate(friday))
+	assert.Error(t, Weekend().UTC().Validate(friday.In(shanghai)))
+}
+
+func TestDateStringBetween(t *testing.T) {
+	min := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rule    *DateStringBetweenRule
+		value   string
+		wantErr bool
+	}{
+		{name: "valid: within range", rule: DateStringBetween("2006-01-02", min, max), value: "2024-06-15", wantErr: false},
+		{name: "valid: equal to min", rule: DateStringBetween("2006-01-02", min, max), value: "2024-01-01", wantErr: false},
+		{name: "valid: equal to max", rule: DateStringBetween("2006-01-02", min, max), value: "2024-12-31", wantErr: false},
+		{name: "valid: empty string", rule: DateStringBetween("2006-01-02", min, max), value: "", wantErr: false},
+		{name: "invalid: after max", rule: DateStringBetween("2006-01-02", min, max), value: "2025-01-01", wantErr: true},
+		{name: "invalid: wrong layout", rule: DateStringBetween("2006-01-02", min, max), value: "15/06/2024", wantErr: true},
+		{name: "invalid: parsed in location", rule: DateStringBetween("2006-01-02 15:04", min, max).InLocation(time.FixedZone("CST", 8*3600)), value: "2024-01-01 07:00", wantErr: true},
+		{name: "custom error message", rule: DateStringBetween("2006-01-02", min, max).Errf("custom error"), value: "2023-12-31", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DateStringBetweenRule.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, DateStringBetween("2006-01-02", min, max).Validate("2025-01-01"), ErrTimeBetween)
+	assert.ErrorIs(t, DateStringBetween("2006-01-02", min, max).Validate("soon"), ErrDateFormat)
+	assert.EqualError(t, DateStringBetween("2006-01-02", min, max).Errf("custom error").Validate("soon"), "custom error")
+	assert.ErrorIs(t, (&DateStringBetweenRule{format: "2006-01-02"}).Validate("soon"), ErrDateFormat)
+	assert.ErrorIs(t, (&DateStringBetweenRule{format: "2006-01-02", min: min, max: max}).Validate("2025-01-01"), ErrTimeBetween)
+}
@@ -0,0 +1,236 @@
+// Package rule provides a collection of validation rules for various data types.
+// This file contains transformers, which normalize a field before it is validated.
+package rule
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformer is implemented by rules that modify a value instead of checking it.
+// arbiter.Field applies transformers to the field in place, in the order they are given,
+// so that the rules after them validate the normalized value.
+//
+// Example:
+//
+//	arbiter.Field(&user.Email, rule.Trim(), rule.Lower(), rule.IsEmail())
+type Transformer[T any] interface {
+	Transform(value T) T
+}
+
+// TransformRule is a Transformer built from a function. Its Validate method always
+// returns nil, so it has no effect when used with arbiter.Validate, which cannot
+// modify the value.
+//
+// Example:
+//
+//	rule := Transform(func(s string) string { return strings.ReplaceAll(s, "-", "") })
+//	s := rule.Transform("555-0100")  // "5550100"
+type TransformRule[T any] struct {
+	fn func(T) T
+}
+
+// Transform creates a transformer that replaces the value with the result of fn.
+//
+// Example:
+//
+//	type Order struct {
+//	    Quantity int
+//	}
+//
+//	clamp := Transform(func(n int) int { return max(n, 1) })
+//	arbiter.Field(&order.Quantity, clamp, rule.Max(100))
+func Transform[T any](fn func(T) T) *TransformRule[T] {
+	return &TransformRule[T]{fn: fn}
+}
+
+// Transform returns the transformed value.
+func (r *TransformRule[T]) Transform(value T) T {
+	if r.fn == nil {
+		return value
+	}
+	return r.fn(value)
+}
+
+// Validate always returns nil; the rule only transforms values.
+func (r *TransformRule[T]) Validate(_ T) error {
+	return nil
+}
+
+// Describe returns the description of the rule.
+func (r *TransformRule[T]) Describe() RuleInfo {
+	return RuleInfo{Name: "transform"}
+}
+
+// Trim creates a transformer that removes leading and trailing white space.
+//
+// Example:
+//
+//	arbiter.Field(&user.Name, rule.Trim(), rule.Required[string]())  // "  " fails Required
+func Trim() *TransformRule[string] {
+	return Transform(strings.TrimSpace)
+}
+
+// Lower creates a transformer that converts a string to lower case.
+//
+// Example:
+//
+//	arbiter.Field(&user.Email, rule.Trim(), rule.Lower(), rule.IsEmail())
+func Lower() *TransformRule[string] {
+	return Transform(strings.ToLower)
+}
+
+// Upper creates a transformer that converts a string to upper case.
+//
+// Example:
+//
+//	arbiter.Field(&address.Country, rule.Upper(), rule.In("US", "CA", "MX"))
+func Upper() *TransformRule[string] {
+	return Transform(strings.ToUpper)
+}
+
+// CollapseWhitespace creates a transformer that trims a string and replaces every run
+// of white space inside it with a single space.
+//
+// Example:
+//
+//	arbiter.Field(&user.FullName, rule.CollapseWhitespace(), rule.Len[string](1, 100))
+//	// " Ada \t  Lovelace " becomes "Ada Lovelace"
+func CollapseWhitespace() *TransformRule[string] {
+	return Transform(collapseWhitespace)
+}
+
+// collapseWhitespace trims s and replaces runs of white space with a single space.
+// It returns s unchanged if there is nothing to collapse.
+func collapseWhitespace(s string) string {
+	fields := strings.FieldsFunc(s, unicode.IsSpace)
+	joined := strings.Join(fields, " ")
+	if joined == s {
+		return s
+	}
+	return joined
+}
+
+// NormalizeNFC creates a transformer that converts a string to Unicode normalization
+// form C, so that a composed character and its decomposed form, such as "é" and
+// "e" followed by a combining accent, are the same string before length, equality and
+// regex rules run.
+//
+// Example:
+//
+//	arbiter.Field(&user.Name, rule.NormalizeNFC(), rule.Len[string](1, 50))
+//	// "Jose\u0301" becomes "José"
+func NormalizeNFC() *TransformRule[string] {
+	return Transform(norm.NFC.String)
+}
+
+// NormalizeNFKC creates a transformer that converts a string to Unicode normalization
+// form KC. In addition to NormalizeNFC, it replaces compatibility characters with
+// their plain equivalents, such as full-width digits and letters and ligatures.
+//
+// Example:
+//
+//	arbiter.Field(&order.Phone, rule.NormalizeNFKC(), rule.HalfWidthOnly())
+//	// "０１２３" becomes "0123"
+func NormalizeNFKC() *TransformRule[string] {
+	return Transform(norm.NFKC.String)
+}
+
+// gmailDomains are the domains of Gmail addresses, which ignore dots in the local part.
+// googlemail.com addresses are delivered to the same mailbox as gmail.com.
+var gmailDomains = []string{"gmail.com", "googlemail.com"}
+
+// NormalizeEmailRule is a transformer that produces the canonical form of an email
+// address, for uniqueness checks and lookups. It trims the address, converts it to
+// Unicode normalization form KC and lowercases the domain. The local part is kept as it
+// is unless the options below are set, as mail servers may treat it case-sensitively.
+//
+// Example:
+//
+//	arbiter.Field(&user.Email, rule.NormalizeEmail().StripPlusTags(), rule.IsEmail())
+//	// " John+news@Example.COM " becomes "John@example.com"
+type NormalizeEmailRule struct {
+	lowerLocal bool
+	plusTags   bool
+	gmailDots  bool
+}
+
+// NormalizeEmail creates a transformer that normalizes email addresses. Values without
+// an @ are only trimmed and normalized, so that IsEmail still reports them.
+//
+// Example:
+//
+//	arbiter.Field(&signup.Email, rule.NormalizeEmail().LowerLocal(), rule.IsEmail())
+func NormalizeEmail() *NormalizeEmailRule {
+	return &NormalizeEmailRule{}
+}
+
+// LowerLocal also lowercases the local part, which almost all providers treat
+// case-insensitively.
+//
+// Example:
+//
+//	s := NormalizeEmail().LowerLocal().Transform("John@Example.com")  // "john@example.com"
+func (r *NormalizeEmailRule) LowerLocal() *NormalizeEmailRule {
+	r.lowerLocal = true
+	return r
+}
+
+// StripPlusTags removes the sub-address from the local part, that is everything from
+// the first +, so that "john+news@example.com" and "john@example.com" are the same.
+//
+// Example:
+//
+//	s := NormalizeEmail().StripPlusTags().Transform("john+news@example.com")  // "john@example.com"
+func (r *NormalizeEmailRule) StripPlusTags() *NormalizeEmailRule {
+	r.plusTags = true
+	return r
+}
+
+// StripGmailDots removes the dots from the local part of Gmail addresses, which Gmail
+// ignores, and replaces the googlemail.com domain with gmail.com. Other domains are not
+// changed, as dots are significant for most providers.
+//
+// Example:
+//
+//	s := NormalizeEmail().StripGmailDots().Transform("j.o.h.n@googlemail.com")  // "john@gmail.com"
+func (r *NormalizeEmailRule) StripGmailDots() *NormalizeEmailRule {
+	r.gmailDots = true
+	return r
+}
+
+// Transform returns the normalized email address.
+func (r *NormalizeEmailRule) Transform(value string) string {
+	value = norm.NFKC.String(strings.TrimSpace(value))
+	at := strings.LastIndexByte(value, '@')
+	if at < 0 {
+		return value
+	}
+	local, domain := value[:at], strings.ToLower(value[at+1:])
+	if r.lowerLocal {
+		local = strings.ToLower(local)
+	}
+	if r.plusTags {
+		if tag := strings.IndexByte(local, '+'); tag > 0 {
+			local = local[:tag]
+		}
+	}
+	if r.gmailDots && slices.Contains(gmailDomains, domain) {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = gmailDomains[0]
+	}
+	return local + "@" + domain
+}
+
+// Validate always returns nil; the rule only transforms values.
+func (r *NormalizeEmailRule) Validate(_ string) error {
+	return nil
+}
+
+// Describe returns the description of the rule.
+func (r *NormalizeEmailRule) Describe() RuleInfo {
+	return RuleInfo{Name: "transform"}
+}
@@ -0,0 +1,68 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformers(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Transformer[string]
+		value string
+		want  string
+	}{
+		{name: "trim", rule: Trim(), value: " \t hello \n", want: "hello"},
+		{name: "lower", rule: Lower(), value: "John@Example.COM", want: "john@example.com"},
+		{name: "upper", rule: Upper(), value: "us", want: "US"},
+		{name: "collapse whitespace", rule: CollapseWhitespace(), value: " Ada \t  Lovelace  ", want: "Ada Lovelace"},
+		{name: "collapse nothing", rule: CollapseWhitespace(), value: "Ada Lovelace", want: "Ada Lovelace"},
+		{name: "collapse empty", rule: CollapseWhitespace(), value: "   ", want: ""},
+		{name: "custom", rule: Transform(func(s string) string { return strings.ReplaceAll(s, "-", "") }), value: "555-0100", want: "5550100"},
+		{name: "nfc", rule: NormalizeNFC(), value: "Jose\u0301", want: "Jos\u00e9"},
+		{name: "nfc keeps full width", rule: NormalizeNFC(), value: "\uff11\uff12\uff13", want: "\uff11\uff12\uff13"},
+		{name: "nfkc full width", rule: NormalizeNFKC(), value: "\uff11\uff12\uff13", want: "123"},
+		{name: "nfkc ligature", rule: NormalizeNFKC(), value: "\ufb01le", want: "file"},
+		{name: "nil func", rule: &TransformRule[string]{}, value: "x", want: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Transform(tt.value))
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  *NormalizeEmailRule
+		value string
+		want  string
+	}{
+		{name: "lower domain", rule: NormalizeEmail(), value: " John+News@Example.COM ", want: "John+News@example.com"},
+		{name: "lower local", rule: NormalizeEmail().LowerLocal(), value: "John@Example.com", want: "john@example.com"},
+		{name: "plus tag", rule: NormalizeEmail().StripPlusTags(), value: "john+news+daily@example.com", want: "john@example.com"},
+		{name: "plus only local", rule: NormalizeEmail().StripPlusTags(), value: "+news@example.com", want: "+news@example.com"},
+		{name: "gmail dots", rule: NormalizeEmail().StripGmailDots(), value: "j.o.h.n@Gmail.com", want: "john@gmail.com"},
+		{name: "googlemail", rule: NormalizeEmail().StripGmailDots(), value: "j.ohn@googlemail.com", want: "john@gmail.com"},
+		{name: "dots of other domains", rule: NormalizeEmail().StripGmailDots(), value: "j.ohn@example.com", want: "j.ohn@example.com"},
+		{name: "all options", rule: NormalizeEmail().LowerLocal().StripPlusTags().StripGmailDots(), value: "J.Ohn+Spam@GMAIL.COM", want: "john@gmail.com"},
+		{name: "full width", rule: NormalizeEmail(), value: "\uff4a\uff4f\uff48\uff4e@\uff25\uff58\uff41\uff4d\uff50\uff4c\uff45.com", want: "john@example.com"},
+		{name: "no at sign", rule: NormalizeEmail().LowerLocal(), value: " John ", want: "John"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Transform(tt.value))
+		})
+	}
+	assert.Nil(t, NormalizeEmail().Validate("x"))
+}
+
+func TestTransformValidate(t *testing.T) {
+	assert.Nil(t, Trim().Validate(" x "))
+	assert.Nil(t, Transform(func(n int) int { return n * 2 }).Validate(1))
+}
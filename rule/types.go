@@ -1,5 +1,7 @@
 package rule
 
+import "database/sql"
+
 // Integer represents all integer types that can be used in validation rules.
 // This includes both signed and unsigned integers of various sizes.
 
@@ -17,7 +19,8 @@ type Ordered interface {
 }
 
 // RequiredType defines types that can be checked for required/optional status.
-// This includes both value types and their pointer variants.
+// This includes both value types and their pointer variants, and the Null types of
+// database/sql.
 type RequiredType interface {
 	~string | ~*string |
 		~int | ~*int |
@@ -31,7 +34,9 @@ type RequiredType interface {
 		~uint32 | ~*uint32 |
 		~uint64 | ~*uint64 |
 		~float32 | ~*float32 |
-		~float64 | ~*float64
+		~float64 | ~*float64 |
+		sql.NullString | sql.NullInt64 | sql.NullInt32 | sql.NullInt16 |
+		sql.NullByte | sql.NullFloat64 | sql.NullBool | sql.NullTime
 }
 
 // InType defines types that can be used in set membership validation.
@@ -63,3 +68,9 @@ type Zeroable interface {
 		~complex64 | ~complex128 |
 		any
 }
+
+// Bytes defines types holding encoded data, such as PEM documents, which may be stored
+// in string or byte slice fields.
+type Bytes interface {
+	~string | ~[]byte
+}
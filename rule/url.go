@@ -3,14 +3,12 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"net/url"
 )
 
 // ErrURL is returned when a string is not a valid URL.
 // The URL must be properly formatted with a scheme (e.g., http://, https://).
-var ErrURL = errors.New("invalid URL format")
+var ErrURL = newError("url", "invalid URL format")
 
 // URLRule validates that a string is a valid URL.
 // The rule uses url.ParseRequestURI to verify the URL format.
@@ -49,7 +47,7 @@ func URL() *URLRule {
 //	err = rule.Validate("not-a-url")            // returns error
 //	err = rule.Validate("")                     // returns nil (empty string is valid)
 func (r *URLRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 	_, err := url.ParseRequestURI(value)
@@ -62,6 +60,20 @@ func (r *URLRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *URLRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrURL)
+}
+
+// JSONSchema returns the JSON Schema keywords of the rule.
+//
+// Example:
+//
+//	keywords := URL().JSONSchema()  // map[string]any{"format": "uri"}
+func (r *URLRule) JSONSchema() map[string]any {
+	return map[string]any{"format": "uri"}
+}
+
 // Errf sets a custom error message for URL validation failures.
 // This allows for context-specific error messages.
 //
@@ -70,7 +82,7 @@ func (r *URLRule) Validate(value string) error {
 //	rule := URL().Errf("Please enter a valid URL including the scheme (e.g., https://)")
 func (r *URLRule) Errf(format string, args ...any) *URLRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
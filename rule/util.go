@@ -1,5 +1,7 @@
 package rule
 
+import "sync"
+
 // Ternary if condition is true, return trueValue, otherwise return falseValue
 func Ternary[T any](condition bool, trueValue, falseValue T) T {
 	if condition {
@@ -7,3 +9,18 @@ func Ternary[T any](condition bool, trueValue, falseValue T) T {
 	}
 	return falseValue
 }
+
+// sharedRules holds one predeclared instance per generic rule type, keyed by a typed nil
+// pointer so that the lookup does not allocate.
+var sharedRules sync.Map
+
+// sharedRule returns the shared instance of the rule type R, creating it with newRule on
+// first use. It is used for predeclared generic rules such as Positivev[int]().
+func sharedRule[R any](newRule func() R) R {
+	var key *R
+	if r, ok := sharedRules.Load(key); ok {
+		return r.(R)
+	}
+	r, _ := sharedRules.LoadOrStore(key, newRule())
+	return r.(R)
+}
@@ -3,8 +3,6 @@
 package rule
 
 import (
-	"errors"
-	"fmt"
 	"regexp"
 	"strings"
 )
@@ -12,7 +10,11 @@ import (
 // Error variable for UUID validation
 var (
 	// ErrUUID is returned when a string is not a valid UUID format
-	ErrUUID = errors.New("invalid UUID format")
+	ErrUUID = newError("uuid", "invalid UUID format")
+
+	// UUIDv is a predeclared, immutable UUID rule that can be shared and used concurrently.
+	// Errf returns a copy instead of modifying it.
+	UUIDv = &UUIDRule{e: ErrUUID, frozen: true}
 
 	uuidRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
@@ -36,7 +38,8 @@ var (
 //	err = rule.Validate("123e4567-e89b-12d3-a456-42661417400g")  // returns error (invalid character)
 //	err = rule.Validate("123e4567-e89b-12d3-a456-42661417400")   // returns error (wrong length)
 type UUIDRule struct {
-	e error
+	frozen bool
+	e      error
 }
 
 // UUID creates a new UUID validation rule.
@@ -81,7 +84,7 @@ func UUID() *UUIDRule {
 //	err = rule.Validate("123e4567-e89b-12d3-a456-42661417400")   // returns error
 //	err = rule.Validate("123e4567e89b12d3a456426614174000")      // returns error
 func (r *UUIDRule) Validate(value string) error {
-	if value == "" {
+	if skipEmpty(value) {
 		return nil
 	}
 
@@ -94,6 +97,11 @@ func (r *UUIDRule) Validate(value string) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *UUIDRule) Describe() RuleInfo {
+	return describe(r, r.e, ErrUUID)
+}
+
 // Errf sets a custom error message for UUID validation failures.
 // This allows for context-specific error messages.
 //
@@ -106,7 +114,19 @@ func (r *UUIDRule) Validate(value string) error {
 //	err = rule.Validate("123e4567-e89b-12d3-a456")  // returns error with custom message
 func (r *UUIDRule) Errf(format string, args ...any) *UUIDRule {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r = r.mutable()
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
+
+// mutable returns r, or a copy of r if r is a shared predeclared instance, so that
+// option methods never modify a rule that other goroutines may be using.
+func (r *UUIDRule) mutable() *UUIDRule {
+	if !r.frozen {
+		return r
+	}
+	c := *r
+	c.frozen = false
+	return &c
+}
@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,28 @@ func TestUUIDFallback(t *testing.T) {
 	err := (&UUIDRule{}).Validate("not a uuid")
 	assert.Error(t, err)
 }
+
+func TestUUIDv(t *testing.T) {
+	assert.Nil(t, UUIDv.Validate("123e4567-e89b-12d3-a456-426614174000"))
+	assert.EqualError(t, UUIDv.Errf("bad id").Validate("nope"), "bad id")
+	assert.ErrorIs(t, UUIDv.Validate("nope"), ErrUUID)
+}
+
+func TestPredeclaredRulesConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = Emailv.Errf("invalid email %d", j).Validate("x")
+				assert.ErrorIs(t, Emailv.Validate("x"), ErrEmail)
+				assert.Nil(t, Phonev.Validate("+8613800138000"))
+				assert.ErrorIs(t, Negativev[int]().Validate(1), ErrNegative)
+				assert.ErrorIs(t, Requiredv[string]().Validate(""), ErrRequired)
+				_ = Requiredv[string]().Errf("required %d", j)
+			}
+		}()
+	}
+	wg.Wait()
+}
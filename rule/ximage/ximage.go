@@ -0,0 +1,17 @@
+// Package ximage registers the WebP, BMP and TIFF decoders of golang.org/x/image with the
+// standard image package, so that rule.ImageFormat accepts "webp", "bmp" and "tiff".
+// The decoders live in this package, a separate module, rather than in package rule, so
+// that only programs validating these formats depend on golang.org/x/image.
+//
+// Example:
+//
+//	import _ "github.com/byteweap/arbiter/rule/ximage"
+//
+//	arbiter.Field(&profile.Avatar, rule.ImageFormat("png", "jpeg", "webp"))
+package ximage
+
+import (
+	_ "golang.org/x/image/bmp"  // register the BMP decoder
+	_ "golang.org/x/image/tiff" // register the TIFF decoder
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
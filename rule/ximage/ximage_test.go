@@ -0,0 +1,46 @@
+package ximage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+func TestImageFormat(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	var bmpData, tiffData bytes.Buffer
+	assert.NoError(t, bmp.Encode(&bmpData, img))
+	assert.NoError(t, tiff.Encode(&tiffData, img, nil))
+	webpData, _ := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+
+	tests := []struct {
+		name    string
+		rule    *rule.ImageFormatRule
+		value   []byte
+		wantErr bool
+	}{
+		{name: "valid: webp", rule: rule.ImageFormat("webp"), value: webpData, wantErr: false},
+		{name: "valid: bmp", rule: rule.ImageFormat("bmp"), value: bmpData.Bytes(), wantErr: false},
+		{name: "valid: tif alias", rule: rule.ImageFormat("tif"), value: tiffData.Bytes(), wantErr: false},
+		{name: "invalid: bmp is not webp", rule: rule.ImageFormat("webp"), value: bmpData.Bytes(), wantErr: true},
+		{name: "invalid: exceeds pixel limit", rule: rule.ImageFormat("bmp").MaxPixels(15), value: bmpData.Bytes(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate(bytes.NewReader(tt.value))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
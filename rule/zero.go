@@ -2,14 +2,13 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 )
 
 // ErrZero is returned when a value is not zero.
 var (
-	ErrZero = errors.New("value must be zero")
+	ErrZero = newError("zero", "value must be zero")
 )
 
 // ZeroRule is a validation rule that checks if a value is zero.
@@ -49,6 +48,7 @@ func Zero[T Zeroable]() *ZeroRule[T] {
 // For strings, it checks if the string is empty.
 // For booleans, it checks if the value is false.
 // For pointers, it checks if the pointer is nil.
+// For the Null types of database/sql, it checks if the value is NULL or its inner value is zero.
 // For other types, it uses reflection to determine if the value is zero.
 //
 // Example:
@@ -67,6 +67,11 @@ func (r *ZeroRule[T]) Validate(value T) error {
 	return nil
 }
 
+// Describe returns the name, parameters and message of the rule.
+func (r *ZeroRule[T]) Describe() RuleInfo {
+	return describe(r, r.e, ErrZero)
+}
+
 // Errf sets a custom error message for the validation rule.
 // Returns the rule instance for method chaining.
 //
@@ -76,7 +81,7 @@ func (r *ZeroRule[T]) Validate(value T) error {
 //	err := rule.Validate(42)  // returns error with message "Value must be zero"
 func (r *ZeroRule[T]) Errf(format string, args ...any) *ZeroRule[T] {
 	if format != "" {
-		r.e = fmt.Errorf(format, args...)
+		r.e = wrapf(r.e, format, args...)
 	}
 	return r
 }
@@ -88,6 +93,7 @@ func (r *ZeroRule[T]) Errf(format string, args ...any) *ZeroRule[T] {
 // - Booleans: checks if the value is false
 // - Pointers: checks if the pointer is nil
 // - Arrays/Slices/Maps: checks if the length is 0
+// - Null types of database/sql: checks if the value is NULL or its inner value is zero
 // - Structs: checks if all fields are zero
 // - Other types: uses reflection to compare with zero value
 //
@@ -149,9 +155,14 @@ func isZero[T Zeroable](value T) bool {
 		case reflect.Array, reflect.Slice, reflect.Map:
 			return rv.Len() == 0
 		case reflect.Struct:
+			if zero, ok := sqlNullZero(rv); ok {
+				return zero
+			}
 			// For structs, check if all fields are zero
 			for i := 0; i < rv.NumField(); i++ {
-				if !isZero(rv.Field(i).Interface()) {
+				field := rv.Field(i)
+				// Unexported fields, such as those of time.Time, cannot be read as interfaces
+				if !field.CanInterface() && !field.IsZero() || field.CanInterface() && !isZero(field.Interface()) {
 					return false
 				}
 			}
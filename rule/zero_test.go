@@ -3,6 +3,7 @@ package rule
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -126,6 +127,8 @@ func TestZeroRuleReflectBranches(t *testing.T) {
 		type s struct{ A int }
 		assert.Nil(t, Zero[s]().Validate(s{}))
 		assert.Equal(t, ErrZero, Zero[s]().Validate(s{A: 1}))
+		assert.Nil(t, Zero[time.Time]().Validate(time.Time{}))
+		assert.Equal(t, ErrZero, Zero[time.Time]().Validate(time.Unix(1, 0)))
 	})
 	t.Run("array", func(t *testing.T) {
 		assert.Nil(t, Zero[[0]int]().Validate([0]int{}))
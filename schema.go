@@ -0,0 +1,432 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains schemas, which declare the rules of a struct type once and reuse them.
+package arbiter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// SchemaBuilder declares the rules of the fields of a struct type T by name.
+// Use For to create one and Build to turn it into a Schema.
+type SchemaBuilder[T any] struct {
+	fields []schemaField
+	err    error
+}
+
+// Schema validates values of the struct type T with rules declared once, instead of
+// building the field rules on every call as ValidateStruct does. A Schema does not change
+// after Build, so it can be stored in a package-level variable. It can be used by many
+// goroutines concurrently only if its rules can: rules that write to a target of their own,
+// such as rule.CoerceInt or rule.Capture, must not be used in a shared Schema.
+//
+// Example:
+//
+//	var userSchema = arbiter.MustBuild(arbiter.For[User]().
+//	    Field("Name", rule.Len[string](2, 50)).
+//	    Field("Age", rule.Between(0, 120)))
+//
+//	func createUser(user *User) error {
+//	    return userSchema.Validate(user)
+//	}
+type Schema[T any] struct {
+	fields []schemaField
+}
+
+// schemaField is a field of a schema with its rules.
+type schemaField struct {
+	name string
+	// path holds the index of the field at each level of a dotted name.
+	path  [][]int
+	rules []schemaRule
+}
+
+// schemaRule is a rule of a schema field with its methods resolved for the field type.
+type schemaRule struct {
+	rule      any
+	validate  reflect.Value
+	transform reflect.Value
+	// adapt converts the field value to the type of the rule if the rule validates the
+	// type of a registered type adapter.
+	adapt reflect.Value
+}
+
+// For creates a builder for a schema of the struct type T.
+//
+// Example:
+//
+//	schema, err := arbiter.For[User]().
+//	    Field("Email", rule.Trim(), rule.IsEmail()).
+//	    Field("Address.City", rule.Required[string]()).
+//	    Build()
+func For[T any]() *SchemaBuilder[T] {
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Struct {
+		return &SchemaBuilder[T]{err: fmt.Errorf("schema of %s: type is not a struct", t)}
+	}
+	return &SchemaBuilder[T]{}
+}
+
+// Field adds rules for the field with the given name, which is its Go name or, for a
+// field of a nested struct, the dotted path such as "Address.City". Each rule must be a
+// rule.Rule of the field's type; transformers such as rule.Trim modify the field in place
+// like they do with Field. Errors in the declaration are reported by Build.
+//
+// Example:
+//
+//	builder := arbiter.For[Order]().
+//	    Field("ID", rule.Required[string]()).
+//	    Field("Quantity", rule.Min(1), rule.Max(100))
+func (b *SchemaBuilder[T]) Field(name string, rules ...any) *SchemaBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+	path, typ, err := schemaPath(reflect.TypeFor[T](), name)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	field := schemaField{name: name, path: path, rules: make([]schemaRule, 0, len(rules))}
+	for i, r := range rules {
+		sr, err := newSchemaRule(r, typ)
+		if err != nil {
+			b.err = fmt.Errorf("schema field %s: rule %d: %w", name, i, err)
+			return b
+		}
+		field.rules = append(field.rules, sr)
+	}
+	b.fields = append(b.fields, field)
+	return b
+}
+
+// Rules adds the rules declared by name in spec for the field with the given name, as
+// created by the factories registered with RegisterRule. The spec lists the rules
+// separated by commas, each optionally followed by "=" and its parameters separated by
+// spaces.
+//
+// Example:
+//
+//	schema, err := arbiter.For[User]().
+//	    Rules("Name", "trim,required,len=2 50").
+//	    Rules("Age", "between=0 120").
+//	    Rules("Role", "in=admin editor viewer").
+//	    Build()
+func (b *SchemaBuilder[T]) Rules(name, spec string) *SchemaBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+	_, typ, err := schemaPath(reflect.TypeFor[T](), name)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	rules, err := newRules(typ, spec)
+	if err != nil {
+		b.err = fmt.Errorf("schema field %s: %w", name, err)
+		return b
+	}
+	return b.Field(name, rules...)
+}
+
+// Build returns the schema, or the first error in its declaration, such as an unknown
+// field name or a rule for a different type.
+//
+// Example:
+//
+//	schema, err := arbiter.For[User]().Field("Age", rule.Min(0)).Build()
+//	if err != nil {
+//	    panic(err)
+//	}
+//	err = schema.Validate(user)
+func (b *SchemaBuilder[T]) Build() (*Schema[T], error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &Schema[T]{fields: append([]schemaField(nil), b.fields...)}, nil
+}
+
+// MustBuild is like Build but panics if the schema cannot be built. It simplifies the
+// initialization of package-level schemas.
+//
+// Example:
+//
+//	var userSchema = arbiter.MustBuild(arbiter.For[User]().Field("Name", rule.Required[string]()))
+func MustBuild[T any](b *SchemaBuilder[T]) *Schema[T] {
+	s, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Validate validates the fields of value in the order they were added and returns the
+// first error as a *FieldError named after the field, like ValidateStruct.
+// Fields behind a nil pointer are skipped. A nil value returns rule.ErrNotNil.
+//
+// Example:
+//
+//	if err := userSchema.Validate(user); err != nil {
+//	    // err.Error() is e.g. "Age: value is less than minimum"
+//	}
+func (s *Schema[T]) Validate(value *T) error {
+	if value == nil {
+		return rule.ErrNotNil
+	}
+	v := reflect.ValueOf(value).Elem()
+	for _, field := range s.fields {
+		fv, ok := field.value(v)
+		if !ok {
+			continue
+		}
+		for _, r := range field.rules {
+			if err := r.check(fv); err != nil {
+				fe := &FieldError{Field: fv.Addr().Interface(), Err: err, Params: params(r.rule)}
+				fe.resolve(value)
+				fe.render("")
+				logErrors(value, fe)
+				return fe
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAll validates value like Validate, but applies every rule of every field and
+// returns all failures as ValidationErrors, like ValidateStructAll. It returns nil if all
+// rules pass.
+//
+// Example:
+//
+//	var errs arbiter.ValidationErrors
+//	if err := userSchema.ValidateAll(user); errors.As(err, &errs) {
+//	    payload := errs.ByField()
+//	}
+func (s *Schema[T]) ValidateAll(value *T) error {
+	if value == nil {
+		return ValidationErrors{{Err: rule.ErrNotNil}}
+	}
+	var errs ValidationErrors
+	v := reflect.ValueOf(value).Elem()
+	for _, field := range s.fields {
+		fv, ok := field.value(v)
+		if !ok {
+			continue
+		}
+		for _, r := range field.rules {
+			if err := r.check(fv); err != nil {
+				fe := &FieldError{Field: fv.Addr().Interface(), Err: err, Params: params(r.rule)}
+				fe.resolve(value)
+				fe.render("")
+				errs = append(errs, fe)
+			}
+		}
+	}
+	logErrors(value, errs...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// FieldInfo describes the rules of a field of a schema.
+type FieldInfo struct {
+	// Field is the name of the field as declared, such as "Address.City".
+	Field string `json:"field"`
+	// Rules describes the rules of the field in the order they are applied.
+	Rules []rule.RuleInfo `json:"rules"`
+}
+
+// Rules describes the rules of every field of the schema, in the order the fields were
+// first added, so that tooling can list, document or serialize the constraints.
+//
+// Example:
+//
+//	for _, field := range userSchema.Rules() {
+//	    for _, r := range field.Rules {
+//	        fmt.Println(field.Field, r.Name, r.Params)  // e.g. "Age between map[max:120 min:0]"
+//	    }
+//	}
+func (s *Schema[T]) Rules() []FieldInfo {
+	var infos []FieldInfo
+	index := make(map[string]int)
+	for _, field := range s.fields {
+		i, ok := index[field.name]
+		if !ok {
+			i = len(infos)
+			index[field.name] = i
+			infos = append(infos, FieldInfo{Field: field.name})
+		}
+		for _, r := range field.rules {
+			infos[i].Rules = append(infos[i].Rules, rule.Describe(r.rule))
+		}
+	}
+	return infos
+}
+
+// validateValue validates value, a *T or a T, like ValidateAll. A T is validated as a
+// copy, so transformers do not modify it.
+func (s *Schema[T]) validateValue(value any) error {
+	switch v := value.(type) {
+	case *T:
+		return s.ValidateAll(v)
+	case T:
+		return s.ValidateAll(&v)
+	}
+	return nil
+}
+
+// Validatable is implemented by types that validate themselves, such as the types with
+// Validate methods generated by arbitergen.
+type Validatable interface {
+	Validate() error
+}
+
+var (
+	schemasMutex sync.RWMutex
+	schemas      = make(map[reflect.Type]interface{ validateValue(any) error })
+)
+
+// RegisterSchema registers s as the schema of T, so that ValidateValue and the
+// framework integrations, such as ginvalidate and echovalidate, validate values of T
+// with it. Registering a schema for T again replaces it.
+//
+// Example:
+//
+//	func init() {
+//	    arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[User]().
+//	        Field("Email", rule.Required[string](), rule.IsEmail())))
+//	}
+func RegisterSchema[T any](s *Schema[T]) {
+	schemasMutex.Lock()
+	schemas[reflect.TypeFor[T]()] = s
+	schemasMutex.Unlock()
+}
+
+// ValidateValue validates value, a struct or a pointer to one, with the schema
+// registered for its type with RegisterSchema, returning an arbiter.ValidationErrors
+// with every failure, or else with its Validate method if it implements Validatable.
+// Values of other types are not validated and return nil.
+//
+// Example:
+//
+//	if err := arbiter.ValidateValue(req); err != nil {
+//	    // Reject the request
+//	}
+func ValidateValue(value any) error {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(value).IsNil() {
+			return nil
+		}
+		t = t.Elem()
+	}
+	schemasMutex.RLock()
+	s := schemas[t]
+	schemasMutex.RUnlock()
+	if s != nil {
+		return s.validateValue(value)
+	}
+	if v, ok := value.(Validatable); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// value returns the field within the struct v, or false if a pointer on its path is nil,
+// including the pointer of an embedded struct.
+func (f schemaField) value(v reflect.Value) (reflect.Value, bool) {
+	for _, index := range f.path {
+		for _, i := range index {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+			v = v.Field(i)
+		}
+	}
+	return v, true
+}
+
+// check transforms the field value fv in place if the rule is a transformer, or
+// validates it otherwise.
+func (r schemaRule) check(fv reflect.Value) error {
+	v := fv
+	if r.adapt.IsValid() {
+		v = r.adapt.Call([]reflect.Value{fv})[0]
+	}
+	if r.transform.IsValid() {
+		fv.Set(r.transform.Call([]reflect.Value{v})[0].Convert(fv.Type()))
+		return nil
+	}
+	err, _ := r.validate.Call([]reflect.Value{v})[0].Interface().(error)
+	return err
+}
+
+// schemaPath resolves the dotted field name within the struct type t. It returns the
+// index of the field at each level and the type of the field.
+func schemaPath(t reflect.Type, name string) ([][]int, reflect.Type, error) {
+	var path [][]int
+	for _, part := range strings.Split(name, ".") {
+		if t.Kind() == reflect.Ptr && len(path) > 0 {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("schema field %s: %s is not a struct", name, t)
+		}
+		sf, ok := t.FieldByName(part)
+		if !ok {
+			return nil, nil, fmt.Errorf("schema field %s: %s has no field %s", name, t, part)
+		}
+		if !sf.IsExported() {
+			return nil, nil, fmt.Errorf("schema field %s: field %s is not exported", name, part)
+		}
+		path = append(path, sf.Index)
+		t = sf.Type
+	}
+	return path, t, nil
+}
+
+// newSchemaRule resolves the Validate and Transform methods of r for a field of type typ.
+// Validate must accept the field type, or the type of the adapter registered for it, and
+// return an error.
+func newSchemaRule(r any, typ reflect.Type) (schemaRule, error) {
+	if r == nil {
+		return schemaRule{}, errors.New("rule is nil")
+	}
+	rv := reflect.ValueOf(r)
+	validate := rv.MethodByName("Validate")
+	if !validate.IsValid() {
+		return schemaRule{}, fmt.Errorf("%T has no Validate method", r)
+	}
+	mt := validate.Type()
+	if mt.NumIn() != 1 || mt.NumOut() != 1 || mt.Out(0) != errorType {
+		return schemaRule{}, fmt.Errorf("%T does not validate %s", r, typ)
+	}
+	sr := schemaRule{rule: r, validate: validate}
+	in := typ
+	if !typ.AssignableTo(mt.In(0)) {
+		a, ok := lookupAdapter(typ)
+		if !ok || !a.to.AssignableTo(mt.In(0)) {
+			return schemaRule{}, fmt.Errorf("%T does not validate %s", r, typ)
+		}
+		sr.adapt, in = a.fn, a.to
+	}
+	if transform := rv.MethodByName("Transform"); transform.IsValid() {
+		tt := transform.Type()
+		if tt.NumIn() == 1 && tt.In(0) == in && tt.NumOut() == 1 && tt.Out(0) == in && in.ConvertibleTo(typ) {
+			sr.transform = transform
+		}
+	}
+	return sr, nil
+}
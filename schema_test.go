@@ -0,0 +1,166 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of schemas.
+package arbiter_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testSchemaUser struct {
+	Name    string
+	Age     int
+	Home    *testAddress
+	Address testAddress
+	secret  string
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := arbiter.For[testSchemaUser]().
+		Field("Name", rule.Trim(), rule.Len[string](2, 10)).
+		Field("Age", rule.Between(0, 120)).
+		Field("Address.City", rule.Required[string]()).
+		Field("Home.City", rule.Required[string]()).
+		Build()
+	assert.NoError(t, err)
+
+	user := &testSchemaUser{Name: "  Ada ", Age: 36, Address: testAddress{City: "London"}}
+	assert.NoError(t, schema.Validate(user))
+	assert.True(t, schema.ValidateAll(user) == nil)
+	assert.Equal(t, "Ada", user.Name)
+
+	user.Age = 130
+	err = schema.Validate(user)
+	var fe *arbiter.FieldError
+	if assert.True(t, errors.As(err, &fe)) {
+		assert.Equal(t, "Age", fe.Name)
+		assert.Equal(t, &user.Age, fe.Field)
+		assert.ErrorIs(t, err, rule.ErrBetween)
+		assert.Equal(t, map[string]any{"min": 0, "max": 120}, fe.Params)
+	}
+
+	user.Home = &testAddress{}
+	errs := validationErrors(schema.ValidateAll(user))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Home.City", errs[1].Name)
+	assert.Equal(t, &user.Home.City, errs[1].Field)
+
+	assert.ErrorIs(t, schema.Validate(nil), rule.ErrNotNil)
+	assert.ErrorIs(t, schema.ValidateAll(nil), rule.ErrNotNil)
+}
+
+func TestSchemaRulesInfo(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaUser]().
+		Field("Name", rule.Trim(), rule.Len[string](2, 10)).
+		Rules("Age", "between=0 120").
+		Field("Name", rule.Required[string]().Errf("Name is required")))
+
+	want := []arbiter.FieldInfo{
+		{Field: "Name", Rules: []rule.RuleInfo{
+			{Name: "transform"},
+			{Name: "length", Params: map[string]any{"min": 2, "max": 10}, Message: rule.ErrLength.Error()},
+			{Name: "required", Message: "Name is required"},
+		}},
+		{Field: "Age", Rules: []rule.RuleInfo{
+			{Name: "between", Params: map[string]any{"min": 0, "max": 120}, Message: rule.ErrBetween.Error()},
+		}},
+	}
+	assert.Equal(t, want, schema.Rules())
+}
+
+func TestSchemaBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *arbiter.SchemaBuilder[testSchemaUser]
+	}{
+		{name: "unknown field", builder: arbiter.For[testSchemaUser]().Field("Email", rule.IsEmail())},
+		{name: "unknown nested field", builder: arbiter.For[testSchemaUser]().Field("Address.Country")},
+		{name: "not a struct", builder: arbiter.For[testSchemaUser]().Field("Name.First")},
+		{name: "unexported field", builder: arbiter.For[testSchemaUser]().Field("secret")},
+		{name: "wrong rule type", builder: arbiter.For[testSchemaUser]().Field("Age", rule.Len[string](1, 3))},
+		{name: "not a rule", builder: arbiter.For[testSchemaUser]().Field("Age", 42)},
+		{name: "nil rule", builder: arbiter.For[testSchemaUser]().Field("Age", nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			assert.Error(t, err)
+			assert.Panics(t, func() { arbiter.MustBuild(tt.builder) })
+		})
+	}
+
+	_, err := arbiter.For[string]().Build()
+	assert.Error(t, err)
+}
+
+type testSchemaEmbedded struct {
+	*testAddress
+	Name string
+}
+
+func TestSchemaNilEmbedded(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaEmbedded]().
+		Field("City", rule.Required[string]()).
+		Field("Name", rule.Required[string]()))
+
+	assert.NoError(t, schema.Validate(&testSchemaEmbedded{Name: "Ada"}))
+	assert.Len(t, schema.ValidateAll(&testSchemaEmbedded{}), 1)
+
+	value := &testSchemaEmbedded{testAddress: &testAddress{}, Name: "Ada"}
+	var fe *arbiter.FieldError
+	if assert.True(t, errors.As(schema.Validate(value), &fe)) {
+		assert.Equal(t, &value.City, fe.Field)
+	}
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	schema := arbiter.MustBuild(arbiter.For[testSchemaUser]().Field("Age", rule.Min(18)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(age int) {
+			defer wg.Done()
+			err := schema.Validate(&testSchemaUser{Age: age})
+			if (err != nil) != (age < 18) {
+				t.Errorf("Validate(age %d) error = %v", age, err)
+			}
+		}(i * 4)
+	}
+	wg.Wait()
+}
+
+type testSelfValidated struct{ OK bool }
+
+func (v testSelfValidated) Validate() error {
+	return rule.Ternary(v.OK, nil, errors.New("not ok"))
+}
+
+func TestValidateValue(t *testing.T) {
+	arbiter.RegisterSchema(arbiter.MustBuild(arbiter.For[testSchemaUser]().
+		Field("Name", rule.Trim(), rule.Required[string]()).
+		Field("Age", rule.Min(0))))
+
+	user := &testSchemaUser{Name: " Ada "}
+	assert.NoError(t, arbiter.ValidateValue(user))
+	assert.Equal(t, "Ada", user.Name)
+
+	err := arbiter.ValidateValue(testSchemaUser{Age: -1})
+	var errs arbiter.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.Len(t, errs, 2)
+	}
+
+	assert.NoError(t, arbiter.ValidateValue(testSelfValidated{OK: true}))
+	assert.EqualError(t, arbiter.ValidateValue(&testSelfValidated{}), "not ok")
+	assert.NoError(t, arbiter.ValidateValue(nil))
+	assert.NoError(t, arbiter.ValidateValue((*testSchemaUser)(nil)))
+	assert.NoError(t, arbiter.ValidateValue(42))
+}
@@ -0,0 +1,86 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains struct-level rules, which check invariants spanning several fields.
+package arbiter
+
+import "fmt"
+
+// StructLevelRule validates a whole struct of type T with a function, for invariants that
+// span several fields, such as "at least one of Email and Phone is set". It implements
+// IFieldRule, so it is passed to ValidateStruct along with the field rules.
+type StructLevelRule[T any] struct {
+	fn     func(*T) error
+	name   string
+	groups []string
+}
+
+// StructRule creates a rule that calls fn with the struct being validated: the value
+// passed to ValidateStruct or, within a NestedField, the nested struct. A nil pointer to
+// the nested struct is not validated. The error of fn belongs to the struct itself, so
+// its FieldError has the name of the struct, which is empty at the top level, unless fn
+// returns a *FieldError for one of the fields, which is returned as is.
+//
+// Example:
+//
+//	err := arbiter.ValidateStruct(contact, "Contact cannot be nil",
+//	    arbiter.Field(&contact.Email, rule.IsEmail()),
+//	    arbiter.StructRule(func(c *Contact) error {
+//	        if c.Email == "" && c.Phone == "" {
+//	            return errors.New("email or phone is required")
+//	        }
+//	        return nil
+//	    }),
+//	)
+func StructRule[T any](fn func(*T) error) *StructLevelRule[T] {
+	return &StructLevelRule[T]{fn: fn}
+}
+
+// Name sets the name of the struct used in errors, like FieldRule.Name. With Only, a
+// struct rule at the top level is only applied if it has a selected name.
+//
+// Example:
+//
+//	arbiter.StructRule(checkTotal).Name("Order")
+func (s *StructLevelRule[T]) Name(name string) *StructLevelRule[T] {
+	s.name = name
+	return s
+}
+
+// Groups sets the validation groups of the rule, like FieldRule.Groups.
+//
+// Example:
+//
+//	arbiter.StructRule(checkTotal).Groups("checkout")
+func (s *StructLevelRule[T]) Groups(groups ...string) *StructLevelRule[T] {
+	s.groups = groups
+	return s
+}
+
+// validate calls the function with the current struct if the rule is selected.
+func (s *StructLevelRule[T]) validate(opts options) error {
+	if s.fn == nil || !opts.inGroups(s.groups) || !opts.selects(s.name, opts.current) {
+		return nil
+	}
+	value, ok := opts.current.(*T)
+	if !ok {
+		return &FieldError{Name: s.name, Err: fmt.Errorf("struct rule of %T used for %T", value, opts.current)}
+	}
+	if value == nil {
+		return nil
+	}
+	err := s.fn(value)
+	if err == nil {
+		return nil
+	}
+	if fe, ok := err.(*FieldError); ok {
+		return fe
+	}
+	return &FieldError{Field: value, Name: s.name, Err: err}
+}
+
+// collect appends the error of the function if it fails.
+func (s *StructLevelRule[T]) collect(errs ValidationErrors, opts options) ValidationErrors {
+	if err := s.validate(opts); err != nil {
+		errs = append(errs, err.(*FieldError))
+	}
+	return errs
+}
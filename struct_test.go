@@ -0,0 +1,128 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the struct-level rules.
+package arbiter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+type testLine struct {
+	Amount int
+}
+
+type testInvoice struct {
+	Email string
+	Phone string
+	Lines []testLine
+	Total int
+	Payer *testContact
+}
+
+type testContact struct {
+	Email string
+	Phone string
+}
+
+var errNoContact = errors.New("email or phone is required")
+
+func checkContact(email, phone string) error {
+	if email == "" && phone == "" {
+		return errNoContact
+	}
+	return nil
+}
+
+func invoiceRules(inv *testInvoice) []arbiter.IFieldRule {
+	return []arbiter.IFieldRule{
+		arbiter.Field(&inv.Total, rule.Min(0)),
+		arbiter.StructRule(func(inv *testInvoice) error {
+			return checkContact(inv.Email, inv.Phone)
+		}),
+		arbiter.StructRule(func(inv *testInvoice) error {
+			sum := 0
+			for _, line := range inv.Lines {
+				sum += line.Amount
+			}
+			if sum != inv.Total {
+				return &arbiter.FieldError{Field: &inv.Total, Err: errors.New("total does not match the lines")}
+			}
+			return nil
+		}),
+		arbiter.NestedField(inv.Payer,
+			arbiter.StructRule(func(c *testContact) error {
+				return checkContact(c.Email, c.Phone)
+			}),
+		).Name("Payer"),
+	}
+}
+
+func TestStructRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		invoice testInvoice
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			invoice: testInvoice{Email: "a@example.com", Lines: []testLine{{2}, {3}}, Total: 5},
+		},
+		{
+			name:    "no contact",
+			invoice: testInvoice{Total: 0},
+			wantErr: "email or phone is required",
+		},
+		{
+			name:    "total mismatch",
+			invoice: testInvoice{Phone: "123", Lines: []testLine{{2}}, Total: 3},
+			wantErr: "Total: total does not match the lines",
+		},
+		{
+			name:    "nested struct",
+			invoice: testInvoice{Phone: "123", Payer: &testContact{}},
+			wantErr: "Payer: email or phone is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &tt.invoice
+			err := arbiter.ValidateStruct(inv, "Invoice cannot be nil", invoiceRules(inv)...)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStructRuleAll(t *testing.T) {
+	inv := &testInvoice{Lines: []testLine{{1}}, Total: -1, Payer: &testContact{}}
+	errs := validationErrors(arbiter.ValidateStructAll(inv, "Invoice cannot be nil", invoiceRules(inv)...))
+	if assert.Len(t, errs, 4) {
+		assert.Equal(t, "Total", errs[0].Name)
+		assert.ErrorIs(t, errs[1], errNoContact)
+		assert.Equal(t, inv, errs[1].Field)
+		assert.Equal(t, "", errs[1].Name)
+		assert.Equal(t, "Total", errs[2].Name)
+		assert.Equal(t, "Payer", errs[3].Name)
+	}
+	assert.Len(t, errs.For(&inv.Total), 2)
+
+	err := arbiter.ValidateStruct(inv, "Invoice cannot be nil",
+		arbiter.StructRule(func(*testContact) error { return nil }),
+	)
+	assert.ErrorContains(t, err, "struct rule of *arbiter_test.testContact used for *arbiter_test.testInvoice")
+
+	err = arbiter.ValidateStruct(inv, "Invoice cannot be nil",
+		arbiter.StructRule(func(*testInvoice) error { return errNoContact }).Groups("create"),
+		arbiter.Group("update"),
+	)
+	assert.NoError(t, err)
+}
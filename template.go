@@ -0,0 +1,96 @@
+// Package arbiter provides validation functionality for various data types.
+// This file contains message templates, which reformat the default messages of rules.
+package arbiter
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/byteweap/arbiter/rule"
+)
+
+var (
+	templatesMutex sync.RWMutex
+	templates      = make(map[string]*template.Template)
+)
+
+// SetMessageTemplate registers a text/template for the default message of the rules
+// with the given error code, such as "between", "length" or "file_size". The template
+// is rendered when ValidateStruct or ValidateStructAll reports a field error, with
+// .Field set to the field name, .Message to the default message and the rule's
+// parameters capitalized, such as .Min and .Max. .Has reports which parameters the rule
+// reported, so {{if .Has.Max}} tests for a maximum even if it is 0. Messages set with
+// Errf are kept.
+// An empty text removes the template for the code.
+//
+// Example:
+//
+//	err := SetMessageTemplate("between", "{{.Field}} must be between {{.Min}} and {{.Max}}")
+//	err = SetMessageTemplate("length", "{{.Field}} must have {{.Min}} to {{.Max}} characters")
+//
+//	err = ValidateStruct(user, "User cannot be nil",
+//	    Field(&user.Age, rule.Between(0, 120)),
+//	)
+//	// err.Error() is "Age must be between 0 and 120"
+func SetMessageTemplate(code, text string) error {
+	if text == "" {
+		templatesMutex.Lock()
+		delete(templates, code)
+		templatesMutex.Unlock()
+		return nil
+	}
+	tmpl, err := template.New(code).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return err
+	}
+	templatesMutex.Lock()
+	templates[code] = tmpl
+	templatesMutex.Unlock()
+	return nil
+}
+
+// render sets the message of the error from the catalog of locale or, if it has no
+// message for the error code, the template registered for the code, unless the rule's
+// message was set with Errf. locale is a registered locale or "" for none.
+func (e *FieldError) render(locale string) {
+	var re *rule.Error
+	if !errors.As(e.Err, &re) || re != e.Err || re.Custom() {
+		return
+	}
+	if msg, ok := e.catalogMessage(locale, re); ok {
+		e.Message = msg
+		return
+	}
+	templatesMutex.RLock()
+	tmpl := templates[re.Code]
+	templatesMutex.RUnlock()
+	if tmpl == nil {
+		return
+	}
+	data := e.templateData(re, false)
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err == nil {
+		e.Message = b.String()
+	}
+}
+
+// templateData returns the data the templates for e are rendered with: .Field, .Message,
+// the parameters of the rule capitalized, formatted with formatParam if text is true, and
+// .Has, which maps the capitalized name of each parameter to true.
+func (e *FieldError) templateData(re *rule.Error, text bool) map[string]any {
+	has := make(map[string]bool, len(e.Params))
+	data := map[string]any{"Field": e.Name, "Message": re.Message, "Has": has}
+	for k, v := range e.Params {
+		if k != "" {
+			name := strings.ToUpper(k[:1]) + k[1:]
+			data[name] = v
+			if text {
+				data[name] = formatParam(v)
+			}
+			has[name] = true
+		}
+	}
+	return data
+}
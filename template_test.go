@@ -0,0 +1,56 @@
+// Package arbiter_test provides tests for the arbiter package.
+// These tests verify the functionality of message templates.
+package arbiter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/byteweap/arbiter"
+	"github.com/byteweap/arbiter/rule"
+)
+
+func TestSetMessageTemplate(t *testing.T) {
+	type Upload struct {
+		Title string
+		Age   int
+		Email string
+		Score int
+	}
+	assert.Nil(t, arbiter.SetMessageTemplate("between", "{{.Field}} must be between {{.Min}} and {{.Max}}"))
+	assert.Nil(t, arbiter.SetMessageTemplate("length", "{{.Field}} must have {{.Min}} to {{.Max}} characters"))
+	assert.Nil(t, arbiter.SetMessageTemplate("required", "{{.Field}} is required ({{.Message}})"))
+	assert.Nil(t, arbiter.SetMessageTemplate("min", "{{.Field}} must be at least {{if .Has.Min}}{{.Min}}{{else}}the minimum{{end}}"))
+	defer func() {
+		for _, code := range []string{"between", "length", "required", "min"} {
+			assert.Nil(t, arbiter.SetMessageTemplate(code, ""))
+		}
+	}()
+
+	u := &Upload{Title: "a", Age: 130, Score: -1}
+	tests := []struct {
+		name  string
+		field arbiter.IFieldRule
+		want  string
+	}{
+		{name: "between", field: arbiter.Field(&u.Age, rule.Between(0, 120)), want: "Age must be between 0 and 120"},
+		{name: "length", field: arbiter.Field(&u.Title, rule.Len[string](2, 50)), want: "Title must have 2 to 50 characters"},
+		{name: "sentinel", field: arbiter.Field(&u.Email, rule.Required[string]()), want: "Email is required (required)"},
+		{name: "custom message is kept", field: arbiter.Field(&u.Age, rule.Between(0, 120).Errf("too old")), want: "too old"},
+		{name: "no template", field: arbiter.Field(&u.Age, rule.Max(100)), want: "Age: value is greater than maximum"},
+		{name: "zero parameter", field: arbiter.Field(&u.Score, rule.Min(0)), want: "Score must be at least 0"},
+		{name: "explicit name", field: arbiter.Field(&u.Age, rule.Between(0, 120)).Name("age"), want: "age must be between 0 and 120"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, arbiter.ValidateStruct(u, "Upload cannot be nil", tt.field), tt.want)
+			assert.EqualError(t, arbiter.ValidateStructAll(u, "Upload cannot be nil", tt.field), tt.want)
+		})
+	}
+}
+
+func TestSetMessageTemplateInvalid(t *testing.T) {
+	assert.Error(t, arbiter.SetMessageTemplate("between", "{{.Min"))
+}